@@ -13,10 +13,12 @@ import (
 	goprofile "github.com/pkg/profile"
 	"github.com/serulian/compiler/builder"
 	"github.com/serulian/compiler/developer"
+	"github.com/serulian/compiler/docgen"
 	"github.com/serulian/compiler/formatter"
 	"github.com/serulian/compiler/integration"
 	"github.com/serulian/compiler/packagetools"
 	"github.com/serulian/compiler/tester"
+	"github.com/serulian/compiler/vcs"
 	"github.com/serulian/compiler/version"
 
 	"github.com/spf13/cobra"
@@ -33,6 +35,14 @@ var (
 	revisionNote              string
 	upgrade                   bool
 	yes                       bool
+	graphFormat               string
+	vcsMirrorConfig           string
+	declaredVersion           string
+	docOutputDirectory        string
+	docSourceURL              string
+	serveDocs                 bool
+	checkFormat               bool
+	diffFormat                bool
 )
 
 func disableGC() {
@@ -40,6 +50,27 @@ func disableGC() {
 	runtime.SetGCPercent(-1)
 }
 
+// configureVCSMirrors loads the VCS mirror configuration specified via flag or environment
+// variable, if any.
+func configureVCSMirrors() {
+	configPath := vcsMirrorConfig
+	if configPath == "" {
+		configPath = os.Getenv(vcs.MirrorConfigEnvironmentVariable)
+	}
+
+	if configPath == "" {
+		return
+	}
+
+	config, err := vcs.LoadMirrorConfig(configPath)
+	if err != nil {
+		fmt.Printf("Could not load VCS mirror configuration `%s`: %v\n", configPath, err)
+		os.Exit(-1)
+	}
+
+	vcs.SetMirrorConfig(config)
+}
+
 func main() {
 	var cmdBuild = &cobra.Command{
 		Use:   "build [entrypoint source file]",
@@ -79,10 +110,33 @@ func main() {
 		},
 	}
 
+	var cmdDoc = &cobra.Command{
+		Use:   "doc [package path]",
+		Short: "Generates the API documentation of a Serulian package",
+		Long:  `Generates static HTML and Markdown documentation for all exported types and members of the Serulian package at the given path, optionally serving it locally.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("Expected package path")
+				os.Exit(-1)
+			}
+
+			if serveDocs {
+				if !docgen.Serve(addr, args[0], docSourceURL, debug, vcsDevelopmentDirectories) {
+					os.Exit(-1)
+				}
+				return
+			}
+
+			if !docgen.Generate(args[0], docOutputDirectory, docSourceURL, debug, vcsDevelopmentDirectories) {
+				os.Exit(-1)
+			}
+		},
+	}
+
 	var cmdTest = &cobra.Command{
 		Use:   "test",
 		Short: "Runs the tests defined at the given source path",
-		Long:  "Runs the tests found in any *_test.seru files at the given source path",
+		Long:  "Runs the tests found in any *_test.seru files, and the examples found in documentation comments, at the given source path",
 		Run: func(cmd *cobra.Command, args []string) {
 			fmt.Println(cmd.UsageString())
 			os.Exit(1)
@@ -99,6 +153,20 @@ func main() {
 				os.Exit(-1)
 			}
 
+			if checkFormat {
+				if !formatter.Check(args[0], upgrade, diffFormat, debug) {
+					os.Exit(1)
+				}
+				return
+			}
+
+			if diffFormat {
+				if !formatter.Diff(args[0], upgrade, debug) {
+					os.Exit(-1)
+				}
+				return
+			}
+
 			if !formatter.Format(args[0], upgrade, debug) {
 				os.Exit(-1)
 			}
@@ -189,6 +257,102 @@ func main() {
 		},
 	}
 
+	var cmdLock = &cobra.Command{
+		Use:   "lock [entrypoint source file]",
+		Short: "Locks imports",
+		Long:  `Records the commit currently resolved for every VCS import (including transitive imports) in the project's lockfile`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("Expected entrypoint source file")
+				os.Exit(-1)
+			}
+
+			if !packagetools.LockImports(args[0], debug, vcsDevelopmentDirectories...) {
+				os.Exit(-1)
+			}
+		},
+	}
+
+	var cmdVerify = &cobra.Command{
+		Use:   "verify [entrypoint source file]",
+		Short: "Verifies the imports lockfile",
+		Long:  `Verifies that the project's lockfile contains exactly the VCS imports (including transitive imports) of the project`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("Expected entrypoint source file")
+				os.Exit(-1)
+			}
+
+			if !packagetools.VerifyLock(args[0], debug, vcsDevelopmentDirectories...) {
+				os.Exit(-1)
+			}
+		},
+	}
+
+	var cmdVendor = &cobra.Command{
+		Use:   "vendor [entrypoint source file]",
+		Short: "Vendors imports",
+		Long:  `Copies every VCS import (including transitive imports) into the project's vendor directory, which is used in lieu of VCS checkout by subsequent builds`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("Expected entrypoint source file")
+				os.Exit(-1)
+			}
+
+			if !packagetools.VendorImports(args[0], debug, vcsDevelopmentDirectories...) {
+				os.Exit(-1)
+			}
+		},
+	}
+
+	var cmdSum = &cobra.Command{
+		Use:   "sum [entrypoint source file]",
+		Short: "Refreshes import checksums",
+		Long:  `Records the content hash of every VCS import (including transitive imports) pinned to a tag or commit in the project's sums file, replacing any existing hashes`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("Expected entrypoint source file")
+				os.Exit(-1)
+			}
+
+			if !packagetools.SumImports(args[0], debug, vcsDevelopmentDirectories...) {
+				os.Exit(-1)
+			}
+		},
+	}
+
+	var cmdGraph = &cobra.Command{
+		Use:   "graph [entrypoint source file]",
+		Short: "Displays the import graph",
+		Long:  `Displays the full transitive import graph of the project, including local packages, VCS packages and WebIDL sources`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("Expected entrypoint source file")
+				os.Exit(-1)
+			}
+
+			if !packagetools.ImportGraph(args[0], packagetools.GraphFormat(graphFormat), debug, vcsDevelopmentDirectories...) {
+				os.Exit(-1)
+			}
+		},
+	}
+
+	var cmdWhy = &cobra.Command{
+		Use:   "why [package] [entrypoint source file]",
+		Short: "Displays why a package is imported",
+		Long:  `Displays every import chain through which the project imports the given package. VCS packages can be specified by their repository URL.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				fmt.Println("Expected package and entrypoint source file")
+				os.Exit(-1)
+			}
+
+			if !packagetools.WhyImported(args[1], args[0], debug, vcsDevelopmentDirectories...) {
+				os.Exit(-1)
+			}
+		},
+	}
+
 	var cmdImports = &cobra.Command{
 		Use:   "imports",
 		Short: "Commands for modifying imports",
@@ -268,6 +432,29 @@ func main() {
 		},
 	}
 
+	var cmdCheckCompat = &cobra.Command{
+		Use:   "check-compat [package path] (compare-version)",
+		Short: "Checks this package for API compatibility against latest tagged",
+		Long: `Checks the API of the current version of this package against the latest tagged (or that specified),
+emitting a JSON report of all changes found and failing if the changes require a major version increment
+(or, if --declared-version is given, a larger increment than that of the declared version)`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 1 {
+				fmt.Println("Expected package path")
+				os.Exit(-1)
+			}
+
+			compareVersion := ""
+			if len(args) > 1 {
+				compareVersion = args[1]
+			}
+
+			if !packagetools.CheckCompatibility(args[0], compareVersion, declaredVersion, debug, vcsDevelopmentDirectories...) {
+				os.Exit(1)
+			}
+		},
+	}
+
 	var cmdListIntegrations = &cobra.Command{
 		Use:   "list",
 		Short: "Lists all installed integrations",
@@ -351,6 +538,7 @@ func main() {
 
 	cmdPackage.AddCommand(cmdDiff)
 	cmdPackage.AddCommand(cmdRev)
+	cmdPackage.AddCommand(cmdCheckCompat)
 
 	// Register command-specific flags.
 	cmdBuild.PersistentFlags().StringSliceVar(&vcsDevelopmentDirectories, "vcs-dev-dir", []string{},
@@ -360,19 +548,42 @@ func main() {
 		"If specified, VCS packages without specification will be first checked against this path")
 	cmdDevelop.PersistentFlags().StringVar(&addr, "addr", ":8080", "The address at which the development code will be served")
 
+	cmdDoc.PersistentFlags().StringSliceVar(&vcsDevelopmentDirectories, "vcs-dev-dir", []string{},
+		"If specified, VCS packages without specification will be first checked against this path")
+	cmdDoc.PersistentFlags().StringVarP(&docOutputDirectory, "output", "o", "docs",
+		"The directory into which the documentation will be generated")
+	cmdDoc.PersistentFlags().StringVar(&docSourceURL, "source-url", "",
+		"If specified, the URL template for source links, with {path} and {line} replaced by the module path and line number")
+	cmdDoc.PersistentFlags().BoolVar(&serveDocs, "serve", false,
+		"If true, the documentation will be served locally instead of written to the output directory")
+	cmdDoc.PersistentFlags().StringVar(&addr, "addr", ":8080", "The address at which the documentation will be served")
+
 	cmdTest.PersistentFlags().StringSliceVar(&vcsDevelopmentDirectories, "vcs-dev-dir", []string{},
 		"If specified, VCS packages without specification will be first checked against this path")
 
 	cmdFormat.PersistentFlags().BoolVarP(&upgrade, "upgrade", "u", false,
 		"If true, older forms of source code syntax are supported for parsing and formatting")
+	cmdFormat.PersistentFlags().BoolVar(&checkFormat, "check", false,
+		"If true, source files are not modified; instead, those not formatted are listed and the command fails")
+	cmdFormat.PersistentFlags().BoolVar(&diffFormat, "diff", false,
+		"If true, source files are not modified; instead, a unified diff of the changes formatting would make is printed")
 
 	cmdImports.AddCommand(cmdFreeze)
 	cmdImports.AddCommand(cmdUnfreeze)
 	cmdImports.AddCommand(cmdUpgrade)
 	cmdImports.AddCommand(cmdUpdate)
+	cmdImports.AddCommand(cmdLock)
+	cmdImports.AddCommand(cmdVerify)
+	cmdImports.AddCommand(cmdVendor)
+	cmdImports.AddCommand(cmdSum)
+	cmdImports.AddCommand(cmdGraph)
+	cmdImports.AddCommand(cmdWhy)
 	cmdImports.PersistentFlags().StringSliceVar(&vcsDevelopmentDirectories, "vcs-dev-dir", []string{},
 		"If specified, VCS packages without specification will be first checked against this path")
 
+	cmdGraph.PersistentFlags().StringVarP(&graphFormat, "format", "f", string(packagetools.GraphFormatTree),
+		"The output format of the graph: tree, dot or json")
+
 	cmdDiff.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false,
 		"Whether to show full diff output")
 
@@ -380,7 +591,10 @@ func main() {
 		"Whether to show full diff output")
 
 	cmdRev.PersistentFlags().StringVarP(&revisionNote, "revision-note", "n", "",
-		"If specified, the note to use when tagging the new version")
+		"If specified, a note placed at the top of the changelog (and tag annotation) for the new version")
+
+	cmdCheckCompat.PersistentFlags().StringVar(&declaredVersion, "declared-version", "",
+		"If specified, the version the package is intended to be released as; changes requiring a larger increment fail the check")
 
 	cmdUpgradeIntegration.PersistentFlags().BoolVarP(&yes, "yes", "y", false,
 		"If true, the prompt will be skipped")
@@ -396,10 +610,14 @@ func main() {
 		Use:   "serulian",
 		Short: fmt.Sprintf("Serulian %s", version.DescriptiveVersion()),
 		Long:  fmt.Sprintf("Serulian %s: A web and mobile development language", version.DescriptiveVersion()),
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			configureVCSMirrors()
+		},
 	}
 
 	rootCmd.AddCommand(cmdBuild)
 	rootCmd.AddCommand(cmdDevelop)
+	rootCmd.AddCommand(cmdDoc)
 	rootCmd.AddCommand(cmdTest)
 	rootCmd.AddCommand(cmdFormat)
 	rootCmd.AddCommand(cmdImports)
@@ -411,5 +629,7 @@ func main() {
 
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "If set to true, Serulian will print debug logs")
 	rootCmd.PersistentFlags().BoolVar(&profile, "profile", false, "If set to true, Serulian will be profiled")
+	rootCmd.PersistentFlags().StringVar(&vcsMirrorConfig, "vcs-mirror-config", "",
+		"If specified, the path of a JSON file configuring mirrors for VCS packages. Defaults to the path in $"+vcs.MirrorConfigEnvironmentVariable)
 	rootCmd.Execute()
 }
@@ -5,6 +5,9 @@
 package compilerutil
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/blang/semver"
 )
 
@@ -65,6 +68,36 @@ func NextSemanticVersion(currentVersionString string, option NextVersionOption)
 	return version.String(), nil
 }
 
+// SemanticVersionIncrement returns the most significant version field incremented from the current
+// version to the updated version. Returns an error if the updated version is not greater than the
+// current version.
+func SemanticVersionIncrement(currentVersionString string, updatedVersionString string) (NextVersionOption, error) {
+	currentVersion, err := semver.ParseTolerant(currentVersionString)
+	if err != nil {
+		return "", err
+	}
+
+	updatedVersion, err := semver.ParseTolerant(updatedVersionString)
+	if err != nil {
+		return "", err
+	}
+
+	if !updatedVersion.GT(currentVersion) {
+		return "", fmt.Errorf("Version %s is not greater than version %s", updatedVersionString, currentVersionString)
+	}
+
+	switch {
+	case updatedVersion.Major != currentVersion.Major:
+		return NextMajorVersion, nil
+
+	case updatedVersion.Minor != currentVersion.Minor:
+		return NextMinorVersion, nil
+
+	default:
+		return NextPatchVersion, nil
+	}
+}
+
 // LatestSemanticVersion returns the latest non-patch semantic version found in the list of available
 // versions, if any.
 func LatestSemanticVersion(availableVersions []string) (string, bool) {
@@ -121,3 +154,138 @@ func SemanticUpdateVersion(currentVersionString string, availableVersions []stri
 
 	return latestVersionString, nil
 }
+
+// SemanticVersionRange defines a range of semantic versions, as specified by a caret (`^1.4.0`)
+// or tilde (`~2.1`) constraint.
+//
+// A caret constraint allows any version that does not modify the left-most non-zero version
+// field: `^1.4.0` allows `>= 1.4.0, < 2.0.0` and `^0.4.1` allows `>= 0.4.1, < 0.5.0`.
+//
+// A tilde constraint allows patch level changes if a minor version is specified and minor level
+// changes if not: `~2.1` allows `>= 2.1.0, < 2.2.0` and `~2` allows `>= 2.0.0, < 3.0.0`.
+type SemanticVersionRange struct {
+	constraint string         // The constraint string, as specified.
+	minimum    semver.Version // The minimum version allowed, inclusive.
+	maximum    semver.Version // The maximum version allowed, exclusive.
+}
+
+// IsSemanticVersionRange returns true if the given string is a semantic version range constraint,
+// rather than a specific version.
+func IsSemanticVersionRange(constraint string) bool {
+	return strings.HasPrefix(constraint, "^") || strings.HasPrefix(constraint, "~")
+}
+
+// ParseSemanticVersionRange parses the given caret or tilde constraint into a semantic version range.
+func ParseSemanticVersionRange(constraint string) (SemanticVersionRange, error) {
+	if !IsSemanticVersionRange(constraint) {
+		return SemanticVersionRange{}, fmt.Errorf("Version range `%s` must start with `^` or `~`", constraint)
+	}
+
+	versionString := strings.TrimPrefix(constraint[1:], "v")
+
+	// Determine the number of version fields specified, ignoring any pre-release or build suffix.
+	fieldsString := versionString
+	if index := strings.IndexAny(fieldsString, "-+"); index >= 0 {
+		fieldsString = fieldsString[0:index]
+	}
+
+	fieldCount := len(strings.Split(fieldsString, "."))
+	if fieldCount > 3 {
+		return SemanticVersionRange{}, fmt.Errorf("Version range `%s` has too many version fields", constraint)
+	}
+
+	minimum, err := semver.ParseTolerant(versionString)
+	if err != nil {
+		return SemanticVersionRange{}, fmt.Errorf("Version range `%s` does not refer to a semantic version: %v", constraint, err)
+	}
+
+	maximum := semver.Version{}
+	switch {
+	case constraint[0] == '~' && fieldCount == 1:
+		maximum.Major = minimum.Major + 1
+
+	case constraint[0] == '~':
+		maximum.Major = minimum.Major
+		maximum.Minor = minimum.Minor + 1
+
+	case minimum.Major > 0 || fieldCount == 1:
+		maximum.Major = minimum.Major + 1
+
+	case minimum.Minor > 0 || fieldCount == 2:
+		maximum.Minor = minimum.Minor + 1
+
+	default:
+		maximum.Patch = minimum.Patch + 1
+	}
+
+	return SemanticVersionRange{constraint, minimum, maximum}, nil
+}
+
+// String returns the constraint string for the range.
+func (r SemanticVersionRange) String() string {
+	return r.constraint
+}
+
+// Contains returns true if the given version string refers to a semantic version within the range.
+// Pre-release versions are only contained if the range's minimum is a pre-release of the same
+// version fields.
+func (r SemanticVersionRange) Contains(versionString string) bool {
+	version, err := semver.ParseTolerant(versionString)
+	if err != nil {
+		return false
+	}
+
+	return r.contains(version)
+}
+
+func (r SemanticVersionRange) contains(version semver.Version) bool {
+	if version.LT(r.minimum) || version.GTE(r.maximum) {
+		return false
+	}
+
+	if len(version.Pre) > 0 {
+		return len(r.minimum.Pre) > 0 && version.Major == r.minimum.Major &&
+			version.Minor == r.minimum.Minor && version.Patch == r.minimum.Patch
+	}
+
+	return true
+}
+
+// MinimalSatisfyingVersion returns the lowest version found in the list of available versions
+// that is contained in *all* of the given ranges, if any.
+func MinimalSatisfyingVersion(ranges []SemanticVersionRange, availableVersions []string) (string, bool) {
+	var minimalVersionString = ""
+	var minimalVersion semver.Version
+
+	for _, possibleVersion := range availableVersions {
+		// Skip empty version strings.
+		if len(possibleVersion) == 0 {
+			continue
+		}
+
+		// Skip possibleVersions that don't parse.
+		parsed, err := semver.ParseTolerant(possibleVersion)
+		if err != nil {
+			continue
+		}
+
+		if minimalVersionString != "" && parsed.GTE(minimalVersion) {
+			continue
+		}
+
+		var satisfied = true
+		for _, versionRange := range ranges {
+			if !versionRange.contains(parsed) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied {
+			minimalVersionString = possibleVersion
+			minimalVersion = parsed
+		}
+	}
+
+	return minimalVersionString, len(minimalVersionString) > 0
+}
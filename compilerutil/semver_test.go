@@ -47,6 +47,37 @@ func TestNextSemanticVersion(t *testing.T) {
 	}
 }
 
+type semverIncrementTest struct {
+	currentVersion string
+	updatedVersion string
+	option         NextVersionOption
+	status         bool
+}
+
+var semverIncrementTests = []semverIncrementTest{
+	semverIncrementTest{"1.0.0", "1.0.1", NextPatchVersion, true},
+	semverIncrementTest{"1.0.0", "1.1.0", NextMinorVersion, true},
+	semverIncrementTest{"1.0.0", "2.0.0", NextMajorVersion, true},
+	semverIncrementTest{"1.1.2", "1.3.0", NextMinorVersion, true},
+	semverIncrementTest{"1.1.2", "3.0.1", NextMajorVersion, true},
+	semverIncrementTest{"v1.1.2", "v1.1.3", NextPatchVersion, true},
+
+	semverIncrementTest{"1.0.0", "1.0.0", "", false},
+	semverIncrementTest{"1.1.0", "1.0.5", "", false},
+	semverIncrementTest{"1.0.0", "a.b.c", "", false},
+}
+
+func TestSemanticVersionIncrement(t *testing.T) {
+	for _, test := range semverIncrementTests {
+		option, err := SemanticVersionIncrement(test.currentVersion, test.updatedVersion)
+		if !assert.Equal(t, test.status, err == nil, "Mismatch in status for increment test `%s` => `%s`", test.currentVersion, test.updatedVersion) {
+			continue
+		}
+
+		assert.Equal(t, test.option, option, "Mismatch in increment for increment test `%s` => `%s`", test.currentVersion, test.updatedVersion)
+	}
+}
+
 type semverUpdateTest struct {
 	currentVersion    string
 	availableVersions []string
@@ -90,3 +121,82 @@ func TestSemanticUpdateVersion(t *testing.T) {
 		}
 	}
 }
+
+type semverRangeTest struct {
+	constraint        string
+	availableVersions []string
+	resultVersion     string
+	status            bool
+}
+
+var semverRangeTests = []semverRangeTest{
+	// Success tests.
+	semverRangeTest{"^1.4.0", []string{"1.3.0", "1.4.0", "1.5.0", "2.0.0"}, "1.4.0", true},
+	semverRangeTest{"^1.4.0", []string{"2.0.0", "1.5.0", "1.3.0"}, "1.5.0", true},
+	semverRangeTest{"^1.4", []string{"v1.3.9", "v1.4.2", "v1.4.1"}, "v1.4.1", true},
+	semverRangeTest{"^1", []string{"0.9.0", "1.0.0"}, "1.0.0", true},
+	semverRangeTest{"^0.4.1", []string{"0.4.0", "0.4.1", "0.5.0"}, "0.4.1", true},
+	semverRangeTest{"^0.4.1", []string{"0.5.0", "0.4.3"}, "0.4.3", true},
+	semverRangeTest{"^0.0.3", []string{"0.0.3", "0.0.4"}, "0.0.3", true},
+	semverRangeTest{"~2.1", []string{"2.0.9", "2.1.7", "2.2.0"}, "2.1.7", true},
+	semverRangeTest{"~2.1.3", []string{"2.1.2", "2.1.4", "2.2.0"}, "2.1.4", true},
+	semverRangeTest{"~2", []string{"1.9.9", "2.9.0", "3.0.0"}, "2.9.0", true},
+	semverRangeTest{"^1.4.0", []string{"1.4.1-alpha", "1.4.2", "notaversion", ""}, "1.4.2", true},
+	semverRangeTest{"^1.4.0-beta", []string{"1.4.0-beta.2", "1.4.0"}, "1.4.0-beta.2", true},
+
+	// Unsatisfiable tests.
+	semverRangeTest{"^1.4.0", []string{}, "", true},
+	semverRangeTest{"^1.4.0", []string{"1.3.0", "2.0.0", "2.0.0-alpha"}, "", true},
+	semverRangeTest{"^0.4.1", []string{"0.5.0"}, "", true},
+	semverRangeTest{"~2.1", []string{"2.2.0"}, "", true},
+
+	// Failure tests.
+	semverRangeTest{"1.4.0", []string{}, "", false},
+	semverRangeTest{"^", []string{}, "", false},
+	semverRangeTest{"^a.b.c", []string{}, "", false},
+	semverRangeTest{"~1.2.3.4", []string{}, "", false},
+	semverRangeTest{"^1.03.0", []string{}, "", false},
+}
+
+func TestSemanticVersionRange(t *testing.T) {
+	for _, test := range semverRangeTests {
+		versionRange, err := ParseSemanticVersionRange(test.constraint)
+		if !assert.Equal(t, test.status, err == nil, "Mismatch in status for range test `%s`", test.constraint) {
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		resultVersion, _ := MinimalSatisfyingVersion([]SemanticVersionRange{versionRange}, test.availableVersions)
+		if !assert.Equal(t, test.resultVersion, resultVersion, "Mismatch in result version for range test `%s`", test.constraint) {
+			continue
+		}
+	}
+}
+
+func TestMinimalSatisfyingVersionAcrossRanges(t *testing.T) {
+	available := []string{"v1.3.0", "v1.4.0", "v1.6.0", "v1.6.2", "v2.0.0"}
+
+	parse := func(constraints ...string) []SemanticVersionRange {
+		ranges := make([]SemanticVersionRange, len(constraints))
+		for index, constraint := range constraints {
+			parsed, err := ParseSemanticVersionRange(constraint)
+			assert.Nil(t, err)
+			ranges[index] = parsed
+		}
+		return ranges
+	}
+
+	selected, found := MinimalSatisfyingVersion(parse("^1.4.0", "^1.6.0"), available)
+	assert.True(t, found)
+	assert.Equal(t, "v1.6.0", selected)
+
+	selected, found = MinimalSatisfyingVersion(parse("^1.4.0", "~1.6.1"), available)
+	assert.True(t, found)
+	assert.Equal(t, "v1.6.2", selected)
+
+	_, found = MinimalSatisfyingVersion(parse("^1.4.0", "^2.0.0"), available)
+	assert.False(t, found)
+}
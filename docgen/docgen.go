@@ -0,0 +1,136 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// docgen package defines the generator of API documentation for Serulian packages.
+package docgen
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/serulian/compiler/builder"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/graphs/scopegraph"
+)
+
+// Generate generates the HTML and Markdown documentation for the Serulian package found at the
+// given path, placing it into the output directory. The source URL, if not empty, is the template
+// for links to the source of documented entities, with `{path}` replaced by the module's path and
+// `{line}` by the line number; otherwise, the source of each module is placed into the documentation.
+func Generate(packagePath string, outputDirectory string, sourceURL string, debug bool, vcsDevelopmentDirectories []string) bool {
+	// Disable logging unless the debug flag is on.
+	if !debug {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	if !generate(packagePath, outputDirectory, sourceURL, vcsDevelopmentDirectories) {
+		return false
+	}
+
+	compilerutil.LogToConsole(compilerutil.SuccessLogLevel, nil, "Documentation for package `%s` generated into `%s`", packagePath, outputDirectory)
+	return true
+}
+
+// Serve generates the documentation for the Serulian package found at the given path and serves it
+// on localhost at the given addr. The documentation is regenerated whenever a page is requested, so
+// that changes to the package are reflected without restarting the server.
+func Serve(addr string, packagePath string, sourceURL string, debug bool, vcsDevelopmentDirectories []string) bool {
+	// Disable logging unless the debug flag is on.
+	if !debug {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	outputDirectory, err := ioutil.TempDir("", "serulian-doc")
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not create output directory: %v", err)
+		return false
+	}
+
+	defer os.RemoveAll(outputDirectory)
+
+	if !generate(packagePath, outputDirectory, sourceURL, vcsDevelopmentDirectories) {
+		return false
+	}
+
+	var lock sync.Mutex
+	fileServer := http.FileServer(http.Dir(outputDirectory))
+
+	serveDocumentation := func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if r.URL.Path == "/" || strings.HasSuffix(r.URL.Path, ".html") {
+			generate(packagePath, outputDirectory, sourceURL, vcsDevelopmentDirectories)
+		}
+
+		fileServer.ServeHTTP(w, r)
+	}
+
+	highlight := color.New(color.FgHiWhite, color.Underline).SprintFunc()
+	fullAddr := addr
+	if strings.HasPrefix(fullAddr, ":") {
+		fullAddr = "localhost" + fullAddr
+	}
+
+	fmt.Printf("Serving documentation for package %v at http://%v/\n", highlight(packagePath), fullAddr)
+
+	err = http.ListenAndServe(addr, http.HandlerFunc(serveDocumentation))
+	if err != nil {
+		fmt.Printf("Error running doc: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// generate builds the documentation site for the given package and writes it into the output directory.
+func generate(packagePath string, outputDirectory string, sourceURL string, vcsDevelopmentDirectories []string) bool {
+	packageRoot, err := filepath.Abs(packagePath)
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Invalid package path `%s`: %v", packagePath, err)
+		return false
+	}
+
+	if _, err := os.Stat(packageRoot); os.IsNotExist(err) {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not find package `%s`", packagePath)
+		return false
+	}
+
+	scopeResult, err := scopegraph.ParseAndBuildScopeGraph(packagePath, vcsDevelopmentDirectories, builder.CORE_LIBRARY)
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "%s", err.Error())
+		return false
+	}
+
+	if !scopeResult.Status {
+		builder.OutputErrors(scopeResult.Errors)
+		return false
+	}
+
+	site := buildSite(scopeResult.Graph.TypeGraph(), packageRoot, sourceURL)
+
+	if err := os.MkdirAll(outputDirectory, 0755); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not create output directory `%s`: %v", outputDirectory, err)
+		return false
+	}
+
+	if err := writeHTML(site, outputDirectory, sourceURL == ""); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not write HTML documentation: %v", err)
+		return false
+	}
+
+	if err := writeMarkdown(site, outputDirectory); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not write Markdown documentation: %v", err)
+		return false
+	}
+
+	return true
+}
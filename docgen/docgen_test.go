@@ -0,0 +1,162 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docgen
+
+import (
+	"testing"
+
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/stretchr/testify/assert"
+)
+
+var testModules = []typegraph.TestModule{
+	typegraph.TestModule{
+		"/somepackage/somemodule.seru",
+		[]typegraph.TestType{
+			typegraph.TestType{"class", "SomeClass", "",
+				[]typegraph.TestGeneric{
+					typegraph.TestGeneric{"T", ""},
+				},
+				[]typegraph.TestMember{
+					typegraph.TestMember{typegraph.FunctionMemberSignature, "DoSomething", "AnotherClass",
+						[]typegraph.TestGeneric{},
+						[]typegraph.TestParam{
+							typegraph.TestParam{"someParam", "int"},
+						},
+					},
+					typegraph.TestMember{typegraph.FunctionMemberSignature, "doSomethingPrivate", "void",
+						[]typegraph.TestGeneric{},
+						[]typegraph.TestParam{},
+					},
+				},
+			},
+			typegraph.TestType{"class", "privateClass", "",
+				[]typegraph.TestGeneric{},
+				[]typegraph.TestMember{},
+			},
+		},
+		[]typegraph.TestMember{
+			typegraph.TestMember{typegraph.FieldMemberSignature, "SomeField", "SomeClass<int>?",
+				[]typegraph.TestGeneric{},
+				[]typegraph.TestParam{},
+			},
+		},
+	},
+	typegraph.TestModule{
+		"/somepackage/sub/anothermodule.seru",
+		[]typegraph.TestType{
+			typegraph.TestType{"interface", "AnotherClass", "",
+				[]typegraph.TestGeneric{},
+				[]typegraph.TestMember{},
+			},
+		},
+		[]typegraph.TestMember{},
+	},
+	typegraph.TestModule{
+		"/somepackage/somemodule_test.seru",
+		[]typegraph.TestType{
+			typegraph.TestType{"class", "TestClass", "",
+				[]typegraph.TestGeneric{},
+				[]typegraph.TestMember{},
+			},
+		},
+		[]typegraph.TestMember{},
+	},
+	typegraph.TestModule{
+		"/somepackage/.pkg/somedependency.seru",
+		[]typegraph.TestType{
+			typegraph.TestType{"class", "DependencyClass", "",
+				[]typegraph.TestGeneric{},
+				[]typegraph.TestMember{},
+			},
+		},
+		[]typegraph.TestMember{},
+	},
+}
+
+func TestBuildSite(t *testing.T) {
+	graph := typegraph.ConstructTypeGraphWithBasicTypes(testModules...)
+	site := buildSite(graph, "/somepackage", "https://example.com/{path}#L{line}")
+
+	if !assert.Equal(t, 2, len(site.Modules), "Expected only the non-test modules under the package to be documented") {
+		return
+	}
+
+	someModule := site.Modules[0]
+	assert.Equal(t, "somemodule.seru", someModule.Name)
+	assert.Equal(t, "somemodule", someModule.Page)
+
+	anotherModule := site.Modules[1]
+	assert.Equal(t, "sub/anothermodule.seru", anotherModule.Name)
+	assert.Equal(t, "sub.anothermodule", anotherModule.Page)
+
+	// Ensure only exported types and members are documented.
+	if !assert.Equal(t, 1, len(someModule.Types)) {
+		return
+	}
+
+	someClass := someModule.Types[0]
+	assert.Equal(t, "SomeClass", someClass.Anchor)
+	assert.Equal(t, "class SomeClass<T>", someClass.Signature.String())
+	assert.Equal(t, 1, len(someClass.Generics))
+
+	if !assert.Equal(t, 1, len(someClass.Members)) {
+		return
+	}
+
+	doSomething := someClass.Members[0]
+	assert.Equal(t, "SomeClass.DoSomething", doSomething.Anchor)
+	assert.Equal(t, "function", doSomething.Kind)
+	assert.Equal(t, "function DoSomething(someParam int) AnotherClass", doSomething.Signature.String())
+	assert.Equal(t, []docParameter{docParameter{"someParam", docSignature{docToken{"int", nil}}, ""}}, doSomething.Parameters)
+
+	// Ensure types in other documented modules are cross-linked.
+	returnToken := doSomething.Signature[len(doSomething.Signature)-1]
+	if assert.NotNil(t, returnToken.Link) {
+		assert.Equal(t, docLink{"sub.anothermodule", "AnotherClass"}, *returnToken.Link)
+	}
+
+	if !assert.Equal(t, 1, len(someModule.Members)) {
+		return
+	}
+
+	someField := someModule.Members[0]
+	assert.Equal(t, "var SomeField SomeClass<int>?", someField.Signature.String())
+	assert.Equal(t, "SomeField", someField.Anchor)
+
+	// Ensure the search index contains all documented entities.
+	assert.Equal(t, []searchEntry{
+		searchEntry{"SomeClass", "class", "somemodule.seru", "somemodule.html#SomeClass"},
+		searchEntry{"SomeClass.DoSomething", "function", "somemodule.seru", "somemodule.html#SomeClass.DoSomething"},
+		searchEntry{"SomeField", "var", "somemodule.seru", "somemodule.html#SomeField"},
+		searchEntry{"AnotherClass", "interface", "sub/anothermodule.seru", "sub.anothermodule.html#AnotherClass"},
+	}, site.Search)
+}
+
+var documentationTests = []struct {
+	documentation string
+	expected      string
+}{
+	{"", ""},
+	{"Some documentation.", "<p>Some documentation.</p>\n"},
+	{"First paragraph.\n\nSecond paragraph.", "<p>First paragraph.</p>\n<p>Second paragraph.</p>\n"},
+	{"Returns `null` if <missing>.", "<p>Returns <code>null</code> if &lt;missing&gt;.</p>\n"},
+}
+
+func TestRenderDocumentation(t *testing.T) {
+	for _, test := range documentationTests {
+		assert.Equal(t, test.expected, string(renderDocumentation(test.documentation)), "Mismatch for documentation %q", test.documentation)
+	}
+}
+
+func TestRenderSignature(t *testing.T) {
+	var signature docSignature
+	signature.text("function DoSomething(someParam ")
+	signature.link("SomeClass", docLink{"somemodule", "SomeClass"})
+	signature.text("<int>)")
+
+	assert.Equal(t, `function DoSomething(someParam <a href="somemodule.html#SomeClass">SomeClass</a>&lt;int&gt;)`, string(renderSignature(signature)))
+	assert.Equal(t, "```serulian\nfunction DoSomething(someParam SomeClass<int>)\n```\n\nSee: [SomeClass](somemodule.md#SomeClass)", markdownSignature(signature))
+}
@@ -0,0 +1,266 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// searchIndexFileName is the name of the JSON search index written into the documentation site.
+const searchIndexFileName = "search-index.json"
+
+// searchScriptFileName is the name of the script defining the search index for the HTML pages.
+const searchScriptFileName = "search-index.js"
+
+var htmlFuncs = template.FuncMap{
+	"signature":     renderSignature,
+	"documentation": renderDocumentation,
+	"sourcePage":    sourcePageURL,
+	"inc":           func(index int) int { return index + 1 },
+}
+
+const htmlHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; margin: 0; display: flex; }
+nav { width: 16em; padding: 1em; background: #f4f4f4; min-height: 100vh; }
+main { flex: 1; padding: 1em 2em; }
+pre, code { font-family: monospace; }
+pre.signature { background: #f8f8f8; padding: 0.5em; }
+.source { float: right; font-size: small; }
+.lines td.number { color: #999; text-align: right; padding-right: 1em; }
+:target { background: #ffffd0; }
+</style>
+</head>
+<body>
+<nav>
+<h3><a href="{{ .Root }}index.html">{{ .SiteTitle }}</a></h3>
+<ul>
+{{ range .Modules }}<li><a href="{{ $.Root }}{{ .Page }}.html">{{ .Name }}</a></li>
+{{ end }}</ul>
+</nav>
+<main>
+`
+
+const htmlFooter = `</main>
+</body>
+</html>
+`
+
+const htmlMember = `{{ define "member" }}<div class="member" id="{{ .Anchor }}">
+{{ if .SourceURL }}<a class="source" href="{{ .SourceURL }}">source</a>{{ end }}
+<h4>{{ .Kind }} {{ .Name }}</h4>
+<pre class="signature">{{ signature .Signature }}</pre>
+{{ documentation .Documentation }}
+{{ template "generics" .Generics }}
+{{ if .Parameters }}<dl class="parameters">
+{{ range .Parameters }}<dt><code>{{ .Name }} {{ signature .Type }}</code></dt>
+<dd>{{ documentation .Documentation }}</dd>
+{{ end }}</dl>{{ end }}
+</div>
+{{ end }}`
+
+const htmlGenerics = `{{ define "generics" }}{{ if . }}<dl class="generics">
+{{ range . }}<dt><code>{{ .Name }} : {{ signature .Constraint }}</code></dt>
+<dd>{{ documentation .Documentation }}</dd>
+{{ end }}</dl>{{ end }}{{ end }}`
+
+var indexTemplate = template.Must(template.New("index").Funcs(htmlFuncs).Parse(htmlHeader + `<h1>{{ .SiteTitle }}</h1>
+<input id="search" type="search" placeholder="Search">
+<ul id="results"></ul>
+<h2>Modules</h2>
+<ul>
+{{ range .Modules }}<li><a href="{{ .Page }}.html">{{ .Name }}</a> ({{ len .Types }} types, {{ len .Members }} members)</li>
+{{ end }}</ul>
+<script src="` + searchScriptFileName + `"></script>
+<script>
+(function() {
+  var input = document.getElementById('search');
+  var results = document.getElementById('results');
+  input.addEventListener('input', function() {
+    var query = input.value.toLowerCase();
+    results.innerHTML = '';
+    if (!query) { return; }
+    window.serulianDocSearchIndex.filter(function(entry) {
+      return entry.name.toLowerCase().indexOf(query) >= 0;
+    }).slice(0, 50).forEach(function(entry) {
+      var item = document.createElement('li');
+      var link = document.createElement('a');
+      link.href = entry.url;
+      link.textContent = entry.kind + ' ' + entry.name;
+      item.appendChild(link);
+      item.appendChild(document.createTextNode(' (' + entry.module + ')'));
+      results.appendChild(item);
+    });
+  });
+})();
+</script>
+` + htmlFooter))
+
+var moduleTemplate = template.Must(template.New("module").Funcs(htmlFuncs).Parse(htmlMember + htmlGenerics + htmlHeader + `{{ with .Module }}<h1>{{ .Name }}</h1>
+{{ if $.HasSourcePages }}<p><a href="{{ sourcePage .Page }}">View source</a></p>{{ end }}
+{{ if .Types }}<h2>Types</h2>
+{{ range .Types }}<div class="type" id="{{ .Anchor }}">
+{{ if .SourceURL }}<a class="source" href="{{ .SourceURL }}">source</a>{{ end }}
+<h3>{{ .Kind }} {{ .Name }}</h3>
+<pre class="signature">{{ signature .Signature }}</pre>
+{{ documentation .Documentation }}
+{{ template "generics" .Generics }}
+{{ range .Members }}{{ template "member" . }}{{ end }}
+</div>
+{{ end }}{{ end }}
+{{ if .Members }}<h2>Members</h2>
+{{ range .Members }}{{ template "member" . }}{{ end }}{{ end }}
+{{ end }}` + htmlFooter))
+
+var sourceTemplate = template.Must(template.New("source").Funcs(htmlFuncs).Parse(htmlHeader + `<h1><a href="../{{ .Module.Page }}.html">{{ .Module.Name }}</a></h1>
+<table class="lines">
+{{ range $index, $line := .Lines }}<tr id="L{{ inc $index }}"><td class="number"><a href="#L{{ inc $index }}">{{ inc $index }}</a></td><td><pre>{{ $line }}</pre></td></tr>
+{{ end }}</table>
+` + htmlFooter))
+
+// htmlPage holds the data used to render a single HTML page.
+type htmlPage struct {
+	Title          string
+	SiteTitle      string
+	Root           string
+	Modules        []*docModule
+	Module         *docModule
+	Lines          []string
+	HasSourcePages bool
+}
+
+// writeHTML writes the HTML pages of the given documentation site, along with its search index,
+// into the output directory. If includeSource is true, a page holding the source of each module is
+// written as well.
+func writeHTML(site *docSite, outputDirectory string, includeSource bool) error {
+	index := htmlPage{
+		Title:     site.Title,
+		SiteTitle: site.Title,
+		Modules:   site.Modules,
+	}
+
+	if err := writeTemplate(indexTemplate, index, filepath.Join(outputDirectory, "index.html")); err != nil {
+		return err
+	}
+
+	for _, module := range site.Modules {
+		page := index
+		page.Title = module.Name + " - " + site.Title
+		page.Module = module
+		page.HasSourcePages = includeSource
+
+		if err := writeTemplate(moduleTemplate, page, filepath.Join(outputDirectory, module.Page+".html")); err != nil {
+			return err
+		}
+
+		if !includeSource {
+			continue
+		}
+
+		contents, err := ioutil.ReadFile(module.SourcePath)
+		if err != nil {
+			return err
+		}
+
+		page.Root = "../"
+		page.Lines = strings.Split(strings.TrimRight(string(contents), "\n"), "\n")
+
+		sourcePath := filepath.Join(outputDirectory, filepath.FromSlash(sourcePageURL(module.Page)))
+		if err := os.MkdirAll(filepath.Dir(sourcePath), 0755); err != nil {
+			return err
+		}
+
+		if err := writeTemplate(sourceTemplate, page, sourcePath); err != nil {
+			return err
+		}
+	}
+
+	searchIndex, err := json.Marshal(site.Search)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(outputDirectory, searchIndexFileName), searchIndex, 0644); err != nil {
+		return err
+	}
+
+	searchScript := "window.serulianDocSearchIndex = " + string(searchIndex) + ";\n"
+	return ioutil.WriteFile(filepath.Join(outputDirectory, searchScriptFileName), []byte(searchScript), 0644)
+}
+
+// executableTemplate is a parsed HTML or text template.
+type executableTemplate interface {
+	Execute(wr io.Writer, data interface{}) error
+}
+
+// writeTemplate executes the given template with the data, writing the result to the given path.
+func writeTemplate(tmpl executableTemplate, data interface{}, path string) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// renderSignature renders the given signature as HTML, linking to any documented entities.
+func renderSignature(signature docSignature) template.HTML {
+	var buf bytes.Buffer
+	for _, token := range signature {
+		if token.Link == nil {
+			buf.WriteString(html.EscapeString(token.Text))
+			continue
+		}
+
+		buf.WriteString(`<a href="`)
+		buf.WriteString(html.EscapeString(htmlURL(*token.Link)))
+		buf.WriteString(`">`)
+		buf.WriteString(html.EscapeString(token.Text))
+		buf.WriteString(`</a>`)
+	}
+	return template.HTML(buf.String())
+}
+
+// renderDocumentation renders the given documentation as HTML. Blank lines separate paragraphs and
+// text within backticks is rendered as code.
+func renderDocumentation(documentation string) template.HTML {
+	if documentation == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	for _, paragraph := range strings.Split(documentation, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		buf.WriteString("<p>")
+		for index, part := range strings.Split(paragraph, "`") {
+			// Odd parts are found between backticks.
+			if index%2 == 1 {
+				buf.WriteString("<code>")
+				buf.WriteString(html.EscapeString(part))
+				buf.WriteString("</code>")
+			} else {
+				buf.WriteString(html.EscapeString(part))
+			}
+		}
+		buf.WriteString("</p>\n")
+	}
+	return template.HTML(buf.String())
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docgen
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+var markdownFuncs = template.FuncMap{
+	"signature":     markdownSignature,
+	"memberSection": newMarkdownMemberSection,
+}
+
+const markdownMember = `{{ define "member" }}<a name="{{ .Anchor }}"></a>
+{{ .Heading }} {{ .Member.Kind }} ` + "`{{ .Member.Name }}`" + `
+
+{{ signature .Member.Signature }}
+{{ if .Member.SourceURL }}
+[source]({{ .Member.SourceURL }})
+{{ end }}{{ if .Member.Documentation }}
+{{ .Member.Documentation }}
+{{ end }}{{ template "generics" .Member.Generics }}{{ if .Member.Parameters }}
+Parameters:
+{{ range .Member.Parameters }}
+- ` + "`{{ .Name }} {{ .Type }}`" + `{{ if .Documentation }}: {{ .Documentation }}{{ end }}{{ end }}
+{{ end }}
+{{ end }}`
+
+const markdownGenerics = `{{ define "generics" }}{{ if . }}
+Generics:
+{{ range . }}
+- ` + "`{{ .Name }} : {{ .Constraint }}`" + `{{ if .Documentation }}: {{ .Documentation }}{{ end }}{{ end }}
+{{ end }}{{ end }}`
+
+var markdownIndexTemplate = template.Must(template.New("index").Funcs(markdownFuncs).Parse(`# {{ .Title }}
+
+## Modules
+{{ range .Modules }}
+- [{{ .Name }}]({{ .Page }}.md) ({{ len .Types }} types, {{ len .Members }} members){{ end }}
+`))
+
+var markdownModuleTemplate = template.Must(template.New("module").Funcs(markdownFuncs).Parse(markdownMember + markdownGenerics + `# {{ .Name }}
+{{ if .Types }}
+## Types
+{{ range .Types }}
+<a name="{{ .Anchor }}"></a>
+### {{ .Kind }} ` + "`{{ .Name }}`" + `
+
+{{ signature .Signature }}
+{{ if .SourceURL }}
+[source]({{ .SourceURL }})
+{{ end }}{{ if .Documentation }}
+{{ .Documentation }}
+{{ end }}{{ template "generics" .Generics }}
+{{ range .Members }}{{ template "member" (memberSection "####" .) }}{{ end }}{{ end }}{{ end }}{{ if .Members }}
+## Members
+{{ range .Members }}
+{{ template "member" (memberSection "###" .) }}{{ end }}{{ end }}`))
+
+// markdownMemberSection holds the data used to render a single member in Markdown.
+type markdownMemberSection struct {
+	Heading string
+	Anchor  string
+	Member  docMember
+}
+
+// newMarkdownMemberSection returns the data for rendering the given member under a heading of the given level.
+func newMarkdownMemberSection(heading string, member docMember) markdownMemberSection {
+	return markdownMemberSection{heading, member.Anchor, member}
+}
+
+// writeMarkdown writes the Markdown pages of the given documentation site into the output directory.
+func writeMarkdown(site *docSite, outputDirectory string) error {
+	if err := writeMarkdownTemplate(markdownIndexTemplate, site, filepath.Join(outputDirectory, "index.md")); err != nil {
+		return err
+	}
+
+	for _, module := range site.Modules {
+		if err := writeMarkdownTemplate(markdownModuleTemplate, module, filepath.Join(outputDirectory, module.Page+".md")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeMarkdownTemplate executes the given template with the data, writing the result to the given
+// path with runs of blank lines collapsed into one.
+func writeMarkdownTemplate(tmpl *template.Template, data interface{}, path string) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	contents := strings.TrimSpace(buf.String())
+	for strings.Contains(contents, "\n\n\n") {
+		contents = strings.Replace(contents, "\n\n\n", "\n\n", -1)
+	}
+
+	return ioutil.WriteFile(path, []byte(contents+"\n"), 0644)
+}
+
+// markdownSignature renders the given signature as a Markdown code block. As links cannot be placed
+// within code blocks, the types referenced are listed with links following the block.
+func markdownSignature(signature docSignature) string {
+	var buf bytes.Buffer
+	buf.WriteString("```serulian\n")
+	buf.WriteString(signature.String())
+	buf.WriteString("\n```")
+
+	seen := map[string]bool{}
+	var references []string
+	for _, token := range signature {
+		if token.Link == nil || seen[token.Text] {
+			continue
+		}
+
+		seen[token.Text] = true
+		references = append(references, "["+token.Text+"]("+token.Link.Page+".md#"+token.Link.Anchor+")")
+	}
+
+	if len(references) > 0 {
+		buf.WriteString("\n\nSee: ")
+		buf.WriteString(strings.Join(references, ", "))
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,541 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package docgen
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// testFileSuffix is the suffix of Serulian test files, which are not documented.
+const testFileSuffix = "_test" + sourceshape.SerulianFileExtension
+
+// docLink is a link to a documented entity.
+type docLink struct {
+	// Page is the name of the page (without extension) on which the entity is documented.
+	Page string
+
+	// Anchor is the anchor of the entity on its page.
+	Anchor string
+}
+
+// docToken is a single token in a signature, optionally linking to a documented entity.
+type docToken struct {
+	Text string
+	Link *docLink
+}
+
+// docSignature is a code-like signature, broken into tokens.
+type docSignature []docToken
+
+// text appends a plain text token to the signature.
+func (ds *docSignature) text(text string) {
+	*ds = append(*ds, docToken{text, nil})
+}
+
+// link appends a token linking to the given entity to the signature.
+func (ds *docSignature) link(text string, link docLink) {
+	*ds = append(*ds, docToken{text, &link})
+}
+
+// String returns the signature as plain text.
+func (ds docSignature) String() string {
+	var parts = make([]string, 0, len(ds))
+	for _, token := range ds {
+		parts = append(parts, token.Text)
+	}
+	return strings.Join(parts, "")
+}
+
+// docSite is the documentation of a single Serulian package.
+type docSite struct {
+	// Title is the title of the documentation site.
+	Title string
+
+	// Modules are the documented modules, sorted by path.
+	Modules []*docModule
+
+	// Search is the search index of all documented entities.
+	Search []searchEntry
+}
+
+// docModule is the documentation of a single module.
+type docModule struct {
+	// Name is the path of the module, relative to the package.
+	Name string
+
+	// Page is the name of the page (without extension) documenting the module.
+	Page string
+
+	// SourcePath is the absolute path of the module's source file.
+	SourcePath string
+
+	Types   []docType
+	Members []docMember
+}
+
+// docType is the documentation of a single type.
+type docType struct {
+	Name          string
+	Kind          string
+	Anchor        string
+	Signature     docSignature
+	Documentation string
+	Generics      []docGeneric
+	Members       []docMember
+	SourceURL     string
+}
+
+// docMember is the documentation of a single type or module member.
+type docMember struct {
+	Name          string
+	Kind          string
+	Anchor        string
+	Signature     docSignature
+	Documentation string
+	Generics      []docGeneric
+	Parameters    []docParameter
+	SourceURL     string
+}
+
+// docGeneric is the documentation of a generic on a type or member.
+type docGeneric struct {
+	Name          string
+	Constraint    docSignature
+	Documentation string
+}
+
+// docParameter is the documentation of a parameter on a member.
+type docParameter struct {
+	Name          string
+	Type          docSignature
+	Documentation string
+}
+
+// searchEntry is a single entry in the search index of the documentation site.
+type searchEntry struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+	Module string `json:"module"`
+	URL    string `json:"url"`
+}
+
+// siteBuilder builds the documentation site for a package from its type graph.
+type siteBuilder struct {
+	graph       *typegraph.TypeGraph
+	packageRoot string
+	sourceURL   string
+	typeLinks   map[compilergraph.GraphNodeId]docLink
+}
+
+// buildSite builds the documentation site for the modules found under the given package root in the
+// type graph. The source URL, if any, is the template for links to the source of documented entities.
+func buildSite(graph *typegraph.TypeGraph, packageRoot string, sourceURL string) *docSite {
+	sb := &siteBuilder{
+		graph:       graph,
+		packageRoot: packageRoot,
+		sourceURL:   sourceURL,
+		typeLinks:   map[compilergraph.GraphNodeId]docLink{},
+	}
+
+	// Collect the modules to document, registering links for all their exported types, so that
+	// types can be cross-linked regardless of the module in which they are referenced.
+	modules := map[string]typegraph.TGModule{}
+	for _, module := range graph.Modules() {
+		name, ok := sb.moduleName(module)
+		if !ok {
+			continue
+		}
+
+		modules[name] = module
+		for _, typeDecl := range module.Types() {
+			if typeDecl.IsExported() {
+				sb.typeLinks[typeDecl.Node().NodeId] = docLink{modulePage(name), typeDecl.Name()}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	site := &docSite{
+		Title:   filepath.Base(packageRoot),
+		Modules: make([]*docModule, 0, len(names)),
+		Search:  make([]searchEntry, 0),
+	}
+
+	for _, name := range names {
+		docModule := sb.buildModule(name, modules[name])
+		site.Modules = append(site.Modules, docModule)
+
+		for _, docType := range docModule.Types {
+			site.Search = append(site.Search, searchEntry{docType.Name, docType.Kind, name, htmlURL(docLink{docModule.Page, docType.Anchor})})
+			for _, member := range docType.Members {
+				site.Search = append(site.Search, searchEntry{docType.Name + "." + member.Name, member.Kind, name, htmlURL(docLink{docModule.Page, member.Anchor})})
+			}
+		}
+
+		for _, member := range docModule.Members {
+			site.Search = append(site.Search, searchEntry{member.Name, member.Kind, name, htmlURL(docLink{docModule.Page, member.Anchor})})
+		}
+	}
+
+	return site
+}
+
+// moduleName returns the path of the given module relative to the package root, if the module
+// is a (non-test) Serulian module found under the package.
+func (sb *siteBuilder) moduleName(module typegraph.TGModule) (string, bool) {
+	modulePath := string(module.Path())
+	if !strings.HasSuffix(modulePath, sourceshape.SerulianFileExtension) || strings.HasSuffix(modulePath, testFileSuffix) {
+		return "", false
+	}
+
+	absolutePath, err := filepath.Abs(modulePath)
+	if err != nil {
+		return "", false
+	}
+
+	relativePath, err := filepath.Rel(sb.packageRoot, absolutePath)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return "", false
+	}
+
+	for _, part := range strings.Split(relativePath, string(filepath.Separator)) {
+		if part == packageloader.SerulianPackageDirectory {
+			return "", false
+		}
+	}
+
+	return filepath.ToSlash(relativePath), true
+}
+
+// buildModule builds the documentation for the given module.
+func (sb *siteBuilder) buildModule(name string, module typegraph.TGModule) *docModule {
+	docModule := &docModule{
+		Name:       name,
+		Page:       modulePage(name),
+		SourcePath: filepath.Join(sb.packageRoot, filepath.FromSlash(name)),
+		Types:      make([]docType, 0),
+		Members:    make([]docMember, 0),
+	}
+
+	for _, typeDecl := range module.Types() {
+		if typeDecl.IsExported() {
+			docModule.Types = append(docModule.Types, sb.buildType(docModule, typeDecl))
+		}
+	}
+
+	for _, member := range module.Members() {
+		if member.IsExported() {
+			docModule.Members = append(docModule.Members, sb.buildMember(docModule, member, ""))
+		}
+	}
+
+	sort.Sort(sortedDocTypes(docModule.Types))
+	sort.Sort(sortedDocMembers(docModule.Members))
+	return docModule
+}
+
+// buildType builds the documentation for the given type.
+func (sb *siteBuilder) buildType(module *docModule, typeDecl typegraph.TGTypeDecl) docType {
+	var signature docSignature
+	signature.text(typeKeyword(typeDecl) + " ")
+	signature.text(typeDecl.Name())
+	sb.appendGenerics(&signature, typeDecl.Generics())
+
+	if principalType, hasPrincipalType := typeDecl.PrincipalType(); hasPrincipalType {
+		signature.text(" for ")
+		sb.appendTypeReference(&signature, principalType)
+	}
+
+	parentTypes := typeDecl.ParentTypes()
+	if len(parentTypes) > 0 {
+		signature.text(" : ")
+		for index, parentType := range parentTypes {
+			if index > 0 {
+				signature.text(" + ")
+			}
+			sb.appendTypeReference(&signature, parentType)
+		}
+	}
+
+	documentation, _ := typeDecl.Documentation()
+	docType := docType{
+		Name:          typeDecl.Name(),
+		Kind:          typeKeyword(typeDecl),
+		Anchor:        typeDecl.Name(),
+		Signature:     signature,
+		Documentation: documentation,
+		Generics:      sb.buildGenerics(typeDecl.Generics()),
+		Members:       make([]docMember, 0),
+		SourceURL:     sb.sourceLink(module, typeDecl),
+	}
+
+	for _, member := range typeDecl.MembersAndOperators() {
+		if member.IsExported() || member.IsOperator() {
+			docType.Members = append(docType.Members, sb.buildMember(module, member, typeDecl.Name()+"."))
+		}
+	}
+
+	sort.Sort(sortedDocMembers(docType.Members))
+	return docType
+}
+
+// buildMember builds the documentation for the given member. The anchor prefix is placed before the
+// member's name to form its anchor.
+func (sb *siteBuilder) buildMember(module *docModule, member typegraph.TGMember, anchorPrefix string) docMember {
+	keyword := memberKeyword(member)
+
+	var signature docSignature
+	signature.text(keyword + " ")
+	signature.text(member.Name())
+	sb.appendGenerics(&signature, member.Generics())
+
+	parameters := member.Parameters()
+	_, isConstructor := member.ConstructorType()
+	if len(parameters) > 0 || isConstructor || keyword == "function" || keyword == "operator" {
+		signature.text("(")
+		for index, parameter := range parameters {
+			if index > 0 {
+				signature.text(", ")
+			}
+
+			if name, hasName := parameter.Name(); hasName {
+				signature.text(name + " ")
+			}
+			sb.appendTypeReference(&signature, parameter.DeclaredType())
+		}
+		signature.text(")")
+	}
+
+	declaredType := member.DeclaredType()
+	if !isConstructor && !member.IsEnumValue() && !declaredType.IsVoid() {
+		signature.text(" ")
+		sb.appendTypeReference(&signature, declaredType)
+	}
+
+	documentation, _ := member.Documentation()
+	docMember := docMember{
+		Name:          member.Name(),
+		Kind:          keyword,
+		Anchor:        anchorPrefix + member.Name(),
+		Signature:     signature,
+		Documentation: documentation,
+		Generics:      sb.buildGenerics(member.Generics()),
+		Parameters:    make([]docParameter, 0, len(parameters)),
+		SourceURL:     sb.sourceLink(module, member),
+	}
+
+	for _, parameter := range parameters {
+		name, _ := parameter.Name()
+		parameterDocumentation, _ := parameter.Documentation()
+
+		var parameterType docSignature
+		sb.appendTypeReference(&parameterType, parameter.DeclaredType())
+		docMember.Parameters = append(docMember.Parameters, docParameter{name, parameterType, parameterDocumentation})
+	}
+
+	return docMember
+}
+
+// buildGenerics builds the documentation for the given generics.
+func (sb *siteBuilder) buildGenerics(generics []typegraph.TGGeneric) []docGeneric {
+	docGenerics := make([]docGeneric, 0, len(generics))
+	for _, generic := range generics {
+		var constraint docSignature
+		sb.appendTypeReference(&constraint, generic.Constraint())
+
+		documentation, _ := generic.AsType().Documentation()
+		docGenerics = append(docGenerics, docGeneric{generic.Name(), constraint, documentation})
+	}
+	return docGenerics
+}
+
+// appendGenerics appends the given generics, with their constraints, to the signature.
+func (sb *siteBuilder) appendGenerics(signature *docSignature, generics []typegraph.TGGeneric) {
+	if len(generics) == 0 {
+		return
+	}
+
+	signature.text("<")
+	for index, generic := range generics {
+		if index > 0 {
+			signature.text(", ")
+		}
+
+		signature.text(generic.Name())
+
+		constraint := generic.Constraint()
+		if !constraint.IsAny() {
+			signature.text(" : ")
+			sb.appendTypeReference(signature, constraint)
+		}
+	}
+	signature.text(">")
+}
+
+// appendTypeReference appends the given type reference to the signature, linking to any documented
+// types referenced.
+func (sb *siteBuilder) appendTypeReference(signature *docSignature, typeRef typegraph.TypeReference) {
+	if !typeRef.IsNormal() {
+		signature.text(typeRef.String())
+		return
+	}
+
+	referredType := typeRef.ReferredType()
+	if referredType.TypeKind() == typegraph.GenericType {
+		signature.text(referredType.Name())
+	} else if link, isDocumented := sb.typeLinks[referredType.Node().NodeId]; isDocumented {
+		signature.link(referredType.DescriptiveName(), link)
+	} else {
+		signature.text(referredType.DescriptiveName())
+	}
+
+	if typeRef.HasGenerics() {
+		signature.text("<")
+		for index, generic := range typeRef.Generics() {
+			if index > 0 {
+				signature.text(", ")
+			}
+			sb.appendTypeReference(signature, generic)
+		}
+		signature.text(">")
+	}
+
+	if typeRef.HasParameters() {
+		signature.text("(")
+		for index, parameter := range typeRef.Parameters() {
+			if index > 0 {
+				signature.text(", ")
+			}
+			sb.appendTypeReference(signature, parameter)
+		}
+		signature.text(")")
+	}
+
+	if typeRef.IsNullable() {
+		signature.text("?")
+	}
+}
+
+type sourceRangeCapable interface {
+	SourceRange() (compilercommon.SourceRange, bool)
+}
+
+// sourceLink returns the URL of the source of the given entity, if any.
+func (sb *siteBuilder) sourceLink(module *docModule, entity sourceRangeCapable) string {
+	sourceRange, hasSourceRange := entity.SourceRange()
+	if !hasSourceRange {
+		return ""
+	}
+
+	line, _, err := sourceRange.Start().LineAndColumn()
+	if err != nil {
+		return ""
+	}
+
+	lineNumber := strconv.Itoa(line + 1)
+	if sb.sourceURL == "" {
+		return sourcePageURL(module.Page) + "#L" + lineNumber
+	}
+
+	url := strings.Replace(sb.sourceURL, "{path}", module.Name, -1)
+	return strings.Replace(url, "{line}", lineNumber, -1)
+}
+
+// typeKeyword returns the keyword used to declare the given type.
+func typeKeyword(typeDecl typegraph.TGTypeDecl) string {
+	switch typeDecl.TypeKind() {
+	case typegraph.ClassType:
+		return "class"
+
+	case typegraph.ImplicitInterfaceType, typegraph.ExternalInternalType:
+		return "interface"
+
+	case typegraph.NominalType:
+		return "type"
+
+	case typegraph.StructType:
+		return "struct"
+
+	case typegraph.AgentType:
+		return "agent"
+
+	case typegraph.EnumType:
+		return "enum"
+
+	default:
+		return typeDecl.Title()
+	}
+}
+
+// memberKeyword returns the keyword used to declare the given member.
+func memberKeyword(member typegraph.TGMember) string {
+	switch typegraph.MemberSignatureKind(member.Signature().MemberKind) {
+	case typegraph.ConstructorMemberSignature, typegraph.NativeConstructorMemberSignature:
+		return "constructor"
+
+	case typegraph.FunctionMemberSignature, typegraph.NativeFunctionMemberSignature:
+		return "function"
+
+	case typegraph.PropertyMemberSignature, typegraph.NativePropertyMemberSignature:
+		return "property"
+
+	case typegraph.OperatorMemberSignature, typegraph.NativeOperatorMemberSignature:
+		return "operator"
+
+	case typegraph.FieldMemberSignature:
+		if member.IsReadOnly() {
+			return "const"
+		}
+
+		return "var"
+
+	case typegraph.EnumValueMemberSignature:
+		return "value"
+
+	default:
+		return "member"
+	}
+}
+
+// modulePage returns the name of the page documenting the module with the given relative path.
+func modulePage(name string) string {
+	return strings.Replace(strings.TrimSuffix(name, sourceshape.SerulianFileExtension), "/", ".", -1)
+}
+
+// sourcePageURL returns the URL of the source page for the module with the given page.
+func sourcePageURL(page string) string {
+	return "source/" + page + ".html"
+}
+
+// htmlURL returns the URL of the given link in the HTML site.
+func htmlURL(link docLink) string {
+	return link.Page + ".html#" + link.Anchor
+}
+
+type sortedDocTypes []docType
+
+func (s sortedDocTypes) Len() int           { return len(s) }
+func (s sortedDocTypes) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortedDocTypes) Less(i, j int) bool { return s[i].Name < s[j].Name }
+
+type sortedDocMembers []docMember
+
+func (s sortedDocMembers) Len() int           { return len(s) }
+func (s sortedDocMembers) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sortedDocMembers) Less(i, j int) bool { return s[i].Name < s[j].Name }
@@ -0,0 +1,135 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	diffmatchpatch "github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// diffContextLines is the number of unchanged lines placed around each change in a unified diff.
+const diffContextLines = 3
+
+// diffLine is a single line of a line-based diff.
+type diffLine struct {
+	operation diffmatchpatch.Operation
+	text      string
+}
+
+// unifiedDiff returns a unified diff of the changes from the original to the updated contents of
+// the file at the given path. Returns the empty string if the contents are the same.
+func unifiedDiff(path string, original string, updated string) string {
+	if original == updated {
+		return ""
+	}
+
+	lines := diffLines(original, updated)
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("--- %s (original)\n", path))
+	buf.WriteString(fmt.Sprintf("+++ %s (formatted)\n", path))
+
+	// Track the line number in both the original and updated contents before each diff line.
+	originalLines := make([]int, len(lines)+1)
+	updatedLines := make([]int, len(lines)+1)
+	for index, line := range lines {
+		originalLines[index+1] = originalLines[index]
+		updatedLines[index+1] = updatedLines[index]
+
+		if line.operation != diffmatchpatch.DiffInsert {
+			originalLines[index+1]++
+		}
+
+		if line.operation != diffmatchpatch.DiffDelete {
+			updatedLines[index+1]++
+		}
+	}
+
+	// Group the changed lines into hunks, merging changes separated by little enough context.
+	index := 0
+	for index < len(lines) {
+		if lines[index].operation == diffmatchpatch.DiffEqual {
+			index++
+			continue
+		}
+
+		start := index - diffContextLines
+		if start < 0 {
+			start = 0
+		}
+
+		lastChange := index
+		for current := index; current < len(lines) && current <= lastChange+2*diffContextLines; current++ {
+			if lines[current].operation != diffmatchpatch.DiffEqual {
+				lastChange = current
+			}
+		}
+
+		end := lastChange + diffContextLines + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		buf.WriteString(fmt.Sprintf("@@ -%s +%s @@\n",
+			hunkRange(originalLines[start], originalLines[end]-originalLines[start]),
+			hunkRange(updatedLines[start], updatedLines[end]-updatedLines[start])))
+
+		for _, line := range lines[start:end] {
+			switch line.operation {
+			case diffmatchpatch.DiffEqual:
+				buf.WriteString(" ")
+
+			case diffmatchpatch.DiffInsert:
+				buf.WriteString("+")
+
+			case diffmatchpatch.DiffDelete:
+				buf.WriteString("-")
+			}
+
+			buf.WriteString(line.text)
+			if !strings.HasSuffix(line.text, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+
+		index = end
+	}
+
+	return buf.String()
+}
+
+// hunkRange returns the range of a hunk in a unified diff, given the 0-indexed line before which the
+// hunk starts and its number of lines.
+func hunkRange(startLine int, lineCount int) string {
+	if lineCount == 0 {
+		return fmt.Sprintf("%d,0", startLine)
+	}
+
+	if lineCount == 1 {
+		return fmt.Sprintf("%d", startLine+1)
+	}
+
+	return fmt.Sprintf("%d,%d", startLine+1, lineCount)
+}
+
+// diffLines returns the line-based diff between the original and updated contents.
+func diffLines(original string, updated string) []diffLine {
+	dmp := diffmatchpatch.New()
+	originalChars, updatedChars, lineArray := dmp.DiffLinesToChars(original, updated)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(originalChars, updatedChars, false), lineArray)
+
+	lines := make([]diffLine, 0, len(diffs))
+	for _, diff := range diffs {
+		for _, text := range strings.SplitAfter(diff.Text, "\n") {
+			if text != "" {
+				lines = append(lines, diffLine{diff.Type, text})
+			}
+		}
+	}
+	return lines
+}
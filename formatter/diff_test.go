@@ -0,0 +1,38 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type unifiedDiffTest struct {
+	name         string
+	original     string
+	updated      string
+	expectedDiff string
+}
+
+var unifiedDiffTests = []unifiedDiffTest{
+	unifiedDiffTest{"no changes", "a\nb\n", "a\nb\n", ""},
+	unifiedDiffTest{"line added", "a\nb\n", "a\nx\nb\n",
+		"--- some.seru (original)\n+++ some.seru (formatted)\n@@ -1,2 +1,3 @@\n a\n+x\n b\n"},
+	unifiedDiffTest{"line changed", "a\nb\nc\n", "a\nx\nc\n",
+		"--- some.seru (original)\n+++ some.seru (formatted)\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n"},
+	unifiedDiffTest{"missing newline", "a\nb", "a\nb\n",
+		"--- some.seru (original)\n+++ some.seru (formatted)\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n"},
+	unifiedDiffTest{"separate hunks", "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "x\n2\n3\n4\n5\n6\n7\n8\n9\ny\n",
+		"--- some.seru (original)\n+++ some.seru (formatted)\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+y\n"},
+	unifiedDiffTest{"merged hunks", "1\n2\n3\n4\n5\n6\n7\n", "x\n2\n3\n4\n5\n6\ny\n",
+		"--- some.seru (original)\n+++ some.seru (formatted)\n@@ -1,7 +1,7 @@\n-1\n+x\n 2\n 3\n 4\n 5\n 6\n-7\n+y\n"},
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	for _, test := range unifiedDiffTests {
+		assert.Equal(t, test.expectedDiff, unifiedDiff("some.seru", test.original, test.updated), "Diff mismatch for test %s", test.name)
+	}
+}
@@ -65,6 +65,7 @@ var nonWrappingUnaryNodeKinds = []sourceshape.NodeType{
 	sourceshape.NodeNumericLiteralExpression,
 	sourceshape.NodeTypeIdentifierExpression,
 	sourceshape.NodeListLiteralExpression,
+	sourceshape.NodeTupleLiteralExpression,
 	sourceshape.NodeMapLiteralExpression,
 	sourceshape.NodeMemberAccessExpression,
 	sourceshape.NodeDynamicMemberAccessExpression,
@@ -247,6 +248,7 @@ var nonWrappingSliceOrCallNodeKinds = []sourceshape.NodeType{
 	sourceshape.NodeNumericLiteralExpression,
 	sourceshape.NodeTypeIdentifierExpression,
 	sourceshape.NodeListLiteralExpression,
+	sourceshape.NodeTupleLiteralExpression,
 	sourceshape.NodeMemberAccessExpression,
 	sourceshape.NodeNullableMemberAccessExpression,
 	sourceshape.NodeDynamicMemberAccessExpression,
@@ -278,6 +280,11 @@ func (sf *sourceFormatter) emitFunctionCallExpression(node formatterNode) {
 			sf.append(", ")
 		}
 
+		if argumentName, isNamed := arg.tryGetProperty(sourceshape.NodeFunctionCallArgumentName); isNamed {
+			sf.append(argumentName)
+			sf.append(": ")
+		}
+
 		sf.emitNode(arg)
 	}
 
@@ -366,6 +373,14 @@ func (sf *sourceFormatter) emitListLiteralExpression(node formatterNode) {
 	sf.append("]")
 }
 
+// emitTupleLiteralExpression emits a tuple literal expression.
+func (sf *sourceFormatter) emitTupleLiteralExpression(node formatterNode) {
+	sf.append("(")
+	exprs := node.getChildren(sourceshape.NodeTupleLiteralExpressionValue)
+	sf.emitInnerExpressions(exprs)
+	sf.append(")")
+}
+
 // emitSliceLiteralExpression emits a slice literal expression.
 func (sf *sourceFormatter) emitSliceLiteralExpression(node formatterNode) {
 	sf.append("[]")
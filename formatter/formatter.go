@@ -100,42 +100,60 @@ func (ih importHandlingInfo) log(level compilerutil.ConsoleLogLevel, node format
 // FreezeAt formats the source files at the given path and freezes the specified VCS import pattern at the given
 // commit or tag.
 func FreezeAt(path string, importPattern string, commitOrTag CommitOrTag, vcsDevelopmentDirectories []string, debug bool) bool {
-	return formatFiles(path, importHandlingInfo{importHandlingCustomFreeze, []string{importPattern}, vcsDevelopmentDirectories, false, commitOrTag}, false, debug)
+	return formatFiles(path, importHandlingInfo{importHandlingCustomFreeze, []string{importPattern}, vcsDevelopmentDirectories, false, commitOrTag}, false, formatModeWrite, debug)
 }
 
 // UnfreezeAt formats the source files at the given path and unfreezes the specified
 // VCS import pattern.
 func UnfreezeAt(path string, importPattern string, vcsDevelopmentDirectories []string, debug bool) bool {
-	return formatFiles(path, importHandlingInfo{importHandlingUnfreeze, []string{importPattern}, vcsDevelopmentDirectories, false, CommitOrTag{}}, false, debug)
+	return formatFiles(path, importHandlingInfo{importHandlingUnfreeze, []string{importPattern}, vcsDevelopmentDirectories, false, CommitOrTag{}}, false, formatModeWrite, debug)
 }
 
 // Freeze formats the source files at the given path and freezes the specified
 // VCS import patterns.
 func Freeze(path string, importPatterns []string, vcsDevelopmentDirectories []string, debug bool) bool {
-	return formatFiles(path, importHandlingInfo{importHandlingFreeze, importPatterns, vcsDevelopmentDirectories, true, CommitOrTag{}}, false, debug)
+	return formatFiles(path, importHandlingInfo{importHandlingFreeze, importPatterns, vcsDevelopmentDirectories, true, CommitOrTag{}}, false, formatModeWrite, debug)
 }
 
 // Unfreeze formats the source files at the given path and unfreezes the specified
 // VCS import patterns.
 func Unfreeze(path string, importPatterns []string, vcsDevelopmentDirectories []string, debug bool) bool {
-	return formatFiles(path, importHandlingInfo{importHandlingUnfreeze, importPatterns, vcsDevelopmentDirectories, true, CommitOrTag{}}, false, debug)
+	return formatFiles(path, importHandlingInfo{importHandlingUnfreeze, importPatterns, vcsDevelopmentDirectories, true, CommitOrTag{}}, false, formatModeWrite, debug)
 }
 
 // Update formats the source files at the given path and updates the specified
 // VCS import patterns by moving forward their minor version, as per semvar.
 func Update(path string, importPatterns []string, vcsDevelopmentDirectories []string, debug bool) bool {
-	return formatFiles(path, importHandlingInfo{importHandlingUpdate, importPatterns, vcsDevelopmentDirectories, true, CommitOrTag{}}, false, debug)
+	return formatFiles(path, importHandlingInfo{importHandlingUpdate, importPatterns, vcsDevelopmentDirectories, true, CommitOrTag{}}, false, formatModeWrite, debug)
 }
 
 // Upgrade formats the source files at the given path and upgrades the specified
 // VCS import patterns by making them refer to the latest stable version, as per semvar.
 func Upgrade(path string, importPatterns []string, vcsDevelopmentDirectories []string, debug bool) bool {
-	return formatFiles(path, importHandlingInfo{importHandlingUpgrade, importPatterns, vcsDevelopmentDirectories, true, CommitOrTag{}}, false, debug)
+	return formatFiles(path, importHandlingInfo{importHandlingUpgrade, importPatterns, vcsDevelopmentDirectories, true, CommitOrTag{}}, false, formatModeWrite, debug)
 }
 
 // Format formats the source files at the given path.
 func Format(path string, supportOlderSyntax bool, debug bool) bool {
-	return formatFiles(path, importHandlingInfo{importHandlingNone, []string{}, []string{}, false, CommitOrTag{}}, supportOlderSyntax, debug)
+	return formatFiles(path, importHandlingInfo{importHandlingNone, []string{}, []string{}, false, CommitOrTag{}}, supportOlderSyntax, formatModeWrite, debug)
+}
+
+// Check checks the formatting of the source files at the given path without modifying them, listing
+// each source file whose formatting would change. If showDiff is true, a unified diff of the changes
+// is printed for each such file as well. Returns false if any source file is not formatted.
+func Check(path string, supportOlderSyntax bool, showDiff bool, debug bool) bool {
+	mode := formatModeList
+	if showDiff {
+		mode = mode | formatModeDiff
+	}
+
+	return formatFiles(path, importHandlingInfo{importHandlingNone, []string{}, []string{}, false, CommitOrTag{}}, supportOlderSyntax, mode, debug)
+}
+
+// Diff prints a unified diff of the changes formatting would make to the source files at the given
+// path, without modifying them.
+func Diff(path string, supportOlderSyntax bool, debug bool) bool {
+	return formatFiles(path, importHandlingInfo{importHandlingNone, []string{}, []string{}, false, CommitOrTag{}}, supportOlderSyntax, formatModeDiff, debug)
 }
 
 // FormatSource formats the given Serulian source code.
@@ -143,57 +161,109 @@ func FormatSource(source string) (string, error) {
 	return formatSource(source, importHandlingInfo{importHandlingNone, []string{}, []string{}, false, CommitOrTag{}}, false)
 }
 
+// formatMode defines how the changes made by formatting a source file are handled.
+type formatMode int
+
+const (
+	// formatModeWrite writes the formatted source back to its source file.
+	formatModeWrite formatMode = 1 << iota
+
+	// formatModeList lists each source file whose formatting would change.
+	formatModeList
+
+	// formatModeDiff prints a unified diff of the changes formatting would make to each source file.
+	formatModeDiff
+)
+
+// skippedDirectories are the names of the directories whose source files are never formatted.
+var skippedDirectories = []string{packageloader.SerulianPackageDirectory, packageloader.SerulianVendorDirectory}
+
 // formatFiles runs formatting of all matching source files found at the given source path.
-func formatFiles(path string, importHandling importHandlingInfo, supportOlderSyntax bool, debug bool) bool {
+func formatFiles(path string, importHandling importHandlingInfo, supportOlderSyntax bool, mode formatMode, debug bool) bool {
 	if !debug {
 		log.SetOutput(ioutil.Discard)
 	}
 
+	var unformattedFiles = 0
 	filesWalked, err := compilerutil.WalkSourcePath(path, func(currentPath string, info os.FileInfo) (bool, error) {
 		if !strings.HasSuffix(info.Name(), sourceshape.SerulianFileExtension) {
 			return false, nil
 		}
 
-		err := parseAndFormatSourceFile(currentPath, info, importHandling, supportOlderSyntax)
+		changed, err := parseAndFormatSourceFile(currentPath, info, importHandling, supportOlderSyntax, mode)
 		if err != nil {
 			compilerutil.LogToConsole(compilerutil.WarningLogLevel, nil, "Found syntax errors for path: `%s`; skipping format", currentPath)
 		}
+
+		if changed || (err != nil && mode&formatModeList == formatModeList) {
+			unformattedFiles++
+		}
 		return true, nil
-	}, packageloader.SerulianPackageDirectory)
+	}, skippedDirectories...)
 
 	if filesWalked == 0 {
 		compilerutil.LogToConsole(compilerutil.WarningLogLevel, nil, "No valid source files found for path `%s`", path)
 		return false
 	}
 
+	if mode&formatModeList == formatModeList && unformattedFiles > 0 {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "%v source file(s) are not formatted", unformattedFiles)
+		return false
+	}
+
 	return err == nil
 }
 
-// parseAndFormatSourceFile parses the source file at the given path (with associated file info),
-// formats it and, if changed, writes it back to that path.
-func parseAndFormatSourceFile(sourceFilePath string, info os.FileInfo, importHandling importHandlingInfo, supportOlderSyntax bool) error {
+// parseAndFormatSourceFile parses the source file at the given path (with associated file info) and
+// formats it, handling any changes as per the given mode. Returns whether formatting changed the file.
+func parseAndFormatSourceFile(sourceFilePath string, info os.FileInfo, importHandling importHandlingInfo, supportOlderSyntax bool, mode formatMode) (bool, error) {
 	// Load the source from the file.
 	source, err := ioutil.ReadFile(sourceFilePath)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	formatted, err := formatSource(string(source), importHandling, supportOlderSyntax)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if string(formatted) == string(source) {
 		// Nothing changed.
-		return nil
+		return false, nil
+	}
+
+	if mode&formatModeList == formatModeList {
+		fmt.Println(sourceFilePath)
+	}
+
+	if mode&formatModeDiff == formatModeDiff {
+		fmt.Print(unifiedDiff(sourceFilePath, string(source), formatted))
+	}
+
+	if mode&formatModeWrite == formatModeWrite {
+		// Overwrite the file with the formatted source.
+		return true, ioutil.WriteFile(sourceFilePath, []byte(formatted), info.Mode())
 	}
 
-	// Overwrite the file with the formatted source.
-	return ioutil.WriteFile(sourceFilePath, []byte(formatted), info.Mode())
+	return true, nil
 }
 
 // formatSource formats the given Serulian source code, with the given import handling.
 func formatSource(source string, importHandling importHandlingInfo, supportOlderSyntax bool) (string, error) {
+	parseTree, rootNode, err := parseSource(source, supportOlderSyntax)
+	if err != nil {
+		return "", err
+	}
+
+	// Create the formatted source.
+	formattedSource := buildFormattedSource(parseTree, rootNode, importHandling)
+	return string(formattedSource), nil
+}
+
+// parseSource parses the given Serulian source code into a parse tree for formatting, returning
+// the tree and its root node.
+func parseSource(source string, supportOlderSyntax bool) (*parseTree, formatterNode, error) {
 	// Conduct the parsing.
 	parseTree := newParseTree([]byte(source))
 	inputSource := compilercommon.InputSource("(formatting)")
@@ -207,10 +277,8 @@ func formatSource(source string, importHandling importHandlingInfo, supportOlder
 
 	// Report any errors found.
 	if len(parseTree.errors) > 0 {
-		return "", fmt.Errorf("Parsing errors found in source")
+		return nil, formatterNode{}, fmt.Errorf("Parsing errors found in source")
 	}
 
-	// Create the formatted source.
-	formattedSource := buildFormattedSource(parseTree, rootNode.(formatterNode), importHandling)
-	return string(formattedSource), nil
+	return parseTree, rootNode.(formatterNode), nil
 }
@@ -50,6 +50,11 @@ func (sf *sourceFormatter) emitTypeDefinition(node formatterNode, kind string) {
 		sf.emitNode(node.getChild(sourceshape.NodeAgentPredicatePrincipalType))
 	}
 
+	if node.hasChild(sourceshape.NodeExtensionPredicateExtendedType) {
+		sf.append(" for ")
+		sf.emitNode(node.getChild(sourceshape.NodeExtensionPredicateExtendedType))
+	}
+
 	if node.GetType() == sourceshape.NodeTypeNominal {
 		baseType := node.getChild(sourceshape.NodeNominalPredicateBaseType)
 		sf.append(" : ")
@@ -67,8 +72,10 @@ func (sf *sourceFormatter) emitTypeDefinition(node formatterNode, kind string) {
 		sf.hasNewScope = true
 
 		// Emit the members, grouped in order:
+		// Enum values
 		// Variables (no default)
 		// Variables (defaults)
+		sf.emitTypeMembers(members, false, nil, sourceshape.NodeTypeEnumValue)
 		sf.emitTypeMembers(members, false, func(current formatterNode) bool {
 			return !current.hasChild(sourceshape.NodePredicateTypeFieldDefaultValue)
 		}, sourceshape.NodeTypeField)
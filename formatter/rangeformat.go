@@ -0,0 +1,331 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/sourceshape"
+
+	diffmatchpatch "github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// TextEdit represents a single edit to Serulian source code, replacing the text found between
+// two rune positions with new text.
+type TextEdit struct {
+	// StartRune is the position (inclusive) at which the replaced text starts.
+	StartRune int
+
+	// EndRune is the position (exclusive) at which the replaced text ends. If the same as the
+	// StartRune, the edit is an insertion.
+	EndRune int
+
+	// Text is the text with which to replace the text between the positions. If empty, the edit
+	// is a deletion.
+	Text string
+}
+
+// unitNodeTypes defines the types of nodes that are formatted as a unit when formatting a
+// range of source code.
+var unitNodeTypes = []sourceshape.NodeType{
+	sourceshape.NodeTypeImport,
+
+	sourceshape.NodeTypeClass,
+	sourceshape.NodeTypeInterface,
+	sourceshape.NodeTypeNominal,
+	sourceshape.NodeTypeStruct,
+	sourceshape.NodeTypeAgent,
+	sourceshape.NodeTypeEnum,
+	sourceshape.NodeTypeExtension,
+
+	sourceshape.NodeTypeFunction,
+	sourceshape.NodeTypeVariable,
+
+	sourceshape.NodeTypeConstructor,
+	sourceshape.NodeTypeProperty,
+	sourceshape.NodeTypeOperator,
+	sourceshape.NodeTypeField,
+	sourceshape.NodeTypeEnumValue,
+
+	sourceshape.NodeTypeArrowStatement,
+	sourceshape.NodeTypeLoopStatement,
+	sourceshape.NodeTypeConditionalStatement,
+	sourceshape.NodeTypeReturnStatement,
+	sourceshape.NodeTypeYieldStatement,
+	sourceshape.NodeTypeRejectStatement,
+	sourceshape.NodeTypeBreakStatement,
+	sourceshape.NodeTypeContinueStatement,
+	sourceshape.NodeTypeVariableStatement,
+	sourceshape.NodeTypeWithStatement,
+	sourceshape.NodeTypeDeferStatement,
+	sourceshape.NodeTypeSwitchStatement,
+	sourceshape.NodeTypeMatchStatement,
+	sourceshape.NodeTypeAssignStatement,
+	sourceshape.NodeTypeResolveStatement,
+	sourceshape.NodeTypeExpressionStatement,
+}
+
+// FormatSourceEdits formats the given Serulian source code, returning the minimal set of edits
+// that transform the source code into its formatted form, in order.
+func FormatSourceEdits(source string) ([]TextEdit, error) {
+	formatted, err := FormatSource(source)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	return computeEdits(source, formatted), nil
+}
+
+// FormatRange formats only the portion of the given Serulian source code found in the given range,
+// returning the minimal set of edits to apply to the source code, in order. The range is expanded
+// to include the statements or members enclosing its start and end positions, as well as the full
+// lines on which they are found.
+func FormatRange(source string, sourceRange compilercommon.SourceRange) ([]TextEdit, error) {
+	startRune, err := sourceRange.Start().RunePosition()
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	endRune, err := sourceRange.End().RunePosition()
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	return formatRunes(source, startRune, endRune)
+}
+
+// FormatOnType formats the statement or member just typed before the given position in the given
+// Serulian source code, returning the minimal set of edits to apply to the source code, in order.
+// Meant to be invoked by editors after a closing brace or a newline is typed.
+func FormatOnType(source string, position compilercommon.SourcePosition) ([]TextEdit, error) {
+	runePosition, err := position.RunePosition()
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	// Find the last non-whitespace character typed before the position.
+	typed := runePosition - 1
+	if typed >= len(source) {
+		typed = len(source) - 1
+	}
+
+	for typed >= 0 && strings.ContainsRune(" \t\r\n", rune(source[typed])) {
+		typed--
+	}
+
+	if typed < 0 {
+		return []TextEdit{}, nil
+	}
+
+	return formatRunes(source, typed, typed)
+}
+
+// formatRunes formats the portion of the given source code between the given rune positions (inclusive),
+// as expanded to the enclosing statements or members and their full lines.
+func formatRunes(source string, startRune int, endRune int) ([]TextEdit, error) {
+	parseTree, rootNode, err := parseSource(source, false)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	if startRune > endRune {
+		startRune, endRune = endRune, startRune
+	}
+
+	// Expand the range to the enclosing units.
+	if unitStart, unitEnd, found := findEnclosingUnit(rootNode, startRune); found {
+		startRune = minInt(startRune, unitStart)
+		endRune = maxInt(endRune, unitEnd)
+	}
+
+	if unitStart, unitEnd, found := findEnclosingUnit(rootNode, endRune); found {
+		startRune = minInt(startRune, unitStart)
+		endRune = maxInt(endRune, unitEnd)
+	}
+
+	// Expand the range to full lines.
+	lineStart, lineEnd := expandToLines(source, startRune, endRune)
+
+	formatted := buildFormattedSource(parseTree, rootNode, importHandlingInfo{importHandlingNone, []string{}, []string{}, false, CommitOrTag{}})
+
+	var edits = make([]TextEdit, 0)
+	for _, edit := range computeEdits(source, string(formatted)) {
+		if edit.StartRune >= lineStart && edit.StartRune < lineEnd && edit.EndRune <= lineEnd {
+			edits = append(edits, edit)
+		}
+	}
+
+	return edits, nil
+}
+
+// findEnclosingUnit returns the start and end (inclusive) rune positions of the smallest unit
+// node containing the given rune position, if any.
+func findEnclosingUnit(node formatterNode, runePosition int) (int, int, bool) {
+	var unitStart, unitEnd int
+	var found = false
+
+	var current = node
+	for {
+		var next formatterNode
+		var hasNext = false
+
+		for _, child := range current.getAllChildren() {
+			childStart, childEnd, hasRange := getNodeRunes(child)
+			if !hasRange || runePosition < childStart || runePosition > childEnd {
+				continue
+			}
+
+			if child.hasType(unitNodeTypes...) {
+				unitStart, unitEnd, found = childStart, childEnd, true
+			}
+
+			next = child
+			hasNext = true
+			break
+		}
+
+		if !hasNext {
+			return unitStart, unitEnd, found
+		}
+
+		current = next
+	}
+}
+
+// getNodeRunes returns the start and end (inclusive) rune positions of the given node, if any.
+func getNodeRunes(node formatterNode) (int, int, bool) {
+	startValue, hasStart := node.tryGetProperty(sourceshape.NodePredicateStartRune)
+	endValue, hasEnd := node.tryGetProperty(sourceshape.NodePredicateEndRune)
+	if !hasStart || !hasEnd {
+		return 0, 0, false
+	}
+
+	start, err := strconv.Atoi(startValue)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	end, err := strconv.Atoi(endValue)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
+
+// expandToLines returns the start (inclusive) and end (exclusive) rune positions of the full
+// lines containing the given rune positions (inclusive), including the trailing newline.
+func expandToLines(source string, startRune int, endRune int) (int, int) {
+	if startRune > len(source) {
+		startRune = len(source)
+	}
+
+	if endRune >= len(source) {
+		endRune = len(source) - 1
+	}
+
+	lineStart := strings.LastIndex(source[:startRune], "\n") + 1
+	if endRune < lineStart {
+		return lineStart, lineStart
+	}
+
+	lineEnd := strings.Index(source[endRune:], "\n")
+	if lineEnd < 0 {
+		return lineStart, len(source)
+	}
+
+	return lineStart, endRune + lineEnd + 1
+}
+
+// computeEdits returns the minimal set of edits that transform the original source code into the
+// updated source code, in order. The changed lines are found first, with the edits then computed
+// between the runs of changed lines.
+func computeEdits(original string, updated string) []TextEdit {
+	var edits = make([]TextEdit, 0)
+	var position = 0
+
+	var deleted bytes.Buffer
+	var inserted bytes.Buffer
+
+	flush := func() {
+		if deleted.Len() == 0 && inserted.Len() == 0 {
+			return
+		}
+
+		edits = append(edits, characterEdits(position, deleted.String(), inserted.String())...)
+		position += deleted.Len()
+
+		deleted.Reset()
+		inserted.Reset()
+	}
+
+	for _, line := range diffLines(original, updated) {
+		switch line.operation {
+		case diffmatchpatch.DiffEqual:
+			flush()
+			position += len(line.text)
+
+		case diffmatchpatch.DiffDelete:
+			deleted.WriteString(line.text)
+
+		case diffmatchpatch.DiffInsert:
+			inserted.WriteString(line.text)
+		}
+	}
+
+	flush()
+	return edits
+}
+
+// characterEdits returns the minimal set of edits that transform the original text, found at the
+// given rune position, into the updated text. Adjacent deletions and insertions are merged into a
+// single replacement.
+func characterEdits(position int, original string, updated string) []TextEdit {
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffMain(original, updated, false)
+
+	var edits = make([]TextEdit, 0)
+	for _, diff := range diffs {
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			position += len(diff.Text)
+
+		case diffmatchpatch.DiffDelete:
+			if len(edits) > 0 && edits[len(edits)-1].EndRune == position {
+				edits[len(edits)-1].EndRune += len(diff.Text)
+			} else {
+				edits = append(edits, TextEdit{position, position + len(diff.Text), ""})
+			}
+
+			position += len(diff.Text)
+
+		case diffmatchpatch.DiffInsert:
+			if len(edits) > 0 && edits[len(edits)-1].EndRune == position {
+				edits[len(edits)-1].Text += diff.Text
+			} else {
+				edits = append(edits, TextEdit{position, position, diff.Text})
+			}
+		}
+	}
+
+	return edits
+}
+
+func minInt(first int, second int) int {
+	if first < second {
+		return first
+	}
+	return second
+}
+
+func maxInt(first int, second int) int {
+	if first > second {
+		return first
+	}
+	return second
+}
@@ -0,0 +1,142 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/stretchr/testify/assert"
+)
+
+const rangeFormatSource = `function First() {
+var a = 1
+    a = 2
+}
+
+function Second() {
+var b   = 2
+}
+`
+
+type rangeFormatTest struct {
+	name     string
+	selected string
+	expected string
+}
+
+var rangeFormatTests = []rangeFormatTest{
+	rangeFormatTest{"single statement", "a = 2", `function First() {
+var a = 1
+	a = 2
+}
+
+function Second() {
+var b   = 2
+}
+`},
+	rangeFormatTest{"enclosing member", "function First", `function First() {
+	var a = 1
+	a = 2
+}
+
+function Second() {
+var b   = 2
+}
+`},
+	rangeFormatTest{"partial statement", "b   =", `function First() {
+var a = 1
+    a = 2
+}
+
+function Second() {
+	var b = 2
+}
+`},
+}
+
+// applyEdits applies the given edits, in order, to the source.
+func applyEdits(source string, edits []TextEdit) string {
+	var updated = source
+	for index := len(edits) - 1; index >= 0; index-- {
+		edit := edits[index]
+		updated = updated[:edit.StartRune] + edit.Text + updated[edit.EndRune:]
+	}
+	return updated
+}
+
+func TestFormatRange(t *testing.T) {
+	inputSource := compilercommon.InputSource("test")
+	for _, test := range rangeFormatTests {
+		start := strings.Index(rangeFormatSource, test.selected)
+		sourceRange := inputSource.RangeForRunePositions(start, start+len(test.selected)-1, nil)
+
+		edits, err := FormatRange(rangeFormatSource, sourceRange)
+		if !assert.Nil(t, err, "Error formatting range for test %s", test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.expected, applyEdits(rangeFormatSource, edits), "Range format mismatch for test %s", test.name)
+	}
+}
+
+func TestFormatOnType(t *testing.T) {
+	inputSource := compilercommon.InputSource("test")
+	position := strings.Index(rangeFormatSource, "2\n}") + 2
+
+	edits, err := FormatOnType(rangeFormatSource, inputSource.PositionForRunePosition(position, nil))
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, `function First() {
+var a = 1
+	a = 2
+}
+
+function Second() {
+var b   = 2
+}
+`, applyEdits(rangeFormatSource, edits))
+}
+
+func TestFormatSourceEdits(t *testing.T) {
+	formatted, err := FormatSource(rangeFormatSource)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	edits, err := FormatSourceEdits(rangeFormatSource)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, formatted, applyEdits(rangeFormatSource, edits))
+
+	// Ensure the edits are minimal, rather than a full replacement.
+	for _, edit := range edits {
+		assert.True(t, edit.EndRune-edit.StartRune <= 4, "Expected minimal edit, found %v", edit)
+	}
+}
+
+var computeEditsTests = []struct {
+	original string
+	updated  string
+	expected []TextEdit
+}{
+	{"a\nb\n", "a\nb\n", []TextEdit{}},
+	{"a\n  b\n", "a\n\tb\n", []TextEdit{TextEdit{2, 4, "\t"}}},
+	{"a\nb\n", "a\n\nb\n", []TextEdit{TextEdit{2, 2, "\n"}}},
+	{"a\n\n\nb", "a\n\nb\n", []TextEdit{TextEdit{3, 4, ""}, TextEdit{5, 5, "\n"}}},
+}
+
+func TestComputeEdits(t *testing.T) {
+	for _, test := range computeEditsTests {
+		edits := computeEdits(test.original, test.updated)
+		assert.Equal(t, test.expected, edits, "Edits mismatch for %q", test.original)
+		assert.Equal(t, test.updated, applyEdits(test.original, edits))
+	}
+}
@@ -113,6 +113,12 @@ func (sf *sourceFormatter) emitNode(node formatterNode) {
 	case sourceshape.NodeTypeAgent:
 		sf.emitTypeDefinition(node, "agent")
 
+	case sourceshape.NodeTypeEnum:
+		sf.emitTypeDefinition(node, "enum")
+
+	case sourceshape.NodeTypeExtension:
+		sf.emitTypeDefinition(node, "extension")
+
 	case sourceshape.NodeTypeDecorator:
 		sf.emitDecorator(node)
 
@@ -129,6 +135,9 @@ func (sf *sourceFormatter) emitNode(node formatterNode) {
 	case sourceshape.NodeTypeField:
 		sf.emitField(node)
 
+	case sourceshape.NodeTypeEnumValue:
+		sf.emitEnumValue(node)
+
 	case sourceshape.NodeTypeConstructor:
 		sf.emitConstructor(node)
 
@@ -175,12 +184,21 @@ func (sf *sourceFormatter) emitNode(node formatterNode) {
 	case sourceshape.NodeTypeWithStatement:
 		sf.emitWithStatement(node)
 
+	case sourceshape.NodeTypeDeferStatement:
+		sf.emitDeferStatement(node)
+
 	case sourceshape.NodeTypeMatchStatement:
 		sf.emitMatchStatement(node)
 
 	case sourceshape.NodeTypeMatchStatementCase:
 		sf.emitMatchStatementCase(node)
 
+	case sourceshape.NodeTypeStructPattern:
+		sf.emitStructPattern(node)
+
+	case sourceshape.NodeTypeStructPatternField:
+		sf.emitStructPatternField(node)
+
 	case sourceshape.NodeTypeSwitchStatement:
 		sf.emitSwitchStatement(node)
 
@@ -221,6 +239,9 @@ func (sf *sourceFormatter) emitNode(node formatterNode) {
 	case sourceshape.NodeTypeStructReference:
 		sf.emitStructTypeRef(node)
 
+	case sourceshape.NodeTypeTuple:
+		sf.emitTupleTypeRef(node)
+
 	case sourceshape.NodeTypeAny:
 		sf.emitAnyTypeRef(node)
 
@@ -406,6 +427,9 @@ func (sf *sourceFormatter) emitNode(node formatterNode) {
 	case sourceshape.NodeListLiteralExpression:
 		sf.emitListLiteralExpression(node)
 
+	case sourceshape.NodeTupleLiteralExpression:
+		sf.emitTupleLiteralExpression(node)
+
 	case sourceshape.NodeSliceLiteralExpression:
 		sf.emitSliceLiteralExpression(node)
 
@@ -65,6 +65,9 @@ var goldenTests = []goldenTest{
 	{"imports test", "imports"},
 	{"class test", "class"},
 	{"agent test", "agent"},
+	{"enum test", "enum"},
+	{"extension test", "extension"},
+	{"defer test", "defer"},
 	{"interface test", "interface"},
 	{"struct test", "struct"},
 	{"nominal test", "nominal"},
@@ -75,6 +78,9 @@ var goldenTests = []goldenTest{
 	{"typerefs test", "typerefs"},
 	{"statements test", "statements"},
 	{"expressions test", "expressions"},
+	{"named arguments test", "namedargs"},
+	{"tuples test", "tuples"},
+	{"match patterns test", "matchpatterns"},
 	{"nullable precedence test", "nullable"},
 	{"sml test", "sml"},
 	{"nested sml test", "nestedsml"},
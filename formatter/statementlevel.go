@@ -186,6 +186,12 @@ func (sf *sourceFormatter) emitWithStatement(node formatterNode) {
 	sf.emitNode(node.getChild(sourceshape.NodeWithStatementBlock))
 }
 
+// emitDeferStatement emits the source for a defer statement.
+func (sf *sourceFormatter) emitDeferStatement(node formatterNode) {
+	sf.append("defer ")
+	sf.emitNode(node.getChild(sourceshape.NodeDeferStatementExpression))
+}
+
 // emitSwitchStatement emits the source for a switch statement.
 func (sf *sourceFormatter) emitSwitchStatement(node formatterNode) {
 	sf.append("switch")
@@ -265,6 +271,12 @@ func (sf *sourceFormatter) emitMatchStatementCase(node formatterNode) {
 	if expr, ok := node.tryGetChild(sourceshape.NodeMatchStatementCaseTypeReference); ok {
 		sf.append("case ")
 		sf.emitNode(expr)
+		sf.emitMatchStatementCaseGuard(node)
+		sf.append(":")
+	} else if pattern, ok := node.tryGetChild(sourceshape.NodeMatchStatementCasePattern); ok {
+		sf.append("case ")
+		sf.emitNode(pattern)
+		sf.emitMatchStatementCaseGuard(node)
 		sf.append(":")
 	} else {
 		sf.append("default:")
@@ -286,6 +298,37 @@ func (sf *sourceFormatter) emitMatchStatementCase(node formatterNode) {
 	}
 }
 
+// emitMatchStatementCaseGuard emits the source for the guard of a match case, if any.
+func (sf *sourceFormatter) emitMatchStatementCaseGuard(node formatterNode) {
+	if guard, ok := node.tryGetChild(sourceshape.NodeMatchStatementCaseGuard); ok {
+		sf.append(" if ")
+		sf.emitNode(guard)
+	}
+}
+
+// emitStructPattern emits the source for a struct pattern under a match case.
+func (sf *sourceFormatter) emitStructPattern(node formatterNode) {
+	sf.emitNode(node.getChild(sourceshape.NodeStructPatternTypeReference))
+	sf.append("{")
+
+	for index, field := range node.getChildren(sourceshape.NodeStructPatternField) {
+		if index > 0 {
+			sf.append(", ")
+		}
+
+		sf.emitNode(field)
+	}
+
+	sf.append("}")
+}
+
+// emitStructPatternField emits the source for a field under a struct pattern.
+func (sf *sourceFormatter) emitStructPatternField(node formatterNode) {
+	sf.append(node.getProperty(sourceshape.NodeStructPatternFieldName))
+	sf.append(": ")
+	sf.emitNode(node.getChild(sourceshape.NodeStructPatternFieldPattern))
+}
+
 // emitAssignStatement emits the source for an assignment statement.
 func (sf *sourceFormatter) emitAssignStatement(node formatterNode) {
 	sf.emitNode(node.getChild(sourceshape.NodeAssignStatementName))
@@ -312,6 +355,11 @@ func (sf *sourceFormatter) emitResolveStatement(node formatterNode) {
 		sf.emitNode(rejection)
 	}
 
+	for _, destructured := range node.getChildren(sourceshape.NodeAssignedDestructured) {
+		sf.append(", ")
+		sf.emitNode(destructured)
+	}
+
 	sf.append(" := ")
 	sf.emitNode(node.getChild(sourceshape.NodeResolveStatementSource))
 }
@@ -51,6 +51,12 @@ func (sf *sourceFormatter) emitField(node formatterNode) {
 	sf.appendLine()
 }
 
+// emitEnumValue emits the source of a value under an enum.
+func (sf *sourceFormatter) emitEnumValue(node formatterNode) {
+	sf.append(node.getProperty(sourceshape.NodePredicateTypeMemberName))
+	sf.appendLine()
+}
+
 // emitMemberTag emits the source of a tag on a type member.
 func (sf *sourceFormatter) emitMemberTag(node formatterNode) {
 	sf.append(node.getProperty(sourceshape.NodePredicateTypeMemberTagName))
@@ -144,6 +150,11 @@ func (sf *sourceFormatter) emitParameter(node formatterNode) {
 		sf.append(" ")
 		sf.emitNode(node.getChild(sourceshape.NodeParameterType))
 	}
+
+	if node.hasChild(sourceshape.NodeParameterDefaultValue) {
+		sf.append(" = ")
+		sf.emitNode(node.getChild(sourceshape.NodeParameterDefaultValue))
+	}
 }
 
 func (sf *sourceFormatter) emitBody(node formatterNode) {
@@ -79,6 +79,19 @@ func (sf *sourceFormatter) emitStructTypeRef(node formatterNode) {
 	sf.append("struct")
 }
 
+// emitTupleTypeRef emits a tuple type reference.
+func (sf *sourceFormatter) emitTupleTypeRef(node formatterNode) {
+	sf.append("(")
+	for index, element := range node.getChildren(sourceshape.NodeTypeReferenceTupleElement) {
+		if index > 0 {
+			sf.append(", ")
+		}
+
+		sf.emitNode(element)
+	}
+	sf.append(")")
+}
+
 // emitVoidTypeRef emits a void type reference.
 func (sf *sourceFormatter) emitVoidTypeRef(node formatterNode) {
 	sf.append("void")
@@ -114,12 +114,17 @@ func IsAsynchronous(statementOrExpression StatementOrExpression, scopegraph *sco
 }
 
 // IsManagingResources returns true if the statement or any of its child statements are
-// a ResourceBlockNode.
+// a ResourceBlockNode or DeferBlockNode.
 func IsManagingResources(statementOrExpression StatementOrExpression) bool {
 	if statement, isStatement := statementOrExpression.(Statement); isStatement {
 		return walkStatementsRecursively(statement, func(s Statement) bool {
-			_, isResourceNode := s.(*ResourceBlockNode)
-			return isResourceNode
+			switch s.(type) {
+			case *ResourceBlockNode, *DeferBlockNode:
+				return true
+
+			default:
+				return false
+			}
 		})
 	}
 
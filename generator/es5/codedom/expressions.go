@@ -380,6 +380,37 @@ func NativeMemberAccess(childExpression Expression, nativeName string, member ty
 	}
 }
 
+// ExtensionMemberReferenceNode is a reference of a member defined by an extension under a child
+// expression of the extended type. The member is found on the extension itself, which binds it
+// to the value of the child expression.
+type ExtensionMemberReferenceNode struct {
+	expressionBase
+	ChildExpression Expression              // The child expression.
+	Member          typegraph.TGMember      // The extension member being accessed.
+	ExtensionType   typegraph.TypeReference // The extension type (including resolved generics).
+}
+
+func (e *ExtensionMemberReferenceNode) IsAsynchronous(scopegraph *scopegraph.ScopeGraph) bool {
+	return e.ChildExpression.IsAsynchronous(scopegraph)
+}
+
+func (e *ExtensionMemberReferenceNode) ReferencedMember() (typegraph.TGMember, bool) {
+	return e.Member, true
+}
+
+func (n *ExtensionMemberReferenceNode) ExprName() string {
+	return n.Member.Name()
+}
+
+func ExtensionMemberReference(childExpression Expression, member typegraph.TGMember, extensionType typegraph.TypeReference, basis compilergraph.GraphNode) Expression {
+	return &ExtensionMemberReferenceNode{
+		expressionBase{domBase{basis}},
+		childExpression,
+		member,
+		extensionType,
+	}
+}
+
 // DynamicAccessNode is the access of an unknown named member under a child expression.
 type DynamicAccessNode struct {
 	expressionBase
@@ -22,6 +22,8 @@ const (
 	FastBoxFunction            RuntimeFunction = "$t.fastbox"
 	UnboxFunction              RuntimeFunction = "$t.unbox"
 	NullableInvokeFunction     RuntimeFunction = "$t.nullableinvoke"
+	TupleItemFunction          RuntimeFunction = "$t.tupleitem"
+	DeferredCallsFunction      RuntimeFunction = "$t.deferredcalls"
 
 	AsyncNullableComparisonFunction RuntimeFunction = "$t.asyncnullcompare"
 	SyncNullableComparisonFunction  RuntimeFunction = "$t.syncnullcompare"
@@ -232,6 +232,38 @@ func (s *ResourceBlockNode) WalkStatements(walker statementWalker) bool {
 	return walker(s) && walker(s.Statement) && s.nextStatementBase.WalkNextStatements(walker)
 }
 
+// DeferBlockNode represents a statement executed with a resource holding deferred calls placed on
+// the resource stack. The deferred calls are registered with the resource as the statement executes
+// and are made, in reverse order, once the statement is exited.
+type DeferBlockNode struct {
+	nextStatementBase
+	ResourceName string       // The name for the resource holding the deferred calls.
+	Deferred     []Expression // The calls that can be deferred under the statement.
+	Statement    Statement    // The statement to execute.
+}
+
+func DeferBlock(resourceName string, deferred []Expression, statement Statement, basis compilergraph.GraphNode) Statement {
+	return &DeferBlockNode{
+		nextStatementBase{statementBase{domBase{basis}, false}, nil},
+		resourceName,
+		deferred,
+		statement,
+	}
+}
+
+// HasAsyncRelease returns true if any of the deferred calls are async.
+func (s *DeferBlockNode) HasAsyncRelease(sg *scopegraph.ScopeGraph) bool {
+	return isAsynchronous(sg, s.Deferred)
+}
+
+func (s *DeferBlockNode) IsLocallyAsynchronous(scopegraph *scopegraph.ScopeGraph) bool {
+	return s.HasAsyncRelease(scopegraph)
+}
+
+func (s *DeferBlockNode) WalkStatements(walker statementWalker) bool {
+	return walker(s) && walker(s.Statement) && s.nextStatementBase.WalkNextStatements(walker)
+}
+
 // ConditionalJumpNode represents a jump to a true statement if an expression is true, and
 // otherwise to a false statement.
 type ConditionalJumpNode struct {
@@ -49,6 +49,22 @@ func (db *domBuilder) buildNamedAccess(node compilergraph.GraphNode, name string
 		if childExprNode != nil {
 			childExpr := db.buildExpression(*childExprNode)
 
+			// Check for a reference to an extension member. Such members are found under the extension,
+			// bound to the child value, with properties being invoked immediately.
+			if memberRef.IsExtensionMember() {
+				childScope, _ := db.scopegraph.GetScope(*childExprNode)
+				childType := childScope.ResolvedTypeRef(db.scopegraph.TypeGraph())
+
+				extension, _ := memberRef.ParentType()
+				extensionType := extension.ExtensionReferenceUnder(childType)
+				memberReference := codedom.ExtensionMemberReference(childExpr, memberRef, extensionType, node)
+				if memberRef.IsImplicitlyCalled() {
+					return codedom.MemberCallWithCallType(memberReference, memberRef, []codedom.Expression{}, scopegraph.PromisingAccessImplicitGet, node)
+				}
+
+				return memberReference
+			}
+
 			// Check for a reference to an aliased function. A alias reference exists if the
 			// member returns a function and it is not immediately invoked by a parent expression
 			// that is either a function call or an SML expression. If we do find an aliased function,
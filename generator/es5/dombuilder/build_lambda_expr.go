@@ -14,7 +14,7 @@ import (
 // buildLambdaExpression builds the CodeDOM for a lambda expression.
 func (db *domBuilder) buildLambdaExpression(node compilergraph.GraphNode) codedom.Expression {
 	if blockNode, ok := node.TryGetNode(sourceshape.NodeLambdaExpressionBlock); ok {
-		blockStatement, _ := db.buildStatements(blockNode)
+		blockStatement, _ := db.buildFunctionBodyStatements(blockNode)
 		bodyScope, _ := db.scopegraph.GetScope(blockNode)
 		isGenerator := bodyScope.HasLabel(proto.ScopeLabel_GENERATOR_STATEMENT)
 		return db.buildLambdaExpressionInternal(node, sourceshape.NodeLambdaExpressionParameter, blockStatement, isGenerator)
@@ -5,6 +5,7 @@
 package dombuilder
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -207,6 +208,151 @@ func (db *domBuilder) buildStringLiteral(node compilergraph.GraphNode) codedom.E
 	return codedom.NominalWrapping(codedom.LiteralValue(stringValueStr, node), db.scopegraph.TypeGraph().StringType(), node)
 }
 
+// buildConstantInitializer builds the CodeDOM for the given node as the folded value of a constant
+// initializer, if applicable. Literal initializers are built as-is.
+func (db *domBuilder) buildConstantInitializer(node compilergraph.GraphNode) (codedom.Expression, bool) {
+	switch node.Kind() {
+	case sourceshape.NodeNumericLiteralExpression:
+		fallthrough
+
+	case sourceshape.NodeBooleanLiteralExpression:
+		fallthrough
+
+	case sourceshape.NodeStringLiteralExpression:
+		return nil, false
+	}
+
+	parentNode, hasParentNode := node.TryGetIncomingNode(sourceshape.NodePredicateTypeFieldDefaultValue)
+	if !hasParentNode {
+		return nil, false
+	}
+
+	if _, isConstant := parentNode.TryGet(sourceshape.NodeVariableStatementConstant); !isConstant {
+		return nil, false
+	}
+
+	return db.buildConstantValue(node)
+}
+
+// buildConstantExpression builds the CodeDOM for the given expression node as its value, if it
+// is a reference to a constant or an operation whose operands are all constant.
+func (db *domBuilder) buildConstantExpression(node compilergraph.GraphNode) (codedom.Expression, bool) {
+	switch node.Kind() {
+	case sourceshape.NodeTypeIdentifierExpression:
+		fallthrough
+
+	case sourceshape.NodeMemberAccessExpression:
+		fallthrough
+
+	case sourceshape.NodeTypeTemplateString:
+		fallthrough
+
+	case sourceshape.NodeBinaryAddExpression:
+		fallthrough
+
+	case sourceshape.NodeBinarySubtractExpression:
+		fallthrough
+
+	case sourceshape.NodeBinaryMultiplyExpression:
+		fallthrough
+
+	case sourceshape.NodeBinaryDivideExpression:
+		fallthrough
+
+	case sourceshape.NodeBinaryModuloExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonEqualsExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonNotEqualsExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonLTEExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonGTEExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonLTExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonGTExpression:
+		fallthrough
+
+	case sourceshape.NodeBooleanOrExpression:
+		fallthrough
+
+	case sourceshape.NodeBooleanAndExpression:
+		fallthrough
+
+	case sourceshape.NodeBooleanNotExpression:
+		fallthrough
+
+	case sourceshape.NodeKeywordNotExpression:
+		return db.buildConstantValue(node)
+
+	default:
+		return nil, false
+	}
+}
+
+// buildConstantValue builds the CodeDOM for the given node as its value, as evaluated at compile
+// time, if applicable.
+func (db *domBuilder) buildConstantValue(node compilergraph.GraphNode) (codedom.Expression, bool) {
+	value, hasValue := db.scopegraph.ConstantValue(node)
+	if !hasValue {
+		return nil, false
+	}
+
+	valueStr := value.String()
+	if value.Kind == scopegraph.ConstantString {
+		valueStr = quoteJSString(value.StringValue)
+	}
+
+	exprScope, _ := db.scopegraph.GetScope(node)
+	valueType := exprScope.ResolvedTypeRef(db.scopegraph.TypeGraph()).ReferredType()
+	return codedom.NominalWrapping(codedom.LiteralValue(valueStr, node), valueType, node), true
+}
+
+// quoteJSString returns the given string as a single-quoted ES5 string literal. Unlike Go quoting,
+// the line and paragraph separators are escaped, as they terminate lines in ES5 source.
+func quoteJSString(value string) string {
+	var buffer bytes.Buffer
+	buffer.WriteByte('\'')
+	for _, r := range value {
+		switch r {
+		case '\'':
+			buffer.WriteString("\\'")
+		case '\\':
+			buffer.WriteString("\\\\")
+		case '\n':
+			buffer.WriteString("\\n")
+		case '\r':
+			buffer.WriteString("\\r")
+		case '\t':
+			buffer.WriteString("\\t")
+		case '\b':
+			buffer.WriteString("\\b")
+		case '\f':
+			buffer.WriteString("\\f")
+		case '\v':
+			buffer.WriteString("\\v")
+		case '\u2028', '\u2029':
+			fmt.Fprintf(&buffer, "\\u%04x", r)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&buffer, "\\x%02x", r)
+				continue
+			}
+
+			buffer.WriteRune(r)
+		}
+	}
+	buffer.WriteByte('\'')
+	return buffer.String()
+}
+
 // buildValLiteral builds the CodeDOM for the val literal.
 func (db *domBuilder) buildValLiteral(node compilergraph.GraphNode) codedom.Expression {
 	return codedom.LocalReference(DEFINED_VAL_PARAMETER, node)
@@ -232,6 +378,22 @@ func (db *domBuilder) buildListLiteralExpression(node compilergraph.GraphNode) c
 	return db.buildCollectionLiteralExpression(node, sourceshape.NodeListLiteralExpressionValue, "Empty", "overArray")
 }
 
+// buildTupleLiteralExpression builds the CodeDOM for a tuple literal expression.
+func (db *domBuilder) buildTupleLiteralExpression(node compilergraph.GraphNode) codedom.Expression {
+	tupleScope, _ := db.scopegraph.GetScope(node)
+	tupleType := tupleScope.ResolvedTypeRef(db.scopegraph.TypeGraph())
+
+	vit := node.StartQuery().
+		Out(sourceshape.NodeTupleLiteralExpressionValue).
+		BuildNodeIterator()
+
+	valueExprs := db.buildExpressions(vit, buildExprNormally)
+	return codedom.RuntimeFunctionCall(
+		codedom.FastBoxFunction,
+		[]codedom.Expression{codedom.ArrayLiteral(valueExprs, node), codedom.TypeLiteral(tupleType, node)},
+		node)
+}
+
 // buildCollectionLiteralExpression builds a literal collection expression.
 func (db *domBuilder) buildCollectionLiteralExpression(node compilergraph.GraphNode, valuePredicate compilergraph.Predicate, emptyConstructorName string, arrayConstructorName string) codedom.Expression {
 	collectionScope, _ := db.scopegraph.GetScope(node)
@@ -81,8 +81,35 @@ func (db *domBuilder) buildFunctionCall(node compilergraph.GraphNode) codedom.Ex
 		BuildNodeIterator()
 
 	arguments := db.buildExpressions(ait, buildExprCheckNominalShortcutting)
+
+	// If any of the arguments were given by name, place them in the order of the parameters of the
+	// member being invoked. Skipped parameters are given `undefined` if they have a default value (which
+	// is then applied by the function itself) and `null` otherwise.
+	if orderedArguments, hasOrderedArguments := db.scopegraph.OrderedCallArguments(node); hasOrderedArguments {
+		arguments = make([]codedom.Expression, len(orderedArguments))
+		for index, argument := range orderedArguments {
+			if argument.IsGiven {
+				arguments[index] = db.buildExpressionWithOption(argument.Node, buildExprCheckNominalShortcutting)
+			} else if argument.Parameter.HasDefaultValue() {
+				arguments[index] = codedom.LiteralValue("undefined", node)
+			} else {
+				arguments[index] = codedom.LiteralValue("null", node)
+			}
+		}
+	}
+
 	childExpr := db.buildExpression(childExprNode)
 
+	// If the generics of the function being called were inferred, specify them explicitly.
+	if inferredGenerics, hasInferredGenerics := db.scopegraph.InferredGenerics(node); hasInferredGenerics {
+		var genericTypes = make([]codedom.Expression, 0, len(inferredGenerics))
+		for _, inferredGeneric := range inferredGenerics {
+			genericTypes = append(genericTypes, codedom.TypeLiteral(inferredGeneric, node))
+		}
+
+		childExpr = codedom.GenericSpecification(childExpr, genericTypes, node)
+	}
+
 	// If the function call is to a member, then we return a MemberCall.
 	namedRef, isNamed := db.scopegraph.GetReferencedName(childScope)
 	if isNamed && !namedRef.IsLocal() {
@@ -6,6 +6,7 @@ package dombuilder
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/serulian/compiler/compilergraph"
 	"github.com/serulian/compiler/generator/es5/codedom"
@@ -288,6 +289,97 @@ func (db *domBuilder) buildWithStatement(node compilergraph.GraphNode) codedom.S
 	return codedom.ResourceBlock(resourceVar, resourceDomExpr, withStatement, releaseMethod, node)
 }
 
+// buildDeferStatement builds the CodeDOM for a defer statement, which registers the deferred call
+// with the deferred calls resource of the containing function. The receiver and arguments of the
+// call are evaluated when the statement is executed and registered alongside the deferred call,
+// which receives them as parameters once made.
+func (db *domBuilder) buildDeferStatement(node compilergraph.GraphNode) codedom.Statement {
+	scope := db.deferScopes[len(db.deferScopes)-1]
+	if scope.resourceName == "" {
+		scope.resourceName = db.generateScopeVarName(node)
+	}
+
+	callNode := node.GetNode(sourceshape.NodeDeferStatementExpression)
+
+	var parameters = make([]string, 0)
+	var capturedValues = make([]codedom.Expression, 0)
+	for _, capturedNode := range db.deferredCallCaptures(callNode) {
+		parameterName := db.generateScopeVarName(capturedNode)
+		parameters = append(parameters, parameterName)
+		capturedValues = append(capturedValues, db.buildExpression(capturedNode))
+
+		if db.capturedExpressions == nil {
+			db.capturedExpressions = map[compilergraph.GraphNodeId]codedom.Expression{}
+		}
+
+		db.capturedExpressions[capturedNode.NodeId] = codedom.LocalReference(parameterName, capturedNode)
+	}
+
+	deferredExpr := db.buildExpression(callNode)
+	scope.deferred = append(scope.deferred, deferredExpr)
+
+	for _, capturedNode := range db.deferredCallCaptures(callNode) {
+		delete(db.capturedExpressions, capturedNode.NodeId)
+	}
+
+	deferredFunction := codedom.FunctionDefinition([]string{}, parameters, deferredExpr, false, codedom.NormalFunction, node)
+	pushCall := codedom.FunctionCall(
+		codedom.NativeAccess(codedom.LocalReference(scope.resourceName, node), "push", node),
+		[]codedom.Expression{deferredFunction, codedom.ArrayLiteral(capturedValues, node)},
+		node)
+
+	return codedom.ExpressionStatement(pushCall, node)
+}
+
+// deferredCallCaptures returns the expression nodes of the given deferred function call whose
+// values are captured when the `defer` statement is executed: the receiver of the member being
+// called (or the callee itself, if a local) and the arguments given.
+func (db *domBuilder) deferredCallCaptures(callNode compilergraph.GraphNode) []compilergraph.GraphNode {
+	var captured = make([]compilergraph.GraphNode, 0)
+
+	childExprNode := callNode.GetNode(sourceshape.NodeFunctionCallExpressionChildExpr)
+	switch childExprNode.Kind() {
+	case sourceshape.NodeMemberAccessExpression:
+		fallthrough
+
+	case sourceshape.NodeNullableMemberAccessExpression:
+		fallthrough
+
+	case sourceshape.NodeDynamicMemberAccessExpression:
+		receiverNode := childExprNode.GetNode(sourceshape.NodeMemberAccessChildExpr)
+		receiverScope, _ := db.scopegraph.GetScope(receiverNode)
+		if receiverScope.GetKind() == proto.ScopeKind_VALUE {
+			captured = append(captured, receiverNode)
+		}
+
+	case sourceshape.NodeTypeIdentifierExpression:
+		childScope, _ := db.scopegraph.GetScope(childExprNode)
+		if namedRef, isNamed := db.scopegraph.GetReferencedName(childScope); isNamed && namedRef.IsLocal() {
+			captured = append(captured, childExprNode)
+		}
+	}
+
+	if orderedArguments, hasOrderedArguments := db.scopegraph.OrderedCallArguments(callNode); hasOrderedArguments {
+		for _, argument := range orderedArguments {
+			if argument.IsGiven {
+				captured = append(captured, argument.Node)
+			}
+		}
+
+		return captured
+	}
+
+	ait := callNode.StartQuery().
+		Out(sourceshape.NodeFunctionCallArgument).
+		BuildNodeIterator()
+
+	for ait.Next() {
+		captured = append(captured, ait.Node())
+	}
+
+	return captured
+}
+
 // buildMatchStatement builds the CodeDOM for a match statement.
 func (db *domBuilder) buildMatchStatement(node compilergraph.GraphNode) (codedom.Statement, codedom.Statement) {
 	// Retrieve (or generate) the name of a variable to hold the value being matched against.
@@ -301,24 +393,143 @@ func (db *domBuilder) buildMatchStatement(node compilergraph.GraphNode) (codedom
 	// Set the match expression's value into the variable.
 	startStatement := codedom.VarDefinitionWithInit(matchExprVarName, db.getExpression(node, sourceshape.NodeMatchStatementExpression), node)
 
-	getCheckExpression := func(caseTypeRefNode compilergraph.GraphNode) codedom.Expression {
-		caseTypeLiteral, _ := db.scopegraph.ResolveSRGTypeRef(
-			db.scopegraph.SourceGraph().GetTypeRef(caseTypeRefNode))
+	matchExprScope, _ := db.scopegraph.GetScope(node.GetNode(sourceshape.NodeMatchStatementExpression))
+	matchExprType := matchExprScope.ResolvedTypeRef(db.scopegraph.TypeGraph())
+
+	getCheckExpression := func(caseNode compilergraph.GraphNode) (codedom.Expression, bool) {
+		var checkExpr codedom.Expression = nil
+		var checkNode = caseNode
+
+		if caseTypeRefNode, hasCaseTypeRef := caseNode.TryGetNode(sourceshape.NodeMatchStatementCaseTypeReference); hasCaseTypeRef {
+			caseTypeLiteral, _ := db.scopegraph.ResolveSRGTypeRef(
+				db.scopegraph.SourceGraph().GetTypeRef(caseTypeRefNode))
 
-		return codedom.NominalWrapping(
-			codedom.RuntimeFunctionCall(codedom.IsTypeFunction,
+			checkExpr = codedom.RuntimeFunctionCall(codedom.IsTypeFunction,
 				[]codedom.Expression{
 					codedom.LocalReference(matchExprVarName, caseTypeRefNode),
 					codedom.TypeLiteral(caseTypeLiteral, caseTypeRefNode),
 				},
-				caseTypeRefNode),
-			db.scopegraph.TypeGraph().BoolType(),
-			caseTypeRefNode)
+				caseTypeRefNode)
+			checkNode = caseTypeRefNode
+		} else if casePatternNode, hasCasePattern := caseNode.TryGetNode(sourceshape.NodeMatchStatementCasePattern); hasCasePattern {
+			checkExpr = db.buildPatternCheck(casePatternNode, codedom.LocalReference(matchExprVarName, casePatternNode), matchExprType)
+			checkNode = casePatternNode
+		} else {
+			return nil, false
+		}
+
+		// If the case has a guard, it is checked only once the type or pattern has matched, and
+		// therefore after any values in the pattern have been bound.
+		if guardNode, hasGuard := caseNode.TryGetNode(sourceshape.NodeMatchStatementCaseGuard); hasGuard {
+			checkExpr = codedom.BinaryOperation(
+				checkExpr,
+				"&&",
+				codedom.NominalUnwrapping(db.buildExpression(guardNode), db.scopegraph.TypeGraph().BoolTypeReference(), guardNode),
+				guardNode)
+		}
+
+		return codedom.NominalWrapping(checkExpr, db.scopegraph.TypeGraph().BoolType(), checkNode), true
 	}
 
 	return db.buildJumpingCaseStatement(node, sourceshape.NodeMatchStatementCase,
-		sourceshape.NodeMatchStatementCaseStatement, sourceshape.NodeMatchStatementCaseTypeReference,
-		startStatement, getCheckExpression)
+		sourceshape.NodeMatchStatementCaseStatement, startStatement, getCheckExpression)
+}
+
+// buildPatternCheck builds a native boolean expression checking whether the value of the given
+// expression, of the given type, matches the given pattern SRG node. Any named values found in the
+// pattern are assigned as the check executes.
+func (db *domBuilder) buildPatternCheck(patternNode compilergraph.GraphNode, valueExpr codedom.Expression, valueType typegraph.TypeReference) codedom.Expression {
+	switch patternNode.Kind() {
+	case sourceshape.NodeTypeNamedValue:
+		name := patternNode.Get(sourceshape.NodeNamedValueName)
+		if name == "_" {
+			return codedom.LiteralValue("true", patternNode)
+		}
+
+		return codedom.CompoundExpression(name, valueExpr, []codedom.Expression{}, codedom.LiteralValue("true", patternNode), patternNode)
+
+	case sourceshape.NodeNullLiteralExpression:
+		return codedom.BinaryOperation(valueExpr, "==", codedom.LiteralValue("null", patternNode), patternNode)
+
+	case sourceshape.NodeTypeStructPattern:
+		patternType, _ := db.scopegraph.StructPatternType(patternNode)
+		checks := db.buildPatternTypeChecks(patternNode, valueExpr, valueType, patternType)
+
+		fit := patternNode.StartQuery().
+			Out(sourceshape.NodeStructPatternField).
+			BuildNodeIterator()
+
+		for fit.Next() {
+			fieldNode := fit.Node()
+			member, fieldType, _ := db.scopegraph.PatternField(fieldNode)
+			subPatternNode := fieldNode.GetNode(sourceshape.NodeStructPatternFieldPattern)
+			if subPatternNode.Kind() == sourceshape.NodeTypeNamedValue && subPatternNode.Get(sourceshape.NodeNamedValueName) == "_" {
+				continue
+			}
+
+			fieldExpr := codedom.MemberReference(valueExpr, member, fieldNode)
+
+			// Nested struct patterns access the field more than once, so its value is placed into
+			// a variable before being checked.
+			if subPatternNode.Kind() == sourceshape.NodeTypeStructPattern {
+				fieldVarName := db.generateScopeVarName(fieldNode)
+				subCheck := db.buildPatternCheck(subPatternNode, codedom.LocalReference(fieldVarName, fieldNode), fieldType)
+				checks = append(checks, codedom.CompoundExpression(fieldVarName, fieldExpr, []codedom.Expression{}, subCheck, fieldNode))
+				continue
+			}
+
+			checks = append(checks, db.buildPatternCheck(subPatternNode, fieldExpr, fieldType))
+		}
+
+		return db.buildConjunction(checks, patternNode)
+
+	default:
+		literalScope, _ := db.scopegraph.GetScope(patternNode)
+		literalType := literalScope.ResolvedTypeRef(db.scopegraph.TypeGraph())
+		checks := db.buildPatternTypeChecks(patternNode, valueExpr, valueType, literalType)
+
+		equalsCheck := codedom.NominalUnwrapping(
+			codedom.AreEqual(valueExpr, db.buildExpression(patternNode), literalType, db.scopegraph.TypeGraph(), patternNode),
+			db.scopegraph.TypeGraph().BoolTypeReference(),
+			patternNode)
+
+		return db.buildConjunction(append(checks, equalsCheck), patternNode)
+	}
+}
+
+// buildPatternTypeChecks returns the native boolean expressions checking that the value of the given
+// expression, of the given type, is a non-null instance of the type matched by a pattern.
+func (db *domBuilder) buildPatternTypeChecks(patternNode compilergraph.GraphNode, valueExpr codedom.Expression, valueType typegraph.TypeReference, patternType typegraph.TypeReference) []codedom.Expression {
+	var checks = make([]codedom.Expression, 0, 2)
+	if valueType.NullValueAllowed() {
+		checks = append(checks, codedom.BinaryOperation(valueExpr, "!=", codedom.LiteralValue("null", patternNode), patternNode))
+	}
+
+	if valueType.AsNonNullable().CheckSubTypeOf(patternType) != nil {
+		checks = append(checks, codedom.RuntimeFunctionCall(codedom.IsTypeFunction,
+			[]codedom.Expression{
+				valueExpr,
+				codedom.TypeLiteral(patternType, patternNode),
+			},
+			patternNode))
+	}
+
+	return checks
+}
+
+// buildConjunction returns a native boolean expression that is true if all the given native boolean
+// expressions are true, evaluated in order.
+func (db *domBuilder) buildConjunction(checks []codedom.Expression, basisNode compilergraph.GraphNode) codedom.Expression {
+	if len(checks) == 0 {
+		return codedom.LiteralValue("true", basisNode)
+	}
+
+	var conjunction = checks[0]
+	for _, check := range checks[1:] {
+		conjunction = codedom.BinaryOperation(conjunction, "&&", check, basisNode)
+	}
+
+	return conjunction
 }
 
 // buildSwitchStatement builds the CodeDOM for a switch statement.
@@ -343,13 +554,18 @@ func (db *domBuilder) buildSwitchStatement(node compilergraph.GraphNode) (codedo
 		switchType = switchScope.ResolvedTypeRef(db.scopegraph.TypeGraph())
 	}
 
-	getCheckExpression := func(caseExpressionNode compilergraph.GraphNode) codedom.Expression {
+	getCheckExpression := func(caseNode compilergraph.GraphNode) (codedom.Expression, bool) {
+		caseExpressionNode, hasCaseExpression := caseNode.TryGetNode(sourceshape.NodeSwitchStatementCaseExpression)
+		if !hasCaseExpression {
+			return nil, false
+		}
+
 		caseExpression := db.buildExpression(caseExpressionNode)
 
 		// If no switch-level expression, then the expression being checked is the case
 		// expression itself, which is guarenteed to be a boolean expression.
 		if !hasSwitchExpr {
-			return caseExpression
+			return caseExpression, true
 		}
 
 		// Otherwise, we check if the case's expression is equal to the value of the switch-level
@@ -359,24 +575,22 @@ func (db *domBuilder) buildSwitchStatement(node compilergraph.GraphNode) (codedo
 			caseExpression,
 			switchType,
 			db.scopegraph.TypeGraph(),
-			caseExpressionNode)
+			caseExpressionNode), true
 	}
 
 	return db.buildJumpingCaseStatement(node, sourceshape.NodeSwitchStatementCase,
-		sourceshape.NodeSwitchStatementCaseStatement, sourceshape.NodeSwitchStatementCaseExpression,
-		startStatement, getCheckExpression)
+		sourceshape.NodeSwitchStatementCaseStatement, startStatement, getCheckExpression)
 }
 
 // checkExpressionGenerator defines a function for generating the expression for a case in a branch
-// statement.
-type checkExpressionGenerator func(caseExpressionNode compilergraph.GraphNode) codedom.Expression
+// statement. Returns false if the case is the default case.
+type checkExpressionGenerator func(caseNode compilergraph.GraphNode) (codedom.Expression, bool)
 
 // buildJumpingCaseStatement builds the CodeDOM for a statement which jumps (branches) based on
 // various cases.
 func (db *domBuilder) buildJumpingCaseStatement(node compilergraph.GraphNode,
 	casePredicate compilergraph.Predicate,
 	caseStatementPredicate compilergraph.Predicate,
-	caseExpressionPredicate compilergraph.Predicate,
 	startStatement codedom.Statement,
 	getCheckExpression checkExpressionGenerator) (codedom.Statement, codedom.Statement) {
 
@@ -399,14 +613,11 @@ func (db *domBuilder) buildJumpingCaseStatement(node compilergraph.GraphNode,
 	for cit.Next() {
 		caseNode := cit.Node()
 		caseStart, caseEnd := db.getStatements(caseNode, caseStatementPredicate)
-		caseExpressionNode, hasCaseExpression := caseNode.TryGetNode(caseExpressionPredicate)
 
 		// Generate the expression against which we should check. If there is no expression on
 		// the case, then this is the default and we compare against "true".
-		var branchExpression codedom.Expression = nil
-		if hasCaseExpression {
-			branchExpression = getCheckExpression(caseExpressionNode)
-		} else {
+		branchExpression, hasCheckExpression := getCheckExpression(caseNode)
+		if !hasCheckExpression {
 			branchExpression = codedom.NominalWrapping(
 				codedom.LiteralValue("true", caseNode),
 				db.scopegraph.TypeGraph().BoolType(),
@@ -488,6 +699,12 @@ func (db *domBuilder) buildArrowStatement(node compilergraph.GraphNode) (codedom
 func (db *domBuilder) buildResolveStatement(node compilergraph.GraphNode) (codedom.Statement, codedom.Statement) {
 	sourceExpr := db.getExpression(node, sourceshape.NodeResolveStatementSource)
 
+	// If the resolve statement destructures a tuple, then we assign the tuple to a temporary
+	// variable and define each named value from its matching element.
+	if tupleType, isDestructuring := db.scopegraph.DestructuredTupleType(node); isDestructuring {
+		return db.buildDestructuringResolveStatement(node, sourceExpr, tupleType)
+	}
+
 	destinationNode := node.GetNode(sourceshape.NodeAssignedDestination)
 	destinationScope, _ := db.scopegraph.GetScope(destinationNode)
 	destinationName := destinationNode.Get(sourceshape.NodeNamedValueName)
@@ -518,3 +735,47 @@ func (db *domBuilder) buildResolveStatement(node compilergraph.GraphNode) (coded
 		return destinationStatement, destinationStatement
 	}
 }
+
+// buildDestructuringResolveStatement builds the CodeDOM for a resolve statement which destructures
+// a tuple into its named values.
+func (db *domBuilder) buildDestructuringResolveStatement(node compilergraph.GraphNode, sourceExpr codedom.Expression, tupleType typegraph.TypeReference) (codedom.Statement, codedom.Statement) {
+	tupleVarName := db.generateScopeVarName(node)
+	startStatement := codedom.VarDefinitionWithInit(tupleVarName, sourceExpr, node)
+
+	names := []compilergraph.GraphNode{node.GetNode(sourceshape.NodeAssignedDestination)}
+	if rejectionNode, hasRejection := node.TryGetNode(sourceshape.NodeAssignedRejection); hasRejection {
+		names = append(names, rejectionNode)
+	}
+
+	dit := node.StartQuery().
+		Out(sourceshape.NodeAssignedDestructured).
+		BuildNodeIterator()
+
+	for dit.Next() {
+		names = append(names, dit.Node())
+	}
+
+	var endStatement = startStatement
+	elementTypes := tupleType.Parameters()
+	for index, nameNode := range names {
+		nameScope, _ := db.scopegraph.GetScope(nameNode)
+		if nameScope.GetIsAnonymousReference() {
+			continue
+		}
+
+		elementExpr := codedom.RuntimeFunctionCall(
+			codedom.TupleItemFunction,
+			[]codedom.Expression{
+				codedom.LocalReference(tupleVarName, node),
+				codedom.LiteralValue(strconv.Itoa(index), node),
+				codedom.TypeLiteral(elementTypes[index], node),
+			},
+			nameNode)
+
+		elementStatement := codedom.VarDefinitionWithInit(nameNode.Get(sourceshape.NodeNamedValueName), elementExpr, nameNode)
+		codedom.AssignNextStatement(endStatement, elementStatement)
+		endStatement = elementStatement
+	}
+
+	return startStatement, endStatement
+}
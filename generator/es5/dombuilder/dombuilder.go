@@ -28,6 +28,20 @@ type domBuilder struct {
 	// breakStatementMap contains a map from a statement node by graph ID to the CodeDOM statement
 	// to which any child `break` statement should jump.
 	breakStatementMap map[compilergraph.GraphNodeId]codedom.Statement
+
+	// deferScopes contains the defer scopes of the function bodies being built, innermost last.
+	deferScopes []*deferScope
+
+	// capturedExpressions contains a map from an expression node by graph ID to the CodeDOM
+	// expression referencing its captured value, if any. Used for deferred calls, whose callee and
+	// arguments are evaluated at the `defer` statement.
+	capturedExpressions map[compilergraph.GraphNodeId]codedom.Expression
+}
+
+// deferScope holds the calls deferred by `defer` statements under a function body.
+type deferScope struct {
+	resourceName string               // The name of the resource holding the deferred calls, if any.
+	deferred     []codedom.Expression // The deferred calls.
 }
 
 // BuildStatement builds the CodeDOM for the given SRG statement node, downward.
@@ -43,12 +57,71 @@ func BuildStatement(scopegraph *scopegraph.ScopeGraph, rootNode compilergraph.Gr
 	return start
 }
 
+// BuildFunctionBody builds the CodeDOM for the given SRG function body node, downward. The body is
+// preceded by the assignment of the default value of each of the given SRG parameter nodes, should
+// the function be invoked without an argument for that parameter.
+func BuildFunctionBody(scopegraph *scopegraph.ScopeGraph, bodyNode compilergraph.GraphNode, defaultedParameters []compilergraph.GraphNode) codedom.Statement {
+	builder := &domBuilder{
+		scopegraph:           scopegraph,
+		counter:              0,
+		continueStatementMap: map[compilergraph.GraphNodeId]codedom.Statement{},
+		breakStatementMap:    map[compilergraph.GraphNodeId]codedom.Statement{},
+	}
+
+	bodyStart, _ := builder.buildFunctionBodyStatements(bodyNode)
+	if len(defaultedParameters) == 0 {
+		return bodyStart
+	}
+
+	// Each default value is built as a jump that assigns the default value to the parameter when
+	// it is `undefined`, and then continues onto the next parameter and, finally, the body.
+	var start codedom.Statement = nil
+	var current codedom.Statement = nil
+	for _, parameterNode := range defaultedParameters {
+		parameterName := parameterNode.Get(sourceshape.NodeParameterName)
+		defaultValue := builder.getExpression(parameterNode, sourceshape.NodeParameterDefaultValue)
+
+		finalStatement := codedom.EmptyStatement(parameterNode)
+		assignStatement := codedom.ExpressionStatement(
+			codedom.LocalAssignment(parameterName, defaultValue, parameterNode),
+			parameterNode)
+
+		codedom.AssignNextStatement(assignStatement, codedom.UnconditionalJump(finalStatement, parameterNode))
+
+		isUndefined := codedom.NominalWrapping(
+			codedom.BinaryOperation(
+				codedom.LocalReference(parameterName, parameterNode),
+				"===",
+				codedom.LiteralValue("undefined", parameterNode),
+				parameterNode),
+			scopegraph.TypeGraph().BoolType(),
+			parameterNode)
+
+		checkStatement := codedom.ConditionalJump(isUndefined, assignStatement, finalStatement, parameterNode)
+		if start == nil {
+			start = checkStatement
+		} else {
+			codedom.AssignNextStatement(current, checkStatement)
+		}
+
+		current = finalStatement
+	}
+
+	codedom.AssignNextStatement(current, bodyStart)
+	return start
+}
+
 // BuildExpression builds the CodeDOM for the given SRG expression node, downward.
 func BuildExpression(scopegraph *scopegraph.ScopeGraph, rootNode compilergraph.GraphNode) codedom.Expression {
 	builder := &domBuilder{
 		scopegraph: scopegraph,
 	}
 
+	// Initializers of constants are emitted as their values, as folded at compile time.
+	if folded, isFolded := builder.buildConstantInitializer(rootNode); isFolded {
+		return folded
+	}
+
 	return builder.buildExpression(rootNode)
 }
 
@@ -60,6 +133,24 @@ func (db *domBuilder) generateScopeVarName(basisNode compilergraph.GraphNode) st
 	return name
 }
 
+// buildFunctionBodyStatements builds the CodeDOM for the given SRG function body node and returns
+// it as start and end statements. If the body contains any `defer` statements, it is placed under a
+// defer block, which makes the deferred calls once the function is exited.
+func (db *domBuilder) buildFunctionBodyStatements(bodyNode compilergraph.GraphNode) (codedom.Statement, codedom.Statement) {
+	db.deferScopes = append(db.deferScopes, &deferScope{})
+	startStatement, endStatement := db.buildStatements(bodyNode)
+
+	scope := db.deferScopes[len(db.deferScopes)-1]
+	db.deferScopes = db.deferScopes[0 : len(db.deferScopes)-1]
+
+	if len(scope.deferred) == 0 {
+		return startStatement, endStatement
+	}
+
+	deferBlock := codedom.DeferBlock(scope.resourceName, scope.deferred, startStatement, bodyNode)
+	return deferBlock, deferBlock
+}
+
 // getStatements retrieves the predicate on the given node and builds it as start and end statements.
 func (db *domBuilder) getStatements(node compilergraph.GraphNode, predicate compilergraph.Predicate) (codedom.Statement, codedom.Statement) {
 	return db.buildStatements(node.GetNode(predicate))
@@ -139,6 +230,15 @@ func (db *domBuilder) buildExpressionWithOption(node compilergraph.GraphNode, op
 // buildExpression builds the CodeDOM for the given SRG node and returns it as an expression. Will
 // panic if the returned DOM type is not an expression.
 func (db *domBuilder) buildExpression(node compilergraph.GraphNode) codedom.Expression {
+	if captured, isCaptured := db.capturedExpressions[node.NodeId]; isCaptured {
+		return captured
+	}
+
+	// Constant expressions are emitted as their values, as folded at compile time.
+	if folded, isFolded := db.buildConstantExpression(node); isFolded {
+		return folded
+	}
+
 	switch node.Kind() {
 
 	// Access Expressions.
@@ -356,6 +456,9 @@ func (db *domBuilder) buildExpression(node compilergraph.GraphNode) codedom.Expr
 	case sourceshape.NodeListLiteralExpression:
 		return db.buildListLiteralExpression(node)
 
+	case sourceshape.NodeTupleLiteralExpression:
+		return db.buildTupleLiteralExpression(node)
+
 	case sourceshape.NodeSliceLiteralExpression:
 		return db.buildSliceLiteralExpression(node)
 
@@ -425,6 +528,10 @@ func (db *domBuilder) buildStatements(node compilergraph.GraphNode) (codedom.Sta
 		stm := db.buildWithStatement(node)
 		return stm, stm
 
+	case sourceshape.NodeTypeDeferStatement:
+		stm := db.buildDeferStatement(node)
+		return stm, stm
+
 	case sourceshape.NodeTypeSwitchStatement:
 		return db.buildSwitchStatement(node)
 
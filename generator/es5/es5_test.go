@@ -110,10 +110,14 @@ var generationTests = []generationTest{
 	generationTest{"generic class test", "class", "generic", integrationTestSuccessExpected, ""},
 	generationTest{"class property test", "class", "property", integrationTestSuccessExpected, ""},
 	generationTest{"class required fields test", "class", "requiredfields", integrationTestSuccessExpected, ""},
+
+	generationTest{"basic extension test", "extension", "basic", integrationTestSuccessExpected, ""},
+
 	generationTest{"constructable interface test", "interface", "constructable", integrationTestSuccessExpected, ""},
 	generationTest{"interface property test", "interface", "interfaceprop", integrationTestSuccessExpected, ""},
 
 	generationTest{"module init test", "module", "init", integrationTestSuccessExpected, ""},
+	generationTest{"module constant folding test", "module", "constfold", integrationTestSuccessExpected, ""},
 
 	generationTest{"basic struct test", "struct", "basic", integrationTestSuccessExpected, ""},
 	generationTest{"struct equality test", "struct", "equals", integrationTestSuccessExpected, ""},
@@ -147,6 +151,11 @@ var generationTests = []generationTest{
 	generationTest{"with as statement", "statements", "withas", integrationTestNone, ""},
 	generationTest{"with exit scope statement", "statements", "withexit", integrationTestSuccessExpected, ""},
 	generationTest{"with async statement", "statements", "withasync", integrationTestSuccessExpected, ""},
+	generationTest{"defer return statement", "statements", "deferreturn", integrationTestSuccessExpected, ""},
+	generationTest{"defer rejection statement", "statements", "deferreject", integrationTestSuccessExpected, ""},
+	generationTest{"defer async statement", "statements", "deferasync", integrationTestSuccessExpected, ""},
+	generationTest{"defer loop statement", "statements", "deferloop", integrationTestSuccessExpected, ""},
+	generationTest{"defer failing call statement", "statements", "deferfailure", integrationTestSuccessExpected, ""},
 	generationTest{"single call statement", "statements", "singlecall", integrationTestSuccessExpected, ""},
 	generationTest{"auto-unboxing assign statement", "statements", "autounboxassign", integrationTestSuccessExpected, ""},
 
@@ -155,6 +164,7 @@ var generationTests = []generationTest{
 	generationTest{"numeric op expression", "opexpr", "numeric", integrationTestSuccessExpected, ""},
 
 	generationTest{"match statement", "statements", "match", integrationTestSuccessExpected, ""},
+	generationTest{"match statement patterns", "statements", "matchpattern", integrationTestSuccessExpected, ""},
 
 	generationTest{"await expression", "arrowexpr", "await", integrationTestSuccessExpected, ""},
 	generationTest{"multiawait expression", "arrowexpr", "multiawait", integrationTestNone, ""},
@@ -204,6 +214,9 @@ var generationTests = []generationTest{
 	generationTest{"short circuit expression", "opexpr", "shortcircuit", integrationTestSuccessExpected, ""},
 	generationTest{"unwrap op expression", "opexpr", "unwrap", integrationTestSuccessExpected, ""},
 	generationTest{"unwrap nullable op expression", "opexpr", "unwrapnullable", integrationTestSuccessExpected, ""},
+	generationTest{"inferred generic function call", "opexpr", "inferredgeneric", integrationTestSuccessExpected, ""},
+	generationTest{"named arguments function call", "opexpr", "namedargs", integrationTestSuccessExpected, ""},
+	generationTest{"default parameter values function call", "opexpr", "defaultparams", integrationTestSuccessExpected, ""},
 
 	generationTest{"identifier expressions", "literals", "identifier", integrationTestNone, ""},
 
@@ -287,6 +300,8 @@ var generationTests = []generationTest{
 	generationTest{"cast ignore interface resolve statement test", "resolve", "castignoreinterface", integrationTestSuccessExpected, ""},
 	generationTest{"cast rejection message resolve statement test", "resolve", "castrejectmessage", integrationTestSuccessExpected, ""},
 	generationTest{"resolve last statement test", "resolve", "last", integrationTestNone, ""},
+	generationTest{"destructuring resolve statement test", "resolve", "destructure", integrationTestSuccessExpected, ""},
+	generationTest{"destructuring resolve statement rejection test", "resolve", "destructurereject", integrationTestSuccessExpected, ""},
 
 	generationTest{"sml simple function test", "sml", "simplefunc", integrationTestSuccessExpected, ""},
 	generationTest{"sml simple class test", "sml", "simpleclass", integrationTestSuccessExpected, ""},
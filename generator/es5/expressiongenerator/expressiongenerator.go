@@ -180,6 +180,9 @@ func (eg *expressionGenerator) generateExpressionWithoutMapping(expression coded
 	case *codedom.StaticMemberReferenceNode:
 		return eg.generateStaticMemberReference(e, context)
 
+	case *codedom.ExtensionMemberReferenceNode:
+		return eg.generateExtensionMemberReference(e, context)
+
 	case *codedom.MemberCallNode:
 		return eg.generateMemberCall(e, context)
 
@@ -13,6 +13,8 @@ import (
 	"github.com/serulian/compiler/generator/es5/shared"
 	"github.com/serulian/compiler/generator/escommon/esbuilder"
 	"github.com/serulian/compiler/graphs/scopegraph"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/integration"
 	"github.com/serulian/compiler/sourceshape"
 )
 
@@ -468,6 +470,15 @@ func (eg *expressionGenerator) generateStaticMemberReference(memberReference *co
 	return esbuilder.Snippet(staticPath)
 }
 
+// generateExtensionMemberReference generates the expression source for a reference to an extension
+// member under a child expression. Extension members are generated as static functions which take
+// the value of the child expression and return the member bound to it.
+func (eg *expressionGenerator) generateExtensionMemberReference(memberReference *codedom.ExtensionMemberReferenceNode, context generationContext) esbuilder.ExpressionBuilder {
+	staticPath := eg.pather.GetStaticMemberPath(memberReference.Member, memberReference.ExtensionType)
+	childExpr := eg.generateExpression(memberReference.ChildExpression, context)
+	return esbuilder.Call(esbuilder.Snippet(staticPath), childExpr)
+}
+
 // generateRuntimeFunctionCall generates the expression source for a call to a runtime function.
 func (eg *expressionGenerator) generateRuntimeFunctionCall(runtimeCall *codedom.RuntimeFunctionCallNode, context generationContext) esbuilder.ExpressionBuilder {
 	arguments := eg.generateExpressions(runtimeCall.Arguments, context)
@@ -641,10 +652,10 @@ func (eg *expressionGenerator) generateMemberReference(memberReference *codedom.
 		return eg.generateExpression(memberCall, context)
 	}
 
-	// This handles the native new case for WebIDL. We should probably handle this directly.
-	// TODO: generalize this.
+	// This handles the native new case for language integrations that construct their types
+	// natively (WebIDL, TypeScript). We should probably handle this directly.
 	if memberReference.Member.IsStatic() {
-		if memberReference.Member.SourceGraphId() == "webidl" {
+		if eg.usesNativeConstructors(memberReference.Member) {
 			return eg.generateExpression(codedom.StaticMemberReference(memberReference.Member, eg.scopegraph.TypeGraph().AnyTypeReference(), memberReference.BasisNode()), context)
 		}
 	}
@@ -653,6 +664,18 @@ func (eg *expressionGenerator) generateMemberReference(memberReference *codedom.
 	return childExpr.Member(eg.pather.GetMemberName(memberReference.Member))
 }
 
+// usesNativeConstructors returns true if the given member comes from a language integration that
+// constructs its types natively.
+func (eg *expressionGenerator) usesNativeConstructors(member typegraph.TGMember) bool {
+	langIntegration, isIntegration := eg.scopegraph.GetLanguageIntegration(member.SourceGraphId())
+	if !isIntegration {
+		return false
+	}
+
+	nativeIntegration, isNative := langIntegration.(integration.NativeConstructorIntegration)
+	return isNative && nativeIntegration.UsesNativeConstructors()
+}
+
 // generateMemberCall generates the expression source for a call to a module or type member.
 func (eg *expressionGenerator) generateMemberCall(memberCall *codedom.MemberCallNode, context generationContext) esbuilder.ExpressionBuilder {
 	if memberCall.Member.IsOperator() && memberCall.Member.IsNative() {
@@ -58,12 +58,20 @@ func (gen *es5generator) generateImplementedMember(member typegraph.TGMember) es
 		fallthrough
 
 	case srg.FunctionMember:
+		if member.IsExtensionMember() {
+			return esbuilder.Template("extensionfunction", extensionFunctionTemplateStr, generating)
+		}
+
 		fallthrough
 
 	case srg.OperatorMember:
 		return esbuilder.Template("function", functionTemplateStr, generating)
 
 	case srg.PropertyMember:
+		if member.IsExtensionMember() {
+			return esbuilder.Template("extensionproperty", extensionPropertyTemplateStr, generating)
+		}
+
 		return esbuilder.Template("property", propertyTemplateStr, generating)
 
 	default:
@@ -83,9 +91,10 @@ func (gm generatingMember) IsStatic() bool {
 	return gm.Member.IsStatic()
 }
 
-// RequiresThis returns whether the generating member is requires the "this" var.
+// RequiresThis returns whether the generating member is requires the "this" var. Extension members
+// receive their "this" value as the $this parameter of their outer function instead.
 func (gm generatingMember) RequiresThis() bool {
-	return !gm.Member.IsStatic()
+	return !gm.Member.IsStatic() && !gm.Member.IsExtensionMember()
 }
 
 // WorkerExecutes returns whether the generating member should be generated to execute under
@@ -123,6 +132,19 @@ func (gm generatingMember) Parameters() []string {
 	return parameterNames
 }
 
+// DefaultedParameters returns the parser nodes of the parameters for this member that have default
+// values, if any.
+func (gm generatingMember) DefaultedParameters() []compilergraph.GraphNode {
+	var defaultedParameters = make([]compilergraph.GraphNode, 0)
+	for _, parameter := range gm.SRGMember.Parameters() {
+		if parameter.HasDefaultValue() {
+			defaultedParameters = append(defaultedParameters, parameter.GraphNode)
+		}
+	}
+
+	return defaultedParameters
+}
+
 // MemberName returns the name of the member, as adjusted by the pather.
 func (gm generatingMember) MemberName() string {
 	return gm.Generator.pather.GetMemberName(gm.Member)
@@ -142,12 +164,13 @@ func (gm generatingMember) InnerInstanceName() string {
 // FunctionSource returns the generated code for the implementation for this member.
 func (gm generatingMember) FunctionSource() esbuilder.SourceBuilder {
 	functionDef := statemachine.FunctionDef{
-		Generics:           gm.Generics(),
-		Parameters:         gm.Parameters(),
-		RequiresThis:       gm.RequiresThis(),
-		WorkerExecutes:     gm.WorkerExecutes(),
-		GeneratorYieldType: gm.GeneratorYieldType(gm.BodyNode()),
-		BodyNode:           gm.BodyNode(),
+		Generics:            gm.Generics(),
+		Parameters:          gm.Parameters(),
+		RequiresThis:        gm.RequiresThis(),
+		WorkerExecutes:      gm.WorkerExecutes(),
+		GeneratorYieldType:  gm.GeneratorYieldType(gm.BodyNode()),
+		BodyNode:            gm.BodyNode(),
+		DefaultedParameters: gm.DefaultedParameters(),
 	}
 
 	return statemachine.GenerateFunctionSource(functionDef, gm.Generator.scopegraph)
@@ -161,7 +184,7 @@ func (gm generatingMember) GetterSource() esbuilder.SourceBuilder {
 	functionDef := statemachine.FunctionDef{
 		Generics:           []string{},
 		Parameters:         []string{},
-		RequiresThis:       true,
+		RequiresThis:       !gm.Member.IsExtensionMember(),
 		WorkerExecutes:     false,
 		GeneratorYieldType: gm.GeneratorYieldType(getterBodyNode),
 		BodyNode:           getterBodyNode,
@@ -232,6 +255,19 @@ const aliasedMemberTemplateStr = `
 const functionTemplateStr = `
 {{ if .Member.IsStatic }}$static{{ else }}$instance{{ end }}.{{ .MemberName }} = {{ emit .FunctionSource }}`
 
+// extensionFunctionTemplateStr defines the template for generating function members under extensions.
+const extensionFunctionTemplateStr = `
+$static.{{ .MemberName }} = function($this) {
+	return ({{ emit .FunctionSource }});
+};`
+
+// extensionPropertyTemplateStr defines the template for generating read-only properties under
+// extensions.
+const extensionPropertyTemplateStr = `
+$static.{{ .MemberName }} = function($this) {
+	return $t.property({{ emit .GetterSource }});
+};`
+
 // propertyTemplateStr defines the template for generating properties.
 const propertyTemplateStr = `
 {{ if not .Member.IsReadOnly }}
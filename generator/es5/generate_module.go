@@ -85,6 +85,11 @@ func (gm generatingModule) recursivelyCollectInitDependencies(current typegraph.
 	}
 
 	if field.NodeId != current.NodeId && current.IsField() {
+		// Constants are folded into their references, so they need not be initialized first.
+		if gm.isFoldedConstant(current) {
+			return
+		}
+
 		deps[current] = true
 	} else {
 		srgMember, hasSRGMember := gm.Generator.getSRGMember(current)
@@ -101,6 +106,23 @@ func (gm generatingModule) recursivelyCollectInitDependencies(current typegraph.
 	}
 }
 
+// isFoldedConstant returns whether the given member is a constant whose value is folded at
+// compile time.
+func (gm generatingModule) isFoldedConstant(member typegraph.TGMember) bool {
+	srgMember, hasSRGMember := gm.Generator.getSRGMember(member)
+	if !hasSRGMember || !srgMember.IsConstant() {
+		return false
+	}
+
+	initializer, hasInitializer := srgMember.Initializer()
+	if !hasInitializer {
+		return false
+	}
+
+	_, isFolded := gm.Generator.scopegraph.ConstantValue(initializer)
+	return isFolded
+}
+
 // FieldId returns a stable, unique ID for the given field.
 func (gm generatingModule) FieldId(member typegraph.TGMember) string {
 	srgMember, _ := gm.Generator.getSRGMember(member)
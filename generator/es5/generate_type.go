@@ -49,6 +49,12 @@ func (gen *es5generator) generateType(typedef typegraph.TGTypeDecl) (esbuilder.S
 	case typegraph.StructType:
 		return esbuilder.Template("struct", structTemplateStr, generating), true
 
+	case typegraph.EnumType:
+		return esbuilder.Template("enum", enumTemplateStr, generating), true
+
+	case typegraph.ExtensionType:
+		return esbuilder.Template("extension", extensionTemplateStr, generating), true
+
 	case typegraph.ExternalInternalType:
 		return esbuilder.Snippet(""), false
 
@@ -99,6 +105,17 @@ func (gt generatingType) Fields() []typegraph.TGMember {
 	return gt.Type.Fields()
 }
 
+// EnumValueNames returns the generated names of the values defined under this enum type,
+// in declaration order.
+func (gt generatingType) EnumValueNames() []string {
+	values := gt.Type.EnumValues()
+	names := make([]string, len(values))
+	for index, value := range values {
+		names[index] = gt.Generator.pather.GetMemberName(value)
+	}
+	return names
+}
+
 // Alias returns the alias for this type, if any.
 func (gt generatingType) Alias() string {
 	alias, hasAlias := gt.Type.GlobalAlias()
@@ -301,6 +318,29 @@ this.$struct('{{ .Type.GlobalUniqueId }}', '{{ .Type.Name }}', {{ .HasGenerics }
 });
 `
 
+// enumTemplateStr defines the template for generating an enum type. The values themselves,
+// as well as the Values, String and Equals members, are constructed by the runtime.
+const enumTemplateStr = `
+this.$enum('{{ .Type.GlobalUniqueId }}', '{{ .Type.Name }}', false, '{{ .Alias }}', function() {
+	var $static = this;
+	$static.$valuenames = [{{ range $idx, $name := .EnumValueNames }}{{ if $idx }}, {{ end }}'{{ $name }}'{{ end }}];
+
+  	{{ .TypeSignatureMethod }}
+});
+`
+
+// extensionTemplateStr defines the template for generating an extension type. Extensions are never
+// instantiated, so all their members are generated as static functions taking the extended value.
+const extensionTemplateStr = `
+this.$extension('{{ .Type.GlobalUniqueId }}', '{{ .Type.Name }}', {{ .HasGenerics }}, '', function({{ .Generics }}) {
+	var $static = this;
+
+	{{ range $idx, $kv := .GenerateImplementedMembers.UnsafeIter }}
+  	  {{ emit $kv.Value }}
+  	{{ end }}
+});
+`
+
 // nominalTemplateStr defines the template for generating a nominal type.
 const nominalTemplateStr = `
 this.$type('{{ .Type.GlobalUniqueId }}', '{{ .Type.Name }}', {{ .HasGenerics }}, '{{ .Alias }}', function({{ .Generics }}) {
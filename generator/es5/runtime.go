@@ -87,6 +87,15 @@ this.Serulian = (function($global) {
       return tpe;
   };
 
+  // $tuple defines the internal tuple type. Tuples are boxed arrays holding the tuple's
+  // element values, in order.
+  var $tuple = $it('Tuple', 'tuple');
+  $tuple.$box = function(data) {
+    var instance = new $tuple();
+    instance[BOXED_DATA_PROPERTY] = data;
+    return instance;
+  };
+
   // $t defines all helper literals and methods used under the type system.
   var $t = {
     // any special type.
@@ -101,6 +110,76 @@ this.Serulian = (function($global) {
     // null special type.
     'null': $it('Null', 'null'),
 
+    // tuple special type.
+    'tuple': $tuple,
+
+    // tupleitem returns the element at the given index in the tuple, boxed to the given
+    // type if necessary.
+    'tupleitem': function(tuple, index, type) {
+      var value = $t.unbox(tuple)[index];
+      if (type.$box) {
+        return $t.box(value, type);
+      }
+
+      return value;
+    },
+
+    // deferredcalls returns a new resource holding the calls deferred under a function, each
+    // registered with the values captured for it. The calls are made in the reverse order of their
+    // registration when the resource is released, with each async call completing before the next
+    // call is made. Every call is made even if an earlier call fails, with the first failure raised
+    // once all calls have completed. As the resource can be released more than once, each call is
+    // removed once it has been made.
+    'deferredcalls': function() {
+      var calls = [];
+      return {
+        'push': function(call, args) {
+          calls.push({ 'call': call, 'args': args });
+        },
+
+        'Release': function() {
+          var pending = calls;
+          calls = [];
+
+          var failed = false;
+          var failure = null;
+          var fail = function(err) {
+            if (!failed) {
+              failed = true;
+              failure = err;
+            }
+          };
+
+          var next = function(index) {
+            for (var i = index; i >= 0; --i) {
+              var result = null;
+              try {
+                result = pending[i].call.apply(null, pending[i].args);
+              } catch (e) {
+                fail(e);
+                continue;
+              }
+
+              if (result && result.then) {
+                return result.then(function() {
+                  return next(i - 1);
+                }, function(err) {
+                  fail(err);
+                  return next(i - 1);
+                });
+              }
+            }
+
+            if (failed) {
+              throw failure;
+            }
+          };
+
+          return next(pending.length - 1);
+        }
+      };
+    },
+
     // toESType returns the ECMAScript type of the given object.
     'toESType': function(obj) {
       return ({}).toString.call(obj).match(/\s([a-zA-Z]+)/)[1].toLowerCase()
@@ -241,6 +320,7 @@ this.Serulian = (function($global) {
         case 'struct':
         case 'type':
         case 'class':
+        case 'enum':
           // Direct matching is the requirement.
           return false;
 
@@ -296,6 +376,7 @@ this.Serulian = (function($global) {
 
         case 'class':
         case 'interface':
+        case 'enum':
           // Since we check for equality above, this must fail.
           throw Error('Cannot cast ' + value.constructor.toString() + ' to ' + type.toString());
 
@@ -371,6 +452,13 @@ this.Serulian = (function($global) {
       if (check('bool', 'boolean')) { return; }
       if (check('slice', 'array')) { return; }
 
+      if (type == $t.tuple) {
+        if ($t.toESType(value) != 'array') {
+          throw Error('Expected tuple for field ' + name + ', found: ' + $t.toESType(value))
+        }
+        return;
+      }
+
       if ($t.toESType(value) != 'object') {
         throw Error('Expected object for field ' + name + ', found: ' + $t.toESType(value))        
       }
@@ -635,10 +723,11 @@ this.Serulian = (function($global) {
         // from the stack (waiting for their promises to complete) and then invokes
         // the bound function. This function is used to bind the $resolve, $reject
         // and $done handlers in functions, to ensure all resources are removed
-        // before they complete.
-        'bind': function(func, isAsync) {          
+        // before they complete. If given, opt_reject is invoked instead of the bound
+        // function should releasing the resources fail.
+        'bind': function(func, isAsync, opt_reject) {          
           if (isAsync) {
-            return this.bindasync(func);
+            return this.bindasync(func, opt_reject);
           } else {
             return this.bindsync(func);
           }
@@ -653,12 +742,18 @@ this.Serulian = (function($global) {
             return f;
         },
 
-        'bindasync': function(func) {
+        'bindasync': function(func, opt_reject) {
           var r = this; // The resource handler.
           var f = function(value) {
             var that = this; // The scope of the function.
             return r.popall().then(function(_) {
               func.call(that, value);
+            }, function(err) {
+              if (opt_reject) {
+                opt_reject.call(that, err);
+              } else {
+                func.call(that, value);
+              }
             });            
           };
 
@@ -1048,6 +1143,31 @@ this.Serulian = (function($global) {
             };
           } // end struct
 
+          if (kind == 'enum') {
+            // Values, in declaration order. Each value is the sole instance of the type with its name.
+            tpe.$values = tpe.$valuenames.map(function(valueName, index) {
+              var instance = new tpe();
+              instance.$name = valueName;
+              instance.$index = index;
+              return tpe[valueName] = instance;
+            });
+
+            // Values.
+            tpe.Values = function() {
+              return $t.fastbox(tpe.$values.slice(), $a['slice'](tpe));
+            };
+
+            // String.
+            tpe.prototype.String = function() {
+              return $t.fastbox(this.$name, $a['string']);
+            };
+
+            // Equals.
+            tpe.$equals = function(left, right) {
+              return $t.fastbox(left === right, $a['bool']);
+            };
+          } // end enum
+
           return tpe;
         };
 
@@ -1106,6 +1226,8 @@ this.Serulian = (function($global) {
     module.$agent = $newtypebuilder('agent');
   	module.$interface = $newtypebuilder('interface');
     module.$type = $newtypebuilder('type');
+    module.$enum = $newtypebuilder('enum');
+    module.$extension = $newtypebuilder('extension');
 
   	creator.call(module)
   };
@@ -46,6 +46,10 @@ func (p Pather) TypeReferenceCall(typeRef typegraph.TypeReference) string {
 		return "$t.void"
 	}
 
+	if typeRef.IsTuple() {
+		return "$t.tuple"
+	}
+
 	referredType := typeRef.ReferredType()
 	if referredType.TypeKind() == typegraph.GenericType {
 		return referredType.Name()
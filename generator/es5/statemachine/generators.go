@@ -7,6 +7,7 @@ package statemachine
 import (
 	"fmt"
 
+	"github.com/serulian/compiler/compilergraph"
 	"github.com/serulian/compiler/generator/es5/codedom"
 	"github.com/serulian/compiler/generator/es5/expressiongenerator"
 	"github.com/serulian/compiler/generator/escommon/esbuilder"
@@ -77,33 +78,55 @@ func (sg *stateGenerator) generateConditionalJump(jump *codedom.ConditionalJumpN
 
 // generateResourceBlock generates the code for a resource block.
 func (sg *stateGenerator) generateResourceBlock(resourceBlock *codedom.ResourceBlockNode) {
+	sg.generateManagedResource(
+		resourceBlock.ResourceName,
+		resourceBlock.Resource,
+		resourceBlock.Statement,
+		resourceBlock.HasAsyncRelease(sg.scopegraph),
+		resourceBlock.BasisNode())
+}
+
+// generateDeferBlock generates the code for a defer block. The deferred calls are registered with
+// a resource placed on the resource stack, ensuring they are made on every exit path from the statement.
+func (sg *stateGenerator) generateDeferBlock(deferBlock *codedom.DeferBlockNode) {
+	basisNode := deferBlock.BasisNode()
+	sg.generateManagedResource(
+		deferBlock.ResourceName,
+		codedom.RuntimeFunctionCall(codedom.DeferredCallsFunction, []codedom.Expression{}, basisNode),
+		deferBlock.Statement,
+		deferBlock.HasAsyncRelease(sg.scopegraph),
+		basisNode)
+}
+
+// generateManagedResource generates the code for executing a statement with a resource placed on
+// the resource stack, releasing the resource once the statement completes.
+func (sg *stateGenerator) generateManagedResource(resourceName string, resourceExpr codedom.Expression, statement codedom.Statement, hasAsyncRelease bool, basisNode compilergraph.GraphNode) {
 	// Generate a variable holding the resource.
-	basisNode := resourceBlock.BasisNode()
 	sg.generateStates(
-		codedom.VarDefinitionWithInit(resourceBlock.ResourceName, resourceBlock.Resource, basisNode),
+		codedom.VarDefinitionWithInit(resourceName, resourceExpr, basisNode),
 		generateImplicitState)
 
 	// Push the resource onto the resource stack.
-	sg.pushResource(resourceBlock.ResourceName, resourceBlock.BasisNode())
+	sg.pushResource(resourceName, basisNode)
 
 	// Generate the inner statement.
-	sg.generateStates(resourceBlock.Statement, generateNextState)
+	sg.generateStates(statement, generateNextState)
 
 	// Pop the resource from the resource stack.
-	sg.popResource(resourceBlock.ResourceName, resourceBlock.BasisNode())
+	sg.popResource(resourceName, basisNode)
 
 	// Generate the popr call for the resource.
 	popCall := codedom.RuntimeFunctionCall(
 		codedom.StatePopResourceFunction,
 		[]codedom.Expression{
-			codedom.LiteralValue("'"+resourceBlock.ResourceName+"'", basisNode),
+			codedom.LiteralValue("'"+resourceName+"'", basisNode),
 		},
 		basisNode,
 	)
 
 	// Determine if the Release() of the resource is async. If so, then await on the popr
 	// call.
-	if resourceBlock.HasAsyncRelease(sg.scopegraph) {
+	if hasAsyncRelease {
 		popCall = codedom.AwaitPromise(popCall, basisNode)
 	}
 
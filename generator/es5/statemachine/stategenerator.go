@@ -136,6 +136,9 @@ func (sg *stateGenerator) generateStates(statement codedom.Statement, option gen
 	case *codedom.ResourceBlockNode:
 		sg.generateResourceBlock(e)
 
+	case *codedom.DeferBlockNode:
+		sg.generateDeferBlock(e)
+
 	case *codedom.ConditionalJumpNode:
 		sg.generateConditionalJump(e)
 
@@ -366,7 +369,7 @@ const generatorStateMachineTemplateStr = `
 
 	var $continue = (function($yield, $yieldin, $reject, $done) {
 		{{ if .ManagesResources }}
-			$done = $resources.bind($done, {{ .IsAsync }});
+			$done = $resources.bind($done, {{ .IsAsync }}{{ if .IsAsync }}, $reject{{ end }});
 
 			{{ if .IsAsync }}
 				$reject = $resources.bind($reject, true);
@@ -409,7 +412,7 @@ const asyncStateMachineTemplateStr = `
 
 	var $continue = (function($resolve, $reject) {
 		{{ if .ManagesResources }}
-			$resolve = $resources.bind($resolve, true);
+			$resolve = $resources.bind($resolve, true, $reject);
 			$reject = $resources.bind($reject, true);
 		{{ end }}
 
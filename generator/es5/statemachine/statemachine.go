@@ -24,18 +24,19 @@ var _ = fmt.Printf
 
 // FunctionDef defines the struct for a function accepted by GenerateFunctionSource.
 type FunctionDef struct {
-	Generics           []string                 // Returns the names of the generics on the function, if any.
-	Parameters         []string                 // Returns the names of the parameters on the function, if any.
-	RequiresThis       bool                     // Returns if this function is requires the "this" var to be added.
-	WorkerExecutes     bool                     // Returns true if this function should be executed by a web worker.
-	GeneratorYieldType *typegraph.TypeReference // Returns a non-nil value if the function being generated is a generator.
-	BodyNode           compilergraph.GraphNode  // The parser root node for the function body.
+	Generics            []string                  // Returns the names of the generics on the function, if any.
+	Parameters          []string                  // Returns the names of the parameters on the function, if any.
+	RequiresThis        bool                      // Returns if this function is requires the "this" var to be added.
+	WorkerExecutes      bool                      // Returns true if this function should be executed by a web worker.
+	GeneratorYieldType  *typegraph.TypeReference  // Returns a non-nil value if the function being generated is a generator.
+	BodyNode            compilergraph.GraphNode   // The parser root node for the function body.
+	DefaultedParameters []compilergraph.GraphNode // The parser nodes for the parameters with default values, if any.
 }
 
 // GenerateFunctionSource generates the source code for a function, including its internal state machine.
 func GenerateFunctionSource(functionDef FunctionDef, scopegraph *scopegraph.ScopeGraph) esbuilder.SourceBuilder {
 	// Build the body via CodeDOM.
-	funcBody := dombuilder.BuildStatement(scopegraph, functionDef.BodyNode)
+	funcBody := dombuilder.BuildFunctionBody(scopegraph, functionDef.BodyNode, functionDef.DefaultedParameters)
 
 	// Instantiate a new state machine generator and use it to generate the function.
 	functionTraits := shared.FunctionTraits(codedom.IsAsynchronous(funcBody, scopegraph), functionDef.GeneratorYieldType != nil, codedom.IsManagingResources(funcBody))
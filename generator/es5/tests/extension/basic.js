@@ -0,0 +1,62 @@
+$module('basic', function () {
+  var $static = this;
+  this.$extension('6775ec60', 'StringHelpers', false, '', function () {
+    var $static = this;
+    $static.Shout = function ($this) {
+      return function () {
+        return $g.________testlib.basictypes.String.$plus($this, $t.fastbox('!', $g.________testlib.basictypes.String));
+      };
+    };
+    $static.IsEmpty = function ($this) {
+      return $t.property(function () {
+        return $t.fastbox($this.Length().$wrapped == 0, $g.________testlib.basictypes.Boolean);
+      });
+    };
+  });
+
+  this.$extension('759420ad', 'SliceHelpers', true, '', function (T) {
+    var $static = this;
+    $static.First = function ($this) {
+      return function () {
+        return $this.$index($t.fastbox(0, $g.________testlib.basictypes.Integer));
+      };
+    };
+  });
+
+  $static.TEST = $t.markpromising(function () {
+    var $result;
+    var s;
+    var values;
+    var $current = 0;
+    var $continue = function ($resolve, $reject) {
+      localasyncloop: while (true) {
+        switch ($current) {
+          case 0:
+            s = $t.fastbox('hello', $g.________testlib.basictypes.String);
+            values = $g.________testlib.basictypes.Slice($g.________testlib.basictypes.Integer).overArray([$t.fastbox(1, $g.________testlib.basictypes.Integer), $t.fastbox(2, $g.________testlib.basictypes.Integer), $t.fastbox(3, $g.________testlib.basictypes.Integer)]);
+            $promise.resolve($g.________testlib.basictypes.String.$equals($g.basic.StringHelpers.Shout(s)(), $t.fastbox('hello!', $g.________testlib.basictypes.String)).$wrapped).then(function ($result1) {
+              return $promise.resolve($result1 && !$g.basic.StringHelpers.IsEmpty(s)().$wrapped).then(function ($result0) {
+                $result = $t.fastbox($result0 && ($t.assertnotnull($g.basic.SliceHelpers($g.________testlib.basictypes.Integer).First(values)()).$wrapped == 1), $g.________testlib.basictypes.Boolean);
+                $current = 1;
+                $continue($resolve, $reject);
+                return;
+              });
+            }).catch(function (err) {
+              $reject(err);
+              return;
+            });
+            return;
+
+          case 1:
+            $resolve($result);
+            return;
+
+          default:
+            $resolve();
+            return;
+        }
+      }
+    };
+    return $promise.new($continue);
+  });
+});
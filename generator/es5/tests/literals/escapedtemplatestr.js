@@ -1,7 +1,7 @@
 $module('escapedtemplatestr', function () {
   var $static = this;
   $static.DoSomething = function () {
-    $t.fastbox("hello 'world'! \"This is a\n\tlong quote!\"", $g.________testlib.basictypes.String);
+    $t.fastbox('hello \'world\'! "This is a\n\tlong quote!"', $g.________testlib.basictypes.String);
     return;
   };
 });
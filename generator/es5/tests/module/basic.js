@@ -4,7 +4,7 @@ $module('basic', function () {
     return $g.basic.someBool;
   };
   $static.TEST = function () {
-    return $t.fastbox($g.basic.anotherBool.$wrapped && ($g.basic.anotherInt.$wrapped == 42), $g.________testlib.basictypes.Boolean);
+    return $t.fastbox($g.basic.anotherBool.$wrapped && true, $g.________testlib.basictypes.Boolean);
   };
   this.$init(function () {
     return $promise.new(function (resolve) {
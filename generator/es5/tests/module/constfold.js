@@ -0,0 +1,42 @@
+$module('constfold', function () {
+  var $static = this;
+  $static.TEST = function () {
+    return $t.fastbox((true && ($t.fastbox('a\u2028b', $g.________testlib.basictypes.String).Length().$wrapped == 3)) && true, $g.________testlib.basictypes.Boolean);
+  };
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.Base = $t.fastbox(10, $g.________testlib.basictypes.Integer);
+      resolve();
+    });
+  }, '487d0d94', []);
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.Doubled = $t.fastbox(20, $g.________testlib.basictypes.Integer);
+      resolve();
+    });
+  }, '071c3592', []);
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.IsLarge = $t.fastbox(true, $g.________testlib.basictypes.Boolean);
+      resolve();
+    });
+  }, '16be39eb', []);
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.Quoted = $t.fastbox('it\'s a "test"\n', $g.________testlib.basictypes.String);
+      resolve();
+    });
+  }, 'fb9ce3be', []);
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.Separated = $t.fastbox('a\u2028b', $g.________testlib.basictypes.String);
+      resolve();
+    });
+  }, '13e4af23', []);
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.Message = $t.fastbox('it\'s a "test"\ndone', $g.________testlib.basictypes.String);
+      resolve();
+    });
+  }, '91b6405a', []);
+});
@@ -0,0 +1,142 @@
+$module('defaultparams', function () {
+  var $static = this;
+  this.$class('1c5cb616', 'SomeClass', false, '', function () {
+    var $static = this;
+    var $instance = this.prototype;
+    $static.new = function (count) {
+      var instance = new $static();
+      instance.count = count;
+      return instance;
+    };
+    $static.Declare = function (count) {
+      var $current = 0;
+      syncloop: while (true) {
+        switch ($current) {
+          case 0:
+            if (count === undefined) {
+              $current = 1;
+              continue syncloop;
+            } else {
+              $current = 2;
+              continue syncloop;
+            }
+            break;
+
+          case 1:
+            count = $t.fastbox(2, $g.________testlib.basictypes.Integer);
+            $current = 2;
+            continue syncloop;
+
+          case 2:
+            return $g.defaultparams.SomeClass.new(count);
+
+          default:
+            return;
+        }
+      }
+    };
+    $instance.Describe = function (prefix, suffix) {
+      var $this = this;
+      var $current = 0;
+      syncloop: while (true) {
+        switch ($current) {
+          case 0:
+            if (prefix === undefined) {
+              $current = 1;
+              continue syncloop;
+            } else {
+              $current = 2;
+              continue syncloop;
+            }
+            break;
+
+          case 1:
+            prefix = $t.fastbox('hello', $g.________testlib.basictypes.String);
+            $current = 2;
+            continue syncloop;
+
+          case 2:
+            if (suffix === undefined) {
+              $current = 3;
+              continue syncloop;
+            } else {
+              $current = 4;
+              continue syncloop;
+            }
+            break;
+
+          case 3:
+            suffix = null;
+            $current = 4;
+            continue syncloop;
+
+          case 4:
+            return $g.________testlib.basictypes.String.$plus(prefix, $t.syncnullcompare(suffix, function () {
+              return $t.fastbox('!', $g.________testlib.basictypes.String);
+            }));
+
+          default:
+            return;
+        }
+      }
+    };
+    this.$typesig = function () {
+      if (this.$cachedtypesig) {
+        return this.$cachedtypesig;
+      }
+      var computed = {
+        "Declare|1|cf412abd<1c5cb616>": true,
+        "Describe|2|cf412abd<cb470bcc>": true,
+      };
+      return this.$cachedtypesig = computed;
+    };
+  });
+
+  $static.Configure = function (host, port, secure) {
+    var $current = 0;
+    syncloop: while (true) {
+      switch ($current) {
+        case 0:
+          if (port === undefined) {
+            $current = 1;
+            continue syncloop;
+          } else {
+            $current = 2;
+            continue syncloop;
+          }
+          break;
+
+        case 1:
+          port = $t.fastbox(80, $g.________testlib.basictypes.Integer);
+          $current = 2;
+          continue syncloop;
+
+        case 2:
+          if (secure === undefined) {
+            $current = 3;
+            continue syncloop;
+          } else {
+            $current = 4;
+            continue syncloop;
+          }
+          break;
+
+        case 3:
+          secure = $t.fastbox(false, $g.________testlib.basictypes.Boolean);
+          $current = 4;
+          continue syncloop;
+
+        case 4:
+          return $t.fastbox((port.$wrapped == 80) && secure.$wrapped, $g.________testlib.basictypes.Boolean);
+
+        default:
+          return;
+      }
+    }
+  };
+  $static.TEST = function () {
+    var sc;
+    sc = $g.defaultparams.SomeClass.Declare();
+    return $t.fastbox(($g.defaultparams.Configure($t.fastbox('localhost', $g.________testlib.basictypes.String), undefined, $t.fastbox(true, $g.________testlib.basictypes.Boolean)).$wrapped && (sc.count.$wrapped == 2)) && $g.________testlib.basictypes.String.$equals(sc.Describe(undefined, $t.fastbox(' world', $g.________testlib.basictypes.String)), $t.fastbox('hello world', $g.________testlib.basictypes.String)).$wrapped, $g.________testlib.basictypes.Boolean);
+  };
+});
@@ -0,0 +1,22 @@
+$module('inferredgeneric', function () {
+  var $static = this;
+  $static.Pick = function (T) {
+    var $f = function (first, second) {
+      return first;
+    };
+    return $f;
+  };
+  $static.PickNullable = function (T) {
+    var $f = function (value) {
+      return $t.assertnotnull(value);
+    };
+    return $f;
+  };
+  $static.TEST = function () {
+    var first;
+    var second;
+    first = $g.inferredgeneric.Pick($g.________testlib.basictypes.Boolean)($t.fastbox(true, $g.________testlib.basictypes.Boolean), $t.fastbox(false, $g.________testlib.basictypes.Boolean));
+    second = $g.inferredgeneric.PickNullable($g.________testlib.basictypes.Boolean)($t.fastbox(true, $g.________testlib.basictypes.Boolean));
+    return $t.fastbox(first.$wrapped && second.$wrapped, $g.________testlib.basictypes.Boolean);
+  };
+});
@@ -0,0 +1,9 @@
+$module('namedargs', function () {
+  var $static = this;
+  $static.Configure = function (host, port, secure) {
+    return $t.fastbox(($g.________testlib.basictypes.String.$equals(host, $t.fastbox('localhost', $g.________testlib.basictypes.String)).$wrapped && (port.$wrapped == 8080)) && secure.$wrapped, $g.________testlib.basictypes.Boolean);
+  };
+  $static.TEST = function () {
+    return $g.namedargs.Configure($t.fastbox('localhost', $g.________testlib.basictypes.String), $t.fastbox(8080, $g.________testlib.basictypes.Integer), $t.fastbox(true, $g.________testlib.basictypes.Boolean));
+  };
+});
@@ -0,0 +1,21 @@
+$module('destructure', function () {
+  var $static = this;
+  $static.SumAndDifference = function (a, b) {
+    return $t.fastbox([$t.fastbox(a.$wrapped + b.$wrapped, $g.________testlib.basictypes.Integer), $t.fastbox(a.$wrapped - b.$wrapped, $g.________testlib.basictypes.Integer)], $t.tuple);
+  };
+  $static.TEST = function () {
+    var $temp0;
+    var $temp1;
+    var count;
+    var difference;
+    var name;
+    var sum;
+    $temp0 = $g.destructure.SumAndDifference($t.fastbox(10, $g.________testlib.basictypes.Integer), $t.fastbox(3, $g.________testlib.basictypes.Integer));
+    sum = $t.tupleitem($temp0, 0, $g.________testlib.basictypes.Integer);
+    difference = $t.tupleitem($temp0, 1, $g.________testlib.basictypes.Integer);
+    $temp1 = $t.fastbox([$t.fastbox('hello', $g.________testlib.basictypes.String), $t.fastbox(false, $g.________testlib.basictypes.Boolean), $t.fastbox(2, $g.________testlib.basictypes.Integer)], $t.tuple);
+    name = $t.tupleitem($temp1, 0, $g.________testlib.basictypes.String);
+    count = $t.tupleitem($temp1, 2, $g.________testlib.basictypes.Integer);
+    return $t.fastbox((((sum.$wrapped == 13) && (difference.$wrapped == 7)) && $g.________testlib.basictypes.String.$equals(name, $t.fastbox('hello', $g.________testlib.basictypes.String)).$wrapped) && (count.$wrapped == 2), $g.________testlib.basictypes.Boolean);
+  };
+});
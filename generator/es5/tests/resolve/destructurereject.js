@@ -0,0 +1,96 @@
+$module('destructurereject', function () {
+  var $static = this;
+  this.$class('2bf3d20a', 'SimpleError', false, '', function () {
+    var $static = this;
+    var $instance = this.prototype;
+    $static.new = function () {
+      var instance = new $static();
+      return instance;
+    };
+    $instance.Message = $t.property(function () {
+      var $this = this;
+      return $t.fastbox('yo!', $g.________testlib.basictypes.String);
+    });
+    this.$typesig = function () {
+      if (this.$cachedtypesig) {
+        return this.$cachedtypesig;
+      }
+      var computed = {
+        "Message|3|cb470bcc": true,
+      };
+      return this.$cachedtypesig = computed;
+    };
+  });
+
+  $static.Pair = function (fail) {
+    var $current = 0;
+    syncloop: while (true) {
+      switch ($current) {
+        case 0:
+          if (fail.$wrapped) {
+            $current = 1;
+            continue syncloop;
+          } else {
+            $current = 2;
+            continue syncloop;
+          }
+          break;
+
+        case 1:
+          throw $g.destructurereject.SimpleError.new();
+
+        case 2:
+          return $t.fastbox([$t.fastbox(1, $g.________testlib.basictypes.Integer), $t.fastbox(2, $g.________testlib.basictypes.Integer)], $t.tuple);
+
+        default:
+          return;
+      }
+    }
+  };
+  $static.Destructure = function (fail) {
+    var $temp0;
+    var first;
+    var second;
+    $temp0 = $g.destructurereject.Pair(fail);
+    first = $t.tupleitem($temp0, 0, $g.________testlib.basictypes.Integer);
+    second = $t.tupleitem($temp0, 1, $g.________testlib.basictypes.Integer);
+    return $t.fastbox(first.$wrapped + second.$wrapped, $g.________testlib.basictypes.Integer);
+  };
+  $static.TEST = function () {
+    var err;
+    var sum;
+    var value;
+    var $current = 0;
+    syncloop: while (true) {
+      switch ($current) {
+        case 0:
+          try {
+            var $expr = $g.destructurereject.Destructure($t.fastbox(true, $g.________testlib.basictypes.Boolean));
+            value = $expr;
+            err = null;
+          } catch ($rejected) {
+            err = $t.ensureerror($rejected);
+            value = null;
+          }
+          $current = 1;
+          continue syncloop;
+
+        case 1:
+          try {
+            var $expr = $g.destructurereject.Destructure($t.fastbox(false, $g.________testlib.basictypes.Boolean));
+            sum = $expr;
+          } catch ($rejected) {
+            sum = null;
+          }
+          $current = 2;
+          continue syncloop;
+
+        case 2:
+          return $t.fastbox(((value == null) && $g.________testlib.basictypes.String.$equals($t.assertnotnull(err).Message(), $t.fastbox('yo!', $g.________testlib.basictypes.String)).$wrapped) && ($t.assertnotnull(sum).$wrapped == 3), $g.________testlib.basictypes.Boolean);
+
+        default:
+          return;
+      }
+    }
+  };
+});
@@ -55,11 +55,71 @@ this.Serulian = (function ($global) {
     };
     return tpe;
   };
+  var $tuple = $it('Tuple', 'tuple');
+  $tuple.$box = function (data) {
+    var instance = new $tuple();
+    instance[BOXED_DATA_PROPERTY] = data;
+    return instance;
+  };
   var $t = {
     any: $it('Any', 'any'),
     struct: $it('Struct', 'struct'),
     void: $it('Void', 'void'),
     null: $it('Null', 'null'),
+    tuple: $tuple,
+    tupleitem: function (tuple, index, type) {
+      var value = $t.unbox(tuple)[index];
+      if (type.$box) {
+        return $t.box(value, type);
+      }
+      return value;
+    },
+    deferredcalls: function () {
+      var calls = [];
+      return {
+        push: function (call, args) {
+          calls.push({
+            call: call,
+            args: args,
+          });
+        },
+        Release: function () {
+          var pending = calls;
+          calls = [];
+          var failed = false;
+          var failure = null;
+          var fail = function (err) {
+            if (!failed) {
+              failed = true;
+              failure = err;
+            }
+          };
+          var next = function (index) {
+            for (var i = index; i >= 0; --i) {
+              var result = null;
+              try {
+                result = pending[i].call.apply(null, pending[i].args);
+              } catch (e) {
+                fail(e);
+                continue;
+              }
+              if (result && result.then) {
+                return result.then(function () {
+                  return next(i - 1);
+                }, function (err) {
+                  fail(err);
+                  return next(i - 1);
+                });
+              }
+            }
+            if (failed) {
+              throw failure;
+            }
+          };
+          return next(pending.length - 1);
+        },
+      };
+    },
     toESType: function (obj) {
       return {
       }.toString.call(obj).match(/\s([a-zA-Z]+)/)[1].toLowerCase();
@@ -151,6 +211,8 @@ this.Serulian = (function ($global) {
         case 'type':
 
         case 'class':
+
+        case 'enum':
           return false;
 
         case 'interface':
@@ -190,6 +252,8 @@ this.Serulian = (function ($global) {
         case 'class':
 
         case 'interface':
+
+        case 'enum':
           throw Error((('Cannot cast ' + value.constructor.toString()) + ' to ') + type.toString());
 
         case 'type':
@@ -249,6 +313,12 @@ this.Serulian = (function ($global) {
       if (check('slice', 'array')) {
         return;
       }
+      if (type == $t.tuple) {
+        if ($t.toESType(value) != 'array') {
+          throw Error((('Expected tuple for field ' + name) + ', found: ') + $t.toESType(value));
+        }
+        return;
+      }
       if ($t.toESType(value) != 'object') {
         throw Error((('Expected object for field ' + name) + ', found: ') + $t.toESType(value));
       }
@@ -431,9 +501,9 @@ this.Serulian = (function ($global) {
       return {
         resources: {
         },
-        bind: function (func, isAsync) {
+        bind: function (func, isAsync, opt_reject) {
           if (isAsync) {
-            return this.bindasync(func);
+            return this.bindasync(func, opt_reject);
           } else {
             return this.bindsync(func);
           }
@@ -446,12 +516,18 @@ this.Serulian = (function ($global) {
           };
           return f;
         },
-        bindasync: function (func) {
+        bindasync: function (func, opt_reject) {
           var r = this;
           var f = function (value) {
             var that = this;
             return r.popall().then(function (_) {
               func.call(that, value);
+            }, function (err) {
+              if (opt_reject) {
+                opt_reject.call(that, err);
+              } else {
+                func.call(that, value);
+              }
             });
           };
           return f;
@@ -741,6 +817,23 @@ this.Serulian = (function ($global) {
               }
             };
           }
+          if (kind == 'enum') {
+            tpe.$values = tpe.$valuenames.map(function (valueName, index) {
+              var instance = new tpe();
+              instance.$name = valueName;
+              instance.$index = index;
+              return tpe[valueName] = instance;
+            });
+            tpe.Values = function () {
+              return $t.fastbox(tpe.$values.slice(), $a['slice'](tpe));
+            };
+            tpe.prototype.String = function () {
+              return $t.fastbox(this.$name, $a['string']);
+            };
+            tpe.$equals = function (left, right) {
+              return $t.fastbox(left === right, $a['bool']);
+            };
+          }
           return tpe;
         };
         if (hasGenerics) {
@@ -786,6 +879,8 @@ this.Serulian = (function ($global) {
     module.$agent = $newtypebuilder('agent');
     module.$interface = $newtypebuilder('interface');
     module.$type = $newtypebuilder('type');
+    module.$enum = $newtypebuilder('enum');
+    module.$extension = $newtypebuilder('extension');
     creator.call(module);
   };
   $module('________testlib.basictypes', function () {
@@ -1182,6 +1277,19 @@ this.Serulian = (function ($global) {
       };
     });
 
+    this.$class('17908339', 'ValueTuple', false, 'valuetuple', function () {
+      var $static = this;
+      var $instance = this.prototype;
+      $static.new = function () {
+        var instance = new $static();
+        return instance;
+      };
+      this.$typesig = function () {
+        return {
+        };
+      };
+    });
+
     this.$interface('2ba9796f', 'Stringable', false, 'stringable', function () {
       var $static = this;
       this.$typesig = function () {
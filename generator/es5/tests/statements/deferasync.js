@@ -0,0 +1,184 @@
+$module('deferasync', function () {
+  var $static = this;
+  $static.DoSomethingAsync = $t.workerwrap('b439d379', function () {
+    return $t.fastbox(true, $g.________testlib.basictypes.Boolean);
+  });
+  $static.Cleanup = $t.markpromising(function (value) {
+    var $result;
+    var result;
+    var $current = 0;
+    var $continue = function ($resolve, $reject) {
+      localasyncloop: while (true) {
+        switch ($current) {
+          case 0:
+            $promise.translate($g.deferasync.DoSomethingAsync()).then(function ($result0) {
+              $result = $result0;
+              $current = 1;
+              $continue($resolve, $reject);
+              return;
+            }).catch(function (err) {
+              $reject(err);
+              return;
+            });
+            return;
+
+          case 1:
+            result = $result;
+            if (result.$wrapped) {
+              $current = 2;
+              continue localasyncloop;
+            } else {
+              $current = 3;
+              continue localasyncloop;
+            }
+            break;
+
+          case 2:
+            $g.deferasync.order = $g.________testlib.basictypes.String.$plus($g.deferasync.order, value);
+            $current = 3;
+            continue localasyncloop;
+
+          default:
+            $resolve();
+            return;
+        }
+      }
+    };
+    return $promise.new($continue);
+  });
+  $static.DoSomething = $t.markpromising(function () {
+    var $result;
+    var $temp0;
+    var $current = 0;
+    var $resources = $t.resourcehandler();
+    var $continue = function ($resolve, $reject) {
+      $resolve = $resources.bind($resolve, true, $reject);
+      $reject = $resources.bind($reject, true);
+      localasyncloop: while (true) {
+        switch ($current) {
+          case 0:
+            $temp0 = $t.deferredcalls();
+            $resources.pushr($temp0, '$temp0');
+            $temp0.push($t.markpromising(function ($temp1) {
+              var $result;
+              var $current = 0;
+              var $continue = function ($resolve, $reject) {
+                localasyncloop: while (true) {
+                  switch ($current) {
+                    case 0:
+                      $promise.maybe($g.deferasync.Cleanup($temp1)).then(function ($result0) {
+                        $result = $result0;
+                        $current = 1;
+                        $continue($resolve, $reject);
+                        return;
+                      }).catch(function (err) {
+                        $reject(err);
+                        return;
+                      });
+                      return;
+
+                    case 1:
+                      $resolve($result);
+                      return;
+
+                    default:
+                      $resolve();
+                      return;
+                  }
+                }
+              };
+              return $promise.new($continue);
+            }), [$t.fastbox('a', $g.________testlib.basictypes.String)]);
+            $temp0.push($t.markpromising(function ($temp2) {
+              var $result;
+              var $current = 0;
+              var $continue = function ($resolve, $reject) {
+                localasyncloop: while (true) {
+                  switch ($current) {
+                    case 0:
+                      $promise.maybe($g.deferasync.Cleanup($temp2)).then(function ($result0) {
+                        $result = $result0;
+                        $current = 1;
+                        $continue($resolve, $reject);
+                        return;
+                      }).catch(function (err) {
+                        $reject(err);
+                        return;
+                      });
+                      return;
+
+                    case 1:
+                      $resolve($result);
+                      return;
+
+                    default:
+                      $resolve();
+                      return;
+                  }
+                }
+              };
+              return $promise.new($continue);
+            }), [$t.fastbox('b', $g.________testlib.basictypes.String)]);
+            $g.deferasync.order = $g.________testlib.basictypes.String.$plus($g.deferasync.order, $t.fastbox('c', $g.________testlib.basictypes.String));
+            $promise.translate($g.deferasync.DoSomethingAsync()).then(function ($result0) {
+              $result = $result0;
+              $current = 1;
+              $continue($resolve, $reject);
+              return;
+            }).catch(function (err) {
+              $reject(err);
+              return;
+            });
+            return;
+
+          case 1:
+            $resolve($result);
+            return;
+
+          default:
+            $resolve();
+            return;
+        }
+      }
+    };
+    return $promise.new($continue);
+  });
+  $static.TEST = $t.markpromising(function () {
+    var $result;
+    var result;
+    var $current = 0;
+    var $continue = function ($resolve, $reject) {
+      localasyncloop: while (true) {
+        switch ($current) {
+          case 0:
+            $promise.maybe($g.deferasync.DoSomething()).then(function ($result0) {
+              $result = $result0;
+              $current = 1;
+              $continue($resolve, $reject);
+              return;
+            }).catch(function (err) {
+              $reject(err);
+              return;
+            });
+            return;
+
+          case 1:
+            result = $result;
+            $resolve($t.fastbox(result.$wrapped && $g.________testlib.basictypes.String.$equals($g.deferasync.order, $t.fastbox('cba', $g.________testlib.basictypes.String)).$wrapped, $g.________testlib.basictypes.Boolean));
+            return;
+
+          default:
+            $resolve();
+            return;
+        }
+      }
+    };
+    return $promise.new($continue);
+  });
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.order = $t.fastbox('', $g.________testlib.basictypes.String);
+      resolve();
+    });
+  }, '9d1fa4f5', []);
+});
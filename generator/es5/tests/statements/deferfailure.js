@@ -0,0 +1,225 @@
+$module('deferfailure', function () {
+  var $static = this;
+  this.$class('54a772d7', 'SimpleError', false, '', function () {
+    var $static = this;
+    var $instance = this.prototype;
+    $static.new = function () {
+      var instance = new $static();
+      return instance;
+    };
+    $instance.Message = $t.property(function () {
+      var $this = this;
+      return $t.fastbox('yo!', $g.________testlib.basictypes.String);
+    });
+    this.$typesig = function () {
+      if (this.$cachedtypesig) {
+        return this.$cachedtypesig;
+      }
+      var computed = {
+        "Message|3|cb470bcc": true,
+      };
+      return this.$cachedtypesig = computed;
+    };
+  });
+
+  $static.DoSomethingAsync = $t.workerwrap('726158f7', function () {
+    return $t.fastbox(true, $g.________testlib.basictypes.Boolean);
+  });
+  $static.Record = function (value) {
+    $g.deferfailure.order = $g.________testlib.basictypes.String.$plus($g.deferfailure.order, value);
+    return;
+  };
+  $static.FailingCleanup = function () {
+    throw $g.deferfailure.SimpleError.new();
+  };
+  $static.FailingAsyncCleanup = $t.markpromising(function () {
+    var $result;
+    var result;
+    var $current = 0;
+    var $continue = function ($resolve, $reject) {
+      localasyncloop: while (true) {
+        switch ($current) {
+          case 0:
+            $promise.translate($g.deferfailure.DoSomethingAsync()).then(function ($result0) {
+              $result = $result0;
+              $current = 1;
+              $continue($resolve, $reject);
+              return;
+            }).catch(function (err) {
+              $reject(err);
+              return;
+            });
+            return;
+
+          case 1:
+            result = $result;
+            if (result.$wrapped) {
+              $current = 2;
+              continue localasyncloop;
+            } else {
+              $current = 3;
+              continue localasyncloop;
+            }
+            break;
+
+          case 2:
+            $reject($g.deferfailure.SimpleError.new());
+            return;
+
+          default:
+            $resolve();
+            return;
+        }
+      }
+    };
+    return $promise.new($continue);
+  });
+  $static.DoSomething = function () {
+    var $temp0;
+    var $resources = $t.resourcehandler();
+    $temp0 = $t.deferredcalls();
+    $resources.pushr($temp0, '$temp0');
+    $temp0.push(function ($temp1) {
+      return $g.deferfailure.Record($temp1);
+    }, [$t.fastbox('a', $g.________testlib.basictypes.String)]);
+    $temp0.push(function () {
+      return $g.deferfailure.FailingCleanup();
+    }, []);
+    $temp0.push(function ($temp2) {
+      return $g.deferfailure.Record($temp2);
+    }, [$t.fastbox('b', $g.________testlib.basictypes.String)]);
+    var $pat = $t.fastbox(true, $g.________testlib.basictypes.Boolean);
+    $resources.popall();
+    return $pat;
+  };
+  $static.DoSomethingElse = $t.markpromising(function () {
+    var $result;
+    var $temp0;
+    var $current = 0;
+    var $resources = $t.resourcehandler();
+    var $continue = function ($resolve, $reject) {
+      $resolve = $resources.bind($resolve, true, $reject);
+      $reject = $resources.bind($reject, true);
+      localasyncloop: while (true) {
+        switch ($current) {
+          case 0:
+            $temp0 = $t.deferredcalls();
+            $resources.pushr($temp0, '$temp0');
+            $temp0.push(function ($temp1) {
+              return $g.deferfailure.Record($temp1);
+            }, [$t.fastbox('c', $g.________testlib.basictypes.String)]);
+            $temp0.push($t.markpromising(function () {
+              var $result;
+              var $current = 0;
+              var $continue = function ($resolve, $reject) {
+                localasyncloop: while (true) {
+                  switch ($current) {
+                    case 0:
+                      $promise.maybe($g.deferfailure.FailingAsyncCleanup()).then(function ($result0) {
+                        $result = $result0;
+                        $current = 1;
+                        $continue($resolve, $reject);
+                        return;
+                      }).catch(function (err) {
+                        $reject(err);
+                        return;
+                      });
+                      return;
+
+                    case 1:
+                      $resolve($result);
+                      return;
+
+                    default:
+                      $resolve();
+                      return;
+                  }
+                }
+              };
+              return $promise.new($continue);
+            }), []);
+            $temp0.push(function ($temp2) {
+              return $g.deferfailure.Record($temp2);
+            }, [$t.fastbox('d', $g.________testlib.basictypes.String)]);
+            $promise.translate($g.deferfailure.DoSomethingAsync()).then(function ($result0) {
+              $result = $result0;
+              $current = 1;
+              $continue($resolve, $reject);
+              return;
+            }).catch(function (err) {
+              $reject(err);
+              return;
+            });
+            return;
+
+          case 1:
+            $resolve($result);
+            return;
+
+          default:
+            $resolve();
+            return;
+        }
+      }
+    };
+    return $promise.new($continue);
+  });
+  $static.TEST = $t.markpromising(function () {
+    var a;
+    var b;
+    var c;
+    var d;
+    var syncOrder;
+    var $current = 0;
+    var $continue = function ($resolve, $reject) {
+      localasyncloop: while (true) {
+        switch ($current) {
+          case 0:
+            try {
+              var $expr = $g.deferfailure.DoSomething();
+              a = $expr;
+              b = null;
+            } catch ($rejected) {
+              b = $t.ensureerror($rejected);
+              a = null;
+            }
+            $current = 1;
+            continue localasyncloop;
+
+          case 1:
+            syncOrder = $g.deferfailure.order;
+            $g.deferfailure.order = $t.fastbox('', $g.________testlib.basictypes.String);
+            $promise.maybe($g.deferfailure.DoSomethingElse()).then(function ($result0) {
+              c = $result0;
+              d = null;
+              $current = 2;
+              $continue($resolve, $reject);
+              return;
+            }).catch(function ($rejected) {
+              d = $t.ensureerror($rejected);
+              c = null;
+              $current = 2;
+              $continue($resolve, $reject);
+              return;
+            });
+            return;
+
+          case 2:
+            $resolve($t.fastbox((((((a == null) && $g.________testlib.basictypes.String.$equals($t.assertnotnull(b).Message(), $t.fastbox('yo!', $g.________testlib.basictypes.String)).$wrapped) && $g.________testlib.basictypes.String.$equals(syncOrder, $t.fastbox('ba', $g.________testlib.basictypes.String)).$wrapped) && (c == null)) && $g.________testlib.basictypes.String.$equals($t.assertnotnull(d).Message(), $t.fastbox('yo!', $g.________testlib.basictypes.String)).$wrapped) && $g.________testlib.basictypes.String.$equals($g.deferfailure.order, $t.fastbox('dc', $g.________testlib.basictypes.String)).$wrapped, $g.________testlib.basictypes.Boolean));
+            return;
+
+          default:
+            $resolve();
+            return;
+        }
+      }
+    };
+    return $promise.new($continue);
+  });
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.order = $t.fastbox('', $g.________testlib.basictypes.String);
+      resolve();
+    });
+  }, '8592342a', []);
+});
@@ -0,0 +1,133 @@
+$module('deferloop', function () {
+  var $static = this;
+  this.$class('50dccfca', 'Recorder', false, '', function () {
+    var $static = this;
+    var $instance = this.prototype;
+    $static.new = function (prefix) {
+      var instance = new $static();
+      instance.prefix = prefix;
+      return instance;
+    };
+    $instance.Record = function (value) {
+      var $this = this;
+      $g.deferloop.order = $g.________testlib.basictypes.String.$plus($g.________testlib.basictypes.String.$plus($g.deferloop.order, $this.prefix), value);
+      return;
+    };
+    this.$typesig = function () {
+      if (this.$cachedtypesig) {
+        return this.$cachedtypesig;
+      }
+      var computed = {
+        "Record|2|cf412abd<void>": true,
+      };
+      return this.$cachedtypesig = computed;
+    };
+  });
+
+  $static.Record = function (value) {
+    $g.deferloop.order = $g.________testlib.basictypes.String.$plus($g.deferloop.order, value);
+    return;
+  };
+  $static.Looped = function () {
+    var $temp0;
+    var $temp1;
+    var $temp2;
+    var index;
+    var values;
+    var $current = 0;
+    var $resources = $t.resourcehandler();
+    syncloop: while (true) {
+      switch ($current) {
+        case 0:
+          $temp2 = $t.deferredcalls();
+          $resources.pushr($temp2, '$temp2');
+          values = $g.________testlib.basictypes.Slice($g.________testlib.basictypes.String).overArray([$t.fastbox('a', $g.________testlib.basictypes.String), $t.fastbox('b', $g.________testlib.basictypes.String), $t.fastbox('c', $g.________testlib.basictypes.String)]);
+          $current = 1;
+          continue syncloop;
+
+        case 1:
+          $temp1 = $g.________testlib.basictypes.Integer.$range($t.fastbox(0, $g.________testlib.basictypes.Integer), $t.fastbox(2, $g.________testlib.basictypes.Integer));
+          $current = 2;
+          continue syncloop;
+
+        case 2:
+          $temp0 = $temp1.Next();
+          index = $temp0.First;
+          if ($temp0.Second.$wrapped) {
+            $current = 3;
+            continue syncloop;
+          } else {
+            $current = 4;
+            continue syncloop;
+          }
+          break;
+
+        case 3:
+          $temp2.push(function ($temp3) {
+            return $g.deferloop.Record($temp3);
+          }, [values.$index(index)]);
+          $current = 2;
+          continue syncloop;
+
+        case 4:
+          $resources.popr('$temp2');
+          return;
+
+        default:
+          return;
+      }
+    }
+  };
+  $static.Changed = function (value) {
+    var $temp0;
+    var current;
+    var $resources = $t.resourcehandler();
+    $temp0 = $t.deferredcalls();
+    $resources.pushr($temp0, '$temp0');
+    current = value;
+    $temp0.push(function ($temp1) {
+      return $g.deferloop.Record($temp1);
+    }, [current]);
+    current = $t.fastbox('z', $g.________testlib.basictypes.String);
+    $g.deferloop.Record(current);
+    $resources.popr('$temp0');
+    var $pat = undefined;
+    $resources.popall();
+    return $pat;
+  };
+  $static.ChangedReceiver = function () {
+    var $temp0;
+    var recorder;
+    var $resources = $t.resourcehandler();
+    $temp0 = $t.deferredcalls();
+    $resources.pushr($temp0, '$temp0');
+    recorder = $g.deferloop.Recorder.new($t.fastbox('x', $g.________testlib.basictypes.String));
+    $temp0.push(function ($temp1, $temp2) {
+      return $temp1.Record($temp2);
+    }, [recorder, $t.fastbox('1', $g.________testlib.basictypes.String)]);
+    recorder = $g.deferloop.Recorder.new($t.fastbox('y', $g.________testlib.basictypes.String));
+    recorder.Record($t.fastbox('2', $g.________testlib.basictypes.String));
+    $resources.popr('$temp0');
+    var $pat = undefined;
+    $resources.popall();
+    return $pat;
+  };
+  $static.TEST = function () {
+    var changedOrder;
+    var loopedOrder;
+    $g.deferloop.Looped();
+    loopedOrder = $g.deferloop.order;
+    $g.deferloop.order = $t.fastbox('', $g.________testlib.basictypes.String);
+    $g.deferloop.Changed($t.fastbox('a', $g.________testlib.basictypes.String));
+    changedOrder = $g.deferloop.order;
+    $g.deferloop.order = $t.fastbox('', $g.________testlib.basictypes.String);
+    $g.deferloop.ChangedReceiver();
+    return $t.fastbox(($g.________testlib.basictypes.String.$equals(loopedOrder, $t.fastbox('cba', $g.________testlib.basictypes.String)).$wrapped && $g.________testlib.basictypes.String.$equals(changedOrder, $t.fastbox('za', $g.________testlib.basictypes.String)).$wrapped) && $g.________testlib.basictypes.String.$equals($g.deferloop.order, $t.fastbox('y2x1', $g.________testlib.basictypes.String)).$wrapped, $g.________testlib.basictypes.Boolean);
+  };
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.order = $t.fastbox('', $g.________testlib.basictypes.String);
+      resolve();
+    });
+  }, '17d5f315', []);
+});
@@ -0,0 +1,73 @@
+$module('deferreject', function () {
+  var $static = this;
+  this.$class('1aff7fca', 'SimpleError', false, '', function () {
+    var $static = this;
+    var $instance = this.prototype;
+    $static.new = function () {
+      var instance = new $static();
+      return instance;
+    };
+    $instance.Message = $t.property(function () {
+      var $this = this;
+      return $t.fastbox('yo!', $g.________testlib.basictypes.String);
+    });
+    this.$typesig = function () {
+      if (this.$cachedtypesig) {
+        return this.$cachedtypesig;
+      }
+      var computed = {
+        "Message|3|cb470bcc": true,
+      };
+      return this.$cachedtypesig = computed;
+    };
+  });
+
+  $static.Cleanup = function () {
+    $g.deferreject.cleaned = $t.fastbox(true, $g.________testlib.basictypes.Boolean);
+    return;
+  };
+  $static.DoSomething = function () {
+    var $temp0;
+    var $resources = $t.resourcehandler();
+    $temp0 = $t.deferredcalls();
+    $resources.pushr($temp0, '$temp0');
+    $temp0.push(function () {
+      return $g.deferreject.Cleanup();
+    }, []);
+    var $pat = $g.deferreject.SimpleError.new();
+    $resources.popall();
+    throw $pat;
+  };
+  $static.TEST = function () {
+    var a;
+    var b;
+    var $current = 0;
+    syncloop: while (true) {
+      switch ($current) {
+        case 0:
+          try {
+            var $expr = $g.deferreject.DoSomething();
+            a = $expr;
+            b = null;
+          } catch ($rejected) {
+            b = $t.ensureerror($rejected);
+            a = null;
+          }
+          $current = 1;
+          continue syncloop;
+
+        case 1:
+          return $t.fastbox(((a == null) && $g.________testlib.basictypes.String.$equals($t.assertnotnull(b).Message(), $t.fastbox('yo!', $g.________testlib.basictypes.String)).$wrapped) && $g.deferreject.cleaned.$wrapped, $g.________testlib.basictypes.Boolean);
+
+        default:
+          return;
+      }
+    }
+  };
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.cleaned = $t.fastbox(false, $g.________testlib.basictypes.Boolean);
+      resolve();
+    });
+  }, '2d1f0679', []);
+});
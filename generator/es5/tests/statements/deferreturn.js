@@ -0,0 +1,78 @@
+$module('deferreturn', function () {
+  var $static = this;
+  $static.Record = function (value) {
+    $g.deferreturn.order = $g.________testlib.basictypes.String.$plus($g.deferreturn.order, value);
+    return;
+  };
+  $static.DoSomething = function (value) {
+    var $temp0;
+    var $current = 0;
+    var $resources = $t.resourcehandler();
+    syncloop: while (true) {
+      switch ($current) {
+        case 0:
+          $temp0 = $t.deferredcalls();
+          $resources.pushr($temp0, '$temp0');
+          $temp0.push(function ($temp1) {
+            return $g.deferreturn.Record($temp1);
+          }, [$t.fastbox('a', $g.________testlib.basictypes.String)]);
+          if (value.$wrapped > 10) {
+            $current = 1;
+            continue syncloop;
+          } else {
+            $current = 2;
+            continue syncloop;
+          }
+          break;
+
+        case 1:
+          $temp0.push(function ($temp2) {
+            return $g.deferreturn.Record($temp2);
+          }, [$t.fastbox('b', $g.________testlib.basictypes.String)]);
+          $g.deferreturn.Record($t.fastbox('c', $g.________testlib.basictypes.String));
+          $current = 2;
+          continue syncloop;
+
+        case 2:
+          $g.deferreturn.Record($t.fastbox('d', $g.________testlib.basictypes.String));
+          if (value.$wrapped > 20) {
+            $current = 3;
+            continue syncloop;
+          } else {
+            $current = 4;
+            continue syncloop;
+          }
+          break;
+
+        case 3:
+          var $pat = $t.fastbox(1, $g.________testlib.basictypes.Integer);
+          $resources.popall();
+          return $pat;
+
+        case 4:
+          var $pat = $t.fastbox(2, $g.________testlib.basictypes.Integer);
+          $resources.popall();
+          return $pat;
+
+        default:
+          return;
+      }
+    }
+  };
+  $static.TEST = function () {
+    var first;
+    var firstOrder;
+    var second;
+    first = $g.deferreturn.DoSomething($t.fastbox(15, $g.________testlib.basictypes.Integer));
+    firstOrder = $g.deferreturn.order;
+    $g.deferreturn.order = $t.fastbox('', $g.________testlib.basictypes.String);
+    second = $g.deferreturn.DoSomething($t.fastbox(25, $g.________testlib.basictypes.Integer));
+    return $t.fastbox((((first.$wrapped == 2) && $g.________testlib.basictypes.String.$equals(firstOrder, $t.fastbox('cdba', $g.________testlib.basictypes.String)).$wrapped) && (second.$wrapped == 1)) && $g.________testlib.basictypes.String.$equals($g.deferreturn.order, $t.fastbox('cdba', $g.________testlib.basictypes.String)).$wrapped, $g.________testlib.basictypes.Boolean);
+  };
+  this.$init(function () {
+    return $promise.new(function (resolve) {
+      $static.order = $t.fastbox('', $g.________testlib.basictypes.String);
+      resolve();
+    });
+  }, '74c96aa4', []);
+});
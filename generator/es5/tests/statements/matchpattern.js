@@ -0,0 +1,220 @@
+$module('matchpattern', function () {
+  var $static = this;
+  this.$struct('4995ccb5', 'Point', false, '', function () {
+    var $static = this;
+    var $instance = this.prototype;
+    $static.new = function (X, Y) {
+      var instance = new $static();
+      instance[BOXED_DATA_PROPERTY] = {
+        X: X,
+        Y: Y,
+      };
+      instance.$markruntimecreated();
+      return instance;
+    };
+    $static.$fields = [];
+    $t.defineStructField($static, 'X', 'X', function () {
+      return $g.________testlib.basictypes.Integer;
+    }, function () {
+      return $g.________testlib.basictypes.Integer;
+    }, false);
+    $t.defineStructField($static, 'Y', 'Y', function () {
+      return $g.________testlib.basictypes.Integer;
+    }, function () {
+      return $g.________testlib.basictypes.Integer;
+    }, false);
+    this.$typesig = function () {
+      if (this.$cachedtypesig) {
+        return this.$cachedtypesig;
+      }
+      var computed = {
+        "Parse|1|cf412abd<4995ccb5>": true,
+        "equals|4|cf412abd<aa28dc2d>": true,
+        "Stringify|2|cf412abd<cb470bcc>": true,
+        "Mapping|2|cf412abd<899aec48<any>>": true,
+        "Clone|2|cf412abd<4995ccb5>": true,
+        "String|2|cf412abd<cb470bcc>": true,
+      };
+      return this.$cachedtypesig = computed;
+    };
+  });
+
+  this.$struct('7044ae34', 'Line', false, '', function () {
+    var $static = this;
+    var $instance = this.prototype;
+    $static.new = function (Start) {
+      var instance = new $static();
+      instance[BOXED_DATA_PROPERTY] = {
+        Start: Start,
+      };
+      instance.$markruntimecreated();
+      return instance;
+    };
+    $static.$fields = [];
+    $t.defineStructField($static, 'Start', 'Start', function () {
+      return $g.matchpattern.Point;
+    }, function () {
+      return $g.matchpattern.Point;
+    }, false);
+    $t.defineStructField($static, 'End', 'End', function () {
+      return $g.matchpattern.Point;
+    }, function () {
+      return $g.matchpattern.Point;
+    }, true);
+    this.$typesig = function () {
+      if (this.$cachedtypesig) {
+        return this.$cachedtypesig;
+      }
+      var computed = {
+        "Parse|1|cf412abd<7044ae34>": true,
+        "equals|4|cf412abd<aa28dc2d>": true,
+        "Stringify|2|cf412abd<cb470bcc>": true,
+        "Mapping|2|cf412abd<899aec48<any>>": true,
+        "Clone|2|cf412abd<7044ae34>": true,
+        "String|2|cf412abd<cb470bcc>": true,
+      };
+      return this.$cachedtypesig = computed;
+    };
+  });
+
+  $static.Describe = function (value) {
+    var $temp0;
+    var end;
+    var v;
+    var x;
+    var y;
+    var $current = 0;
+    syncloop: while (true) {
+      switch ($current) {
+        case 0:
+          v = value;
+          if (((((v != null) && $t.istype(v, $g.matchpattern.Point)) && $g.________testlib.basictypes.Integer.$equals(v.X, $t.fastbox(0, $g.________testlib.basictypes.Integer)).$wrapped) && (y = v.Y, true)) && (y.$wrapped > 2)) {
+            $current = 1;
+            continue syncloop;
+          } else {
+            $current = 2;
+            continue syncloop;
+          }
+          break;
+
+        case 1:
+          return y;
+
+        case 2:
+          if ((((v != null) && $t.istype(v, $g.matchpattern.Line)) && ($temp0 = v.Start, (x = $temp0.X, true))) && (end = v.End, true)) {
+            $current = 3;
+            continue syncloop;
+          } else {
+            $current = 4;
+            continue syncloop;
+          }
+          break;
+
+        case 3:
+          return x;
+
+        case 4:
+          if (((v != null) && $t.istype(v, $g.________testlib.basictypes.String)) && $g.________testlib.basictypes.String.$equals(v, $t.fastbox('hello', $g.________testlib.basictypes.String)).$wrapped) {
+            $current = 5;
+            continue syncloop;
+          } else {
+            $current = 6;
+            continue syncloop;
+          }
+          break;
+
+        case 5:
+          return $t.fastbox(3, $g.________testlib.basictypes.Integer);
+
+        case 6:
+          if ($t.istype(v, $g.________testlib.basictypes.Integer) && (v.$wrapped > 1)) {
+            $current = 7;
+            continue syncloop;
+          } else {
+            $current = 8;
+            continue syncloop;
+          }
+          break;
+
+        case 7:
+          return $t.fastbox(4, $g.________testlib.basictypes.Integer);
+
+        case 8:
+          if (true) {
+            $current = 9;
+            continue syncloop;
+          } else {
+            $current = 10;
+            continue syncloop;
+          }
+          break;
+
+        case 9:
+          return $t.fastbox(0, $g.________testlib.basictypes.Integer);
+
+        default:
+          return;
+      }
+    }
+  };
+  $static.Sum = function (p) {
+    var $temp0;
+    var x;
+    var y;
+    var $current = 0;
+    syncloop: while (true) {
+      switch ($current) {
+        case 0:
+          $temp0 = p;
+          if ($temp0 == null) {
+            $current = 1;
+            continue syncloop;
+          } else {
+            $current = 2;
+            continue syncloop;
+          }
+          break;
+
+        case 1:
+          return $t.fastbox(-1, $g.________testlib.basictypes.Integer);
+
+        case 2:
+          if ((($temp0 != null) && (x = $temp0.X, true)) && (y = $temp0.Y, true)) {
+            $current = 3;
+            continue syncloop;
+          } else {
+            $current = 4;
+            continue syncloop;
+          }
+          break;
+
+        case 3:
+          return $t.fastbox(x.$wrapped + y.$wrapped, $g.________testlib.basictypes.Integer);
+
+        case 4:
+          return $t.fastbox(0, $g.________testlib.basictypes.Integer);
+
+        default:
+          return;
+      }
+    }
+  };
+  $static.TEST = function () {
+    var $temp0;
+    var guarded;
+    var literal;
+    var nested;
+    var nullCase;
+    var summed;
+    var typed;
+    var unguarded;
+    guarded = $t.fastbox($g.matchpattern.Describe($g.matchpattern.Point.new($t.fastbox(0, $g.________testlib.basictypes.Integer), $t.fastbox(5, $g.________testlib.basictypes.Integer))).$wrapped == 5, $g.________testlib.basictypes.Boolean);
+    unguarded = $t.fastbox($g.matchpattern.Describe($g.matchpattern.Point.new($t.fastbox(0, $g.________testlib.basictypes.Integer), $t.fastbox(1, $g.________testlib.basictypes.Integer))).$wrapped == 0, $g.________testlib.basictypes.Boolean);
+    nested = $t.fastbox($g.matchpattern.Describe(($temp0 = $g.matchpattern.Line.new($g.matchpattern.Point.new($t.fastbox(2, $g.________testlib.basictypes.Integer), $t.fastbox(1, $g.________testlib.basictypes.Integer))), $temp0.End = null, $temp0)).$wrapped == 2, $g.________testlib.basictypes.Boolean);
+    literal = $t.fastbox($g.matchpattern.Describe($t.fastbox('hello', $g.________testlib.basictypes.String)).$wrapped == 3, $g.________testlib.basictypes.Boolean);
+    typed = $t.fastbox($g.matchpattern.Describe($t.fastbox(10, $g.________testlib.basictypes.Integer)).$wrapped == 4, $g.________testlib.basictypes.Boolean);
+    nullCase = $t.fastbox($g.matchpattern.Sum(null).$wrapped == -1, $g.________testlib.basictypes.Boolean);
+    summed = $t.fastbox($g.matchpattern.Sum($g.matchpattern.Point.new($t.fastbox(1, $g.________testlib.basictypes.Integer), $t.fastbox(2, $g.________testlib.basictypes.Integer))).$wrapped == 3, $g.________testlib.basictypes.Boolean);
+    return $t.fastbox((((((guarded.$wrapped && unguarded.$wrapped) && nested.$wrapped) && literal.$wrapped) && typed.$wrapped) && nullCase.$wrapped) && summed.$wrapped, $g.________testlib.basictypes.Boolean);
+  };
+});
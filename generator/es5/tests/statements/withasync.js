@@ -58,7 +58,7 @@ $module('withasync', function () {
     var $current = 0;
     var $resources = $t.resourcehandler();
     var $continue = function ($resolve, $reject) {
-      $resolve = $resources.bind($resolve, true);
+      $resolve = $resources.bind($resolve, true, $reject);
       $reject = $resources.bind($reject, true);
       localasyncloop: while (true) {
         switch ($current) {
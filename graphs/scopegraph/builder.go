@@ -121,6 +121,9 @@ func (sb *scopeBuilder) getScopeHandler(node compilergraph.GraphNode) scopeHandl
 	case sourceshape.NodeTypeWithStatement:
 		return sb.scopeWithStatement
 
+	case sourceshape.NodeTypeDeferStatement:
+		return sb.scopeDeferStatement
+
 	case sourceshape.NodeTypeVariableStatement:
 		return sb.scopeVariableStatement
 
@@ -289,6 +292,9 @@ func (sb *scopeBuilder) getScopeHandler(node compilergraph.GraphNode) scopeHandl
 	case sourceshape.NodeListLiteralExpression:
 		return sb.scopeListLiteralExpression
 
+	case sourceshape.NodeTupleLiteralExpression:
+		return sb.scopeTupleLiteralExpression
+
 	case sourceshape.NodeSliceLiteralExpression:
 		return sb.scopeSliceLiteralExpression
 
@@ -0,0 +1,182 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scopegraph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/scopegraph/proto"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// CallArgument represents the argument given for a single parameter of an invoked member.
+type CallArgument struct {
+	// Node is the expression node of the argument, if one was given.
+	Node compilergraph.GraphNode
+
+	// IsGiven indicates whether an argument was given for the parameter.
+	IsGiven bool
+
+	// Parameter is the parameter filled by the argument.
+	Parameter typegraph.TGParameter
+}
+
+// OrderedCallArguments returns the arguments of the given function call expression SRG node, ordered
+// by the parameters of the member being invoked, if any of the arguments were given by name. Parameters
+// for which no argument was given, but which are followed by a parameter with an argument, are returned
+// with IsGiven set to false.
+func (sg *ScopeGraph) OrderedCallArguments(callNode compilergraph.GraphNode) ([]CallArgument, bool) {
+	if !hasNamedArguments(callNode) {
+		return nil, false
+	}
+
+	childExpr, hasChildExpr := callNode.TryGetNode(sourceshape.NodeFunctionCallExpressionChildExpr)
+	if !hasChildExpr {
+		return nil, false
+	}
+
+	childScope, hasChildScope := sg.GetScope(childExpr)
+	if !hasChildScope {
+		return nil, false
+	}
+
+	parameters, hasParameters := sg.calledMemberParameters(&childScope)
+	if !hasParameters {
+		return nil, false
+	}
+
+	argumentsByIndex, err := matchCallArguments(callNode, parameters, true)
+	if err != nil {
+		return nil, false
+	}
+
+	indexes := sortedArgumentIndexes(argumentsByIndex)
+	if len(indexes) == 0 {
+		return []CallArgument{}, true
+	}
+
+	arguments := make([]CallArgument, indexes[len(indexes)-1]+1)
+	for index := range arguments {
+		argumentNode, isGiven := argumentsByIndex[index]
+		arguments[index] = CallArgument{argumentNode, isGiven, parameters[index]}
+	}
+
+	return arguments, true
+}
+
+// calledMemberParameters returns the parameters of the member referenced by the given scope, if it
+// refers to a member whose parameters match those of the function invoked.
+func (sg *ScopeGraph) calledMemberParameters(childScope *proto.ScopeInfo) ([]typegraph.TGParameter, bool) {
+	referencedName, isNamed := sg.GetReferencedName(*childScope)
+	if !isNamed {
+		return nil, false
+	}
+
+	member, isMember := referencedName.Member()
+	if !isMember {
+		return nil, false
+	}
+
+	// Members such as fields and properties can hold functions, in which case their parameters are
+	// not those of the function invoked.
+	var functionType = childScope.ResolvedTypeRef(sg.tdg)
+	if childScope.GetKind() == proto.ScopeKind_GENERIC {
+		functionType = childScope.GenericTypeRef(sg.tdg)
+	}
+
+	if !functionType.HasReferredType(sg.tdg.FunctionType()) {
+		return nil, false
+	}
+
+	parameters := member.Parameters()
+	if len(parameters) != len(functionType.Parameters()) {
+		return nil, false
+	}
+
+	return parameters, true
+}
+
+// hasNamedArguments returns whether any of the arguments of the given function call expression are
+// given by name.
+func hasNamedArguments(callNode compilergraph.GraphNode) bool {
+	ait := callNode.StartQuery().
+		Out(sourceshape.NodeFunctionCallArgument).
+		BuildNodeIterator()
+
+	for ait.Next() {
+		if _, isNamed := ait.Node().TryGet(sourceshape.NodeFunctionCallArgumentName); isNamed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchCallArguments matches the arguments of the given function call expression to the parameters
+// they fill, returning the argument node for each parameter index filled. Positional arguments fill the
+// parameters in order, while named arguments fill the parameter with the matching name. Positional
+// arguments beyond the parameters given are returned under their own indexes.
+func matchCallArguments(callNode compilergraph.GraphNode, parameters []typegraph.TGParameter, hasParameters bool) (map[int]compilergraph.GraphNode, error) {
+	ait := callNode.StartQuery().
+		Out(sourceshape.NodeFunctionCallArgument).
+		BuildNodeIterator()
+
+	argumentsByIndex := map[int]compilergraph.GraphNode{}
+
+	var positionalIndex = 0
+	var encounteredNamed = false
+	for ait.Next() {
+		argumentNode := ait.Node()
+		argumentName, isNamed := argumentNode.TryGet(sourceshape.NodeFunctionCallArgumentName)
+		if !isNamed {
+			if encounteredNamed {
+				return nil, fmt.Errorf("cannot have positional arguments following named arguments")
+			}
+
+			argumentsByIndex[positionalIndex] = argumentNode
+			positionalIndex++
+			continue
+		}
+
+		encounteredNamed = true
+		if !hasParameters {
+			return nil, fmt.Errorf("cannot use named arguments, as the function invoked is not a member")
+		}
+
+		parameterIndex := -1
+		for index, parameter := range parameters {
+			if parameterName, _ := parameter.Name(); parameterName == argumentName {
+				parameterIndex = index
+				break
+			}
+		}
+
+		if parameterIndex < 0 {
+			return nil, fmt.Errorf("has no parameter named '%s'", argumentName)
+		}
+
+		if _, exists := argumentsByIndex[parameterIndex]; exists {
+			return nil, fmt.Errorf("gives more than one argument for parameter '%s'", argumentName)
+		}
+
+		argumentsByIndex[parameterIndex] = argumentNode
+	}
+
+	return argumentsByIndex, nil
+}
+
+// sortedArgumentIndexes returns the parameter indexes of the given matched arguments, in order.
+func sortedArgumentIndexes(argumentsByIndex map[int]compilergraph.GraphNode) []int {
+	indexes := make([]int, 0, len(argumentsByIndex))
+	for index := range argumentsByIndex {
+		indexes = append(indexes, index)
+	}
+
+	sort.Ints(indexes)
+	return indexes
+}
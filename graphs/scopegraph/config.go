@@ -36,6 +36,10 @@ type Config struct {
 	// the integrations are loaded from the binary's directory.
 	LanguageIntegrations []integration.LanguageIntegration
 
+	// RequireExhaustiveCases indicates that switch and match statements over a closed set of values
+	// or types that do not handle all of them should be reported as errors, rather than warnings.
+	RequireExhaustiveCases bool
+
 	// cancelationHandle is the handle to use for cancelation of the scopegraph, if any.
 	cancelationHandle compilerutil.CancelationHandle
 }
@@ -51,6 +55,7 @@ func (c Config) WithCancel() (Config, compilerutil.CancelFunction) {
 		PathLoader:                c.PathLoader,
 		ScopeFilter:               c.ScopeFilter,
 		LanguageIntegrations:      c.LanguageIntegrations,
+		RequireExhaustiveCases:    c.RequireExhaustiveCases,
 		cancelationHandle:         handle,
 	}, handle.Cancel
 }
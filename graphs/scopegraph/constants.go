@@ -0,0 +1,603 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scopegraph
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/scopegraph/proto"
+	"github.com/serulian/compiler/graphs/srg"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// ConstantKind defines the various kinds of values produced by constant evaluation.
+type ConstantKind int
+
+const (
+	// ConstantInt indicates a value of type int.
+	ConstantInt ConstantKind = iota
+
+	// ConstantFloat indicates a value of type float64.
+	ConstantFloat
+
+	// ConstantBool indicates a value of type bool.
+	ConstantBool
+
+	// ConstantString indicates a value of type string.
+	ConstantString
+)
+
+// ConstantValue holds the value of an expression evaluated at compile time.
+type ConstantValue struct {
+	Kind        ConstantKind // The kind of the value.
+	IntValue    int64        // The value, if an int.
+	FloatValue  float64      // The value, if a float.
+	BoolValue   bool         // The value, if a bool.
+	StringValue string       // The value, if a string.
+}
+
+// String returns the value as formatted when placed into a template string.
+func (cv ConstantValue) String() string {
+	switch cv.Kind {
+	case ConstantInt:
+		return strconv.FormatInt(cv.IntValue, 10)
+
+	case ConstantFloat:
+		return formatConstantFloat(cv.FloatValue)
+
+	case ConstantBool:
+		return strconv.FormatBool(cv.BoolValue)
+
+	case ConstantString:
+		return cv.StringValue
+
+	default:
+		panic("Unknown kind of constant value")
+	}
+}
+
+// formatConstantFloat formats the given float value in the same manner as the runtime, which uses
+// positional notation for values in [1e-7, 1e21) and exponential notation otherwise.
+func formatConstantFloat(value float64) string {
+	absValue := math.Abs(value)
+	if absValue == 0 || (absValue >= 1e-7 && absValue < 1e21) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	formatted := strconv.FormatFloat(value, 'e', -1, 64)
+	formatted = strings.Replace(formatted, "e-0", "e-", 1)
+	return strings.Replace(formatted, "e+0", "e+", 1)
+}
+
+// maxConstantInt is the largest magnitude of an int that can be represented exactly at runtime.
+var maxConstantInt = big.NewInt(1<<53 - 1)
+
+var (
+	// errNotConstant is returned when an expression cannot be evaluated at compile time.
+	errNotConstant = errors.New("not a constant expression")
+
+	// errInvalidConstant is returned when an expression refers to a constant that is itself
+	// invalid. Such errors are reported on the referenced constant.
+	errInvalidConstant = errors.New("invalid constant")
+
+	errDivisionByZero = errors.New("division by zero")
+	errIntOverflow    = errors.New("value overflows int")
+	errFloatOverflow  = errors.New("value overflows float64")
+)
+
+// scopeLookup defines a function for retrieving the already-built scope of an SRG node.
+type scopeLookup func(node compilergraph.GraphNode) (proto.ScopeInfo, bool)
+
+// constantEvaluator evaluates expressions at compile time.
+type constantEvaluator struct {
+	sg          *ScopeGraph
+	lookupScope scopeLookup
+
+	// evaluating holds the constant members currently being evaluated, to guard against cycles.
+	evaluating map[compilergraph.GraphNodeId]bool
+}
+
+// ConstantValue returns the value of the given expression SRG node, if it can be evaluated at
+// compile time.
+func (sg *ScopeGraph) ConstantValue(exprNode compilergraph.GraphNode) (ConstantValue, bool) {
+	value, err := newConstantEvaluator(sg, sg.GetScope).evaluate(exprNode)
+	return value, err == nil
+}
+
+// checkConstant ensures that the initializer of the given constant member can be evaluated at
+// compile time.
+func (sb *scopeBuilder) checkConstant(member srg.SRGMember) {
+	initializer, hasInitializer := member.Initializer()
+	if !hasInitializer {
+		return
+	}
+
+	memberScope := sb.getScopeForRootNode(member.Node())
+	if !memberScope.GetIsValid() {
+		return
+	}
+
+	name, _ := member.Name()
+	_, err := newConstantEvaluator(sb.sg, sb.getBuiltScope).evaluate(initializer)
+	switch err {
+	case nil:
+		return
+
+	case errInvalidConstant:
+		return
+
+	case errNotConstant:
+		sb.decorateWithError(member.Node(), "Initializer of constant '%s' must be a constant expression", name)
+
+	default:
+		sb.decorateWithError(member.Node(), "Constant '%s' could not be evaluated: %v", name, err)
+	}
+
+	sb.Status = false
+}
+
+// getBuiltScope returns the scope already built for the given node, if any.
+func (sb *scopeBuilder) getBuiltScope(node compilergraph.GraphNode) (proto.ScopeInfo, bool) {
+	found, ok := sb.nodeMap.Get(string(node.NodeId))
+	if !ok {
+		return proto.ScopeInfo{}, false
+	}
+
+	return found.(proto.ScopeInfo), true
+}
+
+// newConstantEvaluator returns a new constant evaluator, using the given function to lookup scope.
+func newConstantEvaluator(sg *ScopeGraph, lookupScope scopeLookup) *constantEvaluator {
+	return &constantEvaluator{
+		sg:          sg,
+		lookupScope: lookupScope,
+		evaluating:  map[compilergraph.GraphNodeId]bool{},
+	}
+}
+
+// evaluate evaluates the given expression node, returning its value or an error if the expression
+// is not constant or its evaluation failed.
+func (ce *constantEvaluator) evaluate(node compilergraph.GraphNode) (ConstantValue, error) {
+	scope, hasScope := ce.lookupScope(node)
+	if !hasScope || !scope.GetIsValid() {
+		return ConstantValue{}, errInvalidConstant
+	}
+
+	value, err := ce.evaluateExpression(node, scope)
+	if err != nil {
+		return ConstantValue{}, err
+	}
+
+	// Ensure the expression resolves to the builtin type for the kind of value. Nominal types and
+	// interfaces over the builtin types are not folded.
+	if !scope.ResolvedTypeRef(ce.sg.tdg).IsDirectReferenceTo(ce.kindType(value.Kind)) {
+		return ConstantValue{}, errNotConstant
+	}
+
+	return value, nil
+}
+
+// kindType returns the builtin type for the given kind of constant value.
+func (ce *constantEvaluator) kindType(kind ConstantKind) typegraph.TGTypeDecl {
+	switch kind {
+	case ConstantInt:
+		return ce.sg.tdg.IntType()
+
+	case ConstantFloat:
+		return ce.sg.tdg.FloatType()
+
+	case ConstantBool:
+		return ce.sg.tdg.BoolType()
+
+	case ConstantString:
+		return ce.sg.tdg.StringType()
+
+	default:
+		panic("Unknown kind of constant value")
+	}
+}
+
+// evaluateExpression evaluates the given expression node with the given scope.
+func (ce *constantEvaluator) evaluateExpression(node compilergraph.GraphNode, scope proto.ScopeInfo) (ConstantValue, error) {
+	switch node.Kind() {
+	// Literals.
+	case sourceshape.NodeNumericLiteralExpression:
+		return ce.evaluateNumericLiteral(node, scope)
+
+	case sourceshape.NodeBooleanLiteralExpression:
+		return ConstantValue{Kind: ConstantBool, BoolValue: node.Get(sourceshape.NodeBooleanLiteralExpressionValue) == "true"}, nil
+
+	case sourceshape.NodeStringLiteralExpression:
+		return ConstantValue{Kind: ConstantString, StringValue: unquoteStringLiteral(node.Get(sourceshape.NodeStringLiteralExpressionValue))}, nil
+
+	case sourceshape.NodeTypeTemplateString:
+		return ce.evaluateTemplateString(node)
+
+	// References.
+	case sourceshape.NodeTypeIdentifierExpression:
+		fallthrough
+
+	case sourceshape.NodeMemberAccessExpression:
+		return ce.evaluateConstantReference(scope)
+
+	// Operators.
+	case sourceshape.NodeBinaryAddExpression:
+		fallthrough
+
+	case sourceshape.NodeBinarySubtractExpression:
+		fallthrough
+
+	case sourceshape.NodeBinaryMultiplyExpression:
+		fallthrough
+
+	case sourceshape.NodeBinaryDivideExpression:
+		fallthrough
+
+	case sourceshape.NodeBinaryModuloExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonEqualsExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonNotEqualsExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonLTEExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonGTEExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonLTExpression:
+		fallthrough
+
+	case sourceshape.NodeComparisonGTExpression:
+		fallthrough
+
+	case sourceshape.NodeBooleanOrExpression:
+		fallthrough
+
+	case sourceshape.NodeBooleanAndExpression:
+		return ce.evaluateBinaryExpression(node)
+
+	case sourceshape.NodeBooleanNotExpression:
+		fallthrough
+
+	case sourceshape.NodeKeywordNotExpression:
+		child, err := ce.evaluate(node.GetNode(sourceshape.NodeUnaryExpressionChildExpr))
+		if err != nil {
+			return ConstantValue{}, err
+		}
+
+		if child.Kind != ConstantBool {
+			return ConstantValue{}, errNotConstant
+		}
+
+		return ConstantValue{Kind: ConstantBool, BoolValue: !child.BoolValue}, nil
+
+	default:
+		return ConstantValue{}, errNotConstant
+	}
+}
+
+// evaluateNumericLiteral evaluates a numeric literal, based on whether it was scoped as an int or a float.
+func (ce *constantEvaluator) evaluateNumericLiteral(node compilergraph.GraphNode, scope proto.ScopeInfo) (ConstantValue, error) {
+	numericValueStr := strings.TrimSuffix(node.Get(sourceshape.NodeNumericLiteralExpressionValue), "f")
+
+	if scope.ResolvedTypeRef(ce.sg.tdg).HasReferredType(ce.sg.tdg.IntType()) {
+		intValue, ok := new(big.Int).SetString(numericValueStr, 0)
+		if !ok {
+			return ConstantValue{}, errNotConstant
+		}
+
+		return intConstant(intValue)
+	}
+
+	floatValue, err := strconv.ParseFloat(numericValueStr, 64)
+	if err != nil {
+		return ConstantValue{}, errNotConstant
+	}
+
+	return floatConstant(floatValue)
+}
+
+// evaluateTemplateString evaluates an untagged template string, formatting each of its values.
+func (ce *constantEvaluator) evaluateTemplateString(node compilergraph.GraphNode) (ConstantValue, error) {
+	pit := node.StartQuery().
+		Out(sourceshape.NodeTemplateStringPiece).
+		BuildNodeIterator()
+
+	var buffer bytes.Buffer
+	for pit.Next() {
+		piece, err := ce.evaluate(pit.Node())
+		if err != nil {
+			return ConstantValue{}, err
+		}
+
+		buffer.WriteString(piece.String())
+	}
+
+	return ConstantValue{Kind: ConstantString, StringValue: buffer.String()}, nil
+}
+
+// evaluateConstantReference evaluates a reference to a constant member by evaluating its initializer.
+func (ce *constantEvaluator) evaluateConstantReference(scope proto.ScopeInfo) (ConstantValue, error) {
+	namedReference, hasNamedReference := ce.sg.GetReferencedName(scope)
+	if !hasNamedReference {
+		return ConstantValue{}, errNotConstant
+	}
+
+	member, isMember := namedReference.Member()
+	if !isMember {
+		return ConstantValue{}, errNotConstant
+	}
+
+	sourceNodeID, hasSourceNode := member.SourceNodeId()
+	if !hasSourceNode {
+		return ConstantValue{}, errNotConstant
+	}
+
+	srgNode, hasSRGNode := ce.sg.srg.TryGetNode(sourceNodeID)
+	if !hasSRGNode || srgNode.Kind() != sourceshape.NodeTypeVariable {
+		return ConstantValue{}, errNotConstant
+	}
+
+	srgMember := ce.sg.srg.GetMemberReference(srgNode)
+	if !srgMember.IsConstant() {
+		return ConstantValue{}, errNotConstant
+	}
+
+	initializer, hasInitializer := srgMember.Initializer()
+	if !hasInitializer {
+		return ConstantValue{}, errNotConstant
+	}
+
+	// A constant that failed to scope has already been reported.
+	memberScope, hasMemberScope := ce.lookupScope(srgNode)
+	if hasMemberScope && !memberScope.GetIsValid() {
+		return ConstantValue{}, errInvalidConstant
+	}
+
+	// A cycle between constants is reported as an initialization cycle.
+	if ce.evaluating[sourceNodeID] {
+		return ConstantValue{}, errInvalidConstant
+	}
+
+	ce.evaluating[sourceNodeID] = true
+	defer delete(ce.evaluating, sourceNodeID)
+
+	// Any failure to evaluate the referenced constant is reported on the constant itself.
+	value, err := ce.evaluate(initializer)
+	if err != nil {
+		return ConstantValue{}, errInvalidConstant
+	}
+
+	return value, nil
+}
+
+// evaluateBinaryExpression evaluates a binary operator expression over two constant operands.
+func (ce *constantEvaluator) evaluateBinaryExpression(node compilergraph.GraphNode) (ConstantValue, error) {
+	left, err := ce.evaluate(node.GetNode(sourceshape.NodeBinaryExpressionLeftExpr))
+	if err != nil {
+		return ConstantValue{}, err
+	}
+
+	right, err := ce.evaluate(node.GetNode(sourceshape.NodeBinaryExpressionRightExpr))
+	if err != nil {
+		return ConstantValue{}, err
+	}
+
+	if left.Kind != right.Kind {
+		return ConstantValue{}, errNotConstant
+	}
+
+	switch node.Kind() {
+	case sourceshape.NodeComparisonEqualsExpression:
+		return boolConstant(left == right), nil
+
+	case sourceshape.NodeComparisonNotEqualsExpression:
+		return boolConstant(left != right), nil
+	}
+
+	switch left.Kind {
+	case ConstantInt:
+		return evaluateIntOperator(node.Kind(), left.IntValue, right.IntValue)
+
+	case ConstantFloat:
+		return evaluateFloatOperator(node.Kind(), left.FloatValue, right.FloatValue)
+
+	case ConstantBool:
+		switch node.Kind() {
+		case sourceshape.NodeBooleanOrExpression:
+			return boolConstant(left.BoolValue || right.BoolValue), nil
+
+		case sourceshape.NodeBooleanAndExpression:
+			return boolConstant(left.BoolValue && right.BoolValue), nil
+		}
+
+	case ConstantString:
+		if node.Kind() == sourceshape.NodeBinaryAddExpression {
+			return ConstantValue{Kind: ConstantString, StringValue: left.StringValue + right.StringValue}, nil
+		}
+	}
+
+	return ConstantValue{}, errNotConstant
+}
+
+// evaluateIntOperator applies the given operator to two int values. Division is floored, to
+// match the runtime.
+func evaluateIntOperator(kind compilergraph.TaggedValue, left int64, right int64) (ConstantValue, error) {
+	leftValue := big.NewInt(left)
+	rightValue := big.NewInt(right)
+
+	switch kind {
+	case sourceshape.NodeBinaryAddExpression:
+		return intConstant(new(big.Int).Add(leftValue, rightValue))
+
+	case sourceshape.NodeBinarySubtractExpression:
+		return intConstant(new(big.Int).Sub(leftValue, rightValue))
+
+	case sourceshape.NodeBinaryMultiplyExpression:
+		return intConstant(new(big.Int).Mul(leftValue, rightValue))
+
+	case sourceshape.NodeBinaryDivideExpression:
+		if right == 0 {
+			return ConstantValue{}, errDivisionByZero
+		}
+
+		quotient := left / right
+		if left%right != 0 && (left < 0) != (right < 0) {
+			quotient--
+		}
+
+		return intConstant(big.NewInt(quotient))
+
+	case sourceshape.NodeBinaryModuloExpression:
+		if right == 0 {
+			return ConstantValue{}, errDivisionByZero
+		}
+
+		return intConstant(big.NewInt(left % right))
+
+	default:
+		return compareConstants(kind, leftValue.Cmp(rightValue))
+	}
+}
+
+// evaluateFloatOperator applies the given operator to two float values.
+func evaluateFloatOperator(kind compilergraph.TaggedValue, left float64, right float64) (ConstantValue, error) {
+	switch kind {
+	case sourceshape.NodeBinaryAddExpression:
+		return floatConstant(left + right)
+
+	case sourceshape.NodeBinarySubtractExpression:
+		return floatConstant(left - right)
+
+	case sourceshape.NodeBinaryMultiplyExpression:
+		return floatConstant(left * right)
+
+	case sourceshape.NodeBinaryDivideExpression:
+		if right == 0 {
+			return ConstantValue{}, errDivisionByZero
+		}
+
+		return floatConstant(left / right)
+
+	case sourceshape.NodeBinaryModuloExpression:
+		if right == 0 {
+			return ConstantValue{}, errDivisionByZero
+		}
+
+		return floatConstant(math.Mod(left, right))
+
+	default:
+		return compareConstants(kind, big.NewFloat(left).Cmp(big.NewFloat(right)))
+	}
+}
+
+// compareConstants returns the result of the given ordered comparison operator, given the result
+// of comparing its operands.
+func compareConstants(kind compilergraph.TaggedValue, comparison int) (ConstantValue, error) {
+	switch kind {
+	case sourceshape.NodeComparisonLTEExpression:
+		return boolConstant(comparison <= 0), nil
+
+	case sourceshape.NodeComparisonGTEExpression:
+		return boolConstant(comparison >= 0), nil
+
+	case sourceshape.NodeComparisonLTExpression:
+		return boolConstant(comparison < 0), nil
+
+	case sourceshape.NodeComparisonGTExpression:
+		return boolConstant(comparison > 0), nil
+
+	default:
+		return ConstantValue{}, errNotConstant
+	}
+}
+
+// intConstant returns an int constant for the given value, or an error if it cannot be represented.
+func intConstant(value *big.Int) (ConstantValue, error) {
+	if value.CmpAbs(maxConstantInt) > 0 {
+		return ConstantValue{}, errIntOverflow
+	}
+
+	return ConstantValue{Kind: ConstantInt, IntValue: value.Int64()}, nil
+}
+
+// floatConstant returns a float constant for the given value, or an error if it is not finite.
+func floatConstant(value float64) (ConstantValue, error) {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return ConstantValue{}, errFloatOverflow
+	}
+
+	return ConstantValue{Kind: ConstantFloat, FloatValue: value}, nil
+}
+
+// boolConstant returns a bool constant for the given value.
+func boolConstant(value bool) ConstantValue {
+	return ConstantValue{Kind: ConstantBool, BoolValue: value}
+}
+
+// unquoteStringLiteral returns the value of the given string literal, as found in source. Template
+// string pieces are raw, while quoted strings have their escape sequences interpreted.
+func unquoteStringLiteral(literal string) string {
+	contents := literal[1 : len(literal)-1]
+	if literal[0] == '`' {
+		return contents
+	}
+
+	var buffer bytes.Buffer
+	for index := 0; index < len(contents); index++ {
+		if contents[index] != '\\' || index == len(contents)-1 {
+			buffer.WriteByte(contents[index])
+			continue
+		}
+
+		index++
+		switch escaped := contents[index]; escaped {
+		case 'n':
+			buffer.WriteByte('\n')
+		case 'r':
+			buffer.WriteByte('\r')
+		case 't':
+			buffer.WriteByte('\t')
+		case 'b':
+			buffer.WriteByte('\b')
+		case 'f':
+			buffer.WriteByte('\f')
+		case 'v':
+			buffer.WriteByte('\v')
+		case '0':
+			buffer.WriteByte(0)
+		case 'x', 'u':
+			length := 2
+			if escaped == 'u' {
+				length = 4
+			}
+
+			if index+length < len(contents) {
+				if code, err := strconv.ParseUint(contents[index+1:index+1+length], 16, 32); err == nil {
+					buffer.WriteRune(rune(code))
+					index += length
+					continue
+				}
+			}
+
+			buffer.WriteByte(escaped)
+		default:
+			buffer.WriteByte(escaped)
+		}
+	}
+
+	return buffer.String()
+}
@@ -19,10 +19,11 @@ import (
 	"github.com/serulian/compiler/sourceshape"
 
 	"github.com/cevaris/ordered_map"
+	cmap "github.com/streamrail/concurrent-map"
 )
 
 // performConstruction performs the actual construction of the scope graph.
-func performConstruction(target BuildTarget, srg *srg.SRG, tdg *typegraph.TypeGraph, integrations []integration.LanguageIntegration,
+func performConstruction(target BuildTarget, requireExhaustiveCases bool, srg *srg.SRG, tdg *typegraph.TypeGraph, integrations []integration.LanguageIntegration,
 	resolver *typerefresolver.TypeReferenceResolver, packageLoader *packageloader.PackageLoader, filter ScopeFilter,
 	cancelationHandle compilerutil.CancelationHandle) Result {
 
@@ -33,13 +34,15 @@ func performConstruction(target BuildTarget, srg *srg.SRG, tdg *typegraph.TypeGr
 
 	// Build the scope graph, making sure to freeze once complete.
 	scopeGraph := &ScopeGraph{
-		srg:                   srg,
-		tdg:                   tdg,
-		graph:                 srg.Graph,
-		packageLoader:         packageLoader,
-		integrations:          integrationsMap,
-		srgRefResolver:        resolver,
-		dynamicPromisingNames: map[string]bool{},
+		srg:                    srg,
+		tdg:                    tdg,
+		graph:                  srg.Graph,
+		packageLoader:          packageLoader,
+		integrations:           integrationsMap,
+		srgRefResolver:         resolver,
+		dynamicPromisingNames:  map[string]bool{},
+		requireExhaustiveCases: requireExhaustiveCases,
+		implementingTypes:      cmap.New(),
 		layer: srg.Graph.NewGraphLayer("sig", NodeTypeTagged),
 	}
 	defer scopeGraph.layer.Freeze()
@@ -57,6 +60,9 @@ func performConstruction(target BuildTarget, srg *srg.SRG, tdg *typegraph.TypeGr
 	// Check for initialization dependency cycles.
 	checkInitializationCycles(builder, filter, cancelationHandle)
 
+	// Ensure all constants can be evaluated at compile time.
+	checkConstants(builder, filter, cancelationHandle)
+
 	// Determine promising nature of entrypoints.
 	if target.labelEntrypointPromising && builder.Status {
 		labelEntrypointPromising(builder, filter, cancelationHandle)
@@ -310,3 +316,27 @@ func checkInitializationCycles(builder *scopeBuilder, filter ScopeFilter, cancel
 
 	iwg.Wait()
 }
+
+func checkConstants(builder *scopeBuilder, filter ScopeFilter, cancelationHandle compilerutil.CancelationHandle) {
+	var cwg sync.WaitGroup
+	vit := builder.sg.srg.EntrypointVariables()
+	for vit.Next() {
+		if !vit.Member().IsConstant() {
+			continue
+		}
+
+		if filter != nil && !filter(vit.Member().Module().InputSource()) {
+			continue
+		}
+
+		cwg.Add(1)
+		go (func(member srg.SRGMember) {
+			if !cancelationHandle.WasCanceled() {
+				builder.checkConstant(member)
+			}
+			cwg.Done()
+		})(vit.Member())
+	}
+
+	cwg.Wait()
+}
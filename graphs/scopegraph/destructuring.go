@@ -0,0 +1,98 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scopegraph
+
+import (
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// DestructuredTupleType returns the tuple type destructured by the given resolve statement SRG
+// node, if the statement destructures its source into its assigned values.
+func (sg *ScopeGraph) DestructuredTupleType(resolveNode compilergraph.GraphNode) (typegraph.TypeReference, bool) {
+	sourceNode, hasSource := resolveNode.TryGetNode(sourceshape.NodeResolveStatementSource)
+	if !hasSource {
+		return sg.tdg.AnyTypeReference(), false
+	}
+
+	sourceScope, hasSourceScope := sg.GetScope(sourceNode)
+	if !hasSourceScope || !sourceScope.GetIsValid() {
+		return sg.tdg.AnyTypeReference(), false
+	}
+
+	sourceType := sourceScope.ResolvedTypeRef(sg.tdg)
+	if !destructuresTuple(resolveNode, sourceType) {
+		return sg.tdg.AnyTypeReference(), false
+	}
+
+	return sourceType, true
+}
+
+// destructuresTuple returns whether the given resolve statement destructures a source of the given
+// type into its assigned values. A source is destructured if it is a non-nullable tuple with exactly
+// as many elements as there are names, in which case any rejection of the source is raised rather
+// than captured. A statement with two names over an awaited source (`a, err := <- p`) instead
+// assigns the resolved value and the rejection.
+func destructuresTuple(resolveNode compilergraph.GraphNode, sourceType typegraph.TypeReference) bool {
+	nameCount := len(resolveStatementNames(resolveNode))
+	if nameCount < 2 || !sourceType.IsTuple() || sourceType.IsNullable() || sourceType.ParameterCount() != nameCount {
+		return false
+	}
+
+	if nameCount == 2 {
+		sourceNode, hasSource := resolveNode.TryGetNode(sourceshape.NodeResolveStatementSource)
+		return hasSource && sourceNode.Kind() != sourceshape.NodeTypeAwaitExpression
+	}
+
+	return true
+}
+
+// resolveStatementNames returns the assigned value nodes of the given resolve statement, in order.
+func resolveStatementNames(resolveNode compilergraph.GraphNode) []compilergraph.GraphNode {
+	names := make([]compilergraph.GraphNode, 0, 2)
+	if destination, hasDestination := resolveNode.TryGetNode(sourceshape.NodeAssignedDestination); hasDestination {
+		names = append(names, destination)
+	}
+
+	if rejection, hasRejection := resolveNode.TryGetNode(sourceshape.NodeAssignedRejection); hasRejection {
+		names = append(names, rejection)
+	}
+
+	dit := resolveNode.StartQuery().
+		Out(sourceshape.NodeAssignedDestructured).
+		BuildNodeIterator()
+
+	for dit.Next() {
+		names = append(names, dit.Node())
+	}
+
+	return names
+}
+
+// assignedValueParent returns the statement node under which the given assigned value is found,
+// as well as the index of the assigned value amongst the statement's names.
+func assignedValueParent(assignedNode compilergraph.GraphNode) (compilergraph.GraphNode, int, bool) {
+	parentNode, hasParent := assignedNode.TryGetIncomingNode(sourceshape.NodeAssignedDestination)
+	if !hasParent {
+		parentNode, hasParent = assignedNode.TryGetIncomingNode(sourceshape.NodeAssignedRejection)
+	}
+
+	if !hasParent {
+		parentNode, hasParent = assignedNode.TryGetIncomingNode(sourceshape.NodeAssignedDestructured)
+	}
+
+	if !hasParent {
+		return compilergraph.GraphNode{}, -1, false
+	}
+
+	for index, name := range resolveStatementNames(parentNode) {
+		if name.NodeId == assignedNode.NodeId {
+			return parentNode, index, true
+		}
+	}
+
+	return compilergraph.GraphNode{}, -1, false
+}
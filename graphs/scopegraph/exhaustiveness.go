@@ -0,0 +1,415 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scopegraph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/scopegraph/proto"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// domainEntry defines a single value or type in the closed domain of a switch or match statement.
+type domainEntry struct {
+	key     string
+	label   string
+	typeRef typegraph.TypeReference
+}
+
+// caseClause describes a single case clause under a switch or match statement.
+type caseClause struct {
+	node      compilergraph.GraphNode
+	isDefault bool
+
+	// valueKey is the key of the value handled by a switch case, if known.
+	valueKey    string
+	hasValueKey bool
+
+	// typeRef is the type handled by a match case, if valid.
+	typeRef    typegraph.TypeReference
+	hasTypeRef bool
+
+	// isPartial indicates that a match case may not handle all values of its type, as it has
+	// a guard or a pattern that can fail to match.
+	isPartial bool
+
+	// isNull indicates that a match case handles the null value, via a null pattern.
+	isNull bool
+}
+
+// byClausePosition sorts case clauses by their position in source.
+type byClausePosition []caseClause
+
+func (s byClausePosition) Len() int      { return len(s) }
+func (s byClausePosition) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byClausePosition) Less(i, j int) bool {
+	return s[i].node.GetValue(sourceshape.NodePredicateStartRune).Int() < s[j].node.GetValue(sourceshape.NodePredicateStartRune).Int()
+}
+
+// unreachableCase describes a case clause that can never be reached.
+type unreachableCase struct {
+	node   compilergraph.GraphNode
+	reason string
+}
+
+// caseCoverage describes the result of the exhaustiveness analysis of a switch or match statement.
+type caseCoverage struct {
+	missing     []string
+	unreachable []unreachableCase
+}
+
+// MissingCases returns the source representations of the values or types not handled by any case of
+// the given switch or match statement SRG node, as they would appear in a case clause. Returns an empty
+// slice if the statement is exhaustive or operates over a value whose domain is not closed.
+func (sg *ScopeGraph) MissingCases(statementNode compilergraph.GraphNode) []string {
+	switch statementNode.Kind() {
+	case sourceshape.NodeTypeSwitchStatement:
+		exprNode, hasExpression := statementNode.TryGetNode(sourceshape.NodeSwitchStatementExpression)
+		if !hasExpression {
+			return []string{}
+		}
+
+		exprScope, hasExprScope := sg.GetScope(exprNode)
+		if !hasExprScope || !exprScope.GetIsValid() {
+			return []string{}
+		}
+
+		clauses := sg.collectCaseClauses(statementNode, sourceshape.NodeSwitchStatementCase, func(caseNode compilergraph.GraphNode, clause *caseClause) bool {
+			caseExprNode, hasCaseExpression := caseNode.TryGetNode(sourceshape.NodeSwitchStatementCaseExpression)
+			if !hasCaseExpression {
+				return false
+			}
+
+			caseExprScope, hasCaseExprScope := sg.GetScope(caseExprNode)
+			if hasCaseExprScope && caseExprScope.GetIsValid() {
+				clause.valueKey, clause.hasValueKey = sg.switchCaseKey(caseExprNode, &caseExprScope)
+			}
+			return true
+		})
+
+		return sg.analyzeSwitchCases(exprScope.ResolvedTypeRef(sg.tdg), clauses).missing
+
+	case sourceshape.NodeTypeMatchStatement:
+		exprNode, hasExpression := statementNode.TryGetNode(sourceshape.NodeMatchStatementExpression)
+		if !hasExpression {
+			return []string{}
+		}
+
+		exprScope, hasExprScope := sg.GetScope(exprNode)
+		if !hasExprScope || !exprScope.GetIsValid() {
+			return []string{}
+		}
+
+		clauses := sg.collectCaseClauses(statementNode, sourceshape.NodeMatchStatementCase, func(caseNode compilergraph.GraphNode, clause *caseClause) bool {
+			if patternNode, hasPattern := caseNode.TryGetNode(sourceshape.NodeMatchStatementCasePattern); hasPattern {
+				sg.patternCaseClause(caseNode, patternNode, clause)
+				return true
+			}
+
+			caseTypeRefNode, hasCaseTypeRef := caseNode.TryGetNode(sourceshape.NodeMatchStatementCaseTypeReference)
+			if !hasCaseTypeRef {
+				return false
+			}
+
+			_, hasGuard := caseNode.TryGetNode(sourceshape.NodeMatchStatementCaseGuard)
+			typeRef, err := sg.ResolveSRGTypeRef(sg.srg.GetTypeRef(caseTypeRefNode))
+			clause.typeRef, clause.hasTypeRef, clause.isPartial = typeRef, err == nil, hasGuard
+			return true
+		})
+
+		return sg.analyzeMatchCases(exprScope.ResolvedTypeRef(sg.tdg), clauses).missing
+
+	default:
+		return []string{}
+	}
+}
+
+// collectCaseClauses collects the case clauses found under the given statement node via the given
+// predicate. The populate function fills in the clause for each case node, returning
+// false if the case is the default case.
+func (sg *ScopeGraph) collectCaseClauses(statementNode compilergraph.GraphNode, casePredicate compilergraph.Predicate,
+	populate func(caseNode compilergraph.GraphNode, clause *caseClause) bool) []caseClause {
+	var clauses = make([]caseClause, 0)
+
+	cit := statementNode.StartQuery().
+		Out(casePredicate).
+		BuildNodeIterator()
+
+	for cit.Next() {
+		clause := caseClause{node: cit.Node()}
+		clause.isDefault = !populate(cit.Node(), &clause)
+		clauses = append(clauses, clause)
+	}
+
+	return clauses
+}
+
+// switchCaseKey returns the key identifying the value handled by a switch case with the given
+// expression, if that value is a member of a closed domain.
+func (sg *ScopeGraph) switchCaseKey(caseExprNode compilergraph.GraphNode, caseExprScope *proto.ScopeInfo) (string, bool) {
+	switch caseExprNode.Kind() {
+	case sourceshape.NodeBooleanLiteralExpression:
+		return caseExprNode.Get(sourceshape.NodeBooleanLiteralExpressionValue), true
+
+	case sourceshape.NodeNullLiteralExpression:
+		return "null", true
+	}
+
+	namedReference := caseExprScope.GetNamedReference()
+	if namedReference == nil || namedReference.GetIsSRGNode() {
+		return "", false
+	}
+
+	typeOrMember := sg.tdg.GetTypeOrMember(compilergraph.GraphNodeId(namedReference.GetReferencedNode()))
+	member, isMember := typeOrMember.(typegraph.TGMember)
+	if !isMember || !member.IsEnumValue() {
+		return "", false
+	}
+
+	return string(member.GraphNode.NodeId), true
+}
+
+// switchDomain returns the closed domain of values over which a switch of the given type operates,
+// if any. Booleans, enums and nullable forms of both have closed domains.
+func (sg *ScopeGraph) switchDomain(switchValueType typegraph.TypeReference) ([]domainEntry, bool) {
+	var domain = make([]domainEntry, 0)
+	if switchValueType.IsNullable() {
+		domain = append(domain, domainEntry{key: "null", label: "null"})
+		switchValueType = switchValueType.AsNonNullable()
+	}
+
+	if !switchValueType.IsNormal() {
+		return domain, false
+	}
+
+	if switchValueType.HasReferredType(sg.tdg.BoolTypeReference().ReferredType()) {
+		domain = append(domain, domainEntry{key: "true", label: "true"}, domainEntry{key: "false", label: "false"})
+		return domain, true
+	}
+
+	referredType := switchValueType.ReferredType()
+	if !referredType.IsEnum() {
+		return domain, false
+	}
+
+	for _, value := range referredType.EnumValues() {
+		domain = append(domain, domainEntry{
+			key:   string(value.GraphNode.NodeId),
+			label: referredType.Name() + "." + value.Name(),
+		})
+	}
+
+	return domain, true
+}
+
+// matchDomain returns the closed domain of types over which a match of the given type operates,
+// if any. A match over a non-interface type operates over that type alone, while a match over an
+// interface operates over the struct types implementing it, so long as no other kind of type
+// implements it as well. Matches over nullable types also operate over null.
+func (sg *ScopeGraph) matchDomain(matchExprType typegraph.TypeReference) ([]domainEntry, bool) {
+	var domain = make([]domainEntry, 0)
+	if matchExprType.IsNullable() {
+		domain = append(domain, domainEntry{key: "null", label: "null"})
+		matchExprType = matchExprType.AsNonNullable()
+	}
+
+	if !matchExprType.IsNormal() {
+		return domain, false
+	}
+
+	referredType := matchExprType.ReferredType()
+	if referredType.TypeKind() != typegraph.ImplicitInterfaceType {
+		domain = append(domain, domainEntry{
+			key:     matchExprType.String(),
+			label:   matchExprType.String(),
+			typeRef: matchExprType,
+		})
+		return domain, true
+	}
+
+	implementingTypes := sg.getImplementingTypes(matchExprType)
+	if len(implementingTypes) == 0 {
+		return domain, false
+	}
+
+	for _, typeDecl := range implementingTypes {
+		if typeDecl.TypeKind() != typegraph.StructType || typeDecl.HasGenerics() {
+			return domain, false
+		}
+
+		domain = append(domain, domainEntry{
+			key:     string(typeDecl.GraphNode.NodeId),
+			label:   typeDecl.Name(),
+			typeRef: typeDecl.GetTypeReference(),
+		})
+	}
+
+	return domain, true
+}
+
+// getImplementingTypes returns the types implementing the given interface type, other than
+// interfaces. As the implementations of an interface are the same for every match over it, they
+// are computed once per interface type.
+func (sg *ScopeGraph) getImplementingTypes(interfaceType typegraph.TypeReference) []typegraph.TGTypeDecl {
+	if existing, found := sg.implementingTypes.Get(interfaceType.Value()); found {
+		return existing.([]typegraph.TGTypeDecl)
+	}
+
+	var implementingTypes = make([]typegraph.TGTypeDecl, 0)
+	for _, typeDecl := range sg.tdg.TypeDecls() {
+		// Extensions are never the type of a value, so they never implement an interface.
+		if typeDecl.TypeKind() == typegraph.ImplicitInterfaceType || typeDecl.IsExtension() {
+			continue
+		}
+
+		if typeDecl.GetTypeReference().CheckSubTypeOf(interfaceType) != nil {
+			continue
+		}
+
+		implementingTypes = append(implementingTypes, typeDecl)
+	}
+
+	sg.implementingTypes.Set(interfaceType.Value(), implementingTypes)
+	return implementingTypes
+}
+
+// analyzeSwitchCases determines the values missing from and the cases unreachable under a switch over
+// the given type. The clauses are analyzed in source order.
+func (sg *ScopeGraph) analyzeSwitchCases(switchValueType typegraph.TypeReference, clauses []caseClause) caseCoverage {
+	domain, isClosed := sg.switchDomain(switchValueType)
+	var coverage = caseCoverage{[]string{}, []unreachableCase{}}
+	var handled = map[string]bool{}
+	var hasDefault = false
+	var hasUnknownCase = false
+
+	sort.Sort(byClausePosition(clauses))
+
+	for _, clause := range clauses {
+		if hasDefault {
+			coverage.unreachable = append(coverage.unreachable, unreachableCase{clause.node, "it follows the default case"})
+			continue
+		}
+
+		if clause.isDefault {
+			hasDefault = true
+			continue
+		}
+
+		if !clause.hasValueKey {
+			hasUnknownCase = true
+			continue
+		}
+
+		if handled[clause.valueKey] {
+			coverage.unreachable = append(coverage.unreachable, unreachableCase{clause.node, "its value is handled by an earlier case"})
+			continue
+		}
+
+		handled[clause.valueKey] = true
+	}
+
+	if !isClosed || hasDefault || hasUnknownCase {
+		return coverage
+	}
+
+	for _, entry := range domain {
+		if !handled[entry.key] {
+			coverage.missing = append(coverage.missing, entry.label)
+		}
+	}
+
+	return coverage
+}
+
+// analyzeMatchCases determines the types missing from and the cases unreachable under a match over
+// the given type. The clauses are analyzed in source order.
+func (sg *ScopeGraph) analyzeMatchCases(matchExprType typegraph.TypeReference, clauses []caseClause) caseCoverage {
+	domain, isClosed := sg.matchDomain(matchExprType)
+	var coverage = caseCoverage{[]string{}, []unreachableCase{}}
+	var handled = map[string]bool{}
+	var handledTypes = make([]typegraph.TypeReference, 0)
+	var hasDefault = false
+
+	sort.Sort(byClausePosition(clauses))
+
+	for _, clause := range clauses {
+		if hasDefault {
+			coverage.unreachable = append(coverage.unreachable, unreachableCase{clause.node, "it follows the default case"})
+			continue
+		}
+
+		if clause.isDefault {
+			hasDefault = true
+			continue
+		}
+
+		if clause.isNull {
+			if handled["null"] {
+				coverage.unreachable = append(coverage.unreachable, unreachableCase{clause.node, "null is handled by an earlier case"})
+			}
+
+			handled["null"] = true
+			continue
+		}
+
+		if !clause.hasTypeRef {
+			continue
+		}
+
+		var subsumed = false
+		for _, handledType := range handledTypes {
+			if clause.typeRef.CheckSubTypeOf(handledType) == nil {
+				coverage.unreachable = append(coverage.unreachable, unreachableCase{clause.node, "type '" + clause.typeRef.String() + "' is handled by the earlier case for '" + handledType.String() + "'"})
+				subsumed = true
+				break
+			}
+		}
+
+		// A partial case can still be unreachable, but does not handle its type.
+		if subsumed || clause.isPartial {
+			continue
+		}
+
+		handledTypes = append(handledTypes, clause.typeRef)
+		for _, entry := range domain {
+			if entry.key != "null" && entry.typeRef.CheckSubTypeOf(clause.typeRef) == nil {
+				handled[entry.key] = true
+			}
+		}
+	}
+
+	if !isClosed || hasDefault {
+		return coverage
+	}
+
+	for _, entry := range domain {
+		if !handled[entry.key] {
+			coverage.missing = append(coverage.missing, entry.label)
+		}
+	}
+
+	return coverage
+}
+
+// reportCaseCoverage decorates the given switch or match statement node with any missing or
+// unreachable cases found by the exhaustiveness analysis.
+func (sb *scopeBuilder) reportCaseCoverage(node compilergraph.GraphNode, statementKind string, valueType typegraph.TypeReference, coverage caseCoverage) {
+	for _, unreachable := range coverage.unreachable {
+		sb.decorateWithWarning(unreachable.node, "Unreachable %s case: %s", statementKind, unreachable.reason)
+	}
+
+	if len(coverage.missing) == 0 {
+		return
+	}
+
+	if sb.sg.requireExhaustiveCases {
+		sb.decorateWithError(node, "%s over type '%v' does not handle: %s", strings.Title(statementKind), valueType, strings.Join(coverage.missing, ", "))
+	} else {
+		sb.decorateWithWarning(node, "%s over type '%v' does not handle: %s", strings.Title(statementKind), valueType, strings.Join(coverage.missing, ", "))
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scopegraph
+
+import (
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// flowExitOption defines whether `break` statements exit the flow being analyzed.
+type flowExitOption int
+
+const (
+	// breakExitsFlow indicates that a `break` statement exits the flow being analyzed, as
+	// is the case for statements directly under a loop.
+	breakExitsFlow flowExitOption = iota
+
+	// breakContinuesFlow indicates that a `break` statement continues the flow after the
+	// statement being analyzed, as is the case for the cases of a switch or match.
+	breakContinuesFlow
+)
+
+// contextAfterStatement returns the context under which the statements following the given statement
+// (in the same block) are scoped, reflecting the effects of the statement on the definite assignment
+// and nullability of named values.
+func (sb *scopeBuilder) contextAfterStatement(statement compilergraph.GraphNode, context scopeContext) scopeContext {
+	switch statement.Kind() {
+	case sourceshape.NodeTypeVariableStatement:
+		// A variable with a non-nullable declared type and no initializer must be assigned
+		// before it is read.
+		if _, hasExpression := statement.TryGetNode(sourceshape.NodeVariableStatementExpression); hasExpression {
+			return context
+		}
+
+		declaredType, hasDeclaredType := sb.getDeclaredVariableType(statement)
+		if !hasDeclaredType || declaredType.IsNullable() {
+			return context
+		}
+
+		return context.withUnassigned(statement)
+
+	case sourceshape.NodeTypeAssignStatement:
+		return sb.contextAfterAssignment(statement, context)
+	}
+
+	// Any named values assigned under the statement no longer have known types, but may now be
+	// definitely assigned.
+	updatedContext := sb.withoutOverridesAssignedUnder(statement, context)
+	for _, varNodeId := range context.unassignedIds() {
+		varNode := sb.sg.srg.GetNode(varNodeId)
+		if sb.definitelyAssigns(statement, varNode, breakExitsFlow) {
+			updatedContext = updatedContext.withAssigned(varNode)
+		}
+	}
+
+	if statement.Kind() != sourceshape.NodeTypeConditionalStatement {
+		return updatedContext
+	}
+
+	// If exactly one branch of the conditional always exits, then the flow following the conditional
+	// has passed through the other branch, and therefore the type inferred for that branch holds.
+	conditionalExprNode, hasConditionalExpr := statement.TryGetNode(sourceshape.NodeConditionalStatementConditional)
+	if !hasConditionalExpr {
+		return updatedContext
+	}
+
+	blockNode, hasBlock := statement.TryGetNode(sourceshape.NodeConditionalStatementBlock)
+	thenExits := hasBlock && sb.alwaysExits(blockNode, breakExitsFlow)
+
+	elseNode, hasElse := statement.TryGetNode(sourceshape.NodeConditionalStatementElseClause)
+	elseExits := hasElse && sb.alwaysExits(elseNode, breakExitsFlow)
+
+	var option inferrenceOption
+	switch {
+	case thenExits && !elseExits:
+		option = inferredInverted
+
+	case elseExits && !thenExits:
+		option = inferredDirect
+
+	default:
+		return updatedContext
+	}
+
+	namedNode, inferredType, hasInferredType := sb.inferTypeForConditionalExpression(context, conditionalExprNode, option)
+	if !hasInferredType || inferredType.IsNull() || sb.isAssignedUnder(namedNode, statement) {
+		return updatedContext
+	}
+
+	return updatedContext.withFlowTypeOverride(namedNode, inferredType)
+}
+
+// contextAfterAssignment returns the context following the given assignment statement. The assigned
+// value is marked as definitely assigned and, if the value given is non-nullable, is known to be
+// non-null until its next assignment.
+func (sb *scopeBuilder) contextAfterAssignment(statement compilergraph.GraphNode, context scopeContext) scopeContext {
+	namedNode, isNamed := sb.assignedNamedNode(statement)
+	if !isNamed {
+		return context
+	}
+
+	updatedContext := context.withAssigned(namedNode).withoutTypeOverride(namedNode)
+
+	// Note: Both scopes were built when scoping the assignment statement itself, so the context
+	// given here is only used to retrieve them.
+	nameScope := sb.getScopeForPredicate(statement, sourceshape.NodeAssignStatementName, context.withAccess(scopeSetAccess))
+	valueScope := sb.getScopeForPredicate(statement, sourceshape.NodeAssignStatementValue, context)
+	if !nameScope.GetIsValid() || !valueScope.GetIsValid() {
+		return updatedContext
+	}
+
+	assignableType := nameScope.AssignableTypeRef(sb.sg.tdg)
+	valueType := valueScope.ResolvedTypeRef(sb.sg.tdg)
+	if !assignableType.IsNullable() || valueType.NullValueAllowed() || valueType.IsVoid() {
+		return updatedContext
+	}
+
+	return updatedContext.withFlowTypeOverride(namedNode, assignableType.AsNonNullable())
+}
+
+// withoutOverridesAssignedUnder returns the context with the type overrides of any named values
+// assigned under the given statement removed.
+func (sb *scopeBuilder) withoutOverridesAssignedUnder(statement compilergraph.GraphNode, context scopeContext) scopeContext {
+	var updatedContext = context
+	for _, namedNodeId := range context.overriddenIds() {
+		namedNode, found := sb.sg.srg.TryGetNode(namedNodeId)
+		if found && sb.isAssignedUnder(namedNode, statement) {
+			updatedContext = updatedContext.withoutTypeOverride(namedNode)
+		}
+	}
+	return updatedContext
+}
+
+// assignedNamedNode returns the SRG node of the named value assigned by the given assignment statement,
+// if it assigns directly to a name.
+func (sb *scopeBuilder) assignedNamedNode(assignNode compilergraph.GraphNode) (compilergraph.GraphNode, bool) {
+	nameNode, hasNameNode := assignNode.TryGetNode(sourceshape.NodeAssignStatementName)
+	if !hasNameNode || nameNode.Kind() != sourceshape.NodeTypeIdentifierExpression {
+		return compilergraph.GraphNode{}, false
+	}
+
+	name, hasName := nameNode.TryGet(sourceshape.NodeIdentifierExpressionName)
+	if !hasName {
+		return compilergraph.GraphNode{}, false
+	}
+
+	// Note: We resolve the name via the SRG rather than scoping the identifier, as scoping here
+	// would cache the identifier's scope under the wrong context.
+	srgInfo, found := sb.sg.srg.FindNameInScope(name, nameNode)
+	if !found || !srgInfo.IsNamedScope() {
+		return compilergraph.GraphNode{}, false
+	}
+
+	return srgInfo.AsNamedScope().GraphNode, true
+}
+
+// isAssignedUnder returns whether the given named node is assigned anywhere under the given node.
+func (sb *scopeBuilder) isAssignedUnder(namedNode compilergraph.GraphNode, node compilergraph.GraphNode) bool {
+	name, hasName := sb.sg.srg.ScopeNameForNode(namedNode).Name()
+	if !hasName {
+		return false
+	}
+
+	rit := sb.sg.srg.FindReferencesInScope(name, node)
+	for rit.Next() {
+		assignNode, isAssigned := rit.Node().TryGetIncomingNode(sourceshape.NodeAssignStatementName)
+		if !isAssigned {
+			continue
+		}
+
+		assignedNode, isNamed := sb.assignedNamedNode(assignNode)
+		if isNamed && assignedNode.NodeId == namedNode.NodeId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// definitelyAssigns returns whether every path through the given statement that reaches the statement
+// following it assigns a value to the given variable.
+func (sb *scopeBuilder) definitelyAssigns(statement compilergraph.GraphNode, varNode compilergraph.GraphNode, option flowExitOption) bool {
+	assignsOrExits := func(node compilergraph.GraphNode, option flowExitOption) bool {
+		return sb.definitelyAssigns(node, varNode, option) || sb.alwaysExits(node, option)
+	}
+
+	switch statement.Kind() {
+	case sourceshape.NodeTypeAssignStatement:
+		assignedNode, isNamed := sb.assignedNamedNode(statement)
+		return isNamed && assignedNode.NodeId == varNode.NodeId
+
+	case sourceshape.NodeTypeStatementBlock:
+		sit := statement.StartQuery().
+			Out(sourceshape.NodeStatementBlockStatement).
+			BuildNodeIterator()
+
+		for sit.Next() {
+			if assignsOrExits(sit.Node(), option) {
+				return true
+			}
+		}
+
+		return false
+
+	case sourceshape.NodeTypeConditionalStatement:
+		blockNode, hasBlock := statement.TryGetNode(sourceshape.NodeConditionalStatementBlock)
+		elseNode, hasElse := statement.TryGetNode(sourceshape.NodeConditionalStatementElseClause)
+		return hasBlock && hasElse && assignsOrExits(blockNode, option) && assignsOrExits(elseNode, option)
+
+	case sourceshape.NodeTypeWithStatement:
+		blockNode, hasBlock := statement.TryGetNode(sourceshape.NodeWithStatementBlock)
+		return hasBlock && sb.definitelyAssigns(blockNode, varNode, option)
+
+	case sourceshape.NodeTypeSwitchStatement:
+		return sb.allCasesOf(statement, sourceshape.NodeSwitchStatementCase,
+			[]compilergraph.Predicate{sourceshape.NodeSwitchStatementCaseExpression},
+			sourceshape.NodeSwitchStatementCaseStatement, func(blockNode compilergraph.GraphNode) bool {
+				return assignsOrExits(blockNode, breakContinuesFlow)
+			})
+
+	case sourceshape.NodeTypeMatchStatement:
+		return sb.allCasesOf(statement, sourceshape.NodeMatchStatementCase,
+			[]compilergraph.Predicate{sourceshape.NodeMatchStatementCaseTypeReference, sourceshape.NodeMatchStatementCasePattern},
+			sourceshape.NodeMatchStatementCaseStatement, func(blockNode compilergraph.GraphNode) bool {
+				return assignsOrExits(blockNode, breakContinuesFlow)
+			})
+	}
+
+	// Note: Loops are never considered to definitely assign, as their blocks may execute zero times.
+	return false
+}
+
+// alwaysExits returns whether the given statement never continues to the statement following it, as
+// every path through it ends in a `return`, `reject`, `continue`, `yield break` or (depending on the
+// option) `break` statement.
+func (sb *scopeBuilder) alwaysExits(statement compilergraph.GraphNode, option flowExitOption) bool {
+	switch statement.Kind() {
+	case sourceshape.NodeTypeReturnStatement:
+		return true
+
+	case sourceshape.NodeTypeRejectStatement:
+		return true
+
+	case sourceshape.NodeTypeContinueStatement:
+		return true
+
+	case sourceshape.NodeTypeBreakStatement:
+		return option == breakExitsFlow
+
+	case sourceshape.NodeTypeYieldStatement:
+		_, isBreak := statement.TryGet(sourceshape.NodeYieldStatementBreak)
+		return isBreak
+
+	case sourceshape.NodeTypeStatementBlock:
+		sit := statement.StartQuery().
+			Out(sourceshape.NodeStatementBlockStatement).
+			BuildNodeIterator()
+
+		for sit.Next() {
+			if sb.alwaysExits(sit.Node(), option) {
+				return true
+			}
+		}
+
+		return false
+
+	case sourceshape.NodeTypeConditionalStatement:
+		blockNode, hasBlock := statement.TryGetNode(sourceshape.NodeConditionalStatementBlock)
+		elseNode, hasElse := statement.TryGetNode(sourceshape.NodeConditionalStatementElseClause)
+		return hasBlock && hasElse && sb.alwaysExits(blockNode, option) && sb.alwaysExits(elseNode, option)
+
+	case sourceshape.NodeTypeWithStatement:
+		blockNode, hasBlock := statement.TryGetNode(sourceshape.NodeWithStatementBlock)
+		return hasBlock && sb.alwaysExits(blockNode, option)
+
+	case sourceshape.NodeTypeSwitchStatement:
+		return sb.allCasesOf(statement, sourceshape.NodeSwitchStatementCase,
+			[]compilergraph.Predicate{sourceshape.NodeSwitchStatementCaseExpression},
+			sourceshape.NodeSwitchStatementCaseStatement, func(blockNode compilergraph.GraphNode) bool {
+				return sb.alwaysExits(blockNode, breakContinuesFlow)
+			})
+
+	case sourceshape.NodeTypeMatchStatement:
+		return sb.allCasesOf(statement, sourceshape.NodeMatchStatementCase,
+			[]compilergraph.Predicate{sourceshape.NodeMatchStatementCaseTypeReference, sourceshape.NodeMatchStatementCasePattern},
+			sourceshape.NodeMatchStatementCaseStatement, func(blockNode compilergraph.GraphNode) bool {
+				return sb.alwaysExits(blockNode, breakContinuesFlow)
+			})
+	}
+
+	return false
+}
+
+// allCasesOf returns whether the given switch or match statement has a default case and the check
+// function returns true for the statement blocks of all of its cases. A case is the default case
+// if it has none of the given value predicates.
+func (sb *scopeBuilder) allCasesOf(statement compilergraph.GraphNode, casePredicate compilergraph.Predicate,
+	caseValuePredicates []compilergraph.Predicate, caseBlockPredicate compilergraph.Predicate,
+	check func(blockNode compilergraph.GraphNode) bool) bool {
+
+	var hasDefault = false
+
+	cit := statement.StartQuery().
+		Out(casePredicate).
+		BuildNodeIterator()
+
+	for cit.Next() {
+		var hasValue = false
+		for _, caseValuePredicate := range caseValuePredicates {
+			if _, found := cit.Node().TryGetNode(caseValuePredicate); found {
+				hasValue = true
+			}
+		}
+
+		if !hasValue {
+			hasDefault = true
+		}
+
+		blockNode, hasBlock := cit.Node().TryGetNode(caseBlockPredicate)
+		if !hasBlock || !check(blockNode) {
+			return false
+		}
+	}
+
+	return hasDefault
+}
+
+// flowNarrowedType returns the declared type of the named value referenced by the child expression
+// found under the given predicate if, and only if, the named value is declared nullable but flow
+// analysis has determined it to be non-null under the context. If so, a warning is added to the
+// node indicating that the null-handling operator is redundant.
+func (sb *scopeBuilder) flowNarrowedType(node compilergraph.GraphNode, predicate compilergraph.Predicate, operatorTitle string, context scopeContext) (typegraph.TypeReference, bool) {
+	childNode, hasChildNode := node.TryGetNode(predicate)
+	if !hasChildNode {
+		return typegraph.TypeReference{}, false
+	}
+
+	childScope := sb.getScope(childNode, context)
+	namedNode, isNamed := childScope.NamedReferenceNode(sb.sg.srg, sb.sg.tdg)
+	if !isNamed || !context.isFlowNarrowed(namedNode) {
+		return typegraph.TypeReference{}, false
+	}
+
+	namedScope, _ := sb.getNamedScopeForScope(childScope)
+	declaredType, isValid := namedScope.definedValueOrGenericType(context)
+	if !isValid || !declaredType.IsNullable() {
+		return typegraph.TypeReference{}, false
+	}
+
+	sb.decorateWithWarning(node, "Redundant %s: '%v' cannot be null here", operatorTitle, namedScope.NonEmptyName())
+	return declaredType, true
+}
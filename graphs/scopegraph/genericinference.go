@@ -0,0 +1,88 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scopegraph
+
+import (
+	"fmt"
+
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/scopegraph/proto"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// InferredGenerics returns the types inferred for the generics of the function invoked by the given
+// function call expression SRG node, if the call invokes a generic function without specifying its
+// generics explicitly.
+func (sg *ScopeGraph) InferredGenerics(callNode compilergraph.GraphNode) ([]typegraph.TypeReference, bool) {
+	childExpr, hasChildExpr := callNode.TryGetNode(sourceshape.NodeFunctionCallExpressionChildExpr)
+	if !hasChildExpr {
+		return nil, false
+	}
+
+	childScope, hasChildScope := sg.GetScope(childExpr)
+	if !hasChildScope || childScope.GetKind() != proto.ScopeKind_GENERIC {
+		return nil, false
+	}
+
+	parameters, hasParameters := sg.calledMemberParameters(&childScope)
+	argumentsByIndex, err := matchCallArguments(callNode, parameters, hasParameters)
+	if err != nil {
+		return nil, false
+	}
+
+	var argumentTypes = map[int]typegraph.TypeReference{}
+	for index, argumentNode := range argumentsByIndex {
+		argumentScope, hasArgumentScope := sg.GetScope(argumentNode)
+		if !hasArgumentScope || !argumentScope.GetIsValid() {
+			return nil, false
+		}
+
+		argumentTypes[index] = argumentScope.ResolvedTypeRef(sg.tdg)
+	}
+
+	_, inferred, err := sg.inferCallGenerics(&childScope, argumentTypes)
+	return inferred, err == nil
+}
+
+// inferCallGenerics infers the generics of the generic function referenced by the given scope from the
+// types of the arguments with which it is invoked, keyed by the index of the parameter each fills,
+// returning the type of the function with its generics replaced, as well as the inferred types themselves.
+func (sg *ScopeGraph) inferCallGenerics(childScope *proto.ScopeInfo, argumentTypes map[int]typegraph.TypeReference) (typegraph.TypeReference, []typegraph.TypeReference, error) {
+	namedReference := childScope.GetNamedReference()
+	if namedReference == nil || namedReference.GetIsSRGNode() {
+		return sg.tdg.AnyTypeReference(), nil, fmt.Errorf("Generics can only be inferred for members")
+	}
+
+	typeOrMember := sg.tdg.GetTypeOrMember(compilergraph.GraphNodeId(namedReference.GetReferencedNode()))
+	if typeOrMember.IsType() {
+		return sg.tdg.AnyTypeReference(), nil, fmt.Errorf("Generics can only be inferred for members")
+	}
+
+	generics := typeOrMember.Generics()
+	functionType := childScope.GenericTypeRef(sg.tdg)
+
+	// Align the types of the arguments given with those of the parameters they fill.
+	functionParameters := functionType.Parameters()
+	var parameterTypes = make([]typegraph.TypeReference, 0, len(argumentTypes))
+	var alignedArgumentTypes = make([]typegraph.TypeReference, 0, len(argumentTypes))
+	for index := 0; index < len(functionParameters); index++ {
+		if argumentType, hasArgument := argumentTypes[index]; hasArgument {
+			parameterTypes = append(parameterTypes, functionParameters[index])
+			alignedArgumentTypes = append(alignedArgumentTypes, argumentType)
+		}
+	}
+
+	inferred, err := sg.tdg.InferGenerics(generics, parameterTypes, alignedArgumentTypes)
+	if err != nil {
+		return sg.tdg.AnyTypeReference(), nil, err
+	}
+
+	for index, generic := range generics {
+		functionType = functionType.ReplaceType(generic.AsType(), inferred[index])
+	}
+
+	return functionType, inferred, nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scopegraph
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/scopegraph/proto"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// StructPatternType returns the struct type matched by the given struct pattern SRG node, if valid.
+func (sg *ScopeGraph) StructPatternType(patternNode compilergraph.GraphNode) (typegraph.TypeReference, bool) {
+	typeRefNode, hasTypeRef := patternNode.TryGetNode(sourceshape.NodeStructPatternTypeReference)
+	if !hasTypeRef {
+		return sg.tdg.AnyTypeReference(), false
+	}
+
+	patternType, err := sg.ResolveSRGTypeRef(sg.srg.GetTypeRef(typeRefNode))
+	if err != nil || !patternType.IsRefToStruct() {
+		return sg.tdg.AnyTypeReference(), false
+	}
+
+	return patternType, true
+}
+
+// PatternField returns the field matched by the given struct pattern field SRG node, as well as
+// its type under the struct type matched by the parent pattern, if valid.
+func (sg *ScopeGraph) PatternField(fieldNode compilergraph.GraphNode) (typegraph.TGMember, typegraph.TypeReference, bool) {
+	patternType, hasPatternType := sg.StructPatternType(fieldNode.GetIncomingNode(sourceshape.NodeStructPatternField))
+	if !hasPatternType {
+		return typegraph.TGMember{}, sg.tdg.AnyTypeReference(), false
+	}
+
+	fieldName, hasFieldName := fieldNode.TryGet(sourceshape.NodeStructPatternFieldName)
+	if !hasFieldName {
+		return typegraph.TGMember{}, sg.tdg.AnyTypeReference(), false
+	}
+
+	module := compilercommon.InputSource(fieldNode.Get(sourceshape.NodePredicateSource))
+	member, rerr := patternType.ResolveAccessibleMember(fieldName, module, typegraph.MemberResolutionInstance)
+	if rerr != nil || !member.IsField() {
+		return typegraph.TGMember{}, sg.tdg.AnyTypeReference(), false
+	}
+
+	return member, member.MemberType().TransformUnder(patternType), true
+}
+
+// isRefutablePattern returns whether the given pattern SRG node may fail to match a value of the
+// given type. Named values always match, while literals only match a single value. Struct patterns
+// match if the value is of the struct type and all their fields match.
+func (sg *ScopeGraph) isRefutablePattern(patternNode compilergraph.GraphNode, valueType typegraph.TypeReference) bool {
+	switch patternNode.Kind() {
+	case sourceshape.NodeTypeNamedValue:
+		return false
+
+	case sourceshape.NodeTypeStructPattern:
+		patternType, hasPatternType := sg.StructPatternType(patternNode)
+		if !hasPatternType || valueType.NullValueAllowed() || valueType.CheckSubTypeOf(patternType) != nil {
+			return true
+		}
+
+		return sg.hasRefutableFields(patternNode)
+
+	default:
+		return true
+	}
+}
+
+// hasRefutableFields returns whether any of the fields of the given struct pattern SRG node may
+// fail to match.
+func (sg *ScopeGraph) hasRefutableFields(patternNode compilergraph.GraphNode) bool {
+	fit := patternNode.StartQuery().
+		Out(sourceshape.NodeStructPatternField).
+		BuildNodeIterator()
+
+	for fit.Next() {
+		subPatternNode, hasSubPattern := fit.Node().TryGetNode(sourceshape.NodeStructPatternFieldPattern)
+		if !hasSubPattern {
+			return true
+		}
+
+		_, fieldType, hasField := sg.PatternField(fit.Node())
+		if !hasField || sg.isRefutablePattern(subPatternNode, fieldType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// patternCaseClause populates the clause for the given match case SRG node matching via a pattern.
+// Literal patterns do not handle a type, while a struct pattern handles its struct type only if its
+// fields always match and the case has no guard. A null pattern without a guard handles null.
+func (sg *ScopeGraph) patternCaseClause(caseNode compilergraph.GraphNode, patternNode compilergraph.GraphNode, clause *caseClause) {
+	_, hasGuard := caseNode.TryGetNode(sourceshape.NodeMatchStatementCaseGuard)
+	clause.isPartial = true
+
+	if patternNode.Kind() == sourceshape.NodeNullLiteralExpression {
+		clause.isNull = !hasGuard
+		return
+	}
+
+	if patternNode.Kind() != sourceshape.NodeTypeStructPattern {
+		return
+	}
+
+	clause.typeRef, clause.hasTypeRef = sg.StructPatternType(patternNode)
+	clause.isPartial = hasGuard || sg.hasRefutableFields(patternNode)
+}
+
+// scopeMatchPattern scopes a pattern under a match case, matched against a value of the given type,
+// returning whether the pattern is valid.
+func (sb *scopeBuilder) scopeMatchPattern(patternNode compilergraph.GraphNode, valueType typegraph.TypeReference, context scopeContext) bool {
+	switch patternNode.Kind() {
+	case sourceshape.NodeTypeNamedValue:
+		return sb.getScope(patternNode, context).GetIsValid()
+
+	case sourceshape.NodeTypeStructPattern:
+		return sb.scopeStructPattern(patternNode, valueType, context)
+
+	case sourceshape.NodeNullLiteralExpression:
+		if !valueType.NullValueAllowed() {
+			sb.decorateWithError(patternNode, "Null pattern can never match a value of non-nullable type '%v'", valueType)
+			return false
+		}
+
+		return sb.getScope(patternNode, context).GetIsValid()
+
+	default:
+		literalScope := sb.getScope(patternNode, context)
+		if !literalScope.GetIsValid() {
+			return false
+		}
+
+		literalType := literalScope.ResolvedTypeRef(sb.sg.tdg)
+		if serr := literalType.CheckCastableFrom(valueType.AsNonNullable()); serr != nil {
+			sb.decorateWithError(patternNode, "Pattern of type '%v' can never match a value of type '%v': %v", literalType, valueType, serr)
+			return false
+		}
+
+		return true
+	}
+}
+
+// scopeStructPattern scopes a struct pattern matched against a value of the given type, returning
+// whether the pattern is valid.
+func (sb *scopeBuilder) scopeStructPattern(patternNode compilergraph.GraphNode, valueType typegraph.TypeReference, context scopeContext) bool {
+	typeRefNode, hasTypeRef := patternNode.TryGetNode(sourceshape.NodeStructPatternTypeReference)
+	if !hasTypeRef {
+		return false
+	}
+
+	patternType, rerr := sb.sg.ResolveSRGTypeRef(sb.sg.srg.GetTypeRef(typeRefNode))
+	if rerr != nil {
+		sb.decorateWithError(patternNode, "%v", rerr)
+		return false
+	}
+
+	if !patternType.IsRefToStruct() {
+		sb.decorateWithError(patternNode, "Struct patterns can only match struct types. Found: %v", patternType)
+		return false
+	}
+
+	if serr := patternType.CheckCastableFrom(valueType.AsNonNullable()); serr != nil {
+		sb.decorateWithError(patternNode, "Struct pattern of type '%v' can never match a value of type '%v': %v", patternType, valueType, serr)
+		return false
+	}
+
+	var isValid = true
+	var encountered = map[string]bool{}
+
+	fit := patternNode.StartQuery().
+		Out(sourceshape.NodeStructPatternField).
+		BuildNodeIterator()
+
+	for fit.Next() {
+		fieldNode := fit.Node()
+		fieldName, hasFieldName := fieldNode.TryGet(sourceshape.NodeStructPatternFieldName)
+		subPatternNode, hasSubPattern := fieldNode.TryGetNode(sourceshape.NodeStructPatternFieldPattern)
+		if !hasFieldName || !hasSubPattern {
+			isValid = false
+			continue
+		}
+
+		if encountered[fieldName] {
+			sb.decorateWithError(fieldNode, "Field '%v' is matched more than once in this pattern", fieldName)
+			isValid = false
+			continue
+		}
+
+		encountered[fieldName] = true
+
+		module := compilercommon.InputSource(fieldNode.Get(sourceshape.NodePredicateSource))
+		member, merr := patternType.ResolveAccessibleMember(fieldName, module, typegraph.MemberResolutionInstance)
+		if merr != nil {
+			sb.decorateWithError(fieldNode, "%v", merr)
+			isValid = false
+			continue
+		}
+
+		if !member.IsField() {
+			sb.decorateWithError(fieldNode, "%v '%v' under type '%v' is not a field and cannot be matched", member.Title(), fieldName, patternType)
+			isValid = false
+			continue
+		}
+
+		fieldType := member.MemberType().TransformUnder(patternType)
+		if !sb.scopeMatchPattern(subPatternNode, fieldType, context) {
+			isValid = false
+		}
+	}
+
+	return isValid
+}
+
+// withPatternBindings returns the given context with the named values bound by the given pattern
+// added as local names.
+func (sb *scopeBuilder) withPatternBindings(patternNode compilergraph.GraphNode, context scopeContext) scopeContext {
+	switch patternNode.Kind() {
+	case sourceshape.NodeTypeNamedValue:
+		if patternNode.Get(sourceshape.NodeNamedValueName) == ANONYMOUS_REFERENCE {
+			return context
+		}
+
+		return context.withLocalNamed(patternNode, sb)
+
+	case sourceshape.NodeTypeStructPattern:
+		sit := patternNode.StartQuery().
+			Out(sourceshape.NodeStructPatternField).
+			Out(sourceshape.NodeStructPatternFieldPattern).
+			BuildNodeIterator()
+
+		var patternContext = context
+		for sit.Next() {
+			patternContext = sb.withPatternBindings(sit.Node(), patternContext)
+		}
+
+		return patternContext
+
+	default:
+		return context
+	}
+}
+
+// scopePatternBinding scopes a named value bound to the value of a field by a struct pattern.
+func (sb *scopeBuilder) scopePatternBinding(fieldNode compilergraph.GraphNode) proto.ScopeInfo {
+	_, fieldType, hasField := sb.sg.PatternField(fieldNode)
+	if !hasField {
+		return newScope().Invalid().GetScope()
+	}
+
+	return newScope().Valid().Assignable(fieldType).GetScope()
+}
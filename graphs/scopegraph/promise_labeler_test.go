@@ -157,6 +157,18 @@ var promisingLabelTests = []promisingLabelTest{
 		},
 	},
 
+	promisingLabelTest{"defer statement not promising test", "defernotpromising",
+		[]expectedPromiseLabel{
+			expectedPromiseLabel{"TEST", proto.ScopeLabel_SML_PROMISING_NO},
+		},
+	},
+
+	promisingLabelTest{"defer statement promising test", "deferpromising",
+		[]expectedPromiseLabel{
+			expectedPromiseLabel{"TEST", proto.ScopeLabel_SML_PROMISING_YES},
+		},
+	},
+
 	promisingLabelTest{"generator promising test", "generatorpromising",
 		[]expectedPromiseLabel{
 			expectedPromiseLabel{"SomeGenerator", proto.ScopeLabel_SML_PROMISING_YES},
@@ -192,6 +192,11 @@ func (sb *scopeBuilder) scopeStreamMemberAccessExpression(node compilergraph.Gra
 			return newScope().Invalid().GetScope()
 		}
 
+		if typeMember.IsExtensionMember() {
+			sb.decorateWithError(node, "Cannot attempt stream access of extension member '%v'", memberName)
+			return newScope().Invalid().GetScope()
+		}
+
 		memberScope := sb.getNamedScopeForMember(typeMember)
 		context.staticDependencyCollector.checkNamedScopeForDependency(memberScope)
 
@@ -248,6 +253,13 @@ func (sb *scopeBuilder) scopeDynamicMemberAccessExpression(node compilergraph.Gr
 			return newScope().Valid().Resolving(sb.sg.tdg.AnyTypeReference()).GetScope()
 		}
 
+		// Extension members are not found on the value itself, and therefore cannot be accessed
+		// dynamically.
+		if typeMember.IsExtensionMember() {
+			sb.decorateWithError(node, "Cannot attempt dynamic access of extension member '%v'. The . operator must be used.", memberName)
+			return newScope().Invalid().GetScope()
+		}
+
 		// Ensure static isn't accessed under instance and vice versa.
 		if typeMember.IsStatic() != expectStatic {
 			if typeMember.IsStatic() {
@@ -316,8 +328,13 @@ func (sb *scopeBuilder) scopeNullableMemberAccessExpression(node compilergraph.G
 	case proto.ScopeKind_VALUE:
 		childType := childScope.ResolvedTypeRef(sb.sg.tdg)
 		if !childType.IsNullable() {
-			sb.decorateWithError(node, "Cannot access name '%v' under non-nullable type '%v'. Please use the . operator to ensure type safety.", memberName, childType)
-			return newScope().Invalid().GetScope()
+			declaredType, isFlowNarrowed := sb.flowNarrowedType(node, sourceshape.NodeMemberAccessChildExpr, "nullable member access", context)
+			if !isFlowNarrowed {
+				sb.decorateWithError(node, "Cannot access name '%v' under non-nullable type '%v'. Please use the . operator to ensure type safety.", memberName, childType)
+				return newScope().Invalid().GetScope()
+			}
+
+			childType = declaredType
 		}
 
 		childNonNullableType := childType.AsNonNullable()
@@ -327,6 +344,11 @@ func (sb *scopeBuilder) scopeNullableMemberAccessExpression(node compilergraph.G
 			return newScope().Invalid().GetScope()
 		}
 
+		if typeMember.IsExtensionMember() {
+			sb.decorateWithError(node, "Cannot attempt nullable access of extension member '%v'", memberName)
+			return newScope().Invalid().GetScope()
+		}
+
 		memberScope := sb.getNamedScopeForMember(typeMember)
 		context.staticDependencyCollector.checkNamedScopeForDependency(memberScope)
 
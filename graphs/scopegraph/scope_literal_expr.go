@@ -207,6 +207,11 @@ func (sb *scopeBuilder) scopeStructuralNewTypeExpression(node compilergraph.Grap
 		// That check occurs below.
 		break
 
+	case typegraph.EnumType:
+		// Enums can only be referenced via their declared values.
+		sb.decorateWithError(node, "Cannot structurally construct enum type %v: Only its declared values may be used", staticTypeRef)
+		return newScope().Invalid().Resolving(staticTypeRef).GetScope()
+
 	default:
 		sb.decorateWithError(node, "Cannot structurally construct type %v", staticTypeRef)
 		return newScope().Invalid().Resolving(staticTypeRef).GetScope()
@@ -433,6 +438,44 @@ func (sb *scopeBuilder) scopeListLiteralExpression(node compilergraph.GraphNode,
 	return newScope().IsValid(isValid).Resolving(sb.sg.tdg.SliceTypeReference(valueType)).GetScope()
 }
 
+// scopeTupleLiteralExpression scopes a tuple literal expression in the SRG.
+func (sb *scopeBuilder) scopeTupleLiteralExpression(node compilergraph.GraphNode, context scopeContext) proto.ScopeInfo {
+	// The tuple type is defined by the core library, which may predate its introduction.
+	if !sb.sg.tdg.HasTupleType() {
+		sb.decorateWithError(node, "Tuple types require a core library with `valuetuple`")
+		return newScope().Invalid().GetScope()
+	}
+
+	var isValid = true
+	var elementTypes = make([]typegraph.TypeReference, 0)
+
+	// Scope each of the expressions, with the tuple type being built from their types.
+	vit := node.StartQuery().
+		Out(sourceshape.NodeTupleLiteralExpressionValue).
+		BuildNodeIterator()
+
+	for vit.Next() {
+		valueNode := vit.Node()
+		valueScope := sb.getScope(valueNode, context)
+		if !valueScope.GetIsValid() {
+			isValid = false
+			elementTypes = append(elementTypes, sb.sg.tdg.AnyTypeReference())
+			continue
+		}
+
+		valueType := valueScope.ResolvedTypeRef(sb.sg.tdg)
+		if valueType.IsVoid() {
+			sb.decorateWithError(valueNode, "Element #%v of tuple literal cannot be void", len(elementTypes)+1)
+			isValid = false
+			valueType = sb.sg.tdg.AnyTypeReference()
+		}
+
+		elementTypes = append(elementTypes, valueType)
+	}
+
+	return newScope().IsValid(isValid).Resolving(sb.sg.tdg.TupleTypeReference(elementTypes...)).GetScope()
+}
+
 // scopeSliceLiteralExpression scopes a slice literal expression in the SRG.
 func (sb *scopeBuilder) scopeSliceLiteralExpression(node compilergraph.GraphNode, context scopeContext) proto.ScopeInfo {
 	var isValid = true
@@ -624,6 +667,19 @@ func (sb *scopeBuilder) scopeThisLiteralExpression(node compilergraph.GraphNode,
 		return newScope().Invalid().GetScope()
 	}
 
+	// Under extensions, 'this' refers to the instance of the extended type.
+	if tgType.IsExtension() {
+		extendedType, hasExtendedType := tgType.ExtendedType()
+		if !hasExtendedType {
+			return newScope().Invalid().GetScope()
+		}
+
+		return newScope().
+			Valid().
+			Resolving(extendedType).
+			GetScope()
+	}
+
 	return newScope().
 		Valid().
 		Resolving(tgType.GetTypeReference()).
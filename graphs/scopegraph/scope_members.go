@@ -12,12 +12,58 @@ import (
 
 // scopeImplementedMember scopes an implemented type member.
 func (sb *scopeBuilder) scopeImplementedMember(node compilergraph.GraphNode, context scopeContext) proto.ScopeInfo {
+	parametersValid := sb.scopeParameterDefaultValues(node, context)
+
 	if body, hasBody := node.TryGetNode(sourceshape.NodePredicateBody); hasBody {
-		scope := sb.getScope(body, context)
-		return *scope
+		scope := *sb.getScope(body, context)
+		scope.IsValid = scope.IsValid && parametersValid
+		return scope
+	}
+
+	return newScope().IsValid(parametersValid).GetScope()
+}
+
+// scopeParameterDefaultValues scopes the default values of the parameters of the given member, ensuring
+// that each is of the declared type of its parameter. Returns whether all default values are valid.
+func (sb *scopeBuilder) scopeParameterDefaultValues(node compilergraph.GraphNode, context scopeContext) bool {
+	pit := node.StartQuery().
+		Out(sourceshape.NodePredicateTypeMemberParameter).
+		BuildNodeIterator()
+
+	var isValid = true
+	for pit.Next() {
+		parameterNode := pit.Node()
+		defaultValueNode, hasDefaultValue := parameterNode.TryGetNode(sourceshape.NodeParameterDefaultValue)
+		if !hasDefaultValue {
+			continue
+		}
+
+		defaultValueScope := sb.getScope(defaultValueNode, context)
+		if !defaultValueScope.GetIsValid() {
+			isValid = false
+			continue
+		}
+
+		parameterTypeNode, hasParameterType := parameterNode.TryGetNode(sourceshape.NodeParameterType)
+		if !hasParameterType {
+			continue
+		}
+
+		parameterType, rerr := sb.sg.ResolveSRGTypeRef(sb.sg.srg.GetTypeRef(parameterTypeNode))
+		if rerr != nil {
+			isValid = false
+			continue
+		}
+
+		defaultValueType := defaultValueScope.ResolvedTypeRef(sb.sg.tdg)
+		if serr := defaultValueType.CheckSubTypeOf(parameterType); serr != nil {
+			parameterName, _ := parameterNode.TryGet(sourceshape.NodeParameterName)
+			sb.decorateWithError(parameterNode, "Parameter '%s' has declared type '%v': %v", parameterName, parameterType, serr)
+			isValid = false
+		}
 	}
 
-	return newScope().Valid().GetScope()
+	return isValid
 }
 
 // scopeError scopes an error node found in the graph.
@@ -60,6 +60,12 @@ func (sb *scopeBuilder) scopeIdentifierExpression(node compilergraph.GraphNode,
 		return newScope().Invalid().GetScope()
 	}
 
+	// Ensure that variables declared without an initializer have been assigned before being read.
+	if context.accessOption == scopeGetAccess && namedScope.typeInfo == nil && context.isUnassigned(namedScope.srgInfo.GraphNode) {
+		sb.decorateWithError(node, "Variable '%v' is used before being assigned", name)
+		return newScope().Invalid().GetScope()
+	}
+
 	// Warn if we are accessing an assignable value under an async function, as it will be executing
 	// in a different context.
 	if namedScope.IsAssignable() && namedScope.UnderModule() {
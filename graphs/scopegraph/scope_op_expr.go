@@ -107,13 +107,18 @@ func (sb *scopeBuilder) scopeFunctionCallExpression(node compilergraph.GraphNode
 	if childScope.GetKind() == proto.ScopeKind_STATIC {
 		return sb.scopeTypeConversionExpression(node, context)
 	} else if childScope.GetKind() == proto.ScopeKind_GENERIC {
+		// Generic functions can have their generics inferred from the arguments given. All other
+		// generic scopes must be clarified.
 		namedScopedRef, found := sb.getNamedScopeForScope(childScope)
 		if found {
-			sb.decorateWithError(node, "Cannot invoke function call on unclarified generic %s %s.", namedScopedRef.Title(), namedScopedRef.NonEmptyName())
+			if _, isMember := namedScopedRef.Member(); !isMember {
+				sb.decorateWithError(node, "Cannot invoke function call on unclarified generic %s %s.", namedScopedRef.Title(), namedScopedRef.NonEmptyName())
+				return newScope().Invalid().GetScope()
+			}
 		} else {
 			sb.decorateWithError(node, "Cannot invoke function call on unclarified generic scope.")
+			return newScope().Invalid().GetScope()
 		}
-		return newScope().Invalid().GetScope()
 	}
 
 	var argumentContext = context
@@ -146,8 +151,27 @@ func (sb *scopeBuilder) scopeFunctionCallExpression(node compilergraph.GraphNode
 		return fmt.Sprintf("on %v %v ", namedNode.Title(), namedNode.NonEmptyName())
 	}
 
-	// Ensure the child expression has type function.
+	// Match the arguments given to the parameters of the function invoked.
+	parameters, hasParameters := sb.sg.calledMemberParameters(childScope)
+	argumentsByIndex, err := matchCallArguments(node, parameters, hasParameters)
+	if err != nil {
+		sb.decorateWithError(node, "Function call %s%v", getDescription(), err)
+		return newScope().Invalid().GetScope()
+	}
+
+	// If the child expression is a generic function without specified generics, infer them from
+	// the types of the arguments.
 	childType := childScope.ResolvedTypeRef(sb.sg.tdg)
+	if childScope.GetKind() == proto.ScopeKind_GENERIC {
+		inferredType, isValid := sb.inferGenericFunctionType(node, childScope, namedNode, argumentsByIndex, argumentContext)
+		if !isValid {
+			return newScope().Invalid().GetScope()
+		}
+
+		childType = inferredType
+	}
+
+	// Ensure the child expression has type function.
 	if !childType.IsDirectReferenceTo(sb.sg.tdg.FunctionType()) {
 		// If the child type is a function, but nullable, only allow it to be called if the type
 		// is a result of a null access expression. This is a special case to allow writing code
@@ -163,51 +187,68 @@ func (sb *scopeBuilder) scopeFunctionCallExpression(node compilergraph.GraphNode
 		}
 	}
 
-	// Find the starting index of the nullable parameters. Once all parameters are nullable,
-	// they are considered optional.
-	var nonOptionalIndex = -1
+	// Ensure that the arguments of the function call match the parameters of the child type.
 	childParameters := childType.Parameters()
-	for parameterIndex, parameterType := range childParameters {
-		if !parameterType.NullValueAllowed() {
-			nonOptionalIndex = parameterIndex
-		}
-	}
-
-	// Ensure that the parameters of the function call match those of the child type.
-	var index = -1
-	ait := node.StartQuery().
-		Out(sourceshape.NodeFunctionCallArgument).
-		BuildNodeIterator()
+	argumentIndexes := sortedArgumentIndexes(argumentsByIndex)
 
 	var isValid = true
-	for ait.Next() {
-		index = index + 1
+	for _, index := range argumentIndexes {
+		argumentNode := argumentsByIndex[index]
 
 		// Resolve the scope of the argument.
-		argumentScope := sb.getScope(ait.Node(), argumentContext)
+		argumentScope := sb.getScope(argumentNode, argumentContext)
 		if !argumentScope.GetIsValid() {
 			isValid = false
-			nonOptionalIndex = index
 			continue
 		}
 
 		if index < len(childParameters) {
 			// Ensure the type of the argument matches the parameter.
 			argumentType := argumentScope.ResolvedTypeRef(sb.sg.tdg)
-			if !sb.checkArgumentTypeWithAutounboxing(argumentType, childParameters[index], ait.Node(),
+			if !sb.checkArgumentTypeWithAutounboxing(argumentType, childParameters[index], argumentNode,
 				"Parameter #%v %sexpects type %v", index+1, getDescription(), childParameters[index]) {
 				isValid = false
 			}
 		}
 	}
 
-	if index < nonOptionalIndex {
-		sb.decorateWithError(node, "Function call %sexpects %v non-optional arguments, found %v", getDescription(), nonOptionalIndex+1, index+1)
-		return newScope().Invalid().GetScope()
+	// Ensure that an argument was given for every parameter that is not optional. Parameters
+	// with a default value or allowing null are considered optional. If any argument is invalid,
+	// the check is skipped, as the error will have already been reported.
+	if isValid {
+		isOptional := func(parameterIndex int) bool {
+			if childParameters[parameterIndex].NullValueAllowed() {
+				return true
+			}
+
+			return hasParameters && parameters[parameterIndex].HasDefaultValue()
+		}
+
+		var requiredCount = 0
+		for parameterIndex := range childParameters {
+			if !isOptional(parameterIndex) {
+				requiredCount = parameterIndex + 1
+			}
+		}
+
+		for parameterIndex := 0; parameterIndex < requiredCount; parameterIndex++ {
+			if _, hasArgument := argumentsByIndex[parameterIndex]; hasArgument || isOptional(parameterIndex) {
+				continue
+			}
+
+			if !hasNamedArguments(node) {
+				sb.decorateWithError(node, "Function call %sexpects %v non-optional arguments, found %v", getDescription(), requiredCount, len(argumentIndexes))
+				return newScope().Invalid().GetScope()
+			}
+
+			parameterName, _ := parameters[parameterIndex].Name()
+			sb.decorateWithError(node, "Function call %sis missing an argument for parameter '%s'", getDescription(), parameterName)
+			return newScope().Invalid().GetScope()
+		}
 	}
 
-	if index >= len(childParameters) {
-		sb.decorateWithError(node, "Function call %sexpects %v arguments, found %v", getDescription(), len(childParameters), index+1)
+	if len(argumentIndexes) > 0 && argumentIndexes[len(argumentIndexes)-1] >= len(childParameters) {
+		sb.decorateWithError(node, "Function call %sexpects %v arguments, found %v", getDescription(), len(childParameters), len(argumentIndexes))
 		return newScope().Invalid().GetScope()
 	}
 
@@ -230,6 +271,28 @@ func (sb *scopeBuilder) scopeFunctionCallExpression(node compilergraph.GraphNode
 	return newScope().IsValid(isValid).Resolving(returnType).GetScope()
 }
 
+// inferGenericFunctionType infers the generics of the generic function invoked by the given function call
+// from the types of its arguments, returning the type of the function with the generics replaced.
+func (sb *scopeBuilder) inferGenericFunctionType(node compilergraph.GraphNode, childScope *proto.ScopeInfo, namedScope namedScopeInfo, argumentsByIndex map[int]compilergraph.GraphNode, argumentContext scopeContext) (typegraph.TypeReference, bool) {
+	var argumentTypes = map[int]typegraph.TypeReference{}
+	for _, index := range sortedArgumentIndexes(argumentsByIndex) {
+		argumentScope := sb.getScope(argumentsByIndex[index], argumentContext)
+		if !argumentScope.GetIsValid() {
+			return sb.sg.tdg.AnyTypeReference(), false
+		}
+
+		argumentTypes[index] = argumentScope.ResolvedTypeRef(sb.sg.tdg)
+	}
+
+	functionType, _, err := sb.sg.inferCallGenerics(childScope, argumentTypes)
+	if err != nil {
+		sb.decorateWithError(node, "Could not infer generics of %v %v: %v", namedScope.Title(), namedScope.NonEmptyName(), err)
+		return sb.sg.tdg.AnyTypeReference(), false
+	}
+
+	return functionType, true
+}
+
 // scopeSliceExpression scopes a slice expression in the SRG.
 func (sb *scopeBuilder) scopeSliceExpression(node compilergraph.GraphNode, context scopeContext) proto.ScopeInfo {
 	// Check if this is a slice vs an index.
@@ -412,8 +475,10 @@ func (sb *scopeBuilder) scopeIsComparisonExpression(node compilergraph.GraphNode
 
 	// Ensure the left hand side can be nullable.
 	if !leftScope.ResolvedTypeRef(sb.sg.tdg).IsNullable() && !leftScope.ResolvedTypeRef(sb.sg.tdg).IsAny() {
-		sb.decorateWithError(node, "Left side of 'is' operator must be a nullable type. Found: %v", leftScope.ResolvedTypeRef(sb.sg.tdg))
-		return newScope().Invalid().Resolving(sb.sg.tdg.BoolTypeReference()).GetScope()
+		if _, isFlowNarrowed := sb.flowNarrowedType(node, sourceshape.NodeBinaryExpressionLeftExpr, "'is' operator", context); !isFlowNarrowed {
+			sb.decorateWithError(node, "Left side of 'is' operator must be a nullable type. Found: %v", leftScope.ResolvedTypeRef(sb.sg.tdg))
+			return newScope().Invalid().Resolving(sb.sg.tdg.BoolTypeReference()).GetScope()
+		}
 	}
 
 	return newScope().Valid().Resolving(sb.sg.tdg.BoolTypeReference()).GetScope()
@@ -431,8 +496,13 @@ func (sb *scopeBuilder) scopeAssertNotNullExpression(node compilergraph.GraphNod
 
 	// Ensure that the nullable type is nullable.
 	if !nullableType.IsNullable() {
-		sb.decorateWithError(node, "Child expression of an assert not nullable operator must be nullable. Found: %v", nullableType)
-		return newScope().Invalid().GetScope()
+		declaredType, isFlowNarrowed := sb.flowNarrowedType(node, sourceshape.NodeUnaryExpressionChildExpr, "assert not nullable operator", context)
+		if !isFlowNarrowed {
+			sb.decorateWithError(node, "Child expression of an assert not nullable operator must be nullable. Found: %v", nullableType)
+			return newScope().Invalid().GetScope()
+		}
+
+		nullableType = declaredType
 	}
 
 	return newScope().Valid().Resolving(nullableType.AsNonNullable()).GetScope()
@@ -454,8 +524,13 @@ func (sb *scopeBuilder) scopeNullComparisonExpression(node compilergraph.GraphNo
 
 	// Ensure that the nullable type is nullable.
 	if !nullableType.IsNullable() {
-		sb.decorateWithError(node, "Left hand side of a nullable operator must be nullable. Found: %v", nullableType)
-		return newScope().Invalid().GetScope()
+		declaredType, isFlowNarrowed := sb.flowNarrowedType(node, sourceshape.NodeBinaryExpressionLeftExpr, "nullable operator", context)
+		if !isFlowNarrowed {
+			sb.decorateWithError(node, "Left hand side of a nullable operator must be nullable. Found: %v", nullableType)
+			return newScope().Invalid().GetScope()
+		}
+
+		nullableType = declaredType
 	}
 
 	// Ensure that the replacement type is *not* nullable.
@@ -104,12 +104,15 @@ func (sb *scopeBuilder) scopeSwitchStatement(node compilergraph.GraphNode, conte
 	var settlesScope = true
 	var hasDefault = false
 	var labelSet = newLabelSet()
+	var clauses = make([]caseClause, 0)
 
 	sit := node.StartQuery().
 		Out(sourceshape.NodeSwitchStatementCase).
 		BuildNodeIterator()
 
 	for sit.Next() {
+		clause := caseClause{node: sit.Node()}
+
 		// Scope the statement block under the case.
 		statementBlockNode, hasStatementBlockNode := sit.Node().TryGetNode(sourceshape.NodeSwitchStatementCaseStatement)
 		if !hasStatementBlockNode {
@@ -137,12 +140,23 @@ func (sb *scopeBuilder) scopeSwitchStatement(node compilergraph.GraphNode, conte
 					sb.decorateWithError(node, "Switch cases must have values matching type '%v': %v", switchValueType, serr)
 					isValid = false
 				}
+
+				clause.valueKey, clause.hasValueKey = sb.sg.switchCaseKey(caseExprNode, caseExprScope)
 			} else {
 				isValid = false
 			}
 		} else {
 			hasDefault = true
+			clause.isDefault = true
 		}
+
+		clauses = append(clauses, clause)
+	}
+
+	// Check the cases for exhaustiveness. Switches without an expression operate over arbitrary
+	// conditions, and are therefore never checked.
+	if isValid && hasExpression {
+		sb.reportCaseCoverage(node, "switch", switchValueType, sb.sg.analyzeSwitchCases(switchValueType, clauses))
 	}
 
 	// If there isn't a default case, then the switch cannot be known to return in all cases.
@@ -171,6 +185,7 @@ func (sb *scopeBuilder) scopeMatchStatement(node compilergraph.GraphNode, contex
 	var hasDefault = false
 	var labelSet = newLabelSet()
 	var isValid = true
+	var clauses = make([]caseClause, 0)
 
 	// Lookup the named value, if any.
 	matchContext := context
@@ -185,10 +200,28 @@ func (sb *scopeBuilder) scopeMatchStatement(node compilergraph.GraphNode, contex
 
 	for sit.Next() {
 		var matchBranchType = sb.sg.tdg.AnyTypeReference()
+		var caseContext = matchContext
+		clause := caseClause{node: sit.Node()}
+		guardNode, hasGuard := sit.Node().TryGetNode(sourceshape.NodeMatchStatementCaseGuard)
 
-		// Check the case's type reference (if any) against the type expected.
+		// Check the case's type reference or pattern (if any) against the type expected.
 		caseTypeRefNode, hasCaseTypeRef := sit.Node().TryGetNode(sourceshape.NodeMatchStatementCaseTypeReference)
-		if hasCaseTypeRef {
+		patternNode, hasPattern := sit.Node().TryGetNode(sourceshape.NodeMatchStatementCasePattern)
+		if hasPattern {
+			// Scope the pattern and add any named values it binds to the case's context.
+			if !sb.scopeMatchPattern(patternNode, matchExprType, matchContext) {
+				isValid = false
+			} else {
+				sb.sg.patternCaseClause(sit.Node(), patternNode, &clause)
+				if clause.hasTypeRef {
+					matchBranchType = clause.typeRef
+				} else if patternNode.Kind() != sourceshape.NodeNullLiteralExpression {
+					matchBranchType = sb.getScope(patternNode, matchContext).ResolvedTypeRef(sb.sg.tdg)
+				}
+			}
+
+			caseContext = sb.withPatternBindings(patternNode, matchContext)
+		} else if hasCaseTypeRef {
 			matchTypeRef, rerr := sb.sg.ResolveSRGTypeRef(sb.sg.srg.GetTypeRef(caseTypeRefNode))
 			if rerr != nil {
 				isValid = false
@@ -205,16 +238,32 @@ func (sb *scopeBuilder) scopeMatchStatement(node compilergraph.GraphNode, contex
 				isValid = false
 			} else {
 				matchBranchType = matchTypeRef
+				clause.typeRef, clause.hasTypeRef = matchTypeRef, true
+				clause.isPartial = hasGuard
 			}
 		} else {
 			hasDefault = true
+			clause.isDefault = true
 		}
 
+		clauses = append(clauses, clause)
+
 		// Build the local context for scoping. If this match has an 'as', then its type is overridden
 		// to the match type for each branch.
-		localContext := matchContext
+		localContext := caseContext
 		if hasNamedValue {
-			localContext = matchContext.withTypeOverride(matchNamedValue, matchBranchType)
+			localContext = caseContext.withTypeOverride(matchNamedValue, matchBranchType)
+		}
+
+		// Scope the guard (if any) under the case, ensuring that it is a boolean.
+		if hasGuard {
+			guardScope := sb.getScope(guardNode, localContext)
+			if !guardScope.GetIsValid() {
+				isValid = false
+			} else if guardType := guardScope.ResolvedTypeRef(sb.sg.tdg); !guardType.IsDirectReferenceTo(sb.sg.tdg.BoolType()) {
+				sb.decorateWithError(guardNode, "Match case guard must be of type 'bool', found: %v", guardType)
+				isValid = false
+			}
 		}
 
 		// Scope the statement block under the case.
@@ -235,6 +284,11 @@ func (sb *scopeBuilder) scopeMatchStatement(node compilergraph.GraphNode, contex
 		settlesScope = settlesScope && statementBlockScope.GetIsSettlingScope()
 	}
 
+	// Check the cases for exhaustiveness.
+	if isValid {
+		sb.reportCaseCoverage(node, "match", matchExprType, sb.sg.analyzeMatchCases(matchExprType, clauses))
+	}
+
 	// If there isn't a default case, then the match cannot be known to return in all cases.
 	if !hasDefault {
 		returnedType = sb.sg.tdg.VoidTypeReference()
@@ -256,29 +310,45 @@ func (sb *scopeBuilder) scopeAssignedValue(node compilergraph.GraphNode, context
 		return newScope().ForAnonymousScope(sb.sg.tdg).GetScope()
 	}
 
-	// If the assigned value is under a rejection, then it is always an error (but nullable, as it
-	// may not be present always).
-	if _, ok := node.TryGetIncomingNode(sourceshape.NodeAssignedRejection); ok {
-		return newScope().Valid().Assignable(sb.sg.tdg.ErrorTypeReference().AsNullable()).GetScope()
+	parentNode, nameIndex, hasParentNode := assignedValueParent(node)
+	if !hasParentNode {
+		panic("Missing assigned parent")
 	}
 
-	// Otherwise, the value is the assignment of the parent statement's expression.
-	parentNode := node.GetIncomingNode(sourceshape.NodeAssignedDestination)
 	switch parentNode.Kind() {
 	case sourceshape.NodeTypeResolveStatement:
-		// The assigned value exported by a resolve statement has the type of its expression.
 		exprScope := sb.getScopeForPredicate(parentNode, sourceshape.NodeResolveStatementSource, context)
-		if !exprScope.GetIsValid() {
-			return newScope().Invalid().GetScope()
-		}
 
-		// If the parent node has a rejection, then the expression may be null.
+		// If the resolve statement destructures a tuple, then the assigned value has the type of
+		// the matching element.
 		exprType := exprScope.ResolvedTypeRef(sb.sg.tdg)
-		if _, ok := parentNode.TryGetNode(sourceshape.NodeAssignedRejection); ok {
-			exprType = exprType.AsNullable()
+		if exprScope.GetIsValid() && destructuresTuple(parentNode, exprType) {
+			return newScope().Valid().Assignable(exprType.Parameters()[nameIndex]).GetScope()
 		}
 
-		return newScope().Valid().Assignable(exprType).GetScope()
+		switch nameIndex {
+		case 0:
+			// The assigned value exported by a resolve statement has the type of its expression.
+			if !exprScope.GetIsValid() {
+				return newScope().Invalid().GetScope()
+			}
+
+			// If the parent node has a rejection, then the expression may be null.
+			if _, ok := parentNode.TryGetNode(sourceshape.NodeAssignedRejection); ok {
+				exprType = exprType.AsNullable()
+			}
+
+			return newScope().Valid().Assignable(exprType).GetScope()
+
+		case 1:
+			// If the assigned value is under a rejection, then it is always an error (but nullable, as it
+			// may not be present always).
+			return newScope().Valid().Assignable(sb.sg.tdg.ErrorTypeReference().AsNullable()).GetScope()
+
+		default:
+			// Further values can only be assigned by destructuring.
+			return newScope().Invalid().GetScope()
+		}
 
 	default:
 		panic(fmt.Sprintf("Unknown node exporting an assigned value: %v", parentNode.Kind()))
@@ -297,6 +367,11 @@ func (sb *scopeBuilder) scopeNamedValue(node compilergraph.GraphNode, context sc
 		return newScope().ForAnonymousScope(sb.sg.tdg).GetScope()
 	}
 
+	// If the named value is bound by a struct pattern, then it has the type of the matched field.
+	if fieldNode, isPatternBinding := node.TryGetIncomingNode(sourceshape.NodeStructPatternFieldPattern); isPatternBinding {
+		return sb.scopePatternBinding(fieldNode)
+	}
+
 	// Find the parent node creating this named value.
 	parentNode := node.GetIncomingNode(sourceshape.NodeStatementNamedValue)
 
@@ -407,6 +482,28 @@ func (sb *scopeBuilder) scopeWithStatement(node compilergraph.GraphNode, context
 	return newScope().IsValid(valid).ReturningTypeOf(statementBlockScope).LabelSetOf(statementBlockScope).GetScope()
 }
 
+// scopeDeferStatement scopes a defer statement in the SRG. The deferred call is made when the
+// function containing the defer statement is exited, in any manner.
+func (sb *scopeBuilder) scopeDeferStatement(node compilergraph.GraphNode, context scopeContext) proto.ScopeInfo {
+	exprNode, hasExprNode := node.TryGetNode(sourceshape.NodeDeferStatementExpression)
+	if !hasExprNode {
+		return newScope().Invalid().GetScope()
+	}
+
+	exprScope := sb.getScope(exprNode, context)
+	if !exprScope.GetIsValid() {
+		return newScope().Invalid().GetScope()
+	}
+
+	// Ensure that the deferred expression is a call, as its value is otherwise lost.
+	if exprNode.Kind() != sourceshape.NodeFunctionCallExpression {
+		sb.decorateWithError(node, "Deferred expression must be a function call")
+		return newScope().Invalid().GetScope()
+	}
+
+	return newScope().Valid().GetScope()
+}
+
 // scopeLoopStatement scopes a loop statement in the SRG.
 func (sb *scopeBuilder) scopeLoopStatement(node compilergraph.GraphNode, context scopeContext) proto.ScopeInfo {
 	// Scope the underlying block.
@@ -415,7 +512,11 @@ func (sb *scopeBuilder) scopeLoopStatement(node compilergraph.GraphNode, context
 		return newScope().Invalid().GetScope()
 	}
 
-	blockContext := context.withContinuable(node)
+	// Named values assigned under the loop may change between iterations, so any types
+	// inferred for them do not hold under the loop.
+	loopContext := sb.withoutOverridesAssignedUnder(node, context)
+
+	blockContext := loopContext.withContinuable(node)
 	varNode, hasVar := node.TryGetNode(sourceshape.NodeStatementNamedValue)
 	if hasVar {
 		blockContext = blockContext.withLocalNamed(varNode, sb)
@@ -443,7 +544,7 @@ func (sb *scopeBuilder) scopeLoopStatement(node compilergraph.GraphNode, context
 	}
 
 	// Otherwise, scope the expression.
-	loopExprScope := sb.getScope(loopExprNode, context)
+	loopExprScope := sb.getScope(loopExprNode, loopContext)
 	if !loopExprScope.GetIsValid() {
 		return newScope().
 			Invalid().
@@ -487,13 +588,27 @@ func (sb *scopeBuilder) inferTypesForConditionalExpressionContext(baseContext sc
 	conditionalExprNode compilergraph.GraphNode,
 	option inferrenceOption) scopeContext {
 
+	namedNode, inferredType, hasInferredType := sb.inferTypeForConditionalExpression(baseContext, conditionalExprNode, option)
+	if !hasInferredType {
+		return baseContext
+	}
+
+	return baseContext.withTypeOverride(namedNode, inferredType)
+}
+
+// inferTypeForConditionalExpression returns the named node whose type is clarified by the given
+// conditional expression (if any), along with the clarified type under the given branch option.
+func (sb *scopeBuilder) inferTypeForConditionalExpression(baseContext scopeContext,
+	conditionalExprNode compilergraph.GraphNode,
+	option inferrenceOption) (compilergraph.GraphNode, typegraph.TypeReference, bool) {
+
 	// Make sure the conditional expression is valid.
 	conditionalExprScope := sb.getScope(conditionalExprNode, baseContext)
 	if !conditionalExprScope.GetIsValid() {
-		return baseContext
+		return compilergraph.GraphNode{}, typegraph.TypeReference{}, false
 	}
 
-	checkIsExpression := func(isExpressionNode compilergraph.GraphNode, setToNull bool) scopeContext {
+	checkIsExpression := func(isExpressionNode compilergraph.GraphNode, setToNull bool) (compilergraph.GraphNode, typegraph.TypeReference, bool) {
 		// Invert the null-set if requested.
 		if option == inferredInverted {
 			setToNull = !setToNull
@@ -502,43 +617,43 @@ func (sb *scopeBuilder) inferTypesForConditionalExpressionContext(baseContext sc
 		// Ensure the left expression of the `is` has valid scope.
 		leftExpr, hasLeftExpr := isExpressionNode.TryGetNode(sourceshape.NodeBinaryExpressionLeftExpr)
 		if !hasLeftExpr {
-			return baseContext
+			return compilergraph.GraphNode{}, typegraph.TypeReference{}, false
 		}
 
 		leftScope := sb.getScope(leftExpr, baseContext)
 		if !leftScope.GetIsValid() {
-			return baseContext
+			return compilergraph.GraphNode{}, typegraph.TypeReference{}, false
 		}
 
 		// Ensure that the left expression refers to a named scope.
 		leftNamed, isNamed := sb.getNamedScopeForScope(leftScope)
 		if !isNamed {
-			return baseContext
+			return compilergraph.GraphNode{}, typegraph.TypeReference{}, false
 		}
 
 		// Ensure that the left expression does not have a void type. We know it is valid
 		// due to the check above.
 		valueType, _ := leftNamed.ValueType(baseContext)
 		if valueType.IsVoid() {
-			return baseContext
+			return compilergraph.GraphNode{}, typegraph.TypeReference{}, false
 		}
 
 		// Lookup the right expression. If it is itself a `not`, then we invert the set to null.
 		rightExpr, hasRightExpr := isExpressionNode.TryGetNode(sourceshape.NodeBinaryExpressionRightExpr)
 		if !hasRightExpr {
-			return baseContext
+			return compilergraph.GraphNode{}, typegraph.TypeReference{}, false
 		}
 
 		if rightExpr.Kind() == sourceshape.NodeKeywordNotExpression {
 			setToNull = !setToNull
 		}
 
-		// Add an override for the named node.
+		// Return the override for the named node.
 		leftNamedNode, _ := leftScope.NamedReferenceNode(sb.sg.srg, sb.sg.tdg)
 		if setToNull {
-			return baseContext.withTypeOverride(leftNamedNode, sb.sg.tdg.NullTypeReference())
+			return leftNamedNode, sb.sg.tdg.NullTypeReference(), true
 		} else {
-			return baseContext.withTypeOverride(leftNamedNode, leftScope.ResolvedTypeRef(sb.sg.tdg).AsNonNullable())
+			return leftNamedNode, leftScope.ResolvedTypeRef(sb.sg.tdg).AsNonNullable(), true
 		}
 	}
 
@@ -554,7 +669,7 @@ func (sb *scopeBuilder) inferTypesForConditionalExpressionContext(baseContext sc
 		// If the ! is in front of an `is` expression, then invert it.
 		childExpr, hasChildExpr := conditionalExprNode.TryGetNode(sourceshape.NodeUnaryExpressionChildExpr)
 		if !hasChildExpr {
-			return baseContext
+			return compilergraph.GraphNode{}, typegraph.TypeReference{}, false
 		}
 
 		if childExpr.Kind() == sourceshape.NodeIsComparisonExpression {
@@ -562,7 +677,7 @@ func (sb *scopeBuilder) inferTypesForConditionalExpressionContext(baseContext sc
 		}
 	}
 
-	return baseContext
+	return compilergraph.GraphNode{}, typegraph.TypeReference{}, false
 }
 
 // scopeConditionalStatement scopes a conditional statement in the SRG.
@@ -848,6 +963,10 @@ func (sb *scopeBuilder) scopeStatementBlock(node compilergraph.GraphNode, contex
 			}
 		}
 
+		// Update the context with the effects of the statement on the assignment and nullability
+		// of named values.
+		currentContext = sb.contextAfterStatement(sit.Node(), currentContext)
+
 		returnedType = returnedType.Intersect(statementScope.ReturnedTypeRef(sb.sg.tdg))
 		isSettlingScope = isSettlingScope || statementScope.GetIsSettlingScope()
 
@@ -995,9 +1114,19 @@ func (sb *scopeBuilder) scopeResolveStatement(node compilergraph.GraphNode, cont
 
 	isValid := sourceScope.GetIsValid() && destinationScope.GetIsValid()
 
-	if rejection, ok := node.TryGetNode(sourceshape.NodeAssignedRejection); ok {
-		rejectionScope := sb.getScope(rejection, context)
-		isValid = isValid && rejectionScope.GetIsValid()
+	// If more than two values are assigned, the source must be a tuple to be destructured.
+	names := resolveStatementNames(node)
+	if len(names) > 2 && sourceScope.GetIsValid() {
+		sourceType := sourceScope.ResolvedTypeRef(sb.sg.tdg)
+		if !destructuresTuple(node, sourceType) {
+			sb.decorateWithError(node, "Resolve statement assigning %v values requires a non-nullable tuple with %v elements. Found: '%v'", len(names), len(names), sourceType)
+			return newScope().Invalid().GetScope()
+		}
+	}
+
+	for _, name := range names[1:] {
+		nameScope := sb.getScope(name, context)
+		isValid = isValid && nameScope.GetIsValid()
 	}
 
 	if isValid && !destinationScope.GetIsAnonymousReference() {
@@ -20,6 +20,7 @@ type requiresInitializerOption int
 const (
 	requiresInitializer requiresInitializerOption = iota
 	noRequiredInitializer
+	requiresAssignment
 )
 
 // scopeField scopes a field member in the SRG.
@@ -34,7 +35,7 @@ func (sb *scopeBuilder) scopeVariable(node compilergraph.GraphNode, context scop
 
 // scopeVariableStatement scopes a variable statement in the SRG.
 func (sb *scopeBuilder) scopeVariableStatement(node compilergraph.GraphNode, context scopeContext) proto.ScopeInfo {
-	return sb.scopeDeclaredValue(node, "Variable", requiresInitializer, compilergraph.Predicate(sourceshape.NodeVariableStatementExpression), context)
+	return sb.scopeDeclaredValue(node, "Variable", requiresAssignment, compilergraph.Predicate(sourceshape.NodeVariableStatementExpression), context)
 }
 
 // getDeclaredVariableType returns the declared type of a variable statement, member or type field (if any).
@@ -96,6 +97,14 @@ func (sb *scopeBuilder) scopeDeclaredValue(node compilergraph.GraphNode, title s
 			sb.decorateWithError(node, "%s '%s' must have explicit initializer as its type '%v' is non-nullable", title, varName, declaredType)
 			return newScope().Invalid().Assignable(declaredType).GetScope()
 		}
+	} else if option == requiresAssignment {
+		// Make sure if the type is non-nullable that there is an expression or the variable is
+		// assigned under its parent block. Reads before assignment are checked by flow analysis.
+		parentBlock, hasParentBlock := node.TryGetIncomingNode(sourceshape.NodeStatementBlockStatement)
+		if !declaredType.IsNullable() && (!hasParentBlock || !sb.isAssignedUnder(node, parentBlock)) {
+			sb.decorateWithError(node, "%s '%s' must have explicit initializer or be assigned as its type '%v' is non-nullable", title, varName, declaredType)
+			return newScope().Invalid().Assignable(declaredType).GetScope()
+		}
 	}
 
 	return newScope().Valid().Assignable(declaredType).GetScope()
@@ -68,6 +68,15 @@ type scopeContext struct {
 	// scope. Note that as this is a cache, it is not *guarenteed* to have all local names. It merely
 	// makes looking up of local names that we know to add, much faster.
 	localScopeNamesCache compilerutil.ImmutableMap
+
+	// flowNarrowed is (if not nil) the "set" of named nodes whose type override was introduced by
+	// flow analysis across statements (for example, following an early return), rather than directly
+	// under the branch of a conditional.
+	flowNarrowed *map[compilergraph.GraphNodeId]bool
+
+	// unassignedValues is (if not nil) the "set" of variable statements declared without an initializer
+	// that have not yet been definitely assigned under this context.
+	unassignedValues *map[compilergraph.GraphNodeId]bool
 }
 
 // lookupLocalScopeName checks the local name *cache* for the given name returning it if found.
@@ -118,6 +127,8 @@ func (sc scopeContext) withLocalNamed(node compilergraph.GraphNode, builder *sco
 		parentContinuable:       sc.parentContinuable,
 		parentBreakable:         sc.parentBreakable,
 		rootLabelSet:            sc.rootLabelSet,
+		flowNarrowed:            sc.flowNarrowed,
+		unassignedValues:        sc.unassignedValues,
 
 		localScopeNamesCache: localScopeNamesCache.Set(name, scope),
 	}
@@ -182,6 +193,8 @@ func (sc scopeContext) withContinuable(node compilergraph.GraphNode) scopeContex
 		parentImplemented:       sc.parentImplemented,
 
 		rootLabelSet:         sc.rootLabelSet,
+		flowNarrowed:         sc.flowNarrowed,
+		unassignedValues:     sc.unassignedValues,
 		localScopeNamesCache: sc.localScopeNamesCache,
 
 		parentBreakable:   &node,
@@ -204,6 +217,8 @@ func (sc scopeContext) withBreakable(node compilergraph.GraphNode) scopeContext
 		parentContinuable:       sc.parentContinuable,
 
 		rootLabelSet:         sc.rootLabelSet,
+		flowNarrowed:         sc.flowNarrowed,
+		unassignedValues:     sc.unassignedValues,
 		localScopeNamesCache: sc.localScopeNamesCache,
 
 		parentBreakable: &node,
@@ -225,6 +240,8 @@ func (sc scopeContext) withImplemented(node compilergraph.GraphNode) scopeContex
 		parentContinuable:       sc.parentContinuable,
 
 		rootLabelSet:         sc.rootLabelSet,
+		flowNarrowed:         sc.flowNarrowed,
+		unassignedValues:     sc.unassignedValues,
 		localScopeNamesCache: sc.localScopeNamesCache,
 
 		parentImplemented: node,
@@ -245,6 +262,8 @@ func (sc scopeContext) withAccess(access scopeAccessOption) scopeContext {
 		parentContinuable:       sc.parentContinuable,
 
 		rootLabelSet:         sc.rootLabelSet,
+		flowNarrowed:         sc.flowNarrowed,
+		unassignedValues:     sc.unassignedValues,
 		localScopeNamesCache: sc.localScopeNamesCache,
 
 		accessOption: access,
@@ -295,6 +314,8 @@ func (sc scopeContext) withAllowedAgentConstruction(agentType typegraph.TypeRefe
 		parentContinuable: sc.parentContinuable,
 
 		rootLabelSet:         sc.rootLabelSet,
+		flowNarrowed:         sc.flowNarrowed,
+		unassignedValues:     sc.unassignedValues,
 		localScopeNamesCache: sc.localScopeNamesCache,
 
 		allowAgentConstructions: &allowAgentConstructions,
@@ -327,8 +348,179 @@ func (sc scopeContext) withTypeOverride(exprNode compilergraph.GraphNode, typere
 		parentContinuable:       sc.parentContinuable,
 
 		rootLabelSet:         sc.rootLabelSet,
+		flowNarrowed:         sc.flowNarrowed,
+		unassignedValues:     sc.unassignedValues,
 		localScopeNamesCache: sc.localScopeNamesCache,
 
 		overrideTypes: &overrideTypes,
 	}
 }
+
+// withFlowTypeOverride returns the scope context with the type of the given named node overridden
+// by flow analysis.
+func (sc scopeContext) withFlowTypeOverride(namedNode compilergraph.GraphNode, typeref typegraph.TypeReference) scopeContext {
+	overridden := sc.withTypeOverride(namedNode, typeref)
+
+	flowNarrowed := copyNodeSet(sc.flowNarrowed)
+	flowNarrowed[namedNode.NodeId] = true
+
+	return scopeContext{
+		rootNode:                   overridden.rootNode,
+		staticDependencyCollector:  overridden.staticDependencyCollector,
+		dynamicDependencyCollector: overridden.dynamicDependencyCollector,
+
+		accessOption:            overridden.accessOption,
+		overrideTypes:           overridden.overrideTypes,
+		allowAgentConstructions: overridden.allowAgentConstructions,
+		parentImplemented:       overridden.parentImplemented,
+		parentBreakable:         overridden.parentBreakable,
+		parentContinuable:       overridden.parentContinuable,
+
+		rootLabelSet:         overridden.rootLabelSet,
+		localScopeNamesCache: overridden.localScopeNamesCache,
+		unassignedValues:     overridden.unassignedValues,
+
+		flowNarrowed: &flowNarrowed,
+	}
+}
+
+// withoutTypeOverride returns the scope context with any type override of the given named node
+// removed.
+func (sc scopeContext) withoutTypeOverride(namedNode compilergraph.GraphNode) scopeContext {
+	if _, hasOverride := sc.getTypeOverride(namedNode); !hasOverride {
+		return sc
+	}
+
+	overrideTypes := map[compilergraph.GraphNodeId]typegraph.TypeReference{}
+	for key, value := range *sc.overrideTypes {
+		if key != namedNode.NodeId {
+			overrideTypes[key] = value
+		}
+	}
+
+	flowNarrowed := copyNodeSet(sc.flowNarrowed)
+	delete(flowNarrowed, namedNode.NodeId)
+
+	return scopeContext{
+		rootNode:                   sc.rootNode,
+		staticDependencyCollector:  sc.staticDependencyCollector,
+		dynamicDependencyCollector: sc.dynamicDependencyCollector,
+
+		accessOption:            sc.accessOption,
+		allowAgentConstructions: sc.allowAgentConstructions,
+		parentImplemented:       sc.parentImplemented,
+		parentBreakable:         sc.parentBreakable,
+		parentContinuable:       sc.parentContinuable,
+
+		rootLabelSet:         sc.rootLabelSet,
+		localScopeNamesCache: sc.localScopeNamesCache,
+		unassignedValues:     sc.unassignedValues,
+
+		overrideTypes: &overrideTypes,
+		flowNarrowed:  &flowNarrowed,
+	}
+}
+
+// isFlowNarrowed returns whether the type of the given named node was overridden by flow analysis.
+func (sc scopeContext) isFlowNarrowed(namedNode compilergraph.GraphNode) bool {
+	if sc.flowNarrowed == nil {
+		return false
+	}
+
+	flowNarrowed := *sc.flowNarrowed
+	_, found := flowNarrowed[namedNode.NodeId]
+	return found
+}
+
+// withUnassigned returns the scope context with the given variable statement marked as not yet
+// having been assigned a value.
+func (sc scopeContext) withUnassigned(varNode compilergraph.GraphNode) scopeContext {
+	unassignedValues := copyNodeSet(sc.unassignedValues)
+	unassignedValues[varNode.NodeId] = true
+	return sc.withUnassignedValues(unassignedValues)
+}
+
+// withAssigned returns the scope context with the given variable statement marked as having been
+// definitely assigned a value.
+func (sc scopeContext) withAssigned(varNode compilergraph.GraphNode) scopeContext {
+	if !sc.isUnassigned(varNode) {
+		return sc
+	}
+
+	unassignedValues := copyNodeSet(sc.unassignedValues)
+	delete(unassignedValues, varNode.NodeId)
+	return sc.withUnassignedValues(unassignedValues)
+}
+
+// withUnassignedValues returns the scope context with the set of unassigned variable statements
+// replaced by that given.
+func (sc scopeContext) withUnassignedValues(unassignedValues map[compilergraph.GraphNodeId]bool) scopeContext {
+	return scopeContext{
+		rootNode:                   sc.rootNode,
+		staticDependencyCollector:  sc.staticDependencyCollector,
+		dynamicDependencyCollector: sc.dynamicDependencyCollector,
+
+		accessOption:            sc.accessOption,
+		overrideTypes:           sc.overrideTypes,
+		allowAgentConstructions: sc.allowAgentConstructions,
+		parentImplemented:       sc.parentImplemented,
+		parentBreakable:         sc.parentBreakable,
+		parentContinuable:       sc.parentContinuable,
+
+		rootLabelSet:         sc.rootLabelSet,
+		localScopeNamesCache: sc.localScopeNamesCache,
+		flowNarrowed:         sc.flowNarrowed,
+
+		unassignedValues: &unassignedValues,
+	}
+}
+
+// isUnassigned returns whether the given variable statement has not yet been definitely assigned
+// a value under this context.
+func (sc scopeContext) isUnassigned(varNode compilergraph.GraphNode) bool {
+	if sc.unassignedValues == nil {
+		return false
+	}
+
+	unassignedValues := *sc.unassignedValues
+	_, found := unassignedValues[varNode.NodeId]
+	return found
+}
+
+// unassignedIds returns the IDs of the variable statements not yet definitely assigned under this
+// context.
+func (sc scopeContext) unassignedIds() []compilergraph.GraphNodeId {
+	if sc.unassignedValues == nil {
+		return []compilergraph.GraphNodeId{}
+	}
+
+	ids := make([]compilergraph.GraphNodeId, 0, len(*sc.unassignedValues))
+	for id := range *sc.unassignedValues {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// overriddenIds returns the IDs of the named nodes whose types are overridden under this context.
+func (sc scopeContext) overriddenIds() []compilergraph.GraphNodeId {
+	if sc.overrideTypes == nil {
+		return []compilergraph.GraphNodeId{}
+	}
+
+	ids := make([]compilergraph.GraphNodeId, 0, len(*sc.overrideTypes))
+	for id := range *sc.overrideTypes {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// copyNodeSet returns a copy of the given node set, or an empty set if nil.
+func copyNodeSet(set *map[compilergraph.GraphNodeId]bool) map[compilergraph.GraphNodeId]bool {
+	copied := map[compilergraph.GraphNodeId]bool{}
+	if set != nil {
+		for key, value := range *set {
+			copied[key] = value
+		}
+	}
+	return copied
+}
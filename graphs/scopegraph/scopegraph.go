@@ -23,7 +23,10 @@ import (
 	"github.com/serulian/compiler/graphs/typegraph"
 	"github.com/serulian/compiler/integration"
 	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/typescript"
 	"github.com/serulian/compiler/webidl"
+
+	cmap "github.com/streamrail/concurrent-map"
 )
 
 // PromisingAccessType defines an enumeration of access types for the IsPromisingMember check.
@@ -59,6 +62,9 @@ type ScopeGraph struct {
 	srgRefResolver        *typerefresolver.TypeReferenceResolver // The resolver to use for SRG type refs.
 	dynamicPromisingNames map[string]bool
 
+	requireExhaustiveCases bool               // Whether non-exhaustive switch and match statements are errors.
+	implementingTypes      cmap.ConcurrentMap // The types implementing each interface matched over, by interface type reference.
+
 	layer compilergraph.GraphLayer // The ScopeGraph layer in the graph.
 }
 
@@ -130,9 +136,10 @@ func ParseAndBuildScopeGraphWithConfig(config Config) (Result, error) {
 	// Create the SRG for the source and load it.
 	sourcegraph := srg.NewSRG(graph)
 
-	// Create the IRG and register it as an integration.
+	// Create the IRG and TypeScript graph and register them as integrations.
 	webidl := webidl.WebIDLProvider(graph)
-	langIntegrations := []integration.LanguageIntegration{webidl}
+	typescript := typescript.TypeScriptProvider(graph)
+	langIntegrations := []integration.LanguageIntegration{webidl, typescript}
 
 	// Load the dynamic integrations.
 	if config.LanguageIntegrations != nil {
@@ -187,7 +194,7 @@ func ParseAndBuildScopeGraphWithConfig(config Config) (Result, error) {
 	// Construct the type graph.
 	resolver := typerefresolver.NewResolver(sourcegraph)
 	srgConstructor := srgtc.GetConstructorWithResolver(sourcegraph, resolver)
-	typeResult, err := typegraph.BuildTypeGraphWithOption(sourcegraph.Graph, typegraph.FullBuild, cancelationHandle, webidl.TypeConstructor(), srgConstructor)
+	typeResult, err := typegraph.BuildTypeGraphWithOption(sourcegraph.Graph, typegraph.FullBuild, cancelationHandle, webidl.TypeConstructor(), typescript.TypeConstructor(), srgConstructor)
 	if err != nil {
 		return Result{}, err
 	}
@@ -204,7 +211,7 @@ func ParseAndBuildScopeGraphWithConfig(config Config) (Result, error) {
 	resolver.FreezeCache()
 
 	// Construct the scope graph.
-	scopeResult := performConstruction(config.Target, sourcegraph, typeResult.Graph, langIntegrations, resolver, loader, config.ScopeFilter, cancelationHandle)
+	scopeResult := performConstruction(config.Target, config.RequireExhaustiveCases, sourcegraph, typeResult.Graph, langIntegrations, resolver, loader, config.ScopeFilter, cancelationHandle)
 	return Result{
 		Status:               scopeResult.Status && typeResult.Status && loaderResult.Status && !cancelationHandle.WasCanceled(),
 		Errors:               combineErrors(loaderResult.Errors, typeResult.Errors, scopeResult.Errors),
@@ -251,6 +251,16 @@ var scopeGraphTests = []scopegraphTest{
 	scopegraphTest{"invalid with expr test", "with", "nonreleasable", []expectedScopeEntry{},
 		"With expression must implement the Releasable interface: Type 'Boolean' does not define or export member 'Release', which is required by type 'Releasable'", ""},
 
+	/////////// Defer ///////////
+
+	scopegraphTest{"basic defer test", "defer", "basic", []expectedScopeEntry{
+		expectedScopeEntry{"defer", expectedScope{true, proto.ScopeKind_VALUE, "void", "void"}},
+		expectedScopeEntry{"deferarg", expectedScope{true, proto.ScopeKind_VALUE, "void", "void"}},
+	}, "", ""},
+
+	scopegraphTest{"defer non-call test", "defer", "notcall", []expectedScopeEntry{},
+		"Deferred expression must be a function call", ""},
+
 	/////////// Match ///////////
 
 	scopegraphTest{"basic match switch test", "match", "success",
@@ -270,6 +280,57 @@ var scopeGraphTests = []scopegraphTest{
 		[]expectedScopeEntry{},
 		"Match cases cannot be nullable. Found: String?", ""},
 
+	/////////// Match patterns ///////////
+
+	scopegraphTest{"match patterns test", "patterns", "success",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"guard", expectedScope{true, proto.ScopeKind_VALUE, "Boolean", "void"}},
+			expectedScopeEntry{"y", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"x", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"end", expectedScope{true, proto.ScopeKind_VALUE, "Point?", "void"}},
+			expectedScopeEntry{"point", expectedScope{true, proto.ScopeKind_VALUE, "Point", "void"}},
+			expectedScopeEntry{"hello", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"intguard", expectedScope{true, proto.ScopeKind_VALUE, "Boolean", "void"}},
+			expectedScopeEntry{"int", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"match pattern complete test", "patterns", "complete",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"sum", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"match pattern missing case test", "patterns", "missing", []expectedScopeEntry{},
+		"", "Match over type 'Point' does not handle: Point"},
+
+	scopegraphTest{"match pattern nullable complete test", "patterns", "nullablecomplete",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"sum", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"match pattern nullable missing case test", "patterns", "nullablemissing", []expectedScopeEntry{},
+		"", "Match over type 'Point?' does not handle: null"},
+
+	scopegraphTest{"match pattern subsumed case test", "patterns", "subsumed", []expectedScopeEntry{},
+		"", "Unreachable match case: type 'Point' is handled by the earlier case for 'Point'"},
+
+	scopegraphTest{"match pattern literal mismatch test", "patterns", "literalmismatch", []expectedScopeEntry{},
+		"Pattern of type 'String' can never match a value of type 'Integer': 'String' cannot be used in place of non-interface 'Integer'", ""},
+
+	scopegraphTest{"match pattern null mismatch test", "patterns", "nullmismatch", []expectedScopeEntry{},
+		"Null pattern can never match a value of non-nullable type 'Integer'", ""},
+
+	scopegraphTest{"match pattern non-struct test", "patterns", "nonstruct", []expectedScopeEntry{},
+		"Struct patterns can only match struct types. Found: SomeClass", ""},
+
+	scopegraphTest{"match pattern duplicate field test", "patterns", "duplicatefield", []expectedScopeEntry{},
+		"Field 'X' is matched more than once in this pattern", ""},
+
+	scopegraphTest{"match pattern non-bool guard test", "patterns", "guardmismatch", []expectedScopeEntry{},
+		"Match case guard must be of type 'bool', found: Integer", ""},
+
 	/////////// Switch ///////////
 
 	scopegraphTest{"basic bool switch test", "switch", "bool",
@@ -317,6 +378,86 @@ var scopeGraphTests = []scopegraphTest{
 	scopegraphTest{"switch no equals operator test", "switch", "nocompare", []expectedScopeEntry{},
 		"Cannot switch over instance of type 'SomeClass', as it does not define or export an 'equals' operator", ""},
 
+	/////////// Exhaustiveness ///////////
+
+	scopegraphTest{"switch enum complete test", "exhaustive", "enumcomplete",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"switch", expectedScope{true, proto.ScopeKind_VALUE, "void", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"switch enum missing case test", "exhaustive", "enummissing", []expectedScopeEntry{},
+		"", "Switch over type 'Color' does not handle: Color.Blue"},
+
+	scopegraphTest{"switch nullable enum missing case test", "exhaustive", "nullableenum", []expectedScopeEntry{},
+		"", "Switch over type 'Color?' does not handle: null"},
+
+	scopegraphTest{"switch bool missing case test", "exhaustive", "boolmissing", []expectedScopeEntry{},
+		"", "Switch over type 'Boolean' does not handle: false"},
+
+	scopegraphTest{"switch duplicate case test", "exhaustive", "duplicate", []expectedScopeEntry{},
+		"", "Unreachable switch case: its value is handled by an earlier case"},
+
+	scopegraphTest{"match subsumed case test", "exhaustive", "matchsubsumed", []expectedScopeEntry{},
+		"", "Unreachable match case: type 'Integer' is handled by the earlier case for 'Integer'"},
+
+	/////////// Flow ///////////
+
+	scopegraphTest{"flow early return test", "flow", "earlyreturn",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"afterreturn", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"underbranch", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"afterelse", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"flow reject test", "flow", "reject",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"afterreject", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"flow assignment test", "flow", "assign",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"afterclear", expectedScope{true, proto.ScopeKind_VALUE, "Integer?", "void"}},
+			expectedScopeEntry{"afterassign", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"flow loop test", "flow", "loop",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"inloop", expectedScope{true, proto.ScopeKind_VALUE, "Integer?", "void"}},
+			expectedScopeEntry{"afterloop", expectedScope{true, proto.ScopeKind_VALUE, "Integer?", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"flow loop break and continue test", "flow", "loopbreak",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"aftercontinue", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"afterbreak", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"flow redundant assert test", "flow", "redundantassert",
+		[]expectedScopeEntry{},
+		"", "Redundant assert not nullable operator: 'a' cannot be null here"},
+
+	scopegraphTest{"flow definite assignment test", "flow", "definiteassign",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"afterboth", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"afterexit", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"afterswitch", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"flow use before assignment test", "flow", "usebeforeassign",
+		[]expectedScopeEntry{},
+		"Variable 'a' is used before being assigned", ""},
+
+	scopegraphTest{"flow never assigned test", "flow", "neverassigned",
+		[]expectedScopeEntry{},
+		"Variable 'a' must have explicit initializer or be assigned as its type 'Integer' is non-nullable", ""},
+
 	/////////// Var ///////////
 
 	scopegraphTest{"basic var test", "var", "basic",
@@ -1309,6 +1450,122 @@ var scopeGraphTests = []scopegraphTest{
 		[]expectedScopeEntry{},
 		"Cannot use type SomeClass as generic T (#1) over struct SomeStruct: SomeClass is not structural nor serializable", ""},
 
+	/////////// generic inference ///////////
+
+	scopegraphTest{"generic inference success test", "genericinference", "success",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"pickint", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"pickstring", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"picknullable", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"describe", expectedScope{true, proto.ScopeKind_VALUE, "SomeClass", "void"}},
+			expectedScopeEntry{"first", expectedScope{true, proto.ScopeKind_VALUE, "Boolean?", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"generic inference ambiguous test", "genericinference", "ambiguous",
+		[]expectedScopeEntry{},
+		"Could not infer generics of module member Pick: Ambiguous inference for generic T: no common type found between Integer, String; please specify the generics explicitly", ""},
+
+	scopegraphTest{"generic inference no argument test", "genericinference", "noargument",
+		[]expectedScopeEntry{},
+		"Could not infer generics of module member Create: No argument clarifies generic T; please specify the generics explicitly", ""},
+
+	scopegraphTest{"generic inference constraint failure test", "genericinference", "constraintfail",
+		[]expectedScopeEntry{},
+		"Could not infer generics of module member Describe: Inferred type Boolean for generic T does not meet its constraint: Type 'Boolean' does not define or export member 'DoSomething', which is required by type 'ISomeInterface'", ""},
+
+	/////////// default parameters and named arguments ///////////
+
+	scopegraphTest{"default parameters success test", "defaultparams", "success",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"positional", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"allgiven", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"named", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"reordered", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"constructor", expectedScope{true, proto.ScopeKind_VALUE, "SomeClass", "void"}},
+			expectedScopeEntry{"method", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"inferred", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"default parameter bad default value test", "defaultparams", "baddefault",
+		[]expectedScopeEntry{},
+		"Parameter 'port' has declared type 'Integer': 'String' cannot be used in place of non-interface 'Integer'", ""},
+
+	scopegraphTest{"named argument unknown name test", "defaultparams", "unknownname",
+		[]expectedScopeEntry{},
+		"Function call on module member Configure has no parameter named 'timeout'", ""},
+
+	scopegraphTest{"named argument duplicate test", "defaultparams", "duplicate",
+		[]expectedScopeEntry{},
+		"Function call on module member Configure gives more than one argument for parameter 'host'", ""},
+
+	scopegraphTest{"named argument positional after named test", "defaultparams", "positionalafter",
+		[]expectedScopeEntry{},
+		"Function call on module member Configure cannot have positional arguments following named arguments", ""},
+
+	scopegraphTest{"named argument missing parameter test", "defaultparams", "missing",
+		[]expectedScopeEntry{},
+		"Function call on module member Configure is missing an argument for parameter 'host'", ""},
+
+	scopegraphTest{"default parameter missing positional test", "defaultparams", "missingpositional",
+		[]expectedScopeEntry{},
+		"Function call on module member Configure expects 2 non-optional arguments, found 1", ""},
+
+	scopegraphTest{"named argument non-member test", "defaultparams", "nonmember",
+		[]expectedScopeEntry{},
+		"Function call on parameter callback cannot use named arguments, as the function invoked is not a member", ""},
+
+	scopegraphTest{"named argument bad type test", "defaultparams", "badtype",
+		[]expectedScopeEntry{},
+		"Parameter #2 on module member Configure expects type Integer: 'String' cannot be used in place of non-interface 'Integer'", ""},
+
+	/////////// tuples ///////////
+
+	scopegraphTest{"tuples success test", "tuples", "success",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"literal", expectedScope{true, proto.ScopeKind_VALUE, "(Integer, String)", "void"}},
+			expectedScopeEntry{"sum", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"difference", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"name", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"flag", expectedScope{true, proto.ScopeKind_VALUE, "Boolean", "void"}},
+			expectedScopeEntry{"count", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"second", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"value", expectedScope{true, proto.ScopeKind_VALUE, "(Integer, String)?", "void"}},
+			expectedScopeEntry{"err", expectedScope{true, proto.ScopeKind_VALUE, "Error?", "void"}},
+			expectedScopeEntry{"pair", expectedScope{true, proto.ScopeKind_VALUE, "(Integer, String?)", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"tuples mismatched length test", "tuples", "mismatch",
+		[]expectedScopeEntry{},
+		"Resolve statement assigning 3 values requires a non-nullable tuple with 3 elements. Found: '(Integer, Integer)'", ""},
+
+	scopegraphTest{"tuples nullable destructure test", "tuples", "nullable",
+		[]expectedScopeEntry{},
+		"Resolve statement assigning 3 values requires a non-nullable tuple with 3 elements. Found: '(Integer, String, Boolean)?'", ""},
+
+	scopegraphTest{"tuples non-tuple destructure test", "tuples", "nontuple",
+		[]expectedScopeEntry{},
+		"Resolve statement assigning 3 values requires a non-nullable tuple with 3 elements. Found: 'String'", ""},
+
+	scopegraphTest{"tuples bad assignment test", "tuples", "badassign",
+		[]expectedScopeEntry{},
+		"Variable 'pair' has declared type '(Integer, String)': '(String, Integer)' cannot be used in place of '(Integer, String)': Element #1 does not match: 'String' cannot be used in place of non-interface 'Integer'", ""},
+
+	scopegraphTest{"tuples two value destructure test", "tuples", "twonames",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"sum", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"difference", expectedScope{true, proto.ScopeKind_VALUE, "Integer", "void"}},
+			expectedScopeEntry{"pair", expectedScope{true, proto.ScopeKind_VALUE, "(Integer, Integer)?", "void"}},
+			expectedScopeEntry{"err", expectedScope{true, proto.ScopeKind_VALUE, "Error?", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"tuples void element test", "tuples", "voidelement",
+		[]expectedScopeEntry{},
+		"Element #2 of tuple literal cannot be void", ""},
+
 	/////////// constructable types ///////////
 
 	scopegraphTest{"constructable interface test", "types", "constructableinterface",
@@ -1631,6 +1888,29 @@ var scopeGraphTests = []scopegraphTest{
 		[]expectedScopeEntry{},
 		"Cannot assign to non-assignable module member SomeString", ""},
 
+	scopegraphTest{"const var folded test", "constvar", "folded",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"message", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"islarge", expectedScope{true, proto.ScopeKind_VALUE, "Boolean", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"const var non-constant initializer test", "constvar", "nonconstant",
+		[]expectedScopeEntry{},
+		"Initializer of constant 'SomeInt' must be a constant expression", ""},
+
+	scopegraphTest{"const var division by zero test", "constvar", "divisionbyzero",
+		[]expectedScopeEntry{},
+		"Constant 'SomeInt' could not be evaluated: division by zero", ""},
+
+	scopegraphTest{"const var overflow test", "constvar", "overflow",
+		[]expectedScopeEntry{},
+		"Constant 'SomeInt' could not be evaluated: value overflows int", ""},
+
+	scopegraphTest{"const var invalid reference test", "constvar", "invalidreference",
+		[]expectedScopeEntry{},
+		"Constant 'SomeInt' could not be evaluated: division by zero", ""},
+
 	/////////// agent tests /////////////////
 
 	scopegraphTest{"agent constructor success test", "agent", "constructor",
@@ -1653,6 +1933,54 @@ var scopeGraphTests = []scopegraphTest{
 		[]expectedScopeEntry{},
 		"Cannot construct agent 'SomeAgent<Boolean>' outside its own constructor or without a composing type's context", ""},
 
+	/////////// enum tests /////////////////
+
+	scopegraphTest{"enum success test", "enum", "success",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"red", expectedScope{true, proto.ScopeKind_VALUE, "Color", "void"}},
+			expectedScopeEntry{"equal", expectedScope{true, proto.ScopeKind_VALUE, "Boolean", "void"}},
+			expectedScopeEntry{"values", expectedScope{true, proto.ScopeKind_VALUE, "Slice<Color>", "void"}},
+			expectedScopeEntry{"str", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"value", expectedScope{true, proto.ScopeKind_VALUE, "Color", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"enum structural construction test", "enum", "construct",
+		[]expectedScopeEntry{},
+		"Cannot structurally construct enum type Color: Only its declared values may be used", ""},
+
+	scopegraphTest{"enum assignment test", "enum", "assign",
+		[]expectedScopeEntry{},
+		"Variable 'c' has declared type 'Color': 'String' cannot be used in place of non-interface 'Color'", ""},
+
+	/////////// extension tests /////////////////
+
+	scopegraphTest{"extension success test", "extension", "success",
+		[]expectedScopeEntry{
+			expectedScopeEntry{"this", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"shout", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+			expectedScopeEntry{"isempty", expectedScope{true, proto.ScopeKind_VALUE, "Boolean", "void"}},
+			expectedScopeEntry{"first", expectedScope{true, proto.ScopeKind_VALUE, "Integer?", "void"}},
+			expectedScopeEntry{"whisper", expectedScope{true, proto.ScopeKind_VALUE, "String", "void"}},
+		},
+		"", ""},
+
+	scopegraphTest{"extension not imported test", "extension", "notimported",
+		[]expectedScopeEntry{},
+		"Could not find instance name 'Shout' under nominal type String", ""},
+
+	scopegraphTest{"extension ambiguous test", "extension", "ambiguous",
+		[]expectedScopeEntry{},
+		"Name 'Shout' under nominal type String is ambiguous: it is defined by both extension 'MoreStringHelpers' and extension 'StringHelpers'", ""},
+
+	scopegraphTest{"extension dynamic access test", "extension", "dynamic",
+		[]expectedScopeEntry{},
+		"Cannot attempt dynamic access of extension member 'Shout'. The . operator must be used.", ""},
+
+	scopegraphTest{"extension nullable access test", "extension", "nullable",
+		[]expectedScopeEntry{},
+		"Cannot attempt nullable access of extension member 'Shout'", ""},
+
 	/////////// known issue tests /////////////////
 
 	scopegraphTest{"known issue panic test", "knownissues", "knownissue1",
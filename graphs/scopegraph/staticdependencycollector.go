@@ -76,6 +76,12 @@ func (dc *workingStaticDependencyCollector) registerNamedDependency(nsi namedSco
 }
 
 func (dc *workingStaticDependencyCollector) registerDependency(member typegraph.TGMember) {
+	// Enum values are defined by the runtime when their type is created, and therefore
+	// never depend on initialization order.
+	if member.IsEnumValue() {
+		return
+	}
+
 	dc.dependencies[member.GraphNode.NodeId] = member
 }
 
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/serulian/compiler/compilercommon"
 	"github.com/serulian/compiler/compilergraph"
 	"github.com/serulian/compiler/sourceshape"
 )
@@ -114,9 +115,16 @@ func getCommentKind(value string) (srgCommentKind, bool) {
 
 // getTrimmedCommentContentsString returns trimmed contents of the given comment.
 func getTrimmedCommentContentsString(value string) string {
+	return strings.TrimSpace(strings.Join(getTrimmedCommentLines(value), "\n"))
+}
+
+// getTrimmedCommentLines returns the lines of the given comment, with the comment's prefix, suffix
+// and any multiline prefixes trimmed. The returned lines correspond one-to-one with the lines of the
+// comment.
+func getTrimmedCommentLines(value string) []string {
 	kind, isValid := getCommentKind(value)
 	if !isValid {
-		return ""
+		return []string{}
 	}
 
 	lines := strings.Split(value, "\n")
@@ -140,7 +148,54 @@ func getTrimmedCommentContentsString(value string) string {
 		newLines = append(newLines, updatedLine)
 	}
 
-	return strings.TrimSpace(strings.Join(newLines, "\n"))
+	return newLines
+}
+
+// exampleFences defines the opening fences of Serulian code examples in comments.
+var exampleFences = []string{"```serulian", "```seru"}
+
+// exampleClosingFence is the fence closing a code example in a comment.
+const exampleClosingFence = "```"
+
+// commentExample is a code example found in a comment.
+type commentExample struct {
+	code      string // The code of the example.
+	startLine int    // The 0-indexed line of the comment on which the example's code starts.
+	endLine   int    // The 0-indexed line of the comment on which the example's code ends.
+}
+
+// getCommentExamples returns the fenced Serulian code examples found in the given comment. Examples
+// missing their closing fence are ignored.
+func getCommentExamples(value string) []commentExample {
+	examples := make([]commentExample, 0)
+	lines := getTrimmedCommentLines(value)
+
+	var exampleStart = -1
+	for index, line := range lines {
+		if exampleStart < 0 {
+			for _, fence := range exampleFences {
+				if line == fence {
+					exampleStart = index + 1
+					break
+				}
+			}
+			continue
+		}
+
+		if line == exampleClosingFence {
+			if index > exampleStart {
+				examples = append(examples, commentExample{
+					code:      strings.Join(lines[exampleStart:index], "\n"),
+					startLine: exampleStart,
+					endLine:   index - 1,
+				})
+			}
+
+			exampleStart = -1
+		}
+	}
+
+	return examples
 }
 
 // SRGComment wraps a comment node with helpers for accessing documentation.
@@ -185,6 +240,72 @@ func (c SRGComment) Documentation() (SRGDocumentation, bool) {
 	}, true
 }
 
+// Examples returns the fenced Serulian code examples found in this comment, if any.
+func (c SRGComment) Examples() []SRGCodeExample {
+	value := c.GraphNode.Get(sourceshape.NodeCommentPredicateValue)
+	commentExamples := getCommentExamples(value)
+	if len(commentExamples) == 0 {
+		return []SRGCodeExample{}
+	}
+
+	commentRange, hasCommentRange := c.srg.SourceRangeOf(c.GraphNode)
+	if !hasCommentRange {
+		return []SRGCodeExample{}
+	}
+
+	commentLine, _, err := commentRange.Start().LineAndColumn()
+	if err != nil {
+		return []SRGCodeExample{}
+	}
+
+	examples := make([]SRGCodeExample, 0, len(commentExamples))
+	for _, example := range commentExamples {
+		exampleRange := commentRange.Source().RangeForLineAndColPositions(
+			compilercommon.Position{LineNumber: commentLine + example.startLine},
+			compilercommon.Position{LineNumber: commentLine + example.endLine},
+			c.srg.sourceTracker)
+
+		examples = append(examples, SRGCodeExample{example.code, exampleRange, c.srg.sourceTracker})
+	}
+
+	return examples
+}
+
+// SRGCodeExample represents a fenced Serulian code example found in a doc comment.
+type SRGCodeExample struct {
+	// Code is the code of the example.
+	Code string
+
+	// SourceRange is the range of the example's code in its source file.
+	SourceRange compilercommon.SourceRange
+
+	mapper compilercommon.PositionMapper // The position mapper for the example's source file.
+}
+
+// LineRange returns the range of the given 0-indexed line of the example's code in its source file.
+func (e SRGCodeExample) LineRange(line int) compilercommon.SourceRange {
+	startLine, _, err := e.SourceRange.Start().LineAndColumn()
+	if err != nil {
+		return e.SourceRange
+	}
+
+	position := compilercommon.Position{LineNumber: startLine + line}
+	return e.SourceRange.Source().RangeForLineAndColPositions(position, position, e.mapper)
+}
+
+// AllExamples returns all fenced Serulian code examples found in doc comments in the SRG.
+func (g *SRG) AllExamples() []SRGCodeExample {
+	examples := make([]SRGCodeExample, 0)
+	cit := g.AllComments()
+	for cit.Next() {
+		comment := SRGComment{cit.Node(), g}
+		if comment.IsDocComment() {
+			examples = append(examples, comment.Examples()...)
+		}
+	}
+	return examples
+}
+
 // SRGDocumentation represents documentation found on an SRG node. It is distinct from a comment in
 // that it can be *part* of another comment.
 type SRGDocumentation struct {
@@ -49,6 +49,27 @@ var parameterTests = []docParameterTest{
 		"If `someParam` is specified, things happen"},
 }
 
+type exampleTest struct {
+	commentValue     string
+	expectedExamples []commentExample
+}
+
+var exampleTests = []exampleTest{
+	exampleTest{"/** no examples here */", []commentExample{}},
+	exampleTest{"/**\n * Some doc.\n * ```serulian\n * DoSomething()\n * ```\n */",
+		[]commentExample{commentExample{"DoSomething()", 3, 3}},
+	},
+	exampleTest{"/**\n * ```seru\n * var a = 1\n *   a = 2\n * ```\n * And more:\n * ```serulian\n * DoSomething()\n * ```\n */",
+		[]commentExample{
+			commentExample{"var a = 1\na = 2", 2, 3},
+			commentExample{"DoSomething()", 7, 7},
+		},
+	},
+	exampleTest{"/**\n * ```\n * not serulian\n * ```\n */", []commentExample{}},
+	exampleTest{"/**\n * ```serulian\n * ```\n */", []commentExample{}},
+	exampleTest{"/**\n * ```serulian\n * DoSomething()\n */", []commentExample{}},
+}
+
 func TestComments(t *testing.T) {
 	kindsEncountered := map[srgCommentKind]bool{}
 
@@ -90,3 +111,10 @@ func TestDocParameters(t *testing.T) {
 		}
 	}
 }
+
+func TestCommentExamples(t *testing.T) {
+	for _, test := range exampleTests {
+		examples := getCommentExamples(test.commentValue)
+		assert.Equal(t, test.expectedExamples, examples, "Examples mismatch for comment: %s", test.commentValue)
+	}
+}
@@ -4,9 +4,9 @@ package srg
 
 import "fmt"
 
-const _MemberKind_name = "ConstructorMemberVarMemberFunctionMemberPropertyMemberOperatorMember"
+const _MemberKind_name = "ConstructorMemberVarMemberFunctionMemberPropertyMemberOperatorMemberEnumValueMember"
 
-var _MemberKind_index = [...]uint8{0, 17, 26, 40, 54, 68}
+var _MemberKind_index = [...]uint8{0, 17, 26, 40, 54, 68, 83}
 
 func (i MemberKind) String() string {
 	if i < 0 || i >= MemberKind(len(_MemberKind_index)-1) {
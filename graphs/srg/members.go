@@ -44,6 +44,7 @@ const (
 	FunctionMember
 	PropertyMember
 	OperatorMember
+	EnumValueMember
 )
 
 // GetMemberReference returns an SRGMember wrapper around the given SRG member node. Panics
@@ -111,6 +112,9 @@ func (m SRGMember) MemberKind() MemberKind {
 	case sourceshape.NodeTypeVariable:
 		return VarMember
 
+	case sourceshape.NodeTypeEnumValue:
+		return EnumValueMember
+
 	default:
 		panic(fmt.Sprintf("Unknown kind of member %s", m.GraphNode.Kind()))
 	}
@@ -221,7 +225,8 @@ func (m SRGMember) IsStatic() bool {
 		return true
 	}
 
-	return m.MemberKind() == OperatorMember || m.MemberKind() == ConstructorMember
+	kind := m.MemberKind()
+	return kind == OperatorMember || kind == ConstructorMember || kind == EnumValueMember
 }
 
 // IsExported returns whether the given member is exported for use outside its package.
@@ -247,6 +252,9 @@ func (m SRGMember) HasImplementation() bool {
 	case VarMember:
 		return false
 
+	case EnumValueMember:
+		return false
+
 	case PropertyMember:
 		getter, hasGetter := m.Getter()
 		if !hasGetter {
@@ -392,6 +400,16 @@ func (m SRGMember) Code() (compilercommon.CodeSummary, bool) {
 			buffer.WriteString(declaredType.String())
 		}
 
+	case sourceshape.NodeTypeEnumValue:
+		containingType, hasContainingType := m.ContainingType()
+		if hasContainingType {
+			typeName, _ := containingType.Name()
+			buffer.WriteString(typeName)
+			buffer.WriteString(".")
+		}
+
+		buffer.WriteString(name)
+
 	default:
 		panic(fmt.Sprintf("Unknown kind of member %s", m.GraphNode.Kind()))
 	}
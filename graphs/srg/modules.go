@@ -119,6 +119,28 @@ func (m SRGModule) GetImports() []SRGImport {
 	return imports
 }
 
+// ImportedModulePaths returns the paths of all the modules found under the SRG packages imported
+// by this module.
+func (m SRGModule) ImportedModulePaths() []compilercommon.InputSource {
+	pit := m.GraphNode.StartQuery().
+		Out(sourceshape.NodePredicateChild).
+		IsKind(sourceshape.NodeTypeImport).
+		Out(sourceshape.NodeImportPredicatePackageRef).
+		BuildNodeIterator()
+
+	var modulePaths = make([]compilercommon.InputSource, 0)
+	for pit.Next() {
+		packageInfo, err := m.srg.getPackageForImport(pit.Node())
+		if err != nil || !packageInfo.IsSRGPackage() {
+			continue
+		}
+
+		modulePaths = append(modulePaths, packageInfo.ModulePaths()...)
+	}
+
+	return modulePaths
+}
+
 // FindTypeOrMemberByName searches for the type definition, declaration or module member with the given
 // name under this module and returns it (if found). Note that this method does not handle imports.
 func (m SRGModule) FindTypeOrMemberByName(name string, option ModuleResolutionOption) (SRGTypeOrMember, bool) {
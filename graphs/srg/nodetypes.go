@@ -15,6 +15,8 @@ var TYPE_KINDS = []sourceshape.NodeType{
 	sourceshape.NodeTypeNominal,
 	sourceshape.NodeTypeStruct,
 	sourceshape.NodeTypeAgent,
+	sourceshape.NodeTypeEnum,
+	sourceshape.NodeTypeExtension,
 }
 
 var TYPE_MEMBER_KINDS = []sourceshape.NodeType{
@@ -33,6 +35,8 @@ var TYPE_KINDS_TAGGED = []compilergraph.TaggedValue{
 	sourceshape.NodeTypeNominal,
 	sourceshape.NodeTypeStruct,
 	sourceshape.NodeTypeAgent,
+	sourceshape.NodeTypeEnum,
+	sourceshape.NodeTypeExtension,
 }
 
 var MODULE_MEMBER_KINDS_TAGGED = append(TYPE_KINDS_TAGGED,
@@ -47,6 +47,17 @@ func (p SRGParameter) DeclaredType() (SRGTypeRef, bool) {
 	return SRGTypeRef{typeNode, p.srg}, exists
 }
 
+// DefaultValue returns the expression node for the default value of this parameter, if any.
+func (p SRGParameter) DefaultValue() (compilergraph.GraphNode, bool) {
+	return p.GraphNode.TryGetNode(sourceshape.NodeParameterDefaultValue)
+}
+
+// HasDefaultValue returns whether this parameter has a default value.
+func (p SRGParameter) HasDefaultValue() bool {
+	_, hasDefaultValue := p.DefaultValue()
+	return hasDefaultValue
+}
+
 // AsNamedScope returns the parameter as a named scope reference.
 func (p SRGParameter) AsNamedScope() SRGNamedScope {
 	return SRGNamedScope{p.GraphNode, p.srg}
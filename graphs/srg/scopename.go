@@ -229,6 +229,12 @@ func (ns SRGNamedScope) ScopeKind() NamedScopeKind {
 	case sourceshape.NodeTypeAgent:
 		return NamedScopeType
 
+	case sourceshape.NodeTypeEnum:
+		return NamedScopeType
+
+	case sourceshape.NodeTypeExtension:
+		return NamedScopeType
+
 	/* Generic */
 	case sourceshape.NodeTypeGeneric:
 		return NamedScopeType
@@ -244,6 +250,9 @@ func (ns SRGNamedScope) ScopeKind() NamedScopeKind {
 	case sourceshape.NodeTypeField:
 		return NamedScopeMember
 
+	case sourceshape.NodeTypeEnumValue:
+		return NamedScopeMember
+
 	case sourceshape.NodeTypeFunction:
 		return NamedScopeMember
 
@@ -372,6 +381,12 @@ func (ns SRGNamedScope) Name() (string, bool) {
 	case sourceshape.NodeTypeAgent:
 		return ns.TryGet(sourceshape.NodeTypeDefinitionName)
 
+	case sourceshape.NodeTypeEnum:
+		return ns.TryGet(sourceshape.NodeTypeDefinitionName)
+
+	case sourceshape.NodeTypeExtension:
+		return ns.TryGet(sourceshape.NodeTypeDefinitionName)
+
 	case sourceshape.NodeTypeImportPackage:
 		return ns.TryGet(sourceshape.NodeImportPredicatePackageName)
 
@@ -390,6 +405,9 @@ func (ns SRGNamedScope) Name() (string, bool) {
 	case sourceshape.NodeTypeField:
 		fallthrough
 
+	case sourceshape.NodeTypeEnumValue:
+		fallthrough
+
 	case sourceshape.NodeTypeFunction:
 		return ns.TryGet(sourceshape.NodePredicateTypeMemberName)
 
@@ -440,6 +458,9 @@ func (ns SRGNamedScope) GetMember() (SRGMember, bool) {
 	case sourceshape.NodeTypeField:
 		fallthrough
 
+	case sourceshape.NodeTypeEnumValue:
+		fallthrough
+
 	case sourceshape.NodeTypeFunction:
 		return SRGMember{ns.GraphNode, ns.srg}, true
 	}
@@ -633,6 +654,7 @@ func (g *SRG) findAddedNameInScope(name string, node compilergraph.GraphNode) (c
 				sourceshape.NodeStatementNamedValue,
 				sourceshape.NodeAssignedDestination,
 				sourceshape.NodeAssignedRejection,
+				sourceshape.NodeAssignedDestructured,
 				sourceshape.NodePredicateChild,
 				sourceshape.NodeStatementBlockStatement).
 			InIfKind(sourceshape.NodeStatementBlockStatement, sourceshape.NodeTypeResolveStatement).
@@ -663,6 +685,8 @@ func (g *SRG) findAddedNameInScope(name string, node compilergraph.GraphNode) (c
 					endIndex = parentNode.GetValue(sourceshape.NodePredicateEndRune).Int()
 				} else if parentNode, ok := node.TryGetIncomingNode(sourceshape.NodeAssignedRejection); ok {
 					endIndex = parentNode.GetValue(sourceshape.NodePredicateEndRune).Int()
+				} else if parentNode, ok := node.TryGetIncomingNode(sourceshape.NodeAssignedDestructured); ok {
+					endIndex = parentNode.GetValue(sourceshape.NodePredicateEndRune).Int()
 				} else {
 					panic("Missing assigned parent")
 				}
@@ -683,6 +707,28 @@ func (g *SRG) findAddedNameInScope(name string, node compilergraph.GraphNode) (c
 		results = append(results, scopeResultNode{node, startIndex})
 	}
 
+	// Named values bound by a pattern can be nested arbitrarily deep under the match case
+	// whose guard and statements they are added to, so they are checked separately.
+	bit := g.layer.StartQuery(name).
+		In("named").
+		Has(sourceshape.NodePredicateSource, nodeSource).
+		IsKind(sourceshape.NodeTypeNamedValue).
+		BuildNodeIterator(sourceshape.NodePredicateStartRune)
+
+	for bit.Next() {
+		caseNode, isBound := g.patternBindingCase(bit.Node())
+		if !isBound {
+			continue
+		}
+
+		if caseNode.GetValue(sourceshape.NodePredicateStartRune).Int() > nodeStartIndex ||
+			caseNode.GetValue(sourceshape.NodePredicateEndRune).Int() < node.GetValue(sourceshape.NodePredicateEndRune).Int() {
+			continue
+		}
+
+		results = append(results, scopeResultNode{bit.Node(), bit.GetPredicate(sourceshape.NodePredicateStartRune).Int()})
+	}
+
 	if len(results) == 1 {
 		// If there is a single result, return it.
 		return results[0].node, true
@@ -694,3 +740,27 @@ func (g *SRG) findAddedNameInScope(name string, node compilergraph.GraphNode) (c
 
 	return compilergraph.GraphNode{}, false
 }
+
+// patternBindingCase returns the match case under which the given named value is bound by a
+// pattern, if any.
+func (g *SRG) patternBindingCase(namedNode compilergraph.GraphNode) (compilergraph.GraphNode, bool) {
+	fieldNode, isBound := namedNode.TryGetIncomingNode(sourceshape.NodeStructPatternFieldPattern)
+	if !isBound {
+		return compilergraph.GraphNode{}, false
+	}
+
+	var current = fieldNode
+	for {
+		patternNode := current.GetIncomingNode(sourceshape.NodeStructPatternField)
+		if caseNode, isTopLevel := patternNode.TryGetIncomingNode(sourceshape.NodeMatchStatementCasePattern); isTopLevel {
+			return caseNode, true
+		}
+
+		parentFieldNode, isNested := patternNode.TryGetIncomingNode(sourceshape.NodeStructPatternFieldPattern)
+		if !isNested {
+			return compilergraph.GraphNode{}, false
+		}
+
+		current = parentFieldNode
+	}
+}
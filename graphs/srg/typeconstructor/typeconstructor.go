@@ -88,6 +88,14 @@ func (stc *srgTypeConstructor) DefineTypes(builder typegraph.GetTypeBuilder) {
 			typeBuilder.TypeKind(typegraph.AgentType)
 			break
 
+		case srg.EnumType:
+			typeBuilder.TypeKind(typegraph.EnumType)
+			break
+
+		case srg.ExtensionType:
+			typeBuilder.TypeKind(typegraph.ExtensionType)
+			break
+
 		default:
 			panic("Unknown SRG type kind")
 		}
@@ -117,6 +125,13 @@ func (stc *srgTypeConstructor) DefineTypes(builder typegraph.GetTypeBuilder) {
 }
 
 func (stc *srgTypeConstructor) DefineDependencies(annotator typegraph.Annotator, graph *typegraph.TypeGraph) {
+	// Decorate all modules with the modules they import, for resolving extensions.
+	for _, module := range stc.srg.GetModules() {
+		for _, importedModulePath := range module.ImportedModulePaths() {
+			annotator.DefineModuleImport(module.Node(), importedModulePath)
+		}
+	}
+
 	for _, srgType := range stc.srg.GetTypes() {
 		_, hasTypeName := srgType.Name()
 		if !hasTypeName {
@@ -170,6 +185,28 @@ func (stc *srgTypeConstructor) DefineDependencies(annotator typegraph.Annotator,
 			annotator.DefinePrincipalType(srgType.Node(), resolvedType)
 		}
 
+		// Decorate extensions with their extended type.
+		if srgType.TypeKind() == srg.ExtensionType {
+			extendedType, ok := srgType.ExtendedType()
+			if !ok {
+				annotator.ReportError(srgType.Node(), "Missing extended type for extension definition")
+				continue
+			}
+
+			resolvedType, err := stc.BuildTypeRef(extendedType, graph)
+			if err != nil {
+				annotator.ReportError(srgType.Node(), "%s", err.Error())
+				continue
+			}
+
+			if !resolvedType.IsNormal() || resolvedType.IsNullable() {
+				annotator.ReportError(srgType.Node(), "Extended type of an extension must be a non-nullable named type. Found: '%v'", resolvedType)
+				continue
+			}
+
+			annotator.DefineExtendedType(srgType.Node(), resolvedType)
+		}
+
 		// Decorate all type generics with their constraints.
 		for _, srgGeneric := range srgType.Generics() {
 			// Note: If the constraint is not valid, the resolve method will report the error and return Any, which is the correct type.
@@ -290,7 +327,11 @@ func (stc *srgTypeConstructor) defineMember(member srg.SRGMember, parent typegra
 		}
 
 		documentation, _ := parameter.Documentation()
-		builder.WithParameter(parameterName, documentation.String(), parameter.Node())
+		if parameter.HasDefaultValue() {
+			builder.WithDefaultedParameter(parameterName, documentation.String(), parameter.Node())
+		} else {
+			builder.WithParameter(parameterName, documentation.String(), parameter.Node())
+		}
 	}
 
 	builder.Define()
@@ -330,6 +371,14 @@ func (stc *srgTypeConstructor) decorateMember(member srg.SRGMember, parent typeg
 			isPromising = typegraph.MemberNotPromising
 		}
 
+	case srg.EnumValueMember:
+		memberKind = typegraph.EnumValueMemberSignature
+
+		// Enum values are static, read-only instances of the parent enum type.
+		memberType = graph.NewInstanceTypeReference(parent.(typegraph.TGTypeDecl))
+		isStatic = true
+		isPromising = typegraph.MemberNotPromising
+
 	case srg.PropertyMember:
 		// Properties have their declared type.
 		memberType, _ = stc.resolvePossibleType(member.Node(), member.DeclaredType, graph, reporter)
@@ -459,10 +508,19 @@ func (stc *srgTypeConstructor) decorateMember(member srg.SRGMember, parent typeg
 		decorator.WithTag(name, value)
 	}
 
-	// Decorate the member's parameters.
+	// Decorate the member's parameters. Parameters with default values must all be found at the
+	// end of the parameter list, as their arguments can be omitted by callers.
+	var defaultedParameterName = ""
 	for _, parameter := range member.Parameters() {
 		resolvedParameterType, _ := stc.resolvePossibleType(parameter.Node(), parameter.DeclaredType, graph, nil)
 		decorator.DefineParameterType(parameter.Node(), resolvedParameterType)
+
+		parameterName, _ := parameter.Name()
+		if parameter.HasDefaultValue() {
+			defaultedParameterName = parameterName
+		} else if defaultedParameterName != "" {
+			reporter.ReportError(parameter.Node(), "Parameter '%s' must have a default value, as it follows parameter '%s' which has a default value", parameterName, defaultedParameterName)
+		}
 	}
 
 	// Finalize the member.
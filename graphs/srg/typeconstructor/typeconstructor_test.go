@@ -86,6 +86,12 @@ var typeGraphTests = []typegraphTest{
 	typegraphTest{"struct invalid ref test", "struct", "invalidref", "SomeStruct<SomeClass> has non-structural generic type SomeClass: SomeClass is not structural nor serializable"},
 	typegraphTest{"type redeclaration test", "redeclare", "redeclare", "interface 'SomeClass' redefines name 'SomeClass' under Module 'redeclare.seru'"},
 	typegraphTest{"generic redeclaration test", "genericredeclare", "redeclare", "Generic 'T' is already defined under class 'SomeClass'"},
+	typegraphTest{"enum value redeclaration test", "enum", "duplicate", "enum value 'Red' redefines name 'Red' under enum 'Color'"},
+	typegraphTest{"extension member conflict test", "extension", "conflict", "Extension member 'DoSomething' conflicts with an existing member of the same name under type 'SomeClass'"},
+	typegraphTest{"extension generics mismatch test", "extension", "generics", "Extension 'SomeClassHelpers' must declare the generics of extended type 'SomeClass', in order, and pass them to it"},
+	typegraphTest{"extension property read-only test", "extension", "readonly", "Extension member 'Value' must be read-only"},
+	typegraphTest{"extension nullable type test", "extension", "nullable", "Extended type of an extension must be a non-nullable named type. Found: 'SomeClass?'"},
+	typegraphTest{"non-trailing default parameter failure test", "defaultparam", "nottrailing", "Parameter 'third' must have a default value, as it follows parameter 'second' which has a default value"},
 	typegraphTest{"generic constraint resolve failure test", "genericconstraint", "notfound", "Type 'UnknownType' could not be found"},
 	typegraphTest{"unknown operator failure test", "operatorfail", "unknown", "Unknown operator 'notvalid' defined on type 'SomeType'"},
 	typegraphTest{"operator redefine failure test", "operatorfail", "redefine", "operator 'plus' redefines name 'plus' under class 'SomeType'"},
@@ -4,9 +4,9 @@ package srg
 
 import "fmt"
 
-const _TypeKind_name = "ClassTypeInterfaceTypeNominalTypeStructType"
+const _TypeKind_name = "ClassTypeInterfaceTypeNominalTypeStructTypeAgentTypeEnumTypeExtensionType"
 
-var _TypeKind_index = [...]uint8{0, 9, 22, 33, 43}
+var _TypeKind_index = [...]uint8{0, 9, 22, 33, 43, 52, 60, 73}
 
 func (i TypeKind) String() string {
 	if i < 0 || i >= TypeKind(len(_TypeKind_index)-1) {
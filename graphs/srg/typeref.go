@@ -34,6 +34,7 @@ const (
 	TypeRefVoid                        // A void type reference.
 	TypeRefStruct                      // A struct type reference.
 	TypeRefAny                         // An any type reference.
+	TypeRefTuple                       // A tuple type reference.
 )
 
 // GetTypeRef returns an SRGTypeRef wrapper for the given type reference node.
@@ -183,6 +184,13 @@ func (t SRGTypeRef) HasParameters() bool {
 	return found
 }
 
+// TupleElements returns the element type references of this tuple type ref.
+// Panics if this is not a RefKind of TypeRefTuple.
+func (t SRGTypeRef) TupleElements() []SRGTypeRef {
+	compilerutil.DCHECK(func() bool { return t.RefKind() == TypeRefTuple }, "Expected type ref tuple")
+	return t.subReferences(sourceshape.NodeTypeReferenceTupleElement)
+}
+
 // subReferences returns the subreferences found off of the given predicate, if any.
 func (t SRGTypeRef) subReferences(predicate compilergraph.Predicate) []SRGTypeRef {
 	subRefs := make([]SRGTypeRef, 0)
@@ -224,6 +232,18 @@ func (t SRGTypeRef) String() string {
 	case sourceshape.NodeTypeNullable:
 		return innerReferenceString + "?"
 
+	case sourceshape.NodeTypeTuple:
+		var buffer bytes.Buffer
+		buffer.WriteString("(")
+		for index, element := range t.TupleElements() {
+			if index > 0 {
+				buffer.WriteString(", ")
+			}
+			buffer.WriteString(element.String())
+		}
+		buffer.WriteString(")")
+		return buffer.String()
+
 	case sourceshape.NodeTypeTypeReference:
 		var buffer bytes.Buffer
 		buffer.WriteString(t.ResolutionName())
@@ -284,6 +304,9 @@ func (t SRGTypeRef) RefKind() TypeRefKind {
 	case sourceshape.NodeTypeNullable:
 		return TypeRefNullable
 
+	case sourceshape.NodeTypeTuple:
+		return TypeRefTuple
+
 	case sourceshape.NodeTypeTypeReference:
 		return TypeRefPath
 
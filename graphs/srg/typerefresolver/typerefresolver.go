@@ -124,6 +124,24 @@ func (trr *TypeReferenceResolver) resolveTypeRef(typeref srg.SRGTypeRef, tdg *ty
 
 		return innerType.AsNullable(), nil
 
+	case srg.TypeRefTuple:
+		// The tuple type is defined by the core library, which may predate its introduction.
+		if !tdg.HasTupleType() {
+			return tdg.AnyTypeReference(), fmt.Errorf("Tuple types require a core library with `valuetuple`")
+		}
+
+		var elementTypeRefs = make([]typegraph.TypeReference, 0)
+		for _, srgElement := range typeref.TupleElements() {
+			elementTypeRef, err := trr.ResolveTypeRef(srgElement, tdg)
+			if err != nil {
+				return tdg.AnyTypeReference(), err
+			}
+
+			elementTypeRefs = append(elementTypeRefs, elementTypeRef)
+		}
+
+		return tdg.TupleTypeReference(elementTypeRefs...), nil
+
 	case srg.TypeRefPath:
 		// Resolve the package type for the type ref.
 		resolvedTypeInfo, found := typeref.ResolveType()
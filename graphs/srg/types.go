@@ -30,6 +30,8 @@ const (
 	NominalType
 	StructType
 	AgentType
+	EnumType
+	ExtensionType
 )
 
 // GetTypes returns all the types defined in the SRG.
@@ -139,6 +141,12 @@ func (t SRGType) TypeKind() TypeKind {
 	case sourceshape.NodeTypeAgent:
 		return AgentType
 
+	case sourceshape.NodeTypeEnum:
+		return EnumType
+
+	case sourceshape.NodeTypeExtension:
+		return ExtensionType
+
 	default:
 		panic(fmt.Sprintf("Unknown kind of type %s", t.GraphNode.Kind()))
 	}
@@ -204,6 +212,22 @@ func (t SRGType) WrappedType() (SRGTypeRef, bool) {
 	return SRGTypeRef{}, false
 }
 
+// ExtendedType returns a type reference to the type extended, if any. Will only
+// return a valid reference for ExtensionTypes.
+func (t SRGType) ExtendedType() (SRGTypeRef, bool) {
+	if t.TypeKind() == ExtensionType {
+		extendedType, ok := t.GraphNode.TryGetNode(sourceshape.NodeExtensionPredicateExtendedType)
+		if !ok {
+			// Partial graph.
+			return SRGTypeRef{}, false
+		}
+
+		return SRGTypeRef{extendedType, t.srg}, true
+	}
+
+	return SRGTypeRef{}, false
+}
+
 // ComposedAgents returns references to the agents composed by this type,
 // if any.
 func (t SRGType) ComposedAgents() []SRGComposedAgent {
@@ -355,6 +379,25 @@ func (t SRGType) Code() (compilercommon.CodeSummary, bool) {
 		writeCodeGenerics(t, &buffer)
 		writeComposition()
 
+	case sourceshape.NodeTypeEnum:
+		buffer.WriteString("enum ")
+		buffer.WriteString(name)
+
+	case sourceshape.NodeTypeExtension:
+		buffer.WriteString("extension ")
+		buffer.WriteString(name)
+		writeCodeGenerics(t, &buffer)
+		buffer.WriteString(" for ")
+
+		// Note: Extensions should always have extended types, but since this method can be called
+		// from tooling where the SRG may not be completely valid, we check anyway.
+		extendedType, hasExtendedType := t.ExtendedType()
+		if hasExtendedType {
+			buffer.WriteString(extendedType.String())
+		} else {
+			buffer.WriteString("?")
+		}
+
 	default:
 		panic(fmt.Sprintf("Unknown kind of type %s", t.GraphNode.Kind()))
 	}
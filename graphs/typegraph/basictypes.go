@@ -85,6 +85,15 @@ func (t *TypeGraph) MappingTypeReference(value TypeReference) TypeReference {
 	return t.NewTypeReference(t.MappingType(), value)
 }
 
+// TupleTypeReference returns a new reference to the tuple type, with the given element types.
+func (t *TypeGraph) TupleTypeReference(elements ...TypeReference) TypeReference {
+	var tupleRef = t.NewTypeReference(t.TupleType())
+	for _, element := range elements {
+		tupleRef = tupleRef.WithParameter(element)
+	}
+	return tupleRef
+}
+
 // ReleasableTypeReference returns a reference to the releasable type.
 func (t *TypeGraph) ReleasableTypeReference() TypeReference {
 	return t.NewTypeReference(t.ReleasableType())
@@ -130,6 +139,18 @@ func (t *TypeGraph) FunctionType() TGTypeDecl {
 	return t.getGlobalAliasedType("function")
 }
 
+// TupleType returns the tuple type. As older core libraries do not define the tuple type, callers
+// must first check HasTupleType.
+func (t *TypeGraph) TupleType() TGTypeDecl {
+	return t.getGlobalAliasedType("valuetuple")
+}
+
+// HasTupleType returns whether the core library in use defines the tuple type.
+func (t *TypeGraph) HasTupleType() bool {
+	_, found := t.LookupGlobalAliasedType("valuetuple")
+	return found
+}
+
 // StringableType returns the string type.
 func (t *TypeGraph) StringableType() TGTypeDecl {
 	return t.getGlobalAliasedType("stringable")
@@ -31,6 +31,8 @@ const (
 	NativeFunctionMemberSignature
 	NativeOperatorMemberSignature
 	NativePropertyMemberSignature
+
+	EnumValueMemberSignature
 )
 
 // operatorMemberNamePrefix defines a unicode character for prefixing the "member name" of operators. Allows
@@ -174,6 +176,35 @@ func (an Annotator) DefineAliasedType(typeSourceNode compilergraph.GraphNode, al
 	an.modifier.Modify(typeNode).Connect(NodePredicateAliasedType, aliased.GraphNode)
 }
 
+// DefineExtendedType defines the type extended by this extension.
+func (an Annotator) DefineExtendedType(typeSourceNode compilergraph.GraphNode, extended TypeReference) {
+	typeNode, ok := an.tdg.tryGetMatchingTypeGraphNode(typeSourceNode)
+	if !ok {
+		return
+	}
+	if typeNode.Kind() != NodeTypeExtension {
+		panic("Cannot set extended type on non-extension type")
+	}
+
+	an.modifier.Modify(typeNode).DecorateWithTagged(NodePredicateExtendedType, extended)
+}
+
+// DefineModuleImport defines that the given module imports the module found at the given path.
+// Imports determine the visibility of extensions.
+func (an Annotator) DefineModuleImport(moduleSourceNode compilergraph.GraphNode, importedModulePath compilercommon.InputSource) {
+	moduleNode, ok := an.tdg.tryGetMatchingTypeGraphNode(moduleSourceNode)
+	if !ok {
+		return
+	}
+
+	modulePath := TGModule{moduleNode, an.tdg}.Path()
+	if _, exists := an.tdg.moduleImports[modulePath]; !exists {
+		an.tdg.moduleImports[modulePath] = map[compilercommon.InputSource]bool{}
+	}
+
+	an.tdg.moduleImports[modulePath][importedModulePath] = true
+}
+
 // moduleBuilder ////////////////////////////////////////////////////////////////////////////////////
 
 // moduleBuilder defines a helper type for easy construction of module definitions in the type graph.
@@ -372,6 +403,12 @@ func getTypeNodeType(kind TypeKind) NodeType {
 	case AgentType:
 		return NodeTypeAgent
 
+	case EnumType:
+		return NodeTypeEnum
+
+	case ExtensionType:
+		return NodeTypeExtension
+
 	case AliasType:
 		return NodeTypeAlias
 
@@ -483,10 +520,11 @@ type memberGeneric struct {
 
 // memberParameter holds information about a member's parameter.
 type memberParameter struct {
-	name          string
-	documentation string
-	sourceNode    compilergraph.GraphNode
-	hasSourceNode bool
+	name            string
+	documentation   string
+	sourceNode      compilergraph.GraphNode
+	hasSourceNode   bool
+	hasDefaultValue bool
 }
 
 // Name sets the name of the member.
@@ -529,7 +567,17 @@ func (mb *MemberBuilder) withGeneric(name string) *MemberBuilder {
 // WithParameter adds a parameter to this member.
 func (mb *MemberBuilder) WithParameter(name string, documentation string, sourceNode compilergraph.GraphNode) *MemberBuilder {
 	mb.memberParameters = append(mb.memberParameters, memberParameter{
-		name, documentation, sourceNode, true,
+		name, documentation, sourceNode, true, false,
+	})
+
+	return mb
+}
+
+// WithDefaultedParameter adds a parameter with a default value to this member. Callers of the
+// member can omit the argument for the parameter.
+func (mb *MemberBuilder) WithDefaultedParameter(name string, documentation string, sourceNode compilergraph.GraphNode) *MemberBuilder {
+	mb.memberParameters = append(mb.memberParameters, memberParameter{
+		name, documentation, sourceNode, true, true,
 	})
 
 	return mb
@@ -597,6 +645,10 @@ func (mb *MemberBuilder) Define() TGMember {
 			parameterNode.Decorate(NodePredicateParameterDocumentation, parameterInfo.documentation)
 		}
 
+		if parameterInfo.hasDefaultValue {
+			parameterNode.Decorate(NodePredicateParameterHasDefaultValue, "true")
+		}
+
 		// Add the parameter to the member node.
 		memberNode.Connect(NodePredicateMemberParameter, parameterNode)
 	}
@@ -964,17 +1016,26 @@ func (mb *MemberDecorator) decorateWithSig(sigMemberType TypeReference, generics
 		constraintStr[index] = genericConstraint.Localize(generics...).Value()
 	}
 
+	// Count the parameters with default values, as callers of the member can omit their arguments.
+	var defaultedParameterCount uint64
+	for _, parameter := range mb.member.Parameters() {
+		if parameter.HasDefaultValue() {
+			defaultedParameterCount++
+		}
+	}
+
 	isWritable := !mb.readonly
 	name := strings.ToLower(mb.memberName)
 	memberKind := uint64(mb.memberKind)
 
 	signature := &proto.MemberSig{
-		MemberName:         name,
-		MemberKind:         memberKind,
-		IsExported:         mb.exported,
-		IsWritable:         isWritable,
-		MemberType:         memberTypeStr,
-		GenericConstraints: constraintStr,
+		MemberName:              name,
+		MemberKind:              memberKind,
+		IsExported:              mb.exported,
+		IsWritable:              isWritable,
+		MemberType:              memberTypeStr,
+		GenericConstraints:      constraintStr,
+		DefaultedParameterCount: defaultedParameterCount,
 	}
 
 	mb.modifier.Modify(mb.member.GraphNode).DecorateWithTagged(NodePredicateMemberSignature, signature)
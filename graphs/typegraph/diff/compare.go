@@ -10,8 +10,80 @@ import (
 	"github.com/serulian/compiler/graphs/typegraph"
 )
 
+// typeVariance defines the manner in which a type reference can change while remaining backward compatible.
+type typeVariance int
+
+const (
+	// invariant indicates that the type reference cannot change.
+	invariant typeVariance = iota
+
+	// covariant indicates that the type reference can be narrowed to a subtype, as is the case
+	// for return types and read-only members.
+	covariant
+
+	// contravariant indicates that the type reference can be widened to a supertype, as is the
+	// case for parameters and generic constraints.
+	contravariant
+)
+
+// typeChange defines the kinds of changes between two type references, in increasing order of severity.
+type typeChange int
+
+const (
+	// typeUnchanged indicates that the type reference has not changed.
+	typeUnchanged typeChange = iota
+
+	// typeChangedCompatibly indicates that the type reference has changed in a backward compatible manner.
+	typeChangedCompatibly
+
+	// typeChangedIncompatibly indicates that the type reference has changed in a backward incompatible manner.
+	typeChangedIncompatibly
+)
+
+// mostSevere returns the more severe of the two type changes.
+func (tc typeChange) mostSevere(other typeChange) typeChange {
+	if other > tc {
+		return other
+	}
+
+	return tc
+}
+
+// compareTypes returns whether the original and updated type references are the same.
 func compareTypes(original typegraph.TypeReference, updated typegraph.TypeReference, context diffContext) bool {
-	// Adopt the original type reference into the updated graph.
+	return compareTypesWithVariance(original, updated, invariant, context) == typeUnchanged
+}
+
+// compareTypesWithVariance compares the original and updated type references, returning how the
+// reference has changed under the given variance.
+func compareTypesWithVariance(original typegraph.TypeReference, updated typegraph.TypeReference, variance typeVariance, context diffContext) typeChange {
+	adopted, ok := adoptType(original, context)
+	if !ok {
+		return typeChangedIncompatibly
+	}
+
+	if adopted == updated {
+		return typeUnchanged
+	}
+
+	switch variance {
+	case covariant:
+		if updated.CheckSubTypeOf(adopted) == nil {
+			return typeChangedCompatibly
+		}
+
+	case contravariant:
+		if adopted.CheckSubTypeOf(updated) == nil {
+			return typeChangedCompatibly
+		}
+	}
+
+	return typeChangedIncompatibly
+}
+
+// adoptType adopts the original type reference into the updated type graph, returning false if
+// the reference cannot be adopted.
+func adoptType(original typegraph.TypeReference, context diffContext) (typegraph.TypeReference, bool) {
 	adopted, err := original.AdoptReferenceInto(context.updatedGraph.Graph, func(originalType typegraph.TGTypeDecl) (typegraph.TGTypeDecl, bool) {
 		globalAlias, hasGlobalAlias := originalType.GlobalAlias()
 		if hasGlobalAlias {
@@ -47,10 +119,10 @@ func compareTypes(original typegraph.TypeReference, updated typegraph.TypeRefere
 	})
 
 	if err != nil {
-		return false
+		return typegraph.TypeReference{}, false
 	}
 
-	return adopted == updated
+	return adopted, true
 }
 
 func compareParameters(original []typegraph.TGParameter, updated []typegraph.TGParameter, context diffContext) MemberDiffReason {
@@ -58,43 +130,59 @@ func compareParameters(original []typegraph.TGParameter, updated []typegraph.TGP
 		return MemberDiffReasonParametersNotCompatible
 	}
 
-	// For each of the original parameters, ensure that a updated parameter exists and has an assignable type.
+	// For each of the original parameters, ensure that a updated parameter exists and has a type
+	// accepting all values accepted by the original parameter. A default value can be added to
+	// a parameter, but not removed, as callers may rely on it.
+	var change = typeUnchanged
+	var defaultAdded = false
 	for index := range original {
 		originalType := original[index].DeclaredType()
 		updatedType := updated[index].DeclaredType()
-		if !compareTypes(originalType, updatedType, context) {
-			return MemberDiffReasonParametersNotCompatible
+		change = change.mostSevere(compareTypesWithVariance(originalType, updatedType, contravariant, context))
+
+		if original[index].HasDefaultValue() != updated[index].HasDefaultValue() {
+			if original[index].HasDefaultValue() {
+				return MemberDiffReasonParametersNotCompatible
+			}
+
+			defaultAdded = true
 		}
 	}
 
-	// Ensure each of the additional updated parameters (if any) are nullable, making them optional.
+	if change == typeChangedIncompatibly {
+		return MemberDiffReasonParametersNotCompatible
+	}
+
+	// Ensure each of the additional updated parameters (if any) are nullable or have a default value,
+	// making them optional.
 	for _, updatedParam := range updated[len(original):] {
-		if !updatedParam.DeclaredType().NullValueAllowed() {
+		if !updatedParam.DeclaredType().NullValueAllowed() && !updatedParam.HasDefaultValue() {
 			return MemberDiffReasonParametersNotCompatible
 		}
 	}
 
-	if len(updated) > len(original) {
+	if len(updated) > len(original) || defaultAdded || change == typeChangedCompatibly {
 		return MemberDiffReasonParametersCompatible
 	}
 
 	return MemberDiffReasonNotApplicable
 }
 
-func compareGenerics(original []typegraph.TGGeneric, updated []typegraph.TGGeneric, context diffContext) bool {
+// compareGenerics compares the original and updated generics, returning how they have changed. Generics
+// cannot be added, removed or renamed, but their constraints can be relaxed.
+func compareGenerics(original []typegraph.TGGeneric, updated []typegraph.TGGeneric, context diffContext) typeChange {
 	if len(original) != len(updated) {
-		return false
+		return typeChangedIncompatibly
 	}
 
+	var change = typeUnchanged
 	for index, originalGeneric := range original {
 		if originalGeneric.Name() != updated[index].Name() {
-			return false
+			return typeChangedIncompatibly
 		}
 
-		if !compareTypes(originalGeneric.Constraint(), updated[index].Constraint(), context) {
-			return false
-		}
+		change = change.mostSevere(compareTypesWithVariance(originalGeneric.Constraint(), updated[index].Constraint(), contravariant, context))
 	}
 
-	return true
+	return change
 }
@@ -280,6 +280,8 @@ var sourceDiffTests = []string{
 	"memberchanged",
 	"unexportedmemberchanged",
 	"nullableparameteradded",
+	"defaultparameteradded",
+	"tuplechanged",
 	"generics",
 	"withwebidl",
 	"withwebidlchanges",
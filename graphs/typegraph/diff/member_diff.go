@@ -77,12 +77,20 @@ func diffMember(original typegraph.TGMember, updated typegraph.TGMember, context
 	originalType := original.DeclaredType()
 	updatedType := updated.DeclaredType()
 
-	if !compareTypes(originalType, updatedType, context) {
+	switch compareTypesWithVariance(originalType, updatedType, declaredTypeVariance(original, updated), context) {
+	case typeChangedCompatibly:
+		changeReason = changeReason | MemberDiffReasonTypeCompatible
+
+	case typeChangedIncompatibly:
 		changeReason = changeReason | MemberDiffReasonTypeNotCompatible
 	}
 
 	// Compare generics.
-	if !compareGenerics(original.Generics(), updated.Generics(), context) {
+	switch compareGenerics(original.Generics(), updated.Generics(), context) {
+	case typeChangedCompatibly:
+		changeReason = changeReason | MemberDiffReasonGenericConstraintsRelaxed
+
+	case typeChangedIncompatibly:
 		changeReason = changeReason | MemberDiffReasonGenericsChanged
 	}
 
@@ -102,3 +110,25 @@ func diffMember(original typegraph.TGMember, updated typegraph.TGMember, context
 		Updated:      &updated,
 	}
 }
+
+// declaredTypeVariance returns the variance of the declared types of the given members. The return
+// types of functions and the types of read-only members can be narrowed, as they are only ever read by
+// callers; the types of all other members can be assigned and therefore cannot change.
+func declaredTypeVariance(original typegraph.TGMember, updated typegraph.TGMember) typeVariance {
+	_, originalReturns := original.ReturnType()
+	_, updatedReturns := updated.ReturnType()
+	if originalReturns && updatedReturns {
+		return covariant
+	}
+
+	if isReadOnlyMember(original) && isReadOnlyMember(updated) {
+		return covariant
+	}
+
+	return invariant
+}
+
+// isReadOnlyMember returns whether the given member can only be read.
+func isReadOnlyMember(member typegraph.TGMember) bool {
+	return member.IsReadOnly() && !member.IsField()
+}
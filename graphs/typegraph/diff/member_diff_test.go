@@ -119,6 +119,91 @@ var memberDiffTests = []memberDiffTest{
 			[]typegraph.TestParam{}},
 		MemberDiffReasonParametersNotCompatible,
 	},
+
+	memberDiffTest{
+		"widen parameter to nullable test",
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{},
+			[]typegraph.TestParam{
+				typegraph.TestParam{"someParam", "int"},
+			}},
+
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{},
+			[]typegraph.TestParam{
+				typegraph.TestParam{"someParam", "int?"},
+			}},
+		MemberDiffReasonParametersCompatible,
+	},
+
+	memberDiffTest{
+		"widen parameter to any test",
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{},
+			[]typegraph.TestParam{
+				typegraph.TestParam{"someParam", "int"},
+			}},
+
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{},
+			[]typegraph.TestParam{
+				typegraph.TestParam{"someParam", "any"},
+			}},
+		MemberDiffReasonParametersCompatible,
+	},
+
+	memberDiffTest{
+		"narrow parameter to non-nullable test",
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{},
+			[]typegraph.TestParam{
+				typegraph.TestParam{"someParam", "int?"},
+			}},
+
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{},
+			[]typegraph.TestParam{
+				typegraph.TestParam{"someParam", "int"},
+			}},
+		MemberDiffReasonParametersNotCompatible,
+	},
+
+	memberDiffTest{
+		"narrow return type to non-nullable test",
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int?", []typegraph.TestGeneric{}, []typegraph.TestParam{}},
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{}, []typegraph.TestParam{}},
+		MemberDiffReasonTypeCompatible,
+	},
+
+	memberDiffTest{
+		"widen return type to nullable test",
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{}, []typegraph.TestParam{}},
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int?", []typegraph.TestGeneric{}, []typegraph.TestParam{}},
+		MemberDiffReasonTypeNotCompatible,
+	},
+
+	memberDiffTest{
+		"narrow field type to non-nullable test",
+		typegraph.TestMember{typegraph.FieldMemberSignature, "SomeVar", "int?", []typegraph.TestGeneric{}, []typegraph.TestParam{}},
+		typegraph.TestMember{typegraph.FieldMemberSignature, "SomeVar", "int", []typegraph.TestGeneric{}, []typegraph.TestParam{}},
+		MemberDiffReasonTypeNotCompatible,
+	},
+
+	memberDiffTest{
+		"relax generic constraint test",
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{
+			typegraph.TestGeneric{"T", "int"},
+		}, []typegraph.TestParam{}},
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{
+			typegraph.TestGeneric{"T", "any"},
+		}, []typegraph.TestParam{}},
+		MemberDiffReasonGenericConstraintsRelaxed,
+	},
+
+	memberDiffTest{
+		"tighten generic constraint test",
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{
+			typegraph.TestGeneric{"T", "any"},
+		}, []typegraph.TestParam{}},
+		typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{
+			typegraph.TestGeneric{"T", "int"},
+		}, []typegraph.TestParam{}},
+		MemberDiffReasonGenericsChanged,
+	},
 }
 
 func TestMemberDiff(t *testing.T) {
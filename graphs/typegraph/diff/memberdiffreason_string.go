@@ -4,13 +4,47 @@ package diff
 
 import "fmt"
 
-const _MemberDiffReason_name = "MemberDiffReasonNotApplicable"
+const (
+	_MemberDiffReason_name_0 = "MemberDiffReasonNotApplicable"
+	_MemberDiffReason_name_1 = "MemberDiffReasonKindChanged"
+	_MemberDiffReason_name_2 = "MemberDiffReasonGenericsChanged"
+	_MemberDiffReason_name_3 = "MemberDiffReasonParametersCompatible"
+	_MemberDiffReason_name_4 = "MemberDiffReasonParametersNotCompatible"
+	_MemberDiffReason_name_5 = "MemberDiffReasonTypeNotCompatible"
+	_MemberDiffReason_name_6 = "MemberDiffReasonTypeCompatible"
+	_MemberDiffReason_name_7 = "MemberDiffReasonGenericConstraintsRelaxed"
+)
 
-var _MemberDiffReason_index = [...]uint8{0, 29}
+var (
+	_MemberDiffReason_index_0 = [...]uint8{0, 29}
+	_MemberDiffReason_index_1 = [...]uint8{0, 27}
+	_MemberDiffReason_index_2 = [...]uint8{0, 31}
+	_MemberDiffReason_index_3 = [...]uint8{0, 36}
+	_MemberDiffReason_index_4 = [...]uint8{0, 39}
+	_MemberDiffReason_index_5 = [...]uint8{0, 33}
+	_MemberDiffReason_index_6 = [...]uint8{0, 30}
+	_MemberDiffReason_index_7 = [...]uint8{0, 41}
+)
 
 func (i MemberDiffReason) String() string {
-	if i < 0 || i >= MemberDiffReason(len(_MemberDiffReason_index)-1) {
+	switch {
+	case i == 0:
+		return _MemberDiffReason_name_0
+	case i == 2:
+		return _MemberDiffReason_name_1
+	case i == 4:
+		return _MemberDiffReason_name_2
+	case i == 8:
+		return _MemberDiffReason_name_3
+	case i == 16:
+		return _MemberDiffReason_name_4
+	case i == 32:
+		return _MemberDiffReason_name_5
+	case i == 64:
+		return _MemberDiffReason_name_6
+	case i == 128:
+		return _MemberDiffReason_name_7
+	default:
 		return fmt.Sprintf("MemberDiffReason(%d)", i)
 	}
-	return _MemberDiffReason_name[_MemberDiffReason_index[i]:_MemberDiffReason_index[i+1]]
 }
@@ -36,7 +36,11 @@ func diffPackage(path string, originalModules []typegraph.TGModule,
 
 		case Changed:
 			if typeDiff.Original.IsExported() {
-				changeReason = changeReason | PackageDiffReasonExportedTypesChanged
+				if typeDiff.HasBreakingChange() {
+					changeReason = changeReason | PackageDiffReasonExportedTypesChanged
+				} else {
+					changeReason = changeReason | PackageDiffReasonExportedTypesChangedCompatibly
+				}
 			}
 		}
 	}
@@ -55,7 +59,11 @@ func diffPackage(path string, originalModules []typegraph.TGModule,
 
 		case Changed:
 			if memberDiff.Original.IsExported() || memberDiff.Updated.IsExported() {
-				changeReason = changeReason | PackageDiffReasonExportedMembersChanged
+				if memberDiff.HasBreakingChange() {
+					changeReason = changeReason | PackageDiffReasonExportedMembersChanged
+				} else {
+					changeReason = changeReason | PackageDiffReasonExportedMembersChangedCompatibly
+				}
 			}
 		}
 	}
@@ -213,6 +213,35 @@ var packageDiffTests = []packageDiffTest{
 		PackageDiffReasonExportedMembersChanged,
 	},
 
+	packageDiffTest{
+		"changed exported member compatibly test",
+		[]typegraph.TestModule{
+			typegraph.TestModule{
+				"somemodule",
+				[]typegraph.TestType{},
+				[]typegraph.TestMember{
+					typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{},
+						[]typegraph.TestParam{
+							typegraph.TestParam{"someParam", "int"},
+						}},
+				},
+			},
+		},
+		[]typegraph.TestModule{
+			typegraph.TestModule{
+				"somemodule",
+				[]typegraph.TestType{},
+				[]typegraph.TestMember{
+					typegraph.TestMember{typegraph.FunctionMemberSignature, "SomeFunction", "int", []typegraph.TestGeneric{},
+						[]typegraph.TestParam{
+							typegraph.TestParam{"someParam", "int?"},
+						}},
+				},
+			},
+		},
+		PackageDiffReasonExportedMembersChangedCompatibly,
+	},
+
 	packageDiffTest{
 		"changed unexported member test",
 		[]typegraph.TestModule{
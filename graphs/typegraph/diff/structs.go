@@ -63,7 +63,7 @@ const (
 	PackageDiffReasonNotApplicable PackageDiffReason = 0
 
 	// PackageDiffReasonExportedTypesRemoved indicates an exported type was removed.
-	PackageDiffReasonExportedTypesRemoved = 1 << iota
+	PackageDiffReasonExportedTypesRemoved PackageDiffReason = 1 << iota
 
 	// PackageDiffReasonExportedTypesAdded indicates an exported type was added.
 	PackageDiffReasonExportedTypesAdded
@@ -79,6 +79,14 @@ const (
 
 	// PackageDiffReasonExportedMembersChanged indicates an exported member was changed.
 	PackageDiffReasonExportedMembersChanged
+
+	// PackageDiffReasonExportedTypesChangedCompatibly indicates an exported type was changed,
+	// but only in a backward compatible manner.
+	PackageDiffReasonExportedTypesChangedCompatibly
+
+	// PackageDiffReasonExportedMembersChangedCompatibly indicates an exported member was changed,
+	// but only in a backward compatible manner.
+	PackageDiffReasonExportedMembersChangedCompatibly
 )
 
 // Expand expands the package diff reason into individual enumeration values.
@@ -96,6 +104,8 @@ func (pdr PackageDiffReason) Expand() []PackageDiffReason {
 	appendReason(PackageDiffReasonExportedMembersRemoved)
 	appendReason(PackageDiffReasonExportedMembersAdded)
 	appendReason(PackageDiffReasonExportedMembersChanged)
+	appendReason(PackageDiffReasonExportedTypesChangedCompatibly)
+	appendReason(PackageDiffReasonExportedMembersChangedCompatibly)
 	return reasons
 }
 
@@ -124,6 +134,12 @@ func (pdr PackageDiffReason) Describe() (ReasonCompatibility, string) {
 	case PackageDiffReasonExportedMembersChanged:
 		return BackwardIncompatible, "An exported member in the package was changed in an incompatible manner"
 
+	case PackageDiffReasonExportedTypesChangedCompatibly:
+		return BackwardCompatible, "An exported type in the package was changed in a compatible manner"
+
+	case PackageDiffReasonExportedMembersChangedCompatibly:
+		return BackwardCompatible, "An exported member in the package was changed in a compatible manner"
+
 	default:
 		panic("Unknown package diff reason")
 	}
@@ -177,7 +193,7 @@ const (
 	TypeDiffReasonNotApplicable TypeDiffReason = 0
 
 	// TypeDiffReasonKindChanged indicates that the kind of the type has changed.
-	TypeDiffReasonKindChanged = 1 << iota
+	TypeDiffReasonKindChanged TypeDiffReason = 1 << iota
 
 	// TypeDiffReasonGenericsChanged indicates that the generics of the type have changed.
 	TypeDiffReasonGenericsChanged
@@ -209,6 +225,14 @@ const (
 	// TypeDiffReasonRequiredMemberAdded indicates that a *required* member
 	// of the type has been added.
 	TypeDiffReasonRequiredMemberAdded
+
+	// TypeDiffReasonExportedMembersChangedCompatibly indicates that some exported
+	// members of the type have been changed, but only in a backward compatible manner.
+	TypeDiffReasonExportedMembersChangedCompatibly
+
+	// TypeDiffReasonGenericConstraintsRelaxed indicates that the constraints of one or
+	// more generics of the type have been relaxed.
+	TypeDiffReasonGenericConstraintsRelaxed
 )
 
 // Expand expands the type diff reason into individual enumeration values.
@@ -230,6 +254,8 @@ func (tdr TypeDiffReason) Expand() []TypeDiffReason {
 	appendReason(TypeDiffReasonExportedMembersRemoved)
 	appendReason(TypeDiffReasonExportedMembersChanged)
 	appendReason(TypeDiffReasonRequiredMemberAdded)
+	appendReason(TypeDiffReasonExportedMembersChangedCompatibly)
+	appendReason(TypeDiffReasonGenericConstraintsRelaxed)
 	return reasons
 }
 
@@ -240,6 +266,9 @@ func (tdr TypeDiffReason) Describe() (ReasonCompatibility, string) {
 	case TypeDiffReasonNotApplicable:
 		return CompatibilityNotApplicable, "No changes in the type"
 
+	case TypeDiffReasonKindChanged:
+		return BackwardIncompatible, "The kind of the type has been modified"
+
 	case TypeDiffReasonGenericsChanged:
 		return BackwardIncompatible, "One or more generics on the type have been modified"
 
@@ -265,7 +294,13 @@ func (tdr TypeDiffReason) Describe() (ReasonCompatibility, string) {
 		return BackwardIncompatible, "An exported member under the type was changed in an incompatible fashion"
 
 	case TypeDiffReasonRequiredMemberAdded:
-		return BackwardCompatible, "A required member has been added under the type"
+		return BackwardIncompatible, "A required member has been added under the type"
+
+	case TypeDiffReasonExportedMembersChangedCompatibly:
+		return BackwardCompatible, "An exported member under the type was changed in a compatible fashion"
+
+	case TypeDiffReasonGenericConstraintsRelaxed:
+		return BackwardCompatible, "The constraints of one or more generics on the type have been relaxed"
 
 	default:
 		panic("Unknown type diff reason")
@@ -294,6 +329,18 @@ type TypeDiff struct {
 	Members []MemberDiff
 }
 
+// HasBreakingChange returns true if the type has any *forward incompatible* breaking
+// changes.
+func (td TypeDiff) HasBreakingChange() bool {
+	for _, change := range td.ChangeReason.Expand() {
+		if compatibility, _ := change.Describe(); compatibility == BackwardIncompatible {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MemberDiffReason defines a bitwise enumeration specifying why a member changed.
 type MemberDiffReason int
 
@@ -302,7 +349,7 @@ const (
 	MemberDiffReasonNotApplicable MemberDiffReason = 0
 
 	// MemberDiffReasonKindChanged indicates that the kind of the member has changed.
-	MemberDiffReasonKindChanged = 1 << iota
+	MemberDiffReasonKindChanged MemberDiffReason = 1 << iota
 
 	// MemberDiffReasonGenericsChanged indicates that the generics of the member have changed.
 	MemberDiffReasonGenericsChanged
@@ -318,6 +365,15 @@ const (
 	// MemberDiffReasonTypeNotCompatible indicates that the declared/return type of the
 	// member has changed in a forward incompatible manner.
 	MemberDiffReasonTypeNotCompatible
+
+	// MemberDiffReasonTypeCompatible indicates that the declared/return type of the
+	// member has changed in a forward compatible manner, such as a return type being
+	// narrowed to a subtype.
+	MemberDiffReasonTypeCompatible
+
+	// MemberDiffReasonGenericConstraintsRelaxed indicates that the constraints of one or
+	// more generics of the member have been relaxed.
+	MemberDiffReasonGenericConstraintsRelaxed
 )
 
 // Expand expands the member diff reason into individual enumeration values.
@@ -334,6 +390,8 @@ func (mdr MemberDiffReason) Expand() []MemberDiffReason {
 	appendReason(MemberDiffReasonParametersCompatible)
 	appendReason(MemberDiffReasonParametersNotCompatible)
 	appendReason(MemberDiffReasonTypeNotCompatible)
+	appendReason(MemberDiffReasonTypeCompatible)
+	appendReason(MemberDiffReasonGenericConstraintsRelaxed)
 	return reasons
 }
 
@@ -344,6 +402,9 @@ func (mdr MemberDiffReason) Describe() (ReasonCompatibility, string) {
 	case MemberDiffReasonNotApplicable:
 		return CompatibilityNotApplicable, "No changes in the member"
 
+	case MemberDiffReasonKindChanged:
+		return BackwardIncompatible, "The kind of the member has been modified"
+
 	case MemberDiffReasonGenericsChanged:
 		return BackwardIncompatible, "One or more generics on the member have been modified"
 
@@ -356,6 +417,12 @@ func (mdr MemberDiffReason) Describe() (ReasonCompatibility, string) {
 	case MemberDiffReasonTypeNotCompatible:
 		return BackwardIncompatible, "The type signature of this member has been modified"
 
+	case MemberDiffReasonTypeCompatible:
+		return BackwardCompatible, "The type signature of this member has been modified in a backward compatible manner"
+
+	case MemberDiffReasonGenericConstraintsRelaxed:
+		return BackwardCompatible, "The constraints of one or more generics on the member have been relaxed"
+
 	default:
 		panic("Unknown member diff reason")
 	}
@@ -378,3 +445,15 @@ type MemberDiff struct {
 	// Updated is the updated member, if any.
 	Updated *typegraph.TGMember `json:"-"`
 }
+
+// HasBreakingChange returns true if the member has any *forward incompatible* breaking
+// changes.
+func (md MemberDiff) HasBreakingChange() bool {
+	for _, change := range md.ChangeReason.Expand() {
+		if compatibility, _ := change.Describe(); compatibility == BackwardIncompatible {
+			return true
+		}
+	}
+
+	return false
+}
@@ -73,7 +73,11 @@ func diffType(original typegraph.TGTypeDecl, updated typegraph.TGTypeDecl, conte
 	}
 
 	// Compare the generics of the types.
-	if !compareGenerics(original.Generics(), updated.Generics(), context) {
+	switch compareGenerics(original.Generics(), updated.Generics(), context) {
+	case typeChangedCompatibly:
+		changeReason = changeReason | TypeDiffReasonGenericConstraintsRelaxed
+
+	case typeChangedIncompatibly:
 		changeReason = changeReason | TypeDiffReasonGenericsChanged
 	}
 
@@ -140,7 +144,11 @@ func diffType(original typegraph.TGTypeDecl, updated typegraph.TGTypeDecl, conte
 
 		case Changed:
 			if memberDiff.Original.IsExported() || memberDiff.Updated.IsExported() {
-				changeReason = changeReason | TypeDiffReasonExportedMembersChanged
+				if memberDiff.HasBreakingChange() {
+					changeReason = changeReason | TypeDiffReasonExportedMembersChanged
+				} else {
+					changeReason = changeReason | TypeDiffReasonExportedMembersChangedCompatibly
+				}
 			}
 		}
 	}
@@ -161,6 +161,40 @@ var typeDiffTests = []typeDiffTest{
 		TypeDiffReasonExportedMembersChanged,
 	},
 
+	typeDiffTest{
+		"exported member changed compatibly test",
+		typegraph.TestType{"class", "SomeClass", "",
+			[]typegraph.TestGeneric{},
+			[]typegraph.TestMember{
+				typegraph.TestMember{typegraph.FunctionMemberSignature, "ExportedFunction", "int?", []typegraph.TestGeneric{}, []typegraph.TestParam{}},
+			},
+		},
+		typegraph.TestType{"class", "SomeClass", "",
+			[]typegraph.TestGeneric{},
+			[]typegraph.TestMember{
+				typegraph.TestMember{typegraph.FunctionMemberSignature, "ExportedFunction", "int", []typegraph.TestGeneric{}, []typegraph.TestParam{}},
+			},
+		},
+		TypeDiffReasonExportedMembersChangedCompatibly,
+	},
+
+	typeDiffTest{
+		"relaxed generic constraint test",
+		typegraph.TestType{"class", "SomeClass", "",
+			[]typegraph.TestGeneric{
+				typegraph.TestGeneric{"T", "int"},
+			},
+			[]typegraph.TestMember{},
+		},
+		typegraph.TestType{"class", "SomeClass", "",
+			[]typegraph.TestGeneric{
+				typegraph.TestGeneric{"T", "any"},
+			},
+			[]typegraph.TestMember{},
+		},
+		TypeDiffReasonGenericConstraintsRelaxed,
+	},
+
 	typeDiffTest{
 		"unexported member changed test",
 		typegraph.TestType{"class", "SomeClass", "",
@@ -4,28 +4,27 @@ package diff
 
 import "fmt"
 
-const (
-	_TypeDiffReason_name_0 = "TypeDiffReasonNotApplicableTypeDiffReasonKindChanged"
-	_TypeDiffReason_name_1 = "TypeDiffReasonGenericsChanged"
-	_TypeDiffReason_name_2 = "TypeDiffReasonExportedMembersChanged"
-)
+const _TypeDiffReason_name = "TypeDiffReasonNotApplicableTypeDiffReasonKindChangedTypeDiffReasonGenericsChangedTypeDiffReasonParentTypesChangedTypeDiffReasonPricipalTypeChangedTypeDiffReasonAttributesAddedTypeDiffReasonAttributesRemovedTypeDiffReasonExportedMembersAddedTypeDiffReasonExportedMembersRemovedTypeDiffReasonExportedMembersChangedTypeDiffReasonRequiredMemberAddedTypeDiffReasonExportedMembersChangedCompatiblyTypeDiffReasonGenericConstraintsRelaxed"
 
-var (
-	_TypeDiffReason_index_0 = [...]uint8{0, 27, 52}
-	_TypeDiffReason_index_1 = [...]uint8{0, 29}
-	_TypeDiffReason_index_2 = [...]uint8{0, 36}
-)
+var _TypeDiffReason_map = map[TypeDiffReason]string{
+	0:    _TypeDiffReason_name[0:27],
+	2:    _TypeDiffReason_name[27:52],
+	4:    _TypeDiffReason_name[52:81],
+	8:    _TypeDiffReason_name[81:113],
+	16:   _TypeDiffReason_name[113:146],
+	32:   _TypeDiffReason_name[146:175],
+	64:   _TypeDiffReason_name[175:206],
+	128:  _TypeDiffReason_name[206:240],
+	256:  _TypeDiffReason_name[240:276],
+	512:  _TypeDiffReason_name[276:312],
+	1024: _TypeDiffReason_name[312:345],
+	2048: _TypeDiffReason_name[345:391],
+	4096: _TypeDiffReason_name[391:430],
+}
 
 func (i TypeDiffReason) String() string {
-	switch {
-	case 1 <= i && i <= 2:
-		i -= 1
-		return _TypeDiffReason_name_0[_TypeDiffReason_index_0[i]:_TypeDiffReason_index_0[i+1]]
-	case i == 4:
-		return _TypeDiffReason_name_1
-	case i == 8:
-		return _TypeDiffReason_name_2
-	default:
-		return fmt.Sprintf("TypeDiffReason(%d)", i)
+	if str, ok := _TypeDiffReason_map[i]; ok {
+		return str
 	}
+	return fmt.Sprintf("TypeDiffReason(%d)", i)
 }
@@ -377,6 +377,54 @@ func (g *TypeGraph) defineImplicitMembers(typeDecl TGTypeDecl) {
 				Decorate()
 		})
 	}
+
+	// Enums define Values and String methods, as well as an Equals operator.
+	if typeDecl.TypeKind() == EnumType {
+		// static function<[]ThisType> Values()
+		g.defineMember(typeDecl, "Values", []string{}, func(decorator *MemberDecorator, generics map[string]TGGeneric) {
+			returnType := g.SliceTypeReference(g.NewInstanceTypeReference(typeDecl))
+			memberType := g.FunctionTypeReference(returnType)
+			decorator.
+				Static(true).
+				Promising(MemberNotPromising).
+				Exported(true).
+				ReadOnly(true).
+				MemberType(memberType).
+				MemberKind(FunctionMemberSignature).
+				CreateReturnable(decorator.member.GraphNode, returnType).
+				Decorate()
+		})
+
+		// operator Equals(left ThisType, right ThisType)
+		equals, _ := g.GetOperatorDefinition("equals")
+		g.defineOperator(typeDecl, equals, func(decorator *MemberDecorator, generics map[string]TGGeneric) {
+			var memberType = g.FunctionTypeReference(g.BoolTypeReference())
+			memberType = memberType.WithParameter(g.NewInstanceTypeReference(typeDecl))
+			memberType = memberType.WithParameter(g.NewInstanceTypeReference(typeDecl))
+
+			decorator.
+				MemberType(memberType).
+				Promising(MemberNotPromising).
+				Exported(true).
+				MemberKind(OperatorMemberSignature).
+				CreateReturnable(decorator.member.GraphNode, g.BoolTypeReference()).
+				Decorate()
+		})
+
+		// function<string> String()
+		g.defineMember(typeDecl, "String", []string{}, func(decorator *MemberDecorator, generics map[string]TGGeneric) {
+			memberType := g.FunctionTypeReference(g.StringTypeReference())
+			decorator.
+				Static(false).
+				Promising(MemberNotPromising).
+				Exported(true).
+				ReadOnly(true).
+				MemberType(memberType).
+				MemberKind(FunctionMemberSignature).
+				CreateReturnable(decorator.member.GraphNode, g.StringTypeReference()).
+				Decorate()
+		})
+	}
 }
 
 // checkForDuplicateNames ensures that there are not duplicate names defined in the graph.
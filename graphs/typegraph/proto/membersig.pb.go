@@ -31,12 +31,13 @@ var _ = math.Inf
 const _ = proto1.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type MemberSig struct {
-	MemberName         string   `protobuf:"bytes,1,opt,name=MemberName,proto3" json:"MemberName,omitempty"`
-	MemberKind         uint64   `protobuf:"varint,2,opt,name=MemberKind,proto3" json:"MemberKind,omitempty"`
-	IsWritable         bool     `protobuf:"varint,3,opt,name=IsWritable,proto3" json:"IsWritable,omitempty"`
-	IsExported         bool     `protobuf:"varint,4,opt,name=IsExported,proto3" json:"IsExported,omitempty"`
-	MemberType         string   `protobuf:"bytes,5,opt,name=MemberType,proto3" json:"MemberType,omitempty"`
-	GenericConstraints []string `protobuf:"bytes,6,rep,name=GenericConstraints" json:"GenericConstraints,omitempty"`
+	MemberName              string   `protobuf:"bytes,1,opt,name=MemberName,proto3" json:"MemberName,omitempty"`
+	MemberKind              uint64   `protobuf:"varint,2,opt,name=MemberKind,proto3" json:"MemberKind,omitempty"`
+	IsWritable              bool     `protobuf:"varint,3,opt,name=IsWritable,proto3" json:"IsWritable,omitempty"`
+	IsExported              bool     `protobuf:"varint,4,opt,name=IsExported,proto3" json:"IsExported,omitempty"`
+	MemberType              string   `protobuf:"bytes,5,opt,name=MemberType,proto3" json:"MemberType,omitempty"`
+	GenericConstraints      []string `protobuf:"bytes,6,rep,name=GenericConstraints" json:"GenericConstraints,omitempty"`
+	DefaultedParameterCount uint64   `protobuf:"varint,7,opt,name=DefaultedParameterCount,proto3" json:"DefaultedParameterCount,omitempty"`
 }
 
 func (m *MemberSig) Reset()                    { *m = MemberSig{} }
@@ -86,6 +87,13 @@ func (m *MemberSig) GetGenericConstraints() []string {
 	return nil
 }
 
+func (m *MemberSig) GetDefaultedParameterCount() uint64 {
+	if m != nil {
+		return m.DefaultedParameterCount
+	}
+	return 0
+}
+
 func init() {
 	proto1.RegisterType((*MemberSig)(nil), "proto.MemberSig")
 }
@@ -156,6 +164,11 @@ func (m *MemberSig) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if m.DefaultedParameterCount != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintMembersig(dAtA, i, uint64(m.DefaultedParameterCount))
+	}
 	return i, nil
 }
 
@@ -212,6 +225,9 @@ func (m *MemberSig) Size() (n int) {
 			n += 1 + l + sovMembersig(uint64(l))
 		}
 	}
+	if m.DefaultedParameterCount != 0 {
+		n += 1 + sovMembersig(uint64(m.DefaultedParameterCount))
+	}
 	return n
 }
 
@@ -403,6 +419,25 @@ func (m *MemberSig) Unmarshal(dAtA []byte) error {
 			}
 			m.GenericConstraints = append(m.GenericConstraints, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultedParameterCount", wireType)
+			}
+			m.DefaultedParameterCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMembersig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DefaultedParameterCount |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMembersig(dAtA[iNdEx:])
@@ -532,18 +567,19 @@ var (
 func init() { proto1.RegisterFile("membersig.proto", fileDescriptorMembersig) }
 
 var fileDescriptorMembersig = []byte{
-	// 193 bytes of a gzipped FileDescriptorProto
+	// 224 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcf, 0x4d, 0xcd, 0x4d,
 	0x4a, 0x2d, 0x2a, 0xce, 0x4c, 0xd7, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x4a,
-	0xb7, 0x19, 0xb9, 0x38, 0x7d, 0xc1, 0x52, 0xc1, 0x99, 0xe9, 0x42, 0x72, 0x5c, 0x5c, 0x10, 0x8e,
+	0x53, 0x99, 0xb8, 0x38, 0x7d, 0xc1, 0x52, 0xc1, 0x99, 0xe9, 0x42, 0x72, 0x5c, 0x5c, 0x10, 0x8e,
 	0x5f, 0x62, 0x6e, 0xaa, 0x04, 0xa3, 0x02, 0xa3, 0x06, 0x67, 0x10, 0x92, 0x08, 0x42, 0xde, 0x3b,
 	0x33, 0x2f, 0x45, 0x82, 0x49, 0x81, 0x51, 0x83, 0x25, 0x08, 0x49, 0x04, 0x24, 0xef, 0x59, 0x1c,
 	0x5e, 0x94, 0x59, 0x92, 0x98, 0x94, 0x93, 0x2a, 0xc1, 0xac, 0xc0, 0xa8, 0xc1, 0x11, 0x84, 0x24,
 	0x02, 0x91, 0x77, 0xad, 0x28, 0xc8, 0x2f, 0x2a, 0x49, 0x4d, 0x91, 0x60, 0x81, 0xc9, 0xc3, 0x44,
 	0x10, 0xe6, 0x87, 0x54, 0x16, 0xa4, 0x4a, 0xb0, 0x22, 0xdb, 0x0f, 0x12, 0x11, 0xd2, 0xe3, 0x12,
 	0x72, 0x4f, 0xcd, 0x4b, 0x2d, 0xca, 0x4c, 0x76, 0xce, 0xcf, 0x2b, 0x2e, 0x29, 0x4a, 0xcc, 0xcc,
-	0x2b, 0x29, 0x96, 0x60, 0x53, 0x60, 0xd6, 0xe0, 0x0c, 0xc2, 0x22, 0xe3, 0x24, 0x70, 0xe2, 0x91,
-	0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xce, 0x78, 0x2c, 0xc7, 0x90, 0xc4,
-	0x06, 0xf6, 0xb6, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x5b, 0xfb, 0x37, 0x37, 0x10, 0x01, 0x00,
-	0x00,
+	0x2b, 0x29, 0x96, 0x60, 0x53, 0x60, 0xd6, 0xe0, 0x0c, 0xc2, 0x22, 0x23, 0x64, 0xc1, 0x25, 0xee,
+	0x92, 0x9a, 0x96, 0x58, 0x9a, 0x53, 0x92, 0x9a, 0x12, 0x90, 0x58, 0x94, 0x98, 0x9b, 0x5a, 0x92,
+	0x5a, 0xe4, 0x9c, 0x5f, 0x9a, 0x57, 0x22, 0xc1, 0x0e, 0x76, 0x3c, 0x2e, 0x69, 0x27, 0x81, 0x13,
+	0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc6, 0x63, 0x39, 0x86,
+	0x24, 0x36, 0x70, 0x80, 0x19, 0x03, 0x06, 0x00, 0x05, 0x38, 0xac, 0xb4, 0x4a, 0x01, 0x00, 0x00,
 }
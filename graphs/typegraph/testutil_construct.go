@@ -148,6 +148,13 @@ func (t *testBasicTypesConstructor) DefineTypes(builder GetTypeBuilder) {
 		GlobalAlias("$stringifier").
 		Define()
 
+	builder(*t.moduleNode).
+		Name("valuetuple").
+		GlobalId("valuetuple").
+		SourceNode(t.CreateNode(fakeNodeTypeTagged)).
+		GlobalAlias("valuetuple").
+		Define()
+
 	funcGenBuilder := builder(*t.moduleNode).
 		Name("function").
 		GlobalId("function").
@@ -132,11 +132,18 @@ func resolveTypeRefFromSourceNodes(name string, graph *TypeGraph, refSourceNodes
 
 	// Attempt to resolve as a global alias to a type.
 	aliasedType, found := graph.LookupGlobalAliasedType(name)
-	if !found {
-		panic(fmt.Sprintf("Could not resolve type path %s", name))
+	if found {
+		return graph.NewTypeReference(aliasedType)
 	}
 
-	return graph.NewTypeReference(aliasedType)
+	// Attempt to resolve as a type defined in another module.
+	for _, typeDecl := range graph.TypeDecls() {
+		if typeDecl.Name() == name {
+			return graph.NewTypeReference(typeDecl)
+		}
+	}
+
+	panic(fmt.Sprintf("Could not resolve type path %s", name))
 }
 
 // resolveTypeRefFromTypeOrMember attempts to resolve the given type name, starting at the given type or member and moving upwards.
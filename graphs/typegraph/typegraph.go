@@ -19,9 +19,9 @@ import (
 )
 
 // Note: The list below does not contain NodeTypeAlias, which is a special handled case in LookupType.
-var TYPE_NODE_TYPES = []NodeType{NodeTypeClass, NodeTypeInterface, NodeTypeExternalInterface, NodeTypeNominalType, NodeTypeStruct, NodeTypeAgent}
+var TYPE_NODE_TYPES = []NodeType{NodeTypeClass, NodeTypeInterface, NodeTypeExternalInterface, NodeTypeNominalType, NodeTypeStruct, NodeTypeAgent, NodeTypeEnum, NodeTypeExtension}
 
-var TYPE_NODE_TYPES_TAGGED = []compilergraph.TaggedValue{NodeTypeClass, NodeTypeInterface, NodeTypeExternalInterface, NodeTypeNominalType, NodeTypeStruct, NodeTypeAgent, NodeTypeAlias}
+var TYPE_NODE_TYPES_TAGGED = []compilergraph.TaggedValue{NodeTypeClass, NodeTypeInterface, NodeTypeExternalInterface, NodeTypeNominalType, NodeTypeStruct, NodeTypeAgent, NodeTypeEnum, NodeTypeExtension, NodeTypeAlias}
 
 // TypeGraph represents the TypeGraph layer and all its associated helper methods.
 type TypeGraph struct {
@@ -30,6 +30,9 @@ type TypeGraph struct {
 	operators          map[string]operatorDefinition // The supported operators.
 	globalAliasedTypes map[string]TGTypeDecl         // The aliased types.
 	constructors       []TypeGraphConstructor        // The constructors for this type graph.
+
+	extensions    map[compilergraph.GraphNodeId][]TGTypeDecl                         // The extensions of each type, by type node ID.
+	moduleImports map[compilercommon.InputSource]map[compilercommon.InputSource]bool // The paths of the modules imported by each module.
 }
 
 // Result represents the results of building a type graph.
@@ -75,6 +78,8 @@ func BuildTypeGraphWithOption(graph compilergraph.SerulianGraph, buildOption Bui
 		operators:          map[string]operatorDefinition{},
 		globalAliasedTypes: map[string]TGTypeDecl{},
 		constructors:       constructors,
+		extensions:         map[compilergraph.GraphNodeId][]TGTypeDecl{},
+		moduleImports:      map[compilercommon.InputSource]map[compilercommon.InputSource]bool{},
 	}
 
 	if buildOption != BuildForTesting {
@@ -162,6 +167,10 @@ func BuildTypeGraphWithOption(graph compilergraph.SerulianGraph, buildOption Bui
 		modifier.Apply()
 	}
 
+	// Build the extensions map. Requires the dependencies annotated, as it makes use of the
+	// extended types.
+	typeGraph.buildExtensionsMap()
+
 	if cancelationHandle.WasCanceled() {
 		return emptyResult(typeGraph), fmt.Errorf("Construction was canceled")
 	}
@@ -243,6 +252,10 @@ func BuildTypeGraphWithOption(graph compilergraph.SerulianGraph, buildOption Bui
 	// Define implicit members.
 	typeGraph.defineAllImplicitMembers(cancelationHandle)
 
+	// Validate extensions against the types they extend. This occurs after implicit members are
+	// defined to ensure extension members do not conflict with them.
+	typeGraph.validateExtensions(cancelationHandle)
+
 	// If there are no errors yet, validate everything.
 	if _, hasError := typeGraph.layer.StartQuery().In(NodePredicateError).TryGetNode(); !hasError {
 		modifier := typeGraph.layer.NewModifier()
@@ -514,6 +527,12 @@ func (g *TypeGraph) GetTypeOrMemberForNode(node compilergraph.GraphNode) (TGType
 	case NodeTypeAgent:
 		fallthrough
 
+	case NodeTypeEnum:
+		fallthrough
+
+	case NodeTypeExtension:
+		fallthrough
+
 	case NodeTypeGeneric:
 		return TGTypeDecl{node, g}, true
 
@@ -0,0 +1,181 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typegraph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/graphs/srg"
+)
+
+// buildExtensionsMap builds the map from each extended type to the extensions declared for it.
+func (g *TypeGraph) buildExtensionsMap() {
+	eit := g.findAllNodes(NodeTypeExtension).
+		BuildNodeIterator()
+
+	for eit.Next() {
+		extension := TGTypeDecl{eit.Node(), g}
+		extendedType, hasExtendedType := extension.ExtendedType()
+		if !hasExtendedType || !extendedType.IsNormal() || extendedType.IsNullable() {
+			continue
+		}
+
+		extendedTypeNode := extendedType.referredTypeNode()
+		g.extensions[extendedTypeNode.NodeId] = append(g.extensions[extendedTypeNode.NodeId], extension)
+	}
+}
+
+// Extensions returns the extensions declared for this type, if any.
+func (tn TGTypeDecl) Extensions() []TGTypeDecl {
+	return tn.tdg.extensions[tn.GraphNode.NodeId]
+}
+
+// IsExtensionVisibleTo returns whether the members of this extension can be resolved from the module
+// with the given path. Extensions are visible within their own package and to any module
+// which imports their declaring module.
+func (tn TGTypeDecl) IsExtensionVisibleTo(modulePath compilercommon.InputSource) bool {
+	extensionModulePath := tn.ParentModule().Path()
+	if srg.InSamePackage(extensionModulePath, modulePath) {
+		return true
+	}
+
+	return tn.tdg.moduleImports[modulePath][extensionModulePath]
+}
+
+// ExtensionReferenceUnder returns a reference to this extension under the given reference to its
+// extended type (or a generic constrained to it), with the generics of the extended type applied.
+func (tn TGTypeDecl) ExtensionReferenceUnder(extendedTypeRef TypeReference) TypeReference {
+	resolutionType := extendedTypeRef.referenceOrConstraint()
+	return tn.tdg.NewTypeReference(tn, resolutionType.Generics()...)
+}
+
+// resolveExtensionMember looks for an instance member with the given name under the extensions of
+// the referred type visible to the given module, returning it (if any) or an error if the name is
+// ambiguous between multiple extensions.
+func (tr TypeReference) resolveExtensionMember(memberName string, modulePath compilercommon.InputSource, kind MemberResolutionKind) (TGMember, bool, error) {
+	if kind == MemberResolutionOperator || kind == MemberResolutionStatic || !tr.IsNormal() {
+		return TGMember{}, false, nil
+	}
+
+	resolutionType := tr.referenceOrConstraint()
+	if !resolutionType.IsNormal() {
+		return TGMember{}, false, nil
+	}
+
+	var found = make([]TGMember, 0, 1)
+	for _, extension := range resolutionType.ReferredType().Extensions() {
+		if !extension.IsExtensionVisibleTo(modulePath) {
+			continue
+		}
+
+		member, hasMember := extension.GetMember(memberName)
+		if hasMember {
+			found = append(found, member)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return TGMember{}, false, nil
+
+	case 1:
+		return found[0], true, nil
+
+	default:
+		var extensionNames = make([]string, 0, len(found))
+		for _, member := range found {
+			extension, _ := member.ParentType()
+			extensionNames = append(extensionNames, extension.Name())
+		}
+
+		sort.Strings(extensionNames)
+		return TGMember{}, false, fmt.Errorf("Name '%v' under %v is ambiguous: it is defined by both extension '%v' and extension '%v'", memberName, tr.TitledString(), extensionNames[0], extensionNames[1])
+	}
+}
+
+// transformUnderExtensions returns the given type reference with the generics of any extensions of
+// the given type replaced by the given generics. Since extension generics map positionally to those
+// of the extended type, this transforms an extension member's type under a reference to the
+// extended type.
+func (tr TypeReference) transformUnderExtensions(extendedType TGTypeDecl, generics []TypeReference) TypeReference {
+	var currentTypeReference = tr
+	for _, extension := range extendedType.Extensions() {
+		extensionGenerics := extension.Generics()
+		if len(extensionGenerics) != len(generics) {
+			continue
+		}
+
+		for index, generic := range generics {
+			currentTypeReference = currentTypeReference.ReplaceType(extensionGenerics[index].AsType(), generic)
+		}
+	}
+
+	return currentTypeReference
+}
+
+// validateExtensions ensures that all extensions extend a valid type with matching generics, and
+// that their members do not conflict with those of the extended type.
+func (g *TypeGraph) validateExtensions(cancelationHandle compilerutil.CancelationHandle) {
+	modifier := g.layer.NewModifier()
+	defer modifier.ApplyOrClose(!cancelationHandle.WasCanceled())
+
+	g.ForEachTypeDecl([]NodeType{NodeTypeExtension}, func(extension TGTypeDecl) bool {
+		if cancelationHandle.WasCanceled() {
+			return false
+		}
+
+		extendedType, hasExtendedType := extension.ExtendedType()
+		if !hasExtendedType {
+			return true
+		}
+
+		if !extendedType.IsNormal() || extendedType.IsNullable() || extendedType.IsRefToExtension() ||
+			extendedType.ReferredType().TypeKind() == GenericType {
+			g.decorateWithError(
+				modifier.Modify(extension.GraphNode),
+				"Extension '%s' cannot extend type '%s'", extension.Name(), extendedType)
+			return true
+		}
+
+		// Ensure the generics of the extension are exactly those of the extended type, in order.
+		extensionGenerics := extension.Generics()
+		extendedGenerics := extendedType.Generics()
+
+		var genericsMatch = len(extensionGenerics) == len(extendedGenerics)
+		for index := 0; genericsMatch && index < len(extendedGenerics); index++ {
+			genericsMatch = extendedGenerics[index] == extensionGenerics[index].GetTypeReference()
+		}
+
+		if !genericsMatch {
+			g.decorateWithError(
+				modifier.Modify(extension.GraphNode),
+				"Extension '%s' must declare the generics of extended type '%s', in order, and pass them to it",
+				extension.Name(), extendedType.ReferredType().Name())
+			return true
+		}
+
+		// Ensure none of the members of the extension conflict with those of the extended type.
+		// Extension members cannot hold state, so properties must be read-only.
+		for _, member := range extension.Members() {
+			if !member.IsReadOnly() {
+				g.decorateWithError(
+					modifier.Modify(member.GraphNode),
+					"Extension member '%s' must be read-only", member.Name())
+			}
+
+			if _, found := extendedType.ResolveMember(member.Name(), MemberResolutionInstanceOrStatic); found {
+				g.decorateWithError(
+					modifier.Modify(member.GraphNode),
+					"Extension member '%s' conflicts with an existing member of the same name under type '%s'",
+					member.Name(), extendedType.ReferredType().Name())
+			}
+		}
+
+		return true
+	})
+}
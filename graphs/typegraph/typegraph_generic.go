@@ -5,6 +5,9 @@
 package typegraph
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/serulian/compiler/compilergraph"
 )
 
@@ -53,3 +56,148 @@ func (tn TGGeneric) Title() string {
 func (tn TGGeneric) AsType() TGTypeDecl {
 	return TGTypeDecl{tn.GraphNode, tn.tdg}
 }
+
+// InferGenerics infers the types of the given generics by matching the given parameter types (which
+// may refer to the generics) against the types of the arguments given for those parameters. An error
+// is returned if no argument clarifies a generic, if the types found for a generic are ambiguous, or if
+// an inferred type does not meet its generic's constraint.
+func (g *TypeGraph) InferGenerics(generics []TGGeneric, parameterTypes []TypeReference, argumentTypes []TypeReference) ([]TypeReference, error) {
+	candidates := map[compilergraph.GraphNodeId][]TypeReference{}
+	for index, argumentType := range argumentTypes {
+		if index >= len(parameterTypes) {
+			break
+		}
+
+		collectGenericCandidates(generics, parameterTypes[index], argumentType, candidates)
+	}
+
+	// For each generic, choose the candidate type of which all other candidates are subtypes.
+	inferred := make([]TypeReference, len(generics))
+	for index, generic := range generics {
+		genericCandidates := candidates[generic.GraphNode.NodeId]
+		if len(genericCandidates) == 0 {
+			return nil, fmt.Errorf("No argument clarifies generic %v; please specify the generics explicitly", generic.Name())
+		}
+
+		chosen, found := chooseGenericCandidate(genericCandidates)
+		if !found {
+			var names = make([]string, 0, len(genericCandidates))
+			var encountered = map[string]bool{}
+			for _, candidate := range genericCandidates {
+				name := candidate.String()
+				if !encountered[name] {
+					encountered[name] = true
+					names = append(names, name)
+				}
+			}
+
+			return nil, fmt.Errorf("Ambiguous inference for generic %v: no common type found between %v; please specify the generics explicitly", generic.Name(), strings.Join(names, ", "))
+		}
+
+		inferred[index] = chosen
+	}
+
+	// Ensure that the inferred types meet the constraints of the generics. Note that constraints
+	// can refer to other generics, which are replaced with their inferred types.
+	for index, generic := range generics {
+		constraint := generic.Constraint()
+		for otherIndex, otherGeneric := range generics {
+			constraint = constraint.ReplaceType(otherGeneric.AsType(), inferred[otherIndex])
+		}
+
+		if serr := inferred[index].CheckSubTypeOf(constraint); serr != nil {
+			return nil, fmt.Errorf("Inferred type %v for generic %v does not meet its constraint: %v", inferred[index], generic.Name(), serr)
+		}
+	}
+
+	return inferred, nil
+}
+
+// collectGenericCandidates adds to the candidates map the types found for any of the given generics
+// by matching the parameter type against the argument type given for it.
+func collectGenericCandidates(generics []TGGeneric, parameterType TypeReference, argumentType TypeReference, candidates map[compilergraph.GraphNodeId][]TypeReference) {
+	if !parameterType.IsNormal() || argumentType.IsNull() || argumentType.IsVoid() {
+		return
+	}
+
+	// If the parameter is a reference to a generic being inferred, the argument's type is a candidate.
+	parameterGeneric, isGeneric := parameterType.ReferredType().AsGeneric()
+	if isGeneric {
+		for _, generic := range generics {
+			if generic.GraphNode.NodeId != parameterGeneric.GraphNode.NodeId {
+				continue
+			}
+
+			candidateType := argumentType
+			if parameterType.IsNullable() {
+				candidateType = candidateType.AsNonNullable()
+			}
+
+			candidates[generic.GraphNode.NodeId] = append(candidates[generic.GraphNode.NodeId], candidateType)
+		}
+
+		return
+	}
+
+	if !argumentType.IsNormal() || (!parameterType.HasGenerics() && !parameterType.HasParameters()) {
+		return
+	}
+
+	if argumentType.IsNullable() {
+		if !parameterType.IsNullable() {
+			return
+		}
+
+		argumentType = argumentType.AsNonNullable()
+	}
+
+	// If the argument refers to the same type as the parameter, match their generics and parameters
+	// pairwise.
+	parameterTypeDecl := parameterType.ReferredType()
+	if argumentType.ReferredType().GraphNode.NodeId == parameterTypeDecl.GraphNode.NodeId {
+		collectPairwiseCandidates(generics, parameterType.Generics(), argumentType.Generics(), candidates)
+		collectPairwiseCandidates(generics, parameterType.Parameters(), argumentType.Parameters(), candidates)
+		return
+	}
+
+	// Otherwise, if the parameter is a generic interface, find the generics under which the argument
+	// implements it.
+	if parameterTypeDecl.TypeKind() == ImplicitInterfaceType && parameterTypeDecl.HasGenerics() {
+		implementedGenerics, err := argumentType.CheckConcreteSubtypeOf(parameterTypeDecl)
+		if err == nil {
+			collectPairwiseCandidates(generics, parameterType.Generics(), implementedGenerics, candidates)
+		}
+	}
+}
+
+// collectPairwiseCandidates collects generic candidates for each pair of parameter and argument types,
+// if their counts match.
+func collectPairwiseCandidates(generics []TGGeneric, parameterTypes []TypeReference, argumentTypes []TypeReference, candidates map[compilergraph.GraphNodeId][]TypeReference) {
+	if len(parameterTypes) != len(argumentTypes) {
+		return
+	}
+
+	for index, parameterType := range parameterTypes {
+		collectGenericCandidates(generics, parameterType, argumentTypes[index], candidates)
+	}
+}
+
+// chooseGenericCandidate returns the candidate type of which all other candidates are subtypes,
+// if any.
+func chooseGenericCandidate(candidates []TypeReference) (TypeReference, bool) {
+	for _, candidate := range candidates {
+		var isCommon = true
+		for _, other := range candidates {
+			if other.CheckSubTypeOf(candidate) != nil {
+				isCommon = false
+				break
+			}
+		}
+
+		if isCommon {
+			return candidate, true
+		}
+	}
+
+	return TypeReference{}, false
+}
@@ -92,6 +92,8 @@ func (tn TGMember) Title() string {
 	if underType {
 		if tn.IsOperator() {
 			return "operator"
+		} else if tn.IsEnumValue() {
+			return "enum value"
 		} else {
 			return "type member"
 		}
@@ -242,6 +244,17 @@ func (tn TGMember) IsField() bool {
 	return isField
 }
 
+// IsEnumValue returns whether the member is a value declared under an enum type.
+func (tn TGMember) IsEnumValue() bool {
+	return MemberSignatureKind(tn.Signature().MemberKind) == EnumValueMemberSignature
+}
+
+// IsExtensionMember returns whether the member is declared under an extension of another type.
+func (tn TGMember) IsExtensionMember() bool {
+	parentType, hasParentType := tn.ParentType()
+	return hasParentType && parentType.IsExtension()
+}
+
 // InvokesAsync returns whether the member invokes asynchronously.
 func (tn TGMember) InvokesAsync() bool {
 	_, invokesAsync := tn.GraphNode.TryGet(NodePredicateMemberInvokesAsync)
@@ -131,6 +131,8 @@ func (tn TGModule) SourceGraphId() string {
 		return "srg"
 	} else if strings.HasSuffix(path, ".webidl") {
 		return "webidl"
+	} else if strings.HasSuffix(path, ".d.ts") {
+		return "typescript"
 	} else {
 		return "typegraph"
 	}
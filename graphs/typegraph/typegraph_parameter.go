@@ -32,3 +32,9 @@ func (tn TGParameter) Documentation() (string, bool) {
 func (tn TGParameter) DeclaredType() TypeReference {
 	return tn.GraphNode.GetTagged(NodePredicateParameterType, tn.tdg.AnyTypeReference()).(TypeReference)
 }
+
+// HasDefaultValue returns whether this parameter has a default value, making its argument optional.
+func (tn TGParameter) HasDefaultValue() bool {
+	_, hasDefaultValue := tn.GraphNode.TryGet(NodePredicateParameterHasDefaultValue)
+	return hasDefaultValue
+}
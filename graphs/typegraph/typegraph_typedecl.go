@@ -7,6 +7,7 @@ package typegraph
 import (
 	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/serulian/compiler/compilercommon"
 	"github.com/serulian/compiler/compilergraph"
@@ -34,6 +35,8 @@ const (
 	AgentType
 	GenericType
 	AliasType
+	EnumType
+	ExtensionType
 )
 
 // TGTypeDeclaration represents a type declaration (class, interface or generic) in the type graph.
@@ -118,6 +121,12 @@ func (tn TGTypeDecl) Title() string {
 	case NodeTypeAgent:
 		return "agent"
 
+	case NodeTypeEnum:
+		return "enum"
+
+	case NodeTypeExtension:
+		return "extension"
+
 	case NodeTypeAlias:
 		return "type alias"
 
@@ -404,6 +413,16 @@ func (tn TGTypeDecl) PrincipalType() (TypeReference, bool) {
 	return principalTypeRef.(TypeReference), true
 }
 
+// ExtendedType returns the type extended by this extension.
+func (tn TGTypeDecl) ExtendedType() (TypeReference, bool) {
+	extendedTypeRef, hasExtendedType := tn.GraphNode.TryGetTagged(NodePredicateExtendedType, tn.tdg.AnyTypeReference())
+	if !hasExtendedType {
+		return tn.tdg.VoidTypeReference(), false
+	}
+
+	return extendedTypeRef.(TypeReference), true
+}
+
 // Parent returns the module containing this type.
 func (tn TGTypeDecl) Parent() TGTypeOrModule {
 	return tn.ParentModule()
@@ -527,6 +546,61 @@ func (tn TGTypeDecl) IsClass() bool {
 	return tn.TypeKind() == ClassType
 }
 
+// IsExtension returns true if this type is an extension of another type.
+func (tn TGTypeDecl) IsExtension() bool {
+	return tn.TypeKind() == ExtensionType
+}
+
+// IsEnum returns true if this type is an enum.
+func (tn TGTypeDecl) IsEnum() bool {
+	return tn.TypeKind() == EnumType
+}
+
+// EnumValues returns the values declared under this enum type, in declaration order. Returns
+// an empty list for non-enum types.
+func (tn TGTypeDecl) EnumValues() []TGMember {
+	var values = make([]TGMember, 0)
+	for _, member := range tn.Members() {
+		if member.IsEnumValue() {
+			values = append(values, member)
+		}
+	}
+
+	sort.Sort(byDeclarationOrder(values))
+	return values
+}
+
+// byDeclarationOrder sorts members by the position at which they were declared in source.
+type byDeclarationOrder []TGMember
+
+func (s byDeclarationOrder) Len() int {
+	return len(s)
+}
+
+func (s byDeclarationOrder) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s byDeclarationOrder) Less(i, j int) bool {
+	return declarationPosition(s[i]) < declarationPosition(s[j])
+}
+
+// declarationPosition returns the rune position at which the given member was declared, or -1
+// if none.
+func declarationPosition(member TGMember) int {
+	sourceRange, hasSourceRange := member.SourceRange()
+	if !hasSourceRange {
+		return -1
+	}
+
+	position, err := sourceRange.Start().RunePosition()
+	if err != nil {
+		return -1
+	}
+
+	return position
+}
+
 // AliasedType returns the type aliased by this type alias.
 func (tn TGTypeDecl) AliasedType() (TGTypeDecl, bool) {
 	aliasedTypeNode, hasAliasedType := tn.TryGetNode(NodePredicateAliasedType)
@@ -624,6 +698,22 @@ func (tn TGTypeDecl) Code() (compilercommon.CodeSummary, bool) {
 		buffer.WriteString("> ")
 		buffer.WriteString(tn.Name())
 
+	case EnumType:
+		buffer.WriteString("enum ")
+		buffer.WriteString(tn.Name())
+
+	case ExtensionType:
+		ets := "?"
+		extendedType, hasExtendedType := tn.ExtendedType()
+		if hasExtendedType {
+			ets = extendedType.String()
+		}
+
+		buffer.WriteString("extension ")
+		buffer.WriteString(tn.Name())
+		buffer.WriteString(" for ")
+		buffer.WriteString(ets)
+
 	case GenericType:
 		buffer.WriteString(tn.Name())
 		buffer.WriteString(" (generic)")
@@ -663,6 +753,12 @@ func (tn TGTypeDecl) TypeKind() TypeKind {
 	case NodeTypeAgent:
 		return AgentType
 
+	case NodeTypeEnum:
+		return EnumType
+
+	case NodeTypeExtension:
+		return ExtensionType
+
 	case NodeTypeGeneric:
 		return GenericType
 
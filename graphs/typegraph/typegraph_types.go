@@ -52,6 +52,12 @@ const (
 
 	// NodeType is a tagged type.
 	NodeTypeTagged
+
+	// An enum type.
+	NodeTypeEnum
+
+	// An extension of an existing type.
+	NodeTypeExtension
 )
 
 const (
@@ -148,6 +154,13 @@ const (
 	// Connects a type alias to the type it is aliasing.
 	NodePredicateAliasedType = "aliased-type"
 
+	//
+	// NodeTypeExtension
+	//
+
+	// Marks an extension with a type reference to the type it extends.
+	NodePredicateExtendedType = "extended-type"
+
 	//
 	// NodeTypeAgentReference
 	//
@@ -257,6 +270,9 @@ const (
 	// Decorates a parameter with its type.
 	NodePredicateParameterType = "parameter-type"
 
+	// Marks a parameter as having a default value.
+	NodePredicateParameterHasDefaultValue = "parameter-has-default-value"
+
 	//
 	// NodeTypeMemberTag
 	//
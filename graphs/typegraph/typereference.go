@@ -280,7 +280,7 @@ func (tr TypeReference) IsNominalWrapOf(other TypeReference) bool {
 // EnsureStructural ensures that the type reference and all sub-references are structural
 // in nature. A "structural" type, as allowed by this pass, must meet the following rules:
 //
-// 1) The type is marked with a 'serializable' annotation OR
+// 1) The type is a tuple or is marked with a 'serializable' annotation OR
 // 2) The type is a `struct` OR
 // 3) The type refers to a generic OR
 // 4) The type is a nominal type around #1, #2 or #3 AND
@@ -313,8 +313,8 @@ func (tr TypeReference) EnsureStructural() error {
 		}
 
 	default:
-		// Otherwise, the type must have a 'serializable' annotation.
-		if !referredType.HasAttribute(SERIALIZABLE_ATTRIBUTE) {
+		// Otherwise, the type must be a tuple or have a 'serializable' annotation.
+		if !tr.IsTuple() && !referredType.HasAttribute(SERIALIZABLE_ATTRIBUTE) {
 			return fmt.Errorf("%v is not structural nor serializable", tr)
 		}
 	}
@@ -359,6 +359,11 @@ func (tr TypeReference) IsRefToAgent() bool {
 	return tr.IsNormal() && tr.ReferredType().TypeKind() == AgentType
 }
 
+// IsRefToExtension returns whether the referenced type is an extension.
+func (tr TypeReference) IsRefToExtension() bool {
+	return tr.IsNormal() && tr.ReferredType().TypeKind() == ExtensionType
+}
+
 // IsRefToClass returns whether the referenced type is a class.
 func (tr TypeReference) IsRefToClass() bool {
 	return tr.IsNormal() && tr.ReferredType().TypeKind() == ClassType
@@ -670,6 +675,12 @@ func (tr TypeReference) CheckSubTypeOfWithExceptions(other TypeReference, except
 		return nil, NoSubTypingExceptions
 	}
 
+	// If both types are tuples, then this type is a subtype if each of its elements is a subtype
+	// of the matching element in the other tuple.
+	if left.IsTuple() && other.IsTuple() {
+		return left.checkTupleSubTypeOf(other, originalOther), NoSubTypingExceptions
+	}
+
 	// If the constraint is 'any', then we know the generic cannot be used.
 	if left.IsAny() {
 		return fmt.Errorf("Cannot use type '%v' in place of type '%v'", tr, other), NoSubTypingExceptions
@@ -758,6 +769,24 @@ func (tr TypeReference) CheckSubTypeOfWithExceptions(other TypeReference, except
 	return nil, NoSubTypingExceptions
 }
 
+// checkTupleSubTypeOf returns whether this tuple type reference is a subtype of the other tuple
+// type reference, by comparing their elements.
+func (tr TypeReference) checkTupleSubTypeOf(other TypeReference, originalOther TypeReference) error {
+	localElements := tr.Parameters()
+	otherElements := other.Parameters()
+	if len(localElements) != len(otherElements) {
+		return fmt.Errorf("'%v' cannot be used in place of '%v', as they have a different number of elements", tr, originalOther)
+	}
+
+	for index, element := range localElements {
+		if serr := element.CheckSubTypeOf(otherElements[index]); serr != nil {
+			return fmt.Errorf("'%v' cannot be used in place of '%v': Element #%v does not match: %v", tr, originalOther, index+1, serr)
+		}
+	}
+
+	return nil
+}
+
 // buildSubtypeMismatchError returns an error describing the mismatch between the two types for the given
 // member name.
 func buildSubtypeMismatchError(tr TypeReference, left TypeReference, right TypeReference, memberName string) error {
@@ -931,6 +960,17 @@ func (tr TypeReference) Parameters() []TypeReference {
 	return tr.getSubReferences(subReferenceParameter)
 }
 
+// IsTuple returns whether the type reference refers to a tuple type. The elements of the
+// tuple are found as the parameters of the reference.
+func (tr TypeReference) IsTuple() bool {
+	if !tr.IsNormal() {
+		return false
+	}
+
+	tupleType, found := tr.tdg.LookupGlobalAliasedType("valuetuple")
+	return found && tr.referredTypeNode() == tupleType.GraphNode
+}
+
 // IsNullable returns whether the type reference refers to a nullable type.
 func (tr TypeReference) IsNullable() bool {
 	return tr.getSlot(trhSlotFlagNullable)[0] == nullableFlagTrue
@@ -1054,6 +1094,16 @@ func (tr TypeReference) ResolveMember(memberName string, kind MemberResolutionKi
 // ResolveAccessibleMember looks for an member with the given name under the referred type and returns it (if any).
 func (tr TypeReference) ResolveAccessibleMember(memberName string, modulePath compilercommon.InputSource, kind MemberResolutionKind) (TGMember, error) {
 	member, found := tr.ResolveMember(memberName, kind)
+	if !found {
+		// Check the extensions of the type visible to the module, if any.
+		extensionMember, hasExtensionMember, err := tr.resolveExtensionMember(memberName, modulePath, kind)
+		if err != nil {
+			return TGMember{}, err
+		}
+
+		member, found = extensionMember, hasExtensionMember
+	}
+
 	if !found {
 		adjusted := tr.tdg.adjustedName(memberName)
 		_, otherSpellingFound := tr.ResolveMember(adjusted, kind)
@@ -1249,7 +1299,7 @@ func (tr TypeReference) TransformUnder(other TypeReference) TypeReference {
 		currentTypeReference = currentTypeReference.ReplaceType(otherTypeGenerics[index].AsType(), generic)
 	}
 
-	return currentTypeReference
+	return currentTypeReference.transformUnderExtensions(otherType, otherRefGenerics)
 }
 
 // ReplaceType returns a copy of this type reference, with the given type node replaced with the
@@ -1420,6 +1470,24 @@ func (tr TypeReference) appendHumanString(buffer *bytes.Buffer, option humanStri
 		return
 	}
 
+	if tr.IsTuple() {
+		buffer.WriteRune('(')
+		for index, element := range tr.Parameters() {
+			if index > 0 {
+				buffer.WriteString(", ")
+			}
+
+			element.appendHumanString(buffer, option&humanStringPackageQualified)
+		}
+
+		buffer.WriteByte(')')
+
+		if tr.IsNullable() {
+			buffer.WriteByte('?')
+		}
+		return
+	}
+
 	typeNode := tr.referredTypeNode()
 	if typeNode.Kind() == NodeTypeGeneric {
 		if option&humanStringPackageQualified == humanStringPackageQualified {
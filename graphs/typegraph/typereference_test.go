@@ -918,6 +918,24 @@ func TestSubtypes(t *testing.T) {
 
 		// Q of SomeGenericStruct is a subtype of struct
 		subtypeCheckTest{"SomeGenericStruct::Q subtype of struct", "SomeGenericStruct::Q", "struct", ""},
+
+		// (SomeClass, int) is a subtype of (IWithMethod, int)
+		subtypeCheckTest{"(SomeClass, int) subtype of (IWithMethod, int)", "valuetuple(SomeClass, int)", "valuetuple(IWithMethod, int)", ""},
+
+		// (int, string) is a subtype of (int, string)?
+		subtypeCheckTest{"(int, string) subtype of (int, string)?", "valuetuple(int, string)", "valuetuple(int, string)?", ""},
+
+		// (int, string)? is not a subtype of (int, string)
+		subtypeCheckTest{"(int, string)? not subtype of (int, string)", "valuetuple(int, string)?", "valuetuple(int, string)",
+			"Nullable type '(int, string)?' cannot be used in place of non-nullable type '(int, string)'"},
+
+		// (int, bool) is not a subtype of (int, string)
+		subtypeCheckTest{"(int, bool) not subtype of (int, string)", "valuetuple(int, bool)", "valuetuple(int, string)",
+			"'(int, bool)' cannot be used in place of '(int, string)': Element #2 does not match: 'bool' cannot be used in place of non-interface 'string'"},
+
+		// (int, string) is not a subtype of (int, string, bool)
+		subtypeCheckTest{"(int, string) not subtype of (int, string, bool)", "valuetuple(int, string)", "valuetuple(int, string, bool)",
+			"'(int, string)' cannot be used in place of '(int, string, bool)', as they have a different number of elements"},
 	}
 
 	for _, test := range tests {
@@ -1190,6 +1208,12 @@ func TestEnsureStructural(t *testing.T) {
 
 		// GenericNominalOverNonStruct
 		ensureStructuralTest{"GenericNominalOverNonStruct is not structural", "GenericNominalOverNonStruct", "Nominal type GenericNominalOverNonStruct wraps non-structural type GenericStruct<any>: GenericStruct<any> has non-structural generic type any: Type any is not guarenteed to be structural"},
+
+		// (SomeStruct, StructuralNominal)
+		ensureStructuralTest{"(SomeStruct, StructuralNominal) is structural", "valuetuple(SomeStruct, StructuralNominal)", ""},
+
+		// (SomeStruct, SomeClass)
+		ensureStructuralTest{"(SomeStruct, SomeClass) is not structural", "valuetuple(SomeStruct, SomeClass)", "(SomeStruct, SomeClass) has non-structural parameter type SomeClass: SomeClass is not structural nor serializable"},
 	}
 
 	for _, test := range tests {
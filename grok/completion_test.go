@@ -129,6 +129,32 @@ var grokCompletionTests = []grokCompletionTest{
 		},
 	},
 
+	grokCompletionTest{"enum",
+		[]grokCompletionSubTest{
+			// Enum value completions.
+			grokCompletionSubTest{"sl", "Color.", []expectedCompletion{
+				expectedCompletion{EnumValueCompletion, "Red", "Red", "", "Color"},
+				expectedCompletion{EnumValueCompletion, "Green", "Green", "", "Color"},
+				expectedCompletion{EnumValueCompletion, "Blue", "Blue", "", "Color"},
+				expectedCompletion{MemberCompletion, "Values", "Values", "", "function<Slice<Color>>"},
+			}},
+
+			grokCompletionSubTest{"sl", "c.", []expectedCompletion{
+				expectedCompletion{MemberCompletion, "String", "String", "", "function<String>"},
+			}},
+		},
+	},
+
+	grokCompletionTest{"extension",
+		[]grokCompletionSubTest{
+			// Extension member completions.
+			grokCompletionSubTest{"sl", "sc.", []expectedCompletion{
+				expectedCompletion{MemberCompletion, "DoSomething", "DoSomething", "", "function<void>"},
+				expectedCompletion{MemberCompletion, "DoSomethingElse", "DoSomethingElse", "", "function<void>"},
+			}},
+		},
+	},
+
 	grokCompletionTest{"types",
 		[]grokCompletionSubTest{
 			// Type completions.
@@ -42,8 +42,13 @@ func (cb *completionBuilder) addTypeOrMember(typeOrMember typegraph.TGTypeOrMemb
 func (cb *completionBuilder) addMember(member typegraph.TGMember, lookupType typegraph.TypeReference) *completionBuilder {
 	docString, _ := member.Documentation()
 
+	var kind CompletionKind = MemberCompletion
+	if member.IsEnumValue() {
+		kind = EnumValueCompletion
+	}
+
 	return cb.addCompletion(Completion{
-		Kind:          MemberCompletion,
+		Kind:          kind,
 		Title:         member.Name(),
 		Code:          member.Name(),
 		Documentation: trimDocumentation(docString),
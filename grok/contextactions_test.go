@@ -0,0 +1,106 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grok
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/packageloader"
+)
+
+func TestMissingCasesAction(t *testing.T) {
+	testSourcePath := "tests/missingcases/missingcases.seru"
+	groker := NewGroker(testSourcePath, []string{}, []packageloader.Library{packageloader.Library{TESTLIB_PATH, false, "", "testcore"}})
+	handle, err := groker.GetHandle()
+	if !assert.Nil(t, err, "Expected no error for missing cases test") {
+		return
+	}
+
+	contents, err := ioutil.ReadFile(testSourcePath)
+	if !assert.Nil(t, err, "Expected no error when reading missing cases test source") {
+		return
+	}
+
+	// Look up the actions for a position within the switch statement.
+	pm := compilercommon.LocalFilePositionMapper{}
+	sourcePosition := compilercommon.InputSource(testSourcePath).PositionForRunePosition(strings.Index(string(contents), "switch"), pm)
+	actions, err := handle.GetPositionalActions(sourcePosition)
+	if !assert.Nil(t, err, "Expected no error when looking up actions") {
+		return
+	}
+
+	if !assert.Equal(t, 1, len(actions), "Expected a single action") {
+		return
+	}
+
+	assert.Equal(t, Action(InsertMissingCases), actions[0].Action)
+	assert.Equal(t, "Add 2 missing cases", actions[0].Title)
+	assert.Equal(t, "\tcase Color.Green:\n\t\tcase Color.Blue:\n\t", actions[0].ActionParams["text"])
+	assert.Equal(t, strings.LastIndex(string(contents), "\t}")+1, actions[0].ActionParams["position"])
+}
+
+func TestExecuteMissingCasesAction(t *testing.T) {
+	contents, err := ioutil.ReadFile("tests/missingcases/missingcases.seru")
+	if !assert.Nil(t, err, "Expected no error when reading missing cases test source") {
+		return
+	}
+
+	// Execute the action on a copy of the test source, as it rewrites the source file.
+	tempDir, err := ioutil.TempDir("", "missingcases")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(tempDir)
+
+	testSourcePath := path.Join(tempDir, "missingcases.seru")
+	if !assert.Nil(t, ioutil.WriteFile(testSourcePath, contents, 0644)) {
+		return
+	}
+
+	libraries := []packageloader.Library{packageloader.Library{TESTLIB_PATH, false, "", "testcore"}}
+	handle, err := NewGroker(testSourcePath, []string{}, libraries).GetHandle()
+	if !assert.Nil(t, err, "Expected no error for missing cases test") {
+		return
+	}
+
+	if !assert.Equal(t, 1, len(handle.Warnings()), "Expected missing cases warning") {
+		return
+	}
+
+	pm := compilercommon.LocalFilePositionMapper{}
+	sourcePosition := compilercommon.InputSource(testSourcePath).PositionForRunePosition(strings.Index(string(contents), "switch"), pm)
+	actions, err := handle.GetPositionalActions(sourcePosition)
+	if !assert.Nil(t, err, "Expected no error when looking up actions") || !assert.Equal(t, 1, len(actions), "Expected a single action") {
+		return
+	}
+
+	err = handle.ExecuteAction(actions[0].Action, actions[0].ActionParams, compilercommon.InputSource(testSourcePath))
+	if !assert.Nil(t, err, "Expected no error when executing action") {
+		return
+	}
+
+	// Ensure the updated source parses, is formatted and no longer warns.
+	updated, err := ioutil.ReadFile(testSourcePath)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Contains(t, string(updated), "\t\tcase Color.Green:\n\n\t\tcase Color.Blue:\n")
+
+	updatedHandle, err := NewGroker(testSourcePath, []string{}, libraries).GetHandle()
+	if !assert.Nil(t, err, "Expected no error for updated missing cases test") {
+		return
+	}
+
+	assert.True(t, updatedHandle.IsCompilable(), "Expected updated source to compile: %v", updatedHandle.Errors())
+	assert.Equal(t, 0, len(updatedHandle.Warnings()), "Expected no warnings: %v", updatedHandle.Warnings())
+}
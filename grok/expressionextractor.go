@@ -5,6 +5,7 @@
 package grok
 
 import (
+	"regexp"
 	"strings"
 )
 
@@ -102,6 +103,50 @@ func extractCalled(inputString string) (string, int, bool) {
 	return inputString[startIndex:endIndex], commaIndex, true
 }
 
+// argumentNameRegex matches the name of a named argument at the start of an argument.
+var argumentNameRegex = regexp.MustCompile(`^\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*:`)
+
+// extractCalledArgumentName extracts the name of the argument being given to the closest *called*
+// inner expression from the given input string, if the argument is named.
+//
+// For example, this method will perform the following transformations:
+// a( -> (none)
+// a(b -> (none)
+// a(b: -> b
+// a(b: c, d: e -> d
+// a(b: c[ -> (none)
+func extractCalledArgumentName(inputString string) (string, bool) {
+	expressionExtractor := &expressionExtractor{
+		inputString:       strings.TrimSpace(inputString),
+		currentState:      extractorStateNormal,
+		nestingStack:      &nestingStack{},
+		currentStringRune: ' ',
+	}
+
+	_, ok := expressionExtractor.extract()
+	if !ok {
+		return "", false
+	}
+
+	// Find the start of the argument being given under the containing nesting level.
+	containingNesting := expressionExtractor.nestingStack.top
+	if containingNesting.openRune != '(' {
+		return "", false
+	}
+
+	argumentStartIndex := containingNesting.startIndex + 1
+	if containingNesting.lastCommaIndex+1 > argumentStartIndex {
+		argumentStartIndex = containingNesting.lastCommaIndex + 1
+	}
+
+	matches := argumentNameRegex.FindStringSubmatch(expressionExtractor.inputString[argumentStartIndex:])
+	if matches == nil {
+		return "", false
+	}
+
+	return matches[1], true
+}
+
 func (ee *expressionExtractor) extract() (string, bool) {
 	// Build the map of closing runes.
 	closingRunes := map[rune]bool{}
@@ -159,6 +204,7 @@ func (ee *expressionExtractor) extract() (string, bool) {
 				ee.nestingStack.top.lastSeparatorIndex = index
 				if char == ',' {
 					ee.nestingStack.top.commaCounter++
+					ee.nestingStack.top.lastCommaIndex = index
 				}
 			}
 		}
@@ -26,6 +26,12 @@ type calledExtractorTest struct {
 	expectedResult     bool
 }
 
+type calledArgumentNameExtractorTest struct {
+	inputString    string
+	expectedName   string
+	expectedResult bool
+}
+
 var expressionExtractorTests = []expressionExtractorTest{
 	// Success tests.
 	expressionExtractorTest{"1234", "1234", true},
@@ -94,3 +100,32 @@ func TestCalledExtraction(t *testing.T) {
 		}
 	}
 }
+
+var calledArgumentNameExtractorTests = []calledArgumentNameExtractorTest{
+	// Success tests.
+	calledArgumentNameExtractorTest{"a(b:", "b", true},
+	calledArgumentNameExtractorTest{"a(b: 1234", "b", true},
+	calledArgumentNameExtractorTest{"a(b: 1234, c:", "c", true},
+	calledArgumentNameExtractorTest{"a(1234, c: 'hello world'", "c", true},
+	calledArgumentNameExtractorTest{"a(b: c(1, 2), d:", "d", true},
+
+	// Failure tests.
+	calledArgumentNameExtractorTest{"a(", "", false},
+	calledArgumentNameExtractorTest{"a(b", "", false},
+	calledArgumentNameExtractorTest{"a(b: 1234,", "", false},
+	calledArgumentNameExtractorTest{"a[b:", "", false},
+	calledArgumentNameExtractorTest{"a(b: c(", "", false},
+	calledArgumentNameExtractorTest{"'hello world", "", false},
+}
+
+func TestCalledArgumentNameExtraction(t *testing.T) {
+	for _, test := range calledArgumentNameExtractorTests {
+		name, result := extractCalledArgumentName(test.inputString)
+		if !assert.Equal(t, test.expectedResult, result, "Got mismatched result for input string: %s", test.inputString) {
+			continue
+		}
+		if !assert.Equal(t, test.expectedName, name, "Got mismatched name for input string: %s", test.inputString) {
+			continue
+		}
+	}
+}
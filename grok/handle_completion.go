@@ -144,13 +144,38 @@ func (gh Handle) addAccessCompletions(node compilergraph.GraphNode, activationSt
 		return
 	}
 
-	for _, member := range lookupType.ReferredType().Members() {
+	// Enum values are offered first, in the order in which they were declared.
+	referredType := lookupType.ReferredType()
+	if isStatic {
+		for _, value := range referredType.EnumValues() {
+			if value.IsAccessibleTo(source) {
+				builder.addMember(value, lookupType)
+			}
+		}
+	}
+
+	for _, member := range referredType.Members() {
 		if member.IsStatic() == isStatic && member.IsAccessibleTo(source) {
-			if !member.IsOperator() {
+			if !member.IsOperator() && !member.IsEnumValue() {
 				builder.addMember(member, lookupType)
 			}
 		}
 	}
+
+	// Members of extensions visible to the module are offered after those of the type itself.
+	if !isStatic {
+		for _, extension := range referredType.Extensions() {
+			if !extension.IsExtensionVisibleTo(source) {
+				continue
+			}
+
+			for _, member := range extension.Members() {
+				if member.IsAccessibleTo(source) {
+					builder.addMember(member, lookupType)
+				}
+			}
+		}
+	}
 }
 
 // addContextCompletions adds completions based on the node's context.
@@ -5,21 +5,25 @@
 package grok
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"strings"
 
 	"github.com/serulian/compiler/compilercommon"
 	"github.com/serulian/compiler/compilerutil"
 	"github.com/serulian/compiler/formatter"
 	"github.com/serulian/compiler/packageloader"
 	"github.com/serulian/compiler/parser/shared"
+	"github.com/serulian/compiler/sourceshape"
 	"github.com/serulian/compiler/vcs"
 )
 
 const shortSHALength = 7
 
 // AllActions defines the set of all actions supported by Grok.
-var AllActions = []string{string(NoAction), string(FreezeImport), string(UnfreezeImport)}
+var AllActions = []string{string(NoAction), string(FreezeImport), string(UnfreezeImport), string(InsertMissingCases)}
 
 // ExecuteAction executes an action as defined by GetContextActions.
 func (gh Handle) ExecuteAction(action Action, params map[string]interface{}, source compilercommon.InputSource) error {
@@ -60,6 +64,36 @@ func (gh Handle) ExecuteAction(action Action, params map[string]interface{}, sou
 		}
 		return nil
 
+	case InsertMissingCases:
+		position, hasPosition := intParam(params, "position")
+		if !hasPosition {
+			return fmt.Errorf("Missing insertion position")
+		}
+
+		text, hasText := params["text"]
+		if !hasText {
+			return fmt.Errorf("Missing cases text")
+		}
+
+		contents, _, err := gh.loadLiveSource(source)
+		if err != nil {
+			return err
+		}
+
+		runes := []rune(contents)
+		if position < 0 || position > len(runes) {
+			return fmt.Errorf("Invalid insertion position: %v", position)
+		}
+
+		// Format the updated source, to ensure the inserted cases match the surrounding code.
+		updated := string(runes[0:position]) + text.(string) + string(runes[position:])
+		formatted, err := formatter.FormatSource(updated)
+		if err != nil {
+			return fmt.Errorf("Could not insert missing cases: %v", err)
+		}
+
+		return ioutil.WriteFile(string(source), []byte(formatted), 0644)
+
 	default:
 		return fmt.Errorf("Unknown action: %v", action)
 	}
@@ -186,9 +220,104 @@ func (gh Handle) GetPositionalActions(sourcePosition compilercommon.SourcePositi
 		}
 	}
 
+	// Add an action to insert the cases missing from the switch or match statement containing the
+	// position, if any.
+	missingCasesAction, hasMissingCasesAction := gh.missingCasesAction(updatedPosition)
+	if hasMissingCasesAction {
+		actions = append(actions, missingCasesAction)
+	}
+
 	return actions, nil
 }
 
+// missingCasesAction returns an action to insert the cases missing from the nearest switch or match
+// statement containing the given (tracked) position, if any.
+func (gh Handle) missingCasesAction(position compilercommon.SourcePosition) (ContextOrAction, bool) {
+	sourceGraph := gh.scopeResult.Graph.SourceGraph()
+	node, found := sourceGraph.FindNodeForPosition(position)
+	if !found {
+		return ContextOrAction{}, false
+	}
+
+	statementNode, found := sourceGraph.NewSourceStructureFinder().TryGetNearestContainingNode(node, sourceshape.NodeTypeSwitchStatement, sourceshape.NodeTypeMatchStatement)
+	if !found {
+		return ContextOrAction{}, false
+	}
+
+	missingCases := gh.scopeResult.Graph.MissingCases(statementNode)
+	if len(missingCases) == 0 {
+		return ContextOrAction{}, false
+	}
+
+	sourceRange, hasSourceRange := sourceGraph.SourceRangeOf(statementNode)
+	if !hasSourceRange {
+		return ContextOrAction{}, false
+	}
+
+	// The cases are inserted just before the closing brace of the statement, indented one level
+	// past it.
+	lineText, err := sourceRange.End().LineText()
+	if err != nil {
+		return ContextOrAction{}, false
+	}
+
+	closingPosition, err := gh.scopeResult.SourceTracker.GetPositionOffset(sourceRange.End(), packageloader.TrackedFilePosition)
+	if err != nil {
+		return ContextOrAction{}, false
+	}
+
+	closingRune, err := closingPosition.RunePosition()
+	if err != nil {
+		return ContextOrAction{}, false
+	}
+
+	indentation := lineText[0 : len(lineText)-len(strings.TrimLeft(lineText, " \t"))]
+
+	var buf bytes.Buffer
+	for _, missingCase := range missingCases {
+		buf.WriteString("\tcase ")
+		buf.WriteString(missingCase)
+		buf.WriteString(":\n")
+		buf.WriteString(indentation)
+	}
+
+	title := "Add missing case"
+	if len(missingCases) > 1 {
+		title = fmt.Sprintf("Add %v missing cases", len(missingCases))
+	}
+
+	return ContextOrAction{
+		Range:  sourceRange,
+		Title:  title,
+		Action: InsertMissingCases,
+		ActionParams: map[string]interface{}{
+			"source":   string(position.Source()),
+			"position": closingRune,
+			"text":     buf.String(),
+		},
+	}, true
+}
+
+// intParam returns the integer action parameter with the given name, if any. Parameters decoded
+// from JSON are represented as floats, and are therefore converted.
+func intParam(params map[string]interface{}, name string) (int, bool) {
+	value, hasValue := params[name]
+	if !hasValue {
+		return 0, false
+	}
+
+	switch typed := value.(type) {
+	case int:
+		return typed, true
+
+	case float64:
+		return int(typed), true
+
+	default:
+		return 0, false
+	}
+}
+
 // GetContextActions returns all context actions for the given source file.
 func (gh Handle) GetContextActions(source compilercommon.InputSource) ([]CodeContextOrAction, error) {
 	module, found := gh.scopeResult.Graph.SourceGraph().FindModuleBySource(source)
@@ -0,0 +1,111 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grok
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/formatter"
+)
+
+// FormatSource returns the edits to apply to the current contents of the given source file to
+// format it.
+func (gh Handle) FormatSource(source compilercommon.InputSource) ([]TextEdit, error) {
+	contents, mapper, err := gh.loadLiveSource(source)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	edits, err := formatter.FormatSourceEdits(contents)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	return toTextEdits(edits, mapper)
+}
+
+// FormatRange returns the edits to apply to the current contents of the given source file to format
+// the code found between the given start and end (exclusive) positions. The range is expanded to the
+// enclosing statements or members.
+func (gh Handle) FormatRange(source compilercommon.InputSource, startLine int, startCol int, endLine int, endCol int) ([]TextEdit, error) {
+	contents, mapper, err := gh.loadLiveSource(source)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	startRune, err := mapper.LineAndColToRunePosition(startLine, startCol)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	endRune, err := mapper.LineAndColToRunePosition(endLine, endCol)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	// The formatter expects an inclusive range.
+	if endRune > startRune {
+		endRune--
+	}
+
+	edits, err := formatter.FormatRange(contents, source.RangeForRunePositions(startRune, endRune, gh.scopeResult.SourceTracker))
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	return toTextEdits(edits, mapper)
+}
+
+// FormatOnType returns the edits to apply to the current contents of the given source file to format
+// the statement or member just typed before the given position. Meant to be invoked by editors after
+// a closing brace or a newline is typed.
+func (gh Handle) FormatOnType(source compilercommon.InputSource, lineNumber int, colPosition int) ([]TextEdit, error) {
+	contents, mapper, err := gh.loadLiveSource(source)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	runePosition, err := mapper.LineAndColToRunePosition(lineNumber, colPosition)
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	edits, err := formatter.FormatOnType(contents, source.PositionForRunePosition(runePosition, gh.scopeResult.SourceTracker))
+	if err != nil {
+		return []TextEdit{}, err
+	}
+
+	return toTextEdits(edits, mapper)
+}
+
+// loadLiveSource loads the current contents of the given source file, which may differ from the
+// contents tracked by the handle, returning them along with a position mapper over them.
+func (gh Handle) loadLiveSource(source compilercommon.InputSource) (string, compilercommon.SourcePositionMapper, error) {
+	contents, err := gh.groker.pathLoader.LoadSourceFile(string(source))
+	if err != nil {
+		return "", compilercommon.SourcePositionMapper{}, err
+	}
+
+	return string(contents), compilercommon.CreateSourcePositionMapper(contents), nil
+}
+
+// toTextEdits converts the given formatter edits into edits over line numbers and column positions.
+func toTextEdits(edits []formatter.TextEdit, mapper compilercommon.SourcePositionMapper) ([]TextEdit, error) {
+	var textEdits = make([]TextEdit, 0, len(edits))
+	for _, edit := range edits {
+		startLine, startCol, err := mapper.RunePositionToLineAndCol(edit.StartRune)
+		if err != nil {
+			return []TextEdit{}, err
+		}
+
+		endLine, endCol, err := mapper.RunePositionToLineAndCol(edit.EndRune)
+		if err != nil {
+			return []TextEdit{}, err
+		}
+
+		textEdits = append(textEdits, TextEdit{startLine, startCol, endLine, endCol, edit.Text})
+	}
+
+	return textEdits, nil
+}
@@ -162,6 +162,12 @@ func (gh Handle) LookupSourcePosition(sourcePosition compilercommon.SourcePositi
 		fallthrough
 
 	case sourceshape.NodeTypeAgent:
+		fallthrough
+
+	case sourceshape.NodeTypeEnum:
+		fallthrough
+
+	case sourceshape.NodeTypeExtension:
 		srgType := gh.scopeResult.Graph.SourceGraph().GetDefinedTypeReference(node)
 		referencedName := gh.scopeResult.Graph.ReferencedNameForNamedScope(srgType.AsNamedScope())
 
@@ -206,6 +212,9 @@ func (gh Handle) LookupSourcePosition(sourcePosition compilercommon.SourcePositi
 		fallthrough
 
 	case sourceshape.NodeTypeVariable:
+		fallthrough
+
+	case sourceshape.NodeTypeEnumValue:
 		srgMember := gh.scopeResult.Graph.SourceGraph().GetMemberReference(node)
 		referencedName := gh.scopeResult.Graph.ReferencedNameForNamedScope(srgMember.AsNamedScope())
 
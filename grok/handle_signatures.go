@@ -108,9 +108,15 @@ func (gh Handle) GetSignature(activationString string, sourcePosition compilerco
 				parameterName, _ := parameter.Name()
 				parameterDocumentation, _ := parameter.Documentation()
 				parameterInfo[index] = ParameterInformation{
-					Name:          parameterName,
-					TypeReference: parameter.DeclaredType(),
-					Documentation: trimDocumentation(highlightParameter(parameterDocumentation, parameterName)),
+					Name:            parameterName,
+					TypeReference:   parameter.DeclaredType(),
+					Documentation:   trimDocumentation(highlightParameter(parameterDocumentation, parameterName)),
+					HasDefaultValue: parameter.HasDefaultValue(),
+				}
+
+				// If the argument being given is named, the active parameter is that with the name.
+				if argumentName, isNamed := extractCalledArgumentName(activationString); isNamed && argumentName == parameterName {
+					signatureIndex = index
 				}
 			}
 
@@ -136,9 +142,10 @@ func (gh Handle) GetSignature(activationString string, sourcePosition compilerco
 	parameterInfo := make([]ParameterInformation, len(parameterTypes))
 	for index, parameterType := range parameterTypes {
 		parameterInfo[index] = ParameterInformation{
-			Name:          "", // Anonymous
-			TypeReference: parameterType,
-			Documentation: "",
+			Name:            "", // Anonymous
+			TypeReference:   parameterType,
+			Documentation:   "",
+			HasDefaultValue: false,
 		}
 	}
 
@@ -16,6 +16,7 @@ type nestingElement struct {
 	startIndex         int
 	commaCounter       int
 	lastSeparatorIndex int
+	lastCommaIndex     int
 	next               *nestingElement
 }
 
@@ -39,7 +40,7 @@ func (s *nestingStack) topValue() (rune, int, bool) {
 
 // Push pushes an opening rune onto the stack.
 func (s *nestingStack) push(openRune rune, startIndex int) {
-	s.top = &nestingElement{openRune, startIndex, 0, -1, s.top}
+	s.top = &nestingElement{openRune, startIndex, 0, -1, -1, s.top}
 	s.size++
 }
 
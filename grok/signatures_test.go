@@ -79,6 +79,19 @@ var grokSignaturesTests = []grokSignaturesTest{
 					},
 				},
 			},
+			grokSignaturesSubTest{
+				"ids",
+				"DoSomething(anotherParam:",
+				expectedSignature{
+					"DoSomething",
+					"DoSomething does something. When `someParam` is specified, good things happen. `anotherParam` controls other things.",
+					1,
+					[]expectedParam{
+						expectedParam{"someParam", "Integer", "When `someParam` is specified, good things happen"},
+						expectedParam{"anotherParam", "String", "`anotherParam` controls other things"},
+					},
+				},
+			},
 			grokSignaturesSubTest{
 				"ids",
 				"sc[",
@@ -233,6 +233,7 @@ const (
 	ValueCompletion                    = "value"
 	ParameterCompletion                = "parameter"
 	VariableCompletion                 = "variable"
+	EnumValueCompletion                = "enum-value"
 )
 
 // Completion defines a single autocompletion returned by grok.
@@ -337,6 +338,9 @@ type ParameterInformation struct {
 
 	// The human readable documentation on the parameter, if any.
 	Documentation string
+
+	// HasDefaultValue indicates whether the parameter has a default value, making it optional.
+	HasDefaultValue bool
 }
 
 // CodeContextOrAction represents context or an action to display inline with *specific* code. Typically provides
@@ -362,6 +366,10 @@ const (
 
 	// FreezeImport indicates that an import should be frozen at a commit or tag.
 	FreezeImport = "freeze-import"
+
+	// InsertMissingCases indicates that the cases missing from a switch or match statement
+	// should be inserted.
+	InsertMissingCases = "insert-missing-cases"
 )
 
 // ContextOrAction represents context or an action that is applied to code.
@@ -378,3 +386,22 @@ type ContextOrAction struct {
 	// ActionParams is a generic map of data to be sent when the action is invoked, if any.
 	ActionParams map[string]interface{}
 }
+
+// TextEdit represents a single edit to be applied to the current contents of a source file,
+// replacing the text between the start and end positions with new text.
+type TextEdit struct {
+	// StartLine is the 0-indexed line number at which the replaced text starts.
+	StartLine int
+
+	// StartCol is the 0-indexed column position at which the replaced text starts.
+	StartCol int
+
+	// EndLine is the 0-indexed line number at which the replaced text ends.
+	EndLine int
+
+	// EndCol is the 0-indexed column position (exclusive) at which the replaced text ends.
+	EndCol int
+
+	// Text is the text with which to replace the text between the positions.
+	Text string
+}
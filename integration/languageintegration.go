@@ -51,6 +51,16 @@ type BundlerIntegration interface {
 	PopulateFilesToBundle(bundler bundle.Bundler)
 }
 
+// NativeConstructorIntegration defines an integration whose types are constructed natively (via
+// `new`) in JavaScript, rather than via a Serulian-generated constructor.
+type NativeConstructorIntegration interface {
+	LanguageIntegration
+
+	// UsesNativeConstructors returns true if static member references to types defined by the
+	// integration should be generated via its PathHandler, to allow for native construction.
+	UsesNativeConstructors() bool
+}
+
 // PathHandler translates various paths encountered during code generation into those provided by the integration,
 // if any.
 type PathHandler interface {
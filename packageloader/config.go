@@ -30,6 +30,16 @@ type Config struct {
 	// exists.
 	SkipVCSRefresh bool
 
+	// The option for using the lockfile when loading VCS packages.
+	VCSLockOption VCSLockOption
+
+	// The option for using the sums file when loading VCS packages.
+	VCSSumOption VCSSumOption
+
+	// If true, vendored copies of VCS packages will be ignored and the packages checked out
+	// normally.
+	SkipVendoredPackages bool
+
 	// cancelationHandle holds a handle for cancelation of the package loading, if any.
 	cancelationHandle compilerutil.CancelationHandle
 }
@@ -49,6 +59,9 @@ func (c Config) WithCancelationHandle(handle compilerutil.CancelationHandle) Con
 		PathLoader:                c.PathLoader,
 		AlwaysValidate:            c.AlwaysValidate,
 		SkipVCSRefresh:            c.SkipVCSRefresh,
+		VCSLockOption:             c.VCSLockOption,
+		VCSSumOption:              c.VCSSumOption,
+		SkipVendoredPackages:      c.SkipVendoredPackages,
 		cancelationHandle:         handle,
 	}
 }
@@ -62,5 +75,8 @@ func NewBasicConfig(rootSourceFilePath string, sourceHandlers ...SourceHandler)
 		PathLoader:                LocalFilePathLoader{},
 		AlwaysValidate:            false,
 		SkipVCSRefresh:            false,
+		VCSLockOption:             VCSLockUseIfPresent,
+		VCSSumOption:              VCSSumVerify,
+		SkipVendoredPackages:      false,
 	}
 }
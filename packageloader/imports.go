@@ -0,0 +1,94 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/vcs"
+)
+
+// ImportEdge records an import, found in a source module, of a package or module loaded by the loader.
+type ImportEdge struct {
+	SourceModule compilercommon.InputSource // The module containing the import.
+	SourceRange  compilercommon.SourceRange // The source range of the import.
+	ImportPath   string                     // The path imported, as found in the source.
+	ImportType   PackageImportType          // The type of the import.
+	Kind         string                     // The source kind of the imported package or module.
+	ReferenceID  string                     // The reference ID of the imported package or module in the package map.
+}
+
+// VCSPackageInfo holds information about a VCS package loaded by the loader.
+type VCSPackageInfo struct {
+	VCSPath      string               // The VCS path of the package, as imported.
+	CheckoutPath string               // The VCS path checked out. Differs from VCSPath for version range imports.
+	Directory    string               // The local directory containing the package.
+	Status       vcs.VCSPackageStatus // The status of the package's checkout.
+	Commit       string               // The commit recorded in or resolved for the lockfile, if any.
+}
+
+// recordImport records that the given import resolved to the package or module with the given reference ID.
+func (p *PackageLoader) recordImport(importSource compilercommon.InputSource, importInformation PackageImport, referenceID string) {
+	key := fmt.Sprintf("%s::%s::%s", importSource, importInformation.Kind, referenceID)
+	p.importsEncountered.SetIfAbsent(key, ImportEdge{
+		SourceModule: importSource,
+		SourceRange:  importInformation.SourceRange,
+		ImportPath:   importInformation.Path,
+		ImportType:   importInformation.ImportType,
+		Kind:         importInformation.Kind,
+		ReferenceID:  referenceID,
+	})
+}
+
+// buildImports returns all imports recorded, sorted by source module and then reference ID.
+func (p *PackageLoader) buildImports() []ImportEdge {
+	imports := make([]ImportEdge, 0, p.importsEncountered.Count())
+	for entry := range p.importsEncountered.IterBuffered() {
+		imports = append(imports, entry.Val.(ImportEdge))
+	}
+
+	sort.Sort(importEdgeSlice(imports))
+	return imports
+}
+
+type importEdgeSlice []ImportEdge
+
+func (s importEdgeSlice) Len() int      { return len(s) }
+func (s importEdgeSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s importEdgeSlice) Less(i, j int) bool {
+	if s[i].SourceModule != s[j].SourceModule {
+		return s[i].SourceModule < s[j].SourceModule
+	}
+
+	return s[i].ReferenceID < s[j].ReferenceID
+}
+
+// buildVCSPackages returns information about all VCS packages successfully loaded, by VCS path.
+func (p *PackageLoader) buildVCSPackages() map[string]VCSPackageInfo {
+	vcsPackages := map[string]VCSPackageInfo{}
+	for entry := range p.vcsPackagesLoaded.IterBuffered() {
+		checkoutPath := entry.Val.(string)
+		result, found := p.vcsCheckouts.Get(checkoutPath)
+		if !found {
+			continue
+		}
+
+		var commit = ""
+		if locked, hasLocked := p.lockedPackages.Get(entry.Key); hasLocked {
+			commit = locked.(LockedPackage).Commit
+		}
+
+		vcsPackages[entry.Key] = VCSPackageInfo{
+			VCSPath:      entry.Key,
+			CheckoutPath: checkoutPath,
+			Directory:    result.(vcs.VCSCheckoutResult).PackageDirectory,
+			Status:       result.(vcs.VCSCheckoutResult).Status,
+			Commit:       commit,
+		}
+	}
+	return vcsPackages
+}
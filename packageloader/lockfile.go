@@ -0,0 +1,129 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+)
+
+// LockFileName is the name of the lockfile placed in the entrypoint directory of a project,
+// recording the exact commit resolved for every VCS package imported by the project.
+const LockFileName = "serulian.lock"
+
+// VCSLockOption defines the various ways in which the lockfile is used when loading VCS packages.
+type VCSLockOption int
+
+const (
+	// VCSLockUseIfPresent indicates that VCS packages will be checked out at the commits recorded
+	// in the lockfile, if one exists. If a VCS package is missing from an existing lockfile, the
+	// lockfile is considered stale and an error is reported.
+	VCSLockUseIfPresent VCSLockOption = iota
+
+	// VCSLockRequire indicates that the lockfile must exist and VCS packages will be checked out at
+	// the commits recorded within it.
+	VCSLockRequire
+
+	// VCSLockResolve indicates that any existing lockfile will be ignored, VCS packages will be
+	// resolved normally and the commits resolved will be recorded in the LoadResult.
+	VCSLockResolve
+)
+
+// LockFile holds the commits resolved for VCS packages, keyed by the VCS path as imported.
+type LockFile struct {
+	// Packages maps from each VCS path to the package locked for that path.
+	Packages map[string]LockedPackage `json:"packages"`
+}
+
+// LockedPackage holds the information recorded for a single VCS package in the lockfile.
+type LockedPackage struct {
+	// Commit is the commit SHA resolved for the VCS package.
+	Commit string `json:"commit"`
+}
+
+// NewLockFile returns a new, empty lockfile.
+func NewLockFile() LockFile {
+	return LockFile{map[string]LockedPackage{}}
+}
+
+// LockFilePath returns the path of the lockfile for the given entrypoint.
+func LockFilePath(entrypoint Entrypoint, pathLoader PathLoader) string {
+	return path.Join(entrypoint.EntrypointDirectoryPath(pathLoader), LockFileName)
+}
+
+// ReadLockFile reads the lockfile found at the given path, if any.
+func ReadLockFile(lockFilePath string, pathLoader PathLoader) (LockFile, bool, error) {
+	exists, err := pathLoader.Exists(lockFilePath)
+	if err != nil || !exists {
+		return LockFile{}, false, err
+	}
+
+	contents, err := pathLoader.LoadSourceFile(lockFilePath)
+	if err != nil {
+		return LockFile{}, false, err
+	}
+
+	lockFile := NewLockFile()
+	if err := json.Unmarshal(contents, &lockFile); err != nil {
+		return LockFile{}, false, fmt.Errorf("Could not parse lockfile: %v", err)
+	}
+
+	if lockFile.Packages == nil {
+		lockFile.Packages = map[string]LockedPackage{}
+	}
+
+	return lockFile, true, nil
+}
+
+// Write writes the lockfile to the given path.
+func (lf LockFile) Write(lockFilePath string) error {
+	contents, err := json.MarshalIndent(lf, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(lockFilePath, append(contents, '\n'), 0644)
+}
+
+// Lookup returns the package locked for the given VCS path, if any.
+func (lf LockFile) Lookup(vcsPath string) (LockedPackage, bool) {
+	locked, found := lf.Packages[vcsPath]
+	return locked, found
+}
+
+// VCSPaths returns the VCS paths found in the lockfile, in sorted order.
+func (lf LockFile) VCSPaths() []string {
+	var vcsPaths = make([]string, 0, len(lf.Packages))
+	for vcsPath := range lf.Packages {
+		vcsPaths = append(vcsPaths, vcsPath)
+	}
+
+	sort.Strings(vcsPaths)
+	return vcsPaths
+}
+
+// StaleEntries returns the VCS paths whose entries differ between this lockfile and the given
+// lockfile, including those found in only one of the two, in sorted order.
+func (lf LockFile) StaleEntries(other LockFile) []string {
+	var stale = make([]string, 0)
+	for _, vcsPath := range lf.VCSPaths() {
+		otherLocked, found := other.Packages[vcsPath]
+		if !found || otherLocked != lf.Packages[vcsPath] {
+			stale = append(stale, vcsPath)
+		}
+	}
+
+	for _, vcsPath := range other.VCSPaths() {
+		if _, found := lf.Packages[vcsPath]; !found {
+			stale = append(stale, vcsPath)
+		}
+	}
+
+	sort.Strings(stale)
+	return stale
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmap "github.com/streamrail/concurrent-map"
+)
+
+func TestReadLockFile(t *testing.T) {
+	lockFile, found, err := ReadLockFile("tests/lockfile/serulian.lock", LocalFilePathLoader{})
+	if !assert.Nil(t, err) || !assert.True(t, found) {
+		return
+	}
+
+	locked, isLocked := lockFile.Lookup("github.com/some/project")
+	assert.True(t, isLocked)
+	assert.Equal(t, "abcdef", locked.Commit)
+
+	_, isLocked = lockFile.Lookup("github.com/some/otherproject")
+	assert.False(t, isLocked)
+}
+
+func TestReadMissingLockFile(t *testing.T) {
+	_, found, err := ReadLockFile("tests/basic/serulian.lock", LocalFilePathLoader{})
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestReadInvalidLockFile(t *testing.T) {
+	_, found, err := ReadLockFile("tests/invalidlockfile/serulian.lock", LocalFilePathLoader{})
+	assert.NotNil(t, err)
+	assert.False(t, found)
+}
+
+func TestWriteLockFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lockfile")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	defer os.RemoveAll(dir)
+
+	lockFile := NewLockFile()
+	lockFile.Packages["github.com/some/project@v1.0.0"] = LockedPackage{Commit: "123456"}
+	lockFile.Packages["github.com/another/project"] = LockedPackage{Commit: "abcdef"}
+
+	lockFilePath := path.Join(dir, LockFileName)
+	if !assert.Nil(t, lockFile.Write(lockFilePath)) {
+		return
+	}
+
+	read, found, err := ReadLockFile(lockFilePath, LocalFilePathLoader{})
+	if !assert.Nil(t, err) || !assert.True(t, found) {
+		return
+	}
+
+	assert.Equal(t, lockFile, read)
+	assert.Equal(t, []string{"github.com/another/project", "github.com/some/project@v1.0.0"}, read.VCSPaths())
+}
+
+func TestStaleEntries(t *testing.T) {
+	first := NewLockFile()
+	first.Packages["github.com/same/project"] = LockedPackage{Commit: "123456"}
+	first.Packages["github.com/changed/project"] = LockedPackage{Commit: "123456"}
+	first.Packages["github.com/removed/project"] = LockedPackage{Commit: "123456"}
+
+	second := NewLockFile()
+	second.Packages["github.com/same/project"] = LockedPackage{Commit: "123456"}
+	second.Packages["github.com/changed/project"] = LockedPackage{Commit: "abcdef"}
+	second.Packages["github.com/added/project"] = LockedPackage{Commit: "123456"}
+
+	assert.Equal(t, []string{"github.com/added/project", "github.com/changed/project", "github.com/removed/project"}, first.StaleEntries(second))
+	assert.Equal(t, []string{}, first.StaleEntries(first))
+}
+
+func TestLoadingWithInvalidLockFile(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	loader := NewPackageLoader(NewBasicConfig("tests/invalidlockfile/somefile.json", tt.createHandler()))
+	result := loader.Load()
+	if !assert.False(t, result.Status, "Expected failure when loading with invalid lockfile") {
+		return
+	}
+
+	assert.Equal(t, 1, len(result.Errors))
+}
+
+func TestLoadingWithRequiredLockFile(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	config := NewBasicConfig("tests/basic/somefile.json", tt.createHandler())
+	config.VCSLockOption = VCSLockRequire
+
+	result := NewPackageLoader(config).Load()
+	assert.False(t, result.Status, "Expected failure when loading without required lockfile")
+
+	config = NewBasicConfig("tests/lockfile/somefile.json", tt.createHandler())
+	config.VCSLockOption = VCSLockRequire
+
+	result = NewPackageLoader(config).Load()
+	assert.True(t, result.Status, "Expected success when loading with required lockfile: %v", result.Errors)
+	assert.Equal(t, 0, len(result.LockFile.Packages))
+}
@@ -32,10 +32,24 @@ type PackageLoader struct {
 	entrypoint Entrypoint         // The entrypoint for the package loader.
 	libraries  map[string]Library // The libraries being loaded.
 
-	vcsDevelopmentDirectories []string   // Directories to check for VCS packages before VCS checkout.
-	pathLoader                PathLoader // The path loaders to use.
-	alwaysValidate            bool       // Whether to always run validation, regardless of errors. Useful to IDE tooling.
-	skipVCSRefresh            bool       // Whether to skip VCS refresh if cache exists. Useful to IDE tooling.
+	vcsDevelopmentDirectories []string      // Directories to check for VCS packages before VCS checkout.
+	pathLoader                PathLoader    // The path loaders to use.
+	alwaysValidate            bool          // Whether to always run validation, regardless of errors. Useful to IDE tooling.
+	skipVCSRefresh            bool          // Whether to skip VCS refresh if cache exists. Useful to IDE tooling.
+	skipVendoredPackages      bool          // Whether to ignore vendored copies of VCS packages.
+	vcsLockOption             VCSLockOption // The option for using the lockfile.
+	vcsSumOption              VCSSumOption  // The option for using the sums file.
+
+	lockFile       LockFile           // The lockfile read for the entrypoint, if any.
+	hasLockFile    bool               // Whether a lockfile was read.
+	vendorManifest LockFile           // The manifest of the vendor directory, if any.
+	lockedPackages cmap.ConcurrentMap // The VCS packages loaded, mapping to their locked package information.
+
+	sumFile     SumFile            // The sums file read for the entrypoint, if any.
+	hasSumFile  bool               // Whether a sums file was read.
+	packageSums cmap.ConcurrentMap // The pinned VCS paths checked out, mapping to their content hash.
+
+	versionSelector *versionSelector // The selector for VCS packages imported via a semantic version range.
 
 	errors   chan compilercommon.SourceError   // Errors are reported on this channel
 	warnings chan compilercommon.SourceWarning // Warnings are reported on this channel
@@ -46,6 +60,9 @@ type PackageLoader struct {
 	pathKindsEncountered cmap.ConcurrentMap    // The path+kinds processed by the loader goroutine
 	vcsPathsLoaded       cmap.ConcurrentMap    // The VCS paths that have been loaded, mapping to their checkout dir
 	vcsLockMap           compilerutil.LockMap  // LockMap for ensuring single loads of all VCS paths.
+	vcsCheckouts         cmap.ConcurrentMap    // The VCS paths checked out, mapping to their checkout result
+	vcsPackagesLoaded    cmap.ConcurrentMap    // The VCS paths imported, mapping to the VCS path checked out for them
+	importsEncountered   cmap.ConcurrentMap    // The imports encountered, mapping to their ImportEdge
 	packageMap           *mutablePackageMap    // The package map.
 	sourceTracker        *mutableSourceTracker // The source tracker.
 
@@ -72,6 +89,10 @@ type LoadResult struct {
 	Warnings      []compilercommon.SourceWarning // The warnings encountered, if any
 	PackageMap    LoadedPackageMap               // Map of packages loaded.
 	SourceTracker SourceTracker                  // Tracker of all source loaded.
+	LockFile      LockFile                       // The lockfile entries used or resolved for the VCS packages loaded.
+	Imports       []ImportEdge                   // The imports found in all modules loaded.
+	VCSPackages   map[string]VCSPackageInfo      // Information about the VCS packages loaded, by VCS path.
+	SumFile       SumFile                        // The content hashes computed for the pinned VCS packages loaded.
 }
 
 // NewPackageLoader creates and returns a new package loader for the given config.
@@ -94,6 +115,18 @@ func NewPackageLoader(config Config) *PackageLoader {
 		pathLoader:                pathLoader,
 		alwaysValidate:            config.AlwaysValidate,
 		skipVCSRefresh:            config.SkipVCSRefresh,
+		skipVendoredPackages:      config.SkipVendoredPackages,
+		vcsLockOption:             config.VCSLockOption,
+		vcsSumOption:              config.VCSSumOption,
+
+		lockFile:       NewLockFile(),
+		vendorManifest: NewLockFile(),
+		lockedPackages: cmap.New(),
+
+		sumFile:     NewSumFile(),
+		packageSums: cmap.New(),
+
+		versionSelector: newVersionSelector(),
 
 		errors:   make(chan compilercommon.SourceError, 32),
 		warnings: make(chan compilercommon.SourceWarning, 32),
@@ -109,6 +142,10 @@ func NewPackageLoader(config Config) *PackageLoader {
 		vcsPathsLoaded: cmap.New(),
 		vcsLockMap:     compilerutil.CreateLockMap(),
 
+		vcsCheckouts:       cmap.New(),
+		vcsPackagesLoaded:  cmap.New(),
+		importsEncountered: cmap.New(),
+
 		finished: make(chan bool, 1),
 
 		cancelationHandle: compilerutil.GetCancelationHandle(config.cancelationHandle),
@@ -119,15 +156,7 @@ func NewPackageLoader(config Config) *PackageLoader {
 // Any libraries specified will be loaded as well.
 func (p *PackageLoader) Load(libraries ...Library) LoadResult {
 	// Start the parsers for each of the handlers.
-	parsersMap := map[string]SourceHandlerParser{}
-	for _, handler := range p.handlers {
-		parser := handler.NewParser()
-		if parser == nil {
-			panic(fmt.Sprintf("Got a nil parser from handler `%s`", handler.Kind()))
-		}
-		parsersMap[handler.Kind()] = parser
-	}
-	p.parsers = parsersMap
+	p.startParsers()
 
 	// Populate the libraries map.
 	for _, library := range libraries {
@@ -143,6 +172,33 @@ func (p *PackageLoader) Load(libraries ...Library) LoadResult {
 
 	go p.collectIssues(result)
 
+	// Load the lockfile, if any.
+	if err := p.loadLockFile(); err != nil {
+		lockFilePath := LockFilePath(p.entrypoint, p.pathLoader)
+		sourceRange := compilercommon.InputSource(lockFilePath).RangeForRunePosition(0, p.sourceTracker)
+		result.Status = false
+		result.Errors = append(result.Errors, compilercommon.SourceErrorf(sourceRange, "Could not load lockfile '%s': %v", lockFilePath, err))
+		return *result
+	}
+
+	// Load the manifest of the vendor directory, if any.
+	if err := p.loadVendorManifest(); err != nil {
+		manifestPath := VendorManifestPath(p.entrypoint, p.pathLoader)
+		sourceRange := compilercommon.InputSource(manifestPath).RangeForRunePosition(0, p.sourceTracker)
+		result.Status = false
+		result.Errors = append(result.Errors, compilercommon.SourceErrorf(sourceRange, "Could not load vendor manifest '%s': %v", manifestPath, err))
+		return *result
+	}
+
+	// Load the sums file, if any.
+	if err := p.loadSumFile(); err != nil {
+		sumFilePath := SumFilePath(p.entrypoint, p.pathLoader)
+		sourceRange := compilercommon.InputSource(sumFilePath).RangeForRunePosition(0, p.sourceTracker)
+		result.Status = false
+		result.Errors = append(result.Errors, compilercommon.SourceErrorf(sourceRange, "Could not load sums file '%s': %v", sumFilePath, err))
+		return *result
+	}
+
 	// Add the root source file(s) as the first items to be parsed.
 	entrypointPaths, err := p.entrypoint.EntrypointPaths(p.pathLoader)
 	if err != nil {
@@ -152,24 +208,21 @@ func (p *PackageLoader) Load(libraries ...Library) LoadResult {
 		return *result
 	}
 
-	for _, path := range entrypointPaths {
-		sourceRange := compilercommon.InputSource(path).RangeForRunePosition(0, p.sourceTracker)
-		for _, handler := range p.handlers {
-			if strings.HasSuffix(path, handler.PackageFileExtension()) {
-				p.pushPath(pathSourceFile, handler.Kind(), path, sourceRange)
-				break
-			}
-		}
-	}
+	p.loadAll(entrypointPaths, libraries)
 
-	// Add the libraries to be parsed.
-	for _, library := range libraries {
-		sourceRange := compilercommon.InputSource(library.PathOrURL).RangeForRunePosition(0, p.sourceTracker)
-		p.pushLibrary(library, library.Kind, sourceRange)
-	}
+	// If a constraint found on a VCS repository excluded the version already selected for it, all
+	// loading must be redone, as packages have been loaded at the excluded version. The constraints
+	// found in the load are retained, ensuring that a version satisfying all of them is selected.
+	// Loading is likewise redone if a retained constraint was not found again.
+	for p.versionSelector.needsReselection() && !p.cancelationHandle.WasCanceled() {
+		p.resetForReselection()
 
-	// Wait for all packages and source files to be completed.
-	p.workTracker.Wait()
+		result.Status = true
+		result.Errors = make([]compilercommon.SourceError, 0)
+		result.Warnings = make([]compilercommon.SourceWarning, 0)
+
+		p.loadAll(entrypointPaths, libraries)
+	}
 
 	// Tell the goroutines to quit.
 	p.finished <- true
@@ -190,6 +243,10 @@ func (p *PackageLoader) Load(libraries ...Library) LoadResult {
 	// Save the package map.
 	result.PackageMap = p.packageMap.Build()
 	result.SourceTracker = p.sourceTracker.Freeze()
+	result.LockFile = p.buildLockFile()
+	result.Imports = p.buildImports()
+	result.VCSPackages = p.buildVCSPackages()
+	result.SumFile = p.buildSumFile()
 
 	// Apply all parser changes.
 	for _, parser := range p.parsers {
@@ -223,6 +280,69 @@ func (p *PackageLoader) Load(libraries ...Library) LoadResult {
 	return *result
 }
 
+// startParsers starts a new parser for each of the handlers.
+func (p *PackageLoader) startParsers() {
+	parsersMap := map[string]SourceHandlerParser{}
+	for _, handler := range p.handlers {
+		parser := handler.NewParser()
+		if parser == nil {
+			panic(fmt.Sprintf("Got a nil parser from handler `%s`", handler.Kind()))
+		}
+		parsersMap[handler.Kind()] = parser
+	}
+	p.parsers = parsersMap
+}
+
+// loadAll loads the given entrypoint paths and libraries, as well as everything they import, and
+// waits for all loading to complete.
+func (p *PackageLoader) loadAll(entrypointPaths []string, libraries []Library) {
+	for _, path := range entrypointPaths {
+		sourceRange := compilercommon.InputSource(path).RangeForRunePosition(0, p.sourceTracker)
+		for _, handler := range p.handlers {
+			if strings.HasSuffix(path, handler.PackageFileExtension()) {
+				p.pushPath(pathSourceFile, handler.Kind(), path, sourceRange)
+				break
+			}
+		}
+	}
+
+	// Add the libraries to be parsed.
+	for _, library := range libraries {
+		sourceRange := compilercommon.InputSource(library.PathOrURL).RangeForRunePosition(0, p.sourceTracker)
+		p.pushLibrary(library, library.Kind, sourceRange)
+	}
+
+	// Wait for all packages and source files to be completed. Version range imports are resolved
+	// once everything else has loaded, which can in turn load further packages.
+	p.workTracker.Wait()
+	for p.resolveVersionRanges() {
+		p.workTracker.Wait()
+	}
+}
+
+// resetForReselection discards all packages and source loaded, in preparation for loading them
+// again with newly selected versions of VCS packages. The constraints found by the version
+// selector are retained.
+func (p *PackageLoader) resetForReselection() {
+	for _, parser := range p.parsers {
+		parser.Cancel()
+	}
+
+	p.startParsers()
+
+	p.lockedPackages = cmap.New()
+	p.packageSums = cmap.New()
+	p.versionSelector.reset()
+
+	p.pathKindsEncountered = cmap.New()
+	p.vcsPathsLoaded = cmap.New()
+	p.vcsCheckouts = cmap.New()
+	p.vcsPackagesLoaded = cmap.New()
+	p.importsEncountered = cmap.New()
+	p.packageMap = newMutablePackageMap()
+	p.sourceTracker = newMutableSourceTracker(p.sourceTracker.pathLoader)
+}
+
 // PathLoader returns the path loader used by this package manager.
 func (p *PackageLoader) PathLoader() PathLoader {
 	return p.pathLoader
@@ -330,7 +450,7 @@ func (p *PackageLoader) packageInfoForPackageDirectory(packagePath string, sourc
 			continue
 		}
 
-		if !entry.IsDirectory && path.Ext(entry.Name) == handler.PackageFileExtension() {
+		if !entry.IsDirectory && strings.HasSuffix(entry.Name, handler.PackageFileExtension()) {
 			filePath := path.Join(packagePath, entry.Name)
 
 			// Add the source file to the package information.
@@ -343,6 +463,16 @@ func (p *PackageLoader) packageInfoForPackageDirectory(packagePath string, sourc
 
 // getVCSDirectoryForPath returns the directory on disk where the given VCS path will be placed, if any.
 func (p *PackageLoader) getVCSDirectoryForPath(vcsPath string) (string, error) {
+	// Prefer a vendored copy of the package, if any.
+	if _, isVendored := p.vendorManifest.Lookup(vcsPath); isVendored {
+		result, err := vcs.GetVendoredCheckout(vcsPath, VendorDirectoryPath(p.entrypoint, p.pathLoader))
+		return result.PackageDirectory, err
+	}
+
+	if resolvedPath, found := p.versionSelector.resolvedPath(vcsPath); found {
+		vcsPath = resolvedPath
+	}
+
 	pkgDirectory := p.pathLoader.VCSPackageDirectory(p.entrypoint)
 	return vcs.GetVCSCheckoutDirectory(vcsPath, pkgDirectory, p.vcsDevelopmentDirectories...)
 }
@@ -401,40 +531,57 @@ func (p *PackageLoader) loadAndParsePath(currentPath pathInformation) {
 
 // loadVCSPackage loads the package found at the given VCS path.
 func (p *PackageLoader) loadVCSPackage(packagePath pathInformation) {
+	p.loadVCSPackageAt(packagePath, packagePath.path)
+}
+
+// loadVCSPackageAt loads the package imported via the given VCS path, checking it out at the
+// given checkout path. The two paths differ only for version range imports, which are checked
+// out at the tag selected for them.
+func (p *PackageLoader) loadVCSPackageAt(packagePath pathInformation, checkoutPath string) {
 	if p.cancelationHandle.WasCanceled() {
 		return
 	}
 
-	// Lock on the package path to ensure no other checkouts occur for this path.
-	pathLock := p.vcsLockMap.GetLock(packagePath.path)
+	// Lock on the checkout path to ensure no other checkouts occur for this path.
+	pathLock := p.vcsLockMap.GetLock(checkoutPath)
 	pathLock.Lock()
 	defer pathLock.Unlock()
 
-	existingCheckoutDir, exists := p.vcsPathsLoaded.Get(packagePath.path)
+	existingCheckoutDir, exists := p.vcsPathsLoaded.Get(checkoutPath)
 	if exists {
 		// Note: existingCheckoutDir will be empty if there was an error loading the VCS.
 		if existingCheckoutDir != "" {
+			if packagePath.path != checkoutPath && !p.recordLockedPackage(packagePath, checkoutPath, "") {
+				return
+			}
+
+			p.vcsPackagesLoaded.Set(packagePath.path, checkoutPath)
+
 			// Push the now-local directory onto the package loading channel.
 			p.pushPathWithId(packagePath.referenceID, packagePath.sourceKind, pathLocalPackage, existingCheckoutDir.(string), packagePath.sourceRange)
 			return
 		}
 	}
 
-	// Perform the checkout of the VCS package.
-	var cacheOption = vcs.VCSFollowNormalCacheRules
-	if p.skipVCSRefresh {
-		cacheOption = vcs.VCSAlwaysUseCache
+	result, lockedCommit, ok := p.checkoutVCSPackage(packagePath, checkoutPath)
+	if !ok {
+		return
 	}
 
-	pkgDirectory := p.pathLoader.VCSPackageDirectory(p.entrypoint)
-	result, err := vcs.PerformVCSCheckout(packagePath.path, pkgDirectory, cacheOption, p.vcsDevelopmentDirectories...)
-	if err != nil {
-		p.vcsPathsLoaded.Set(packagePath.path, "")
-		p.enqueueError(compilercommon.SourceErrorf(packagePath.sourceRange, "Error loading VCS package '%s': %v", packagePath.path, err))
+	if !p.recordLockedPackage(packagePath, checkoutPath, lockedCommit) {
+		p.vcsPathsLoaded.Set(checkoutPath, "")
+		return
+	}
+
+	// Ensure the contents of the package match those recorded in the sums file.
+	if !p.verifyPackageSum(packagePath, checkoutPath, lockedCommit, result) {
+		p.vcsPathsLoaded.Set(checkoutPath, "")
 		return
 	}
 
-	p.vcsPathsLoaded.Set(packagePath.path, result.PackageDirectory)
+	p.vcsPathsLoaded.Set(checkoutPath, result.PackageDirectory)
+	p.vcsCheckouts.Set(checkoutPath, result)
+	p.vcsPackagesLoaded.Set(packagePath.path, checkoutPath)
 	if result.Warning != "" {
 		p.enqueueWarning(compilercommon.NewSourceWarning(packagePath.sourceRange, result.Warning))
 	}
@@ -463,6 +610,123 @@ func (p *PackageLoader) loadVCSPackage(packagePath pathInformation) {
 	p.pushPathWithId(packagePath.referenceID, packagePath.sourceKind, pathLocalPackage, result.PackageDirectory, packagePath.sourceRange)
 }
 
+// checkoutVCSPackage checks out the package imported via the given VCS path at the given checkout
+// path, preferring a vendored copy of the package if one exists. Returns the result of the checkout
+// and the commit locked for the package, if any. Returns false if the package could not be checked
+// out, or if its loading was deferred, as is the case for version range imports.
+func (p *PackageLoader) checkoutVCSPackage(packagePath pathInformation, checkoutPath string) (vcs.VCSCheckoutResult, string, bool) {
+	// Prefer a vendored copy of the package, if any.
+	if vendored, isVendored := p.vendorManifest.Lookup(packagePath.path); isVendored {
+		return p.loadVendoredPackage(packagePath, checkoutPath, vendored)
+	}
+
+	// If a lockfile was loaded, the package must be found within it.
+	var lockedCommit = ""
+	if p.hasLockFile {
+		locked, found := p.lockFile.Lookup(packagePath.path)
+		if !found {
+			p.vcsPathsLoaded.Set(checkoutPath, "")
+			p.enqueueError(compilercommon.SourceErrorf(packagePath.sourceRange,
+				"VCS package '%s' is not found in lockfile '%s'. Run `serulian imports lock` to update the lockfile.",
+				packagePath.path, LockFilePath(p.entrypoint, p.pathLoader)))
+			return vcs.VCSCheckoutResult{}, "", false
+		}
+
+		lockedCommit = locked.Commit
+	}
+
+	// Version range imports with a locked commit are checked out at that commit, so record it with
+	// the version selector to ensure the package's directory is found under the commit.
+	if lockedCommit != "" && packagePath.path == checkoutPath {
+		parsedPath, err := vcs.ParseVCSPath(packagePath.path)
+		if err == nil && parsedPath.IsVersionRange() {
+			p.versionSelector.recordResolvedPath(packagePath.path, parsedPath.WithCommit(lockedCommit).String())
+		}
+	}
+
+	// Version range imports without a locked commit are resolved once the constraints found across
+	// the entire dependency graph are known.
+	if lockedCommit == "" && packagePath.path == checkoutPath {
+		parsedPath, err := vcs.ParseVCSPath(packagePath.path)
+		if err == nil && parsedPath.IsVersionRange() {
+			p.versionSelector.addPendingImport(packagePath)
+			return vcs.VCSCheckoutResult{}, "", false
+		}
+	}
+
+	// Perform the checkout of the VCS package.
+	var cacheOption = vcs.VCSFollowNormalCacheRules
+	if p.skipVCSRefresh {
+		cacheOption = vcs.VCSAlwaysUseCache
+	}
+
+	pkgDirectory := p.pathLoader.VCSPackageDirectory(p.entrypoint)
+	result, err := vcs.PerformVCSCheckoutAtCommit(checkoutPath, lockedCommit, pkgDirectory, cacheOption, p.vcsDevelopmentDirectories...)
+	if err != nil {
+		p.vcsPathsLoaded.Set(checkoutPath, "")
+		p.enqueueError(compilercommon.SourceErrorf(packagePath.sourceRange, "Error loading VCS package '%s': %v", packagePath.path, err))
+		return vcs.VCSCheckoutResult{}, "", false
+	}
+
+	return result, lockedCommit, true
+}
+
+// recordLockedPackage records the commit at which the VCS package imported via the given path was
+// checked out, if any. If resolving and no commit is known, the commit is determined by inspecting
+// the checkout. Returns false if the commit could not be determined.
+func (p *PackageLoader) recordLockedPackage(packagePath pathInformation, checkoutPath string, lockedCommit string) bool {
+	if p.vcsLockOption == VCSLockResolve && lockedCommit == "" {
+		pkgDirectory := p.pathLoader.VCSPackageDirectory(p.entrypoint)
+		commitSha, err := vcs.InspectVCSCheckout(checkoutPath, pkgDirectory, p.vcsDevelopmentDirectories...)
+		if err != nil {
+			p.enqueueError(compilercommon.SourceErrorf(packagePath.sourceRange, "Could not determine commit of VCS package '%s': %v", packagePath.path, err))
+			return false
+		}
+
+		lockedCommit = commitSha
+	}
+
+	if lockedCommit != "" {
+		p.lockedPackages.Set(packagePath.path, LockedPackage{Commit: lockedCommit})
+	}
+
+	return true
+}
+
+// loadLockFile loads the lockfile for the entrypoint, as per the VCS lock option.
+func (p *PackageLoader) loadLockFile() error {
+	if p.vcsLockOption == VCSLockResolve {
+		return nil
+	}
+
+	lockFilePath := LockFilePath(p.entrypoint, p.pathLoader)
+	lockFile, found, err := ReadLockFile(lockFilePath, p.pathLoader)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		if p.vcsLockOption == VCSLockRequire {
+			return fmt.Errorf("Lockfile not found. Run `serulian imports lock` to create it.")
+		}
+
+		return nil
+	}
+
+	p.lockFile = lockFile
+	p.hasLockFile = true
+	return nil
+}
+
+// buildLockFile returns a lockfile containing the locked packages for all VCS packages loaded.
+func (p *PackageLoader) buildLockFile() LockFile {
+	lockFile := NewLockFile()
+	for entry := range p.lockedPackages.IterBuffered() {
+		lockFile.Packages[entry.Key] = entry.Val.(LockedPackage)
+	}
+	return lockFile
+}
+
 // loadLocalPackage loads the package found at the path relative to the package directory.
 func (p *PackageLoader) loadLocalPackage(packagePath pathInformation) {
 	packageInfo, err := p.packageInfoForPackageDirectory(packagePath.path, packagePath.sourceKind)
@@ -553,6 +817,17 @@ func (p *PackageLoader) handleImport(sourceKind string, importPath string, impor
 	sourceRange := importSource.RangeForRunePosition(runePosition, p.sourceTracker)
 	importInformation := PackageImport{sourceKind, importPath, importType, sourceRange}
 
+	referenceID := p.queueImport(importInformation)
+	if referenceID != "" {
+		p.recordImport(importSource, importInformation, referenceID)
+	}
+
+	return referenceID
+}
+
+// queueImport queues the loading of the package or module imported, returning its reference ID
+// or empty string on error.
+func (p *PackageLoader) queueImport(importInformation PackageImport) string {
 	handler, hasHandler := p.handlers[importInformation.Kind]
 	if !hasHandler {
 		p.enqueueError(compilercommon.SourceErrorf(importInformation.SourceRange, "Unknown kind of import '%s'. Did you forgot to install a source plugin?", importInformation.Kind))
@@ -22,7 +22,8 @@ import (
 var _ = fmt.Printf
 
 type testFile struct {
-	Imports []string
+	Imports    []string
+	VCSImports []string
 }
 
 type testTracker struct {
@@ -68,6 +69,10 @@ func (tt *testParser) Parse(source compilercommon.InputSource, input string, imp
 	for _, importPath := range file.Imports {
 		importHandler("", importPath, ImportTypeLocal, source, 0)
 	}
+
+	for index, importPath := range file.VCSImports {
+		importHandler("", importPath, ImportTypeVCS, source, index)
+	}
 }
 
 func TestBasicLoading(t *testing.T) {
@@ -93,6 +98,39 @@ func TestBasicLoading(t *testing.T) {
 	}
 }
 
+func TestImportsRecorded(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	loader := NewPackageLoader(NewBasicConfig("tests/basic/somefile.json", tt.createHandler()))
+	result := loader.Load()
+	if !assert.True(t, result.Status, "Expected success, found: %v", result.Errors) {
+		return
+	}
+
+	if !assert.Equal(t, 2, len(result.Imports), "Expected two imports") {
+		return
+	}
+
+	assert.Equal(t, compilercommon.InputSource("tests/basic/anotherfile.json"), result.Imports[0].SourceModule)
+	assert.Equal(t, "somesubdir", result.Imports[0].ImportPath)
+	assert.Equal(t, "tests/basic/somesubdir", result.Imports[0].ReferenceID)
+
+	assert.Equal(t, compilercommon.InputSource("tests/basic/somefile.json"), result.Imports[1].SourceModule)
+	assert.Equal(t, "anotherfile", result.Imports[1].ImportPath)
+	assert.Equal(t, "tests/basic/anotherfile.json", result.Imports[1].ReferenceID)
+
+	entries := result.PackageMap.Entries()
+	paths := make([]string, len(entries))
+	for index, entry := range entries {
+		paths[index] = entry.Path
+	}
+
+	assert.Equal(t, []string{"tests/basic/anotherfile.json", "tests/basic/somefile.json", "tests/basic/somesubdir", "tests/basic/somesubdir/subdirfile.json"}, paths)
+	assert.Equal(t, 0, len(result.VCSPackages))
+}
+
 func TestRelativeImportSuccess(t *testing.T) {
 	tt := &testTracker{
 		pathsImported: cmap.New(),
@@ -6,6 +6,8 @@ package packageloader
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/streamrail/concurrent-map"
 )
@@ -57,3 +59,26 @@ func (lpm LoadedPackageMap) Get(sourceKind string, packagePath string) (PackageI
 	info, ok := lpm.packageMap[packageKey(sourceKind, packagePath)]
 	return info, ok
 }
+
+// LoadedPackageEntry holds a package found in a LoadedPackageMap, along with the path under
+// which it was loaded.
+type LoadedPackageEntry struct {
+	Path string      // The path under which the package was loaded. For VCS packages, the VCS path.
+	Info PackageInfo // The information for the package.
+}
+
+// Entries returns all packages in the map, sorted by source kind and path.
+func (lpm LoadedPackageMap) Entries() []LoadedPackageEntry {
+	keys := make([]string, 0, len(lpm.packageMap))
+	for key := range lpm.packageMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]LoadedPackageEntry, 0, len(keys))
+	for _, key := range keys {
+		info := lpm.packageMap[key]
+		entries = append(entries, LoadedPackageEntry{strings.TrimPrefix(key, packageKey(info.kind, "")), info})
+	}
+	return entries
+}
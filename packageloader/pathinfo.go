@@ -39,7 +39,13 @@ type pathInformation struct {
 	sourceRange compilercommon.SourceRange
 }
 
-// Returns the string representation of the given path.
+// Returns the string representation of the given path. Note that the reference ID is included
+// if it differs from the path, as multiple VCS imports (such as version range imports) can refer
+// to the same local package, which must be registered under each of their reference IDs.
 func (p *pathInformation) String() string {
+	if p.referenceID != p.path {
+		return fmt.Sprintf("%v::%s::%s::%s", int(p.kind), p.sourceKind, p.path, p.referenceID)
+	}
+
 	return fmt.Sprintf("%v::%s::%s", int(p.kind), p.sourceKind, p.path)
 }
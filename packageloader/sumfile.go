@@ -0,0 +1,241 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/vcs"
+)
+
+// SumFileName is the name of the sums file placed in the entrypoint directory of a project,
+// recording a hash of the contents of every pinned VCS package imported by the project.
+const SumFileName = "serulian.sum"
+
+// sumHashPrefix is the prefix of all content hashes, identifying the hashing algorithm.
+const sumHashPrefix = "sha256:"
+
+// VCSSumOption defines the various ways in which the sums file is used when loading VCS packages.
+type VCSSumOption int
+
+const (
+	// VCSSumVerify indicates that the contents of pinned VCS packages will be verified against the
+	// hashes recorded in the sums file, if one exists. A mismatch is reported as an error.
+	VCSSumVerify VCSSumOption = iota
+
+	// VCSSumRefresh indicates that any existing sums file will be ignored and the hashes computed
+	// for the pinned VCS packages loaded will be recorded in the LoadResult.
+	VCSSumRefresh
+)
+
+// SumFile holds the content hashes of pinned VCS packages, keyed by the VCS path checked out. Only
+// packages pinned to a specific tag or commit are hashed, as all others are expected to change.
+type SumFile struct {
+	// Sums maps from each VCS path to the content hash of the package checked out for that path.
+	Sums map[string]string
+}
+
+// NewSumFile returns a new, empty sums file.
+func NewSumFile() SumFile {
+	return SumFile{map[string]string{}}
+}
+
+// SumFilePath returns the path of the sums file for the given entrypoint.
+func SumFilePath(entrypoint Entrypoint, pathLoader PathLoader) string {
+	return path.Join(entrypoint.EntrypointDirectoryPath(pathLoader), SumFileName)
+}
+
+// ReadSumFile reads the sums file found at the given path, if any. Each line of the file holds
+// a VCS path followed by its content hash, separated by whitespace.
+func ReadSumFile(sumFilePath string, pathLoader PathLoader) (SumFile, bool, error) {
+	exists, err := pathLoader.Exists(sumFilePath)
+	if err != nil || !exists {
+		return SumFile{}, false, err
+	}
+
+	contents, err := pathLoader.LoadSourceFile(sumFilePath)
+	if err != nil {
+		return SumFile{}, false, err
+	}
+
+	sumFile := NewSumFile()
+	for index, line := range strings.Split(string(contents), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], sumHashPrefix) {
+			return SumFile{}, false, fmt.Errorf("Could not parse sums file: invalid entry on line %v", index+1)
+		}
+
+		if existing, found := sumFile.Sums[fields[0]]; found && existing != fields[1] {
+			return SumFile{}, false, fmt.Errorf("Could not parse sums file: conflicting entries for VCS package `%s`", fields[0])
+		}
+
+		sumFile.Sums[fields[0]] = fields[1]
+	}
+
+	return sumFile, true, nil
+}
+
+// Write writes the sums file to the given path.
+func (sf SumFile) Write(sumFilePath string) error {
+	var buffer bytes.Buffer
+	for _, vcsPath := range sf.VCSPaths() {
+		buffer.WriteString(fmt.Sprintf("%s %s\n", vcsPath, sf.Sums[vcsPath]))
+	}
+
+	return ioutil.WriteFile(sumFilePath, buffer.Bytes(), 0644)
+}
+
+// Lookup returns the content hash recorded for the given VCS path, if any.
+func (sf SumFile) Lookup(vcsPath string) (string, bool) {
+	sum, found := sf.Sums[vcsPath]
+	return sum, found
+}
+
+// VCSPaths returns the VCS paths found in the sums file, in sorted order.
+func (sf SumFile) VCSPaths() []string {
+	var vcsPaths = make([]string, 0, len(sf.Sums))
+	for vcsPath := range sf.Sums {
+		vcsPaths = append(vcsPaths, vcsPath)
+	}
+
+	sort.Strings(vcsPaths)
+	return vcsPaths
+}
+
+// loadSumFile loads the sums file for the entrypoint, as per the VCS sum option.
+func (p *PackageLoader) loadSumFile() error {
+	if p.vcsSumOption == VCSSumRefresh {
+		return nil
+	}
+
+	sumFile, found, err := ReadSumFile(SumFilePath(p.entrypoint, p.pathLoader), p.pathLoader)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		p.sumFile = sumFile
+		p.hasSumFile = true
+	}
+
+	return nil
+}
+
+// buildSumFile returns a sums file containing the content hashes of all pinned VCS packages loaded.
+func (p *PackageLoader) buildSumFile() SumFile {
+	sumFile := NewSumFile()
+	for entry := range p.packageSums.IterBuffered() {
+		sumFile.Sums[entry.Key] = entry.Val.(string)
+	}
+	return sumFile
+}
+
+// verifyPackageSum computes the content hash of the VCS package checked out with the given result
+// and, if the package is pinned to a specific tag or commit, verifies it against the hash recorded
+// in the sums file. Returns false if the package's contents do not match those recorded.
+func (p *PackageLoader) verifyPackageSum(packagePath pathInformation, checkoutPath string, lockedCommit string, result vcs.VCSCheckoutResult) bool {
+	// Only packages pinned to a specific tag or commit are expected to have unchanging contents.
+	if lockedCommit == "" && result.Status != vcs.DetachedPackage {
+		return true
+	}
+
+	sumKey := checkoutPath
+	if lockedCommit != "" {
+		parsedPath, err := vcs.ParseVCSPath(checkoutPath)
+		if err != nil {
+			return true
+		}
+
+		sumKey = parsedPath.WithCommit(lockedCommit).String()
+	}
+
+	sum, err := p.computePackageSum(result.PackageDirectory)
+	if err != nil {
+		p.enqueueError(compilercommon.SourceErrorf(packagePath.sourceRange, "Could not compute checksum of VCS package '%s': %v", packagePath.path, err))
+		return false
+	}
+
+	if p.hasSumFile {
+		recorded, found := p.sumFile.Lookup(sumKey)
+		if found && recorded != sum {
+			p.enqueueError(compilercommon.SourceErrorf(packagePath.sourceRange,
+				"SECURITY ERROR: Contents of VCS package '%s' do not match the checksum recorded in '%s': expected %s, found %s. The package may have been tampered with (for example, via a force-pushed tag). If this change is expected, run `serulian imports sum` to refresh the checksums.",
+				sumKey, SumFilePath(p.entrypoint, p.pathLoader), recorded, sum))
+			return false
+		}
+
+		if !found {
+			p.enqueueWarning(compilercommon.SourceWarningf(packagePath.sourceRange,
+				"VCS package '%s' has no checksum recorded in '%s'. Run `serulian imports sum` to record it.",
+				sumKey, SumFilePath(p.entrypoint, p.pathLoader)))
+		}
+	}
+
+	p.packageSums.Set(sumKey, sum)
+	return true
+}
+
+// computePackageSum computes the content hash of all files found (recursively) under the given
+// package directory. The hash covers the relative path and contents of each file, and is independent
+// of the source handlers registered, ensuring the same hash is computed by all tooling.
+func (p *PackageLoader) computePackageSum(packageDirectory string) (string, error) {
+	packageFiles := make([]string, 0)
+	if err := p.collectPackageFiles(packageDirectory, "", &packageFiles); err != nil {
+		return "", err
+	}
+
+	sort.Strings(packageFiles)
+
+	hash := sha256.New()
+	for _, relativePath := range packageFiles {
+		contents, err := p.pathLoader.LoadSourceFile(path.Join(packageDirectory, relativePath))
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(hash, "%x  %s\n", sha256.Sum256(contents), relativePath)
+	}
+
+	return fmt.Sprintf("%s%x", sumHashPrefix, hash.Sum(nil)), nil
+}
+
+// collectPackageFiles adds the paths, relative to the root directory, of all files found under the
+// given subdirectory to the packageFiles slice. Hidden directories, such as those holding VCS metadata
+// and package caches, are skipped.
+func (p *PackageLoader) collectPackageFiles(rootDirectory string, subdirectory string, packageFiles *[]string) error {
+	directoryContents, err := p.pathLoader.LoadDirectory(path.Join(rootDirectory, subdirectory))
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range directoryContents {
+		relativePath := path.Join(subdirectory, entry.Name)
+		if entry.IsDirectory {
+			if strings.HasPrefix(entry.Name, ".") {
+				continue
+			}
+
+			if err := p.collectPackageFiles(rootDirectory, relativePath, packageFiles); err != nil {
+				return err
+			}
+			continue
+		}
+
+		*packageFiles = append(*packageFiles, relativePath)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	cmap "github.com/streamrail/concurrent-map"
+)
+
+const projectFileSum = "sha256:b5cca50f0920f6348b9be2256cf00e5a8ab94e9d9d361fd45bb78f74d5a94ebe"
+
+func TestSumRecorded(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	loader := NewPackageLoader(NewBasicConfig("tests/vendored/somefile.json", tt.createHandler()))
+	result := loader.Load(Library{"github.com/some/project", true, "", "someproject"})
+	if !assert.True(t, result.Status, "Expected success, found: %v", result.Errors) {
+		return
+	}
+
+	sum, found := result.SumFile.Lookup("github.com/some/project:abcdef")
+	if !assert.True(t, found, "Expected checksum for pinned VCS package") {
+		return
+	}
+
+	assert.Equal(t, projectFileSum, sum)
+}
+
+func TestSumVerified(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	loader := NewPackageLoader(NewBasicConfig("tests/vendoredsum/somefile.json", tt.createHandler()))
+	result := loader.Load(Library{"github.com/some/project", true, "", "someproject"})
+	if !assert.True(t, result.Status, "Expected success, found: %v", result.Errors) {
+		return
+	}
+
+	assert.Equal(t, 0, len(result.Warnings), "Expected no warnings, found: %v", result.Warnings)
+	assertFileImported(t, tt, result, "tests/vendoredsum/vendor/github.com/some/project/HEAD/projectfile.json")
+}
+
+func TestSumMismatch(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	loader := NewPackageLoader(NewBasicConfig("tests/vendoredsummismatch/somefile.json", tt.createHandler()))
+	result := loader.Load(Library{"github.com/some/project", true, "", "someproject"})
+	if !assert.False(t, result.Status, "Expected failure for checksum mismatch") {
+		return
+	}
+
+	if !assert.Equal(t, 1, len(result.Errors)) {
+		return
+	}
+
+	assert.Equal(t, "SECURITY ERROR: Contents of VCS package 'github.com/some/project:abcdef' do not match the checksum recorded in 'tests/vendoredsummismatch/serulian.sum': expected sha256:0000000000000000000000000000000000000000000000000000000000000000, found "+projectFileSum+". The package may have been tampered with (for example, via a force-pushed tag). If this change is expected, run `serulian imports sum` to refresh the checksums.", result.Errors[0].Error())
+}
+
+func TestSumRefresh(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	config := NewBasicConfig("tests/vendoredsummismatch/somefile.json", tt.createHandler())
+	config.VCSSumOption = VCSSumRefresh
+
+	loader := NewPackageLoader(config)
+	result := loader.Load(Library{"github.com/some/project", true, "", "someproject"})
+	if !assert.True(t, result.Status, "Expected success, found: %v", result.Errors) {
+		return
+	}
+
+	sum, _ := result.SumFile.Lookup("github.com/some/project:abcdef")
+	assert.Equal(t, projectFileSum, sum)
+}
+
+func TestReadSumFile(t *testing.T) {
+	sumFile, found, err := ReadSumFile("tests/vendoredsum/serulian.sum", LocalFilePathLoader{})
+	if !assert.Nil(t, err) || !assert.True(t, found) {
+		return
+	}
+
+	assert.Equal(t, []string{"github.com/some/project:abcdef"}, sumFile.VCSPaths())
+
+	_, found, err = ReadSumFile("tests/vendored/serulian.sum", LocalFilePathLoader{})
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestSumIndependentOfHandlers(t *testing.T) {
+	loader := NewPackageLoader(NewBasicConfig("tests/vendoredsum/somefile.json"))
+
+	sum, err := loader.computePackageSum("tests/vendoredsum/vendor/github.com/some/project/HEAD")
+	if !assert.Nil(t, err, "Expected no error computing checksum") {
+		return
+	}
+
+	assert.Equal(t, projectFileSum, sum)
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"path"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/vcs"
+)
+
+// SerulianVendorDirectory is the directory under the entrypoint directory holding vendored copies
+// of VCS packages. When present, vendored copies are used in lieu of VCS checkout, allowing for
+// builds that never access the network.
+const SerulianVendorDirectory = "vendor"
+
+// VendorDirectoryPath returns the path of the vendor directory for the given entrypoint.
+func VendorDirectoryPath(entrypoint Entrypoint, pathLoader PathLoader) string {
+	return path.Join(entrypoint.EntrypointDirectoryPath(pathLoader), SerulianVendorDirectory)
+}
+
+// VendorManifestPath returns the path of the manifest of the vendor directory for the given
+// entrypoint. The manifest is a lockfile recording the commit of every VCS package vendored.
+func VendorManifestPath(entrypoint Entrypoint, pathLoader PathLoader) string {
+	return path.Join(VendorDirectoryPath(entrypoint, pathLoader), LockFileName)
+}
+
+// loadVendorManifest loads the manifest of the vendor directory for the entrypoint, if any.
+func (p *PackageLoader) loadVendorManifest() error {
+	if p.skipVendoredPackages {
+		return nil
+	}
+
+	manifest, _, err := ReadLockFile(VendorManifestPath(p.entrypoint, p.pathLoader), p.pathLoader)
+	if err != nil {
+		return err
+	}
+
+	p.vendorManifest = manifest
+	return nil
+}
+
+// loadVendoredPackage returns the checkout result for the vendored copy of the package imported
+// via the given VCS path. If a lockfile was loaded, the vendored copy must be at the locked commit.
+func (p *PackageLoader) loadVendoredPackage(packagePath pathInformation, checkoutPath string, vendored LockedPackage) (vcs.VCSCheckoutResult, string, bool) {
+	if p.hasLockFile {
+		locked, found := p.lockFile.Lookup(packagePath.path)
+		if found && locked.Commit != vendored.Commit {
+			p.vcsPathsLoaded.Set(checkoutPath, "")
+			p.enqueueError(compilercommon.SourceErrorf(packagePath.sourceRange,
+				"Vendored copy of VCS package '%s' is at commit '%s', but lockfile '%s' specifies commit '%s'. Run `serulian imports vendor` to update the vendored copy.",
+				packagePath.path, vendored.Commit, LockFilePath(p.entrypoint, p.pathLoader), locked.Commit))
+			return vcs.VCSCheckoutResult{}, "", false
+		}
+	}
+
+	vendorDirectory := VendorDirectoryPath(p.entrypoint, p.pathLoader)
+	result, err := vcs.GetVendoredCheckout(packagePath.path, vendorDirectory)
+	if err != nil {
+		p.vcsPathsLoaded.Set(checkoutPath, "")
+		p.enqueueError(compilercommon.SourceErrorf(packagePath.sourceRange, "Error loading vendored VCS package '%s': %v", packagePath.path, err))
+		return vcs.VCSCheckoutResult{}, "", false
+	}
+
+	return result, vendored.Commit, true
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"testing"
+
+	"github.com/serulian/compiler/vcs"
+
+	"github.com/stretchr/testify/assert"
+
+	cmap "github.com/streamrail/concurrent-map"
+)
+
+func TestVendoredLoading(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	loader := NewPackageLoader(NewBasicConfig("tests/vendored/somefile.json", tt.createHandler()))
+	result := loader.Load(Library{"github.com/some/project", true, "", "someproject"})
+	if !assert.True(t, result.Status, "Expected success, found: %v", result.Errors) {
+		return
+	}
+
+	assertFileImported(t, tt, result, "tests/vendored/somefile.json")
+	assertFileImported(t, tt, result, "tests/vendored/vendor/github.com/some/project/HEAD/projectfile.json")
+
+	vcsPackage, found := result.VCSPackages["github.com/some/project"]
+	if !assert.True(t, found, "Expected vendored VCS package") {
+		return
+	}
+
+	assert.Equal(t, vcs.VendoredPackage, vcsPackage.Status)
+	assert.Equal(t, "abcdef", vcsPackage.Commit)
+	assert.Equal(t, "tests/vendored/vendor/github.com/some/project/HEAD", vcsPackage.Directory)
+}
+
+func TestVendoredLockFileMismatch(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	loader := NewPackageLoader(NewBasicConfig("tests/vendoredmismatch/somefile.json", tt.createHandler()))
+	result := loader.Load(Library{"github.com/some/project", true, "", "someproject"})
+	if !assert.False(t, result.Status, "Expected failure for vendored lockfile mismatch") {
+		return
+	}
+
+	if !assert.Equal(t, 1, len(result.Errors)) {
+		return
+	}
+
+	assert.Equal(t, "Vendored copy of VCS package 'github.com/some/project' is at commit 'abcdef', but lockfile 'tests/vendoredmismatch/serulian.lock' specifies commit '123456'. Run `serulian imports vendor` to update the vendored copy.", result.Errors[0].Error())
+}
+
+func TestSkipVendoredPackages(t *testing.T) {
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	config := NewBasicConfig("tests/vendored/somefile.json", tt.createHandler())
+	config.SkipVendoredPackages = true
+
+	loader := NewPackageLoader(config)
+	result := loader.Load()
+	if !assert.True(t, result.Status, "Expected success, found: %v", result.Errors) {
+		return
+	}
+
+	assert.Equal(t, 0, len(loader.vendorManifest.Packages))
+}
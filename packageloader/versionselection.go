@@ -0,0 +1,354 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/vcs"
+)
+
+// versionConstraint is a semantic version range placed on a VCS repository by an import.
+type versionConstraint struct {
+	versionRange compilerutil.SemanticVersionRange // The range of versions allowed by the import.
+	sourceRange  compilercommon.SourceRange        // The source range of the import.
+}
+
+// versionSelector tracks the semantic version range imports of VCS packages found across the
+// entire dependency graph, and the versions selected for them.
+//
+// Version range imports are not checked out as they are encountered. Instead, they are held as
+// pending until all other loading has completed, at which point the minimal version satisfying
+// every constraint found on each repository is selected, and the imports are loaded at that version.
+// As loading the selected packages can introduce further constraints, selection is repeated until
+// no pending imports remain. If a constraint found in a later round excludes the version already
+// selected for a repository, the selector is marked as needing reselection, and the loader reloads
+// all packages, with the constraints found in the load just completed retained for use in selecting
+// versions.
+//
+// Constraints are keyed by the source range of the import that placed them, ensuring that an import
+// encountered again on reload does not add a duplicate constraint. As the retained constraints are
+// rebuilt from each load, constraints placed by packages at versions no longer selected are dropped;
+// should any retained constraint not be found again, the selector is likewise marked as needing
+// reselection, to ensure the versions selected do not depend on it.
+type versionSelector struct {
+	sync.Mutex
+
+	pendingImports []pathInformation                       // The version range imports awaiting selection.
+	constraints    map[string]map[string]versionConstraint // The constraints found in this load, by repository URL and import source range.
+	retained       map[string]map[string]versionConstraint // The constraints found in the previous load, by repository URL and import source range.
+	retainedSeen   map[string]bool                         // The retained constraint sets used across all loads.
+	selectedTags   map[string]string                       // The tag selected, by repository URL.
+	resolvedPaths  map[string]string                       // The VCS path checked out, by version range import path.
+	reselect       bool                                    // Whether a selected tag was excluded by a later constraint.
+}
+
+// newVersionSelector returns a new, empty version selector.
+func newVersionSelector() *versionSelector {
+	return &versionSelector{
+		pendingImports: make([]pathInformation, 0),
+		constraints:    map[string]map[string]versionConstraint{},
+		retained:       map[string]map[string]versionConstraint{},
+		retainedSeen:   map[string]bool{},
+		selectedTags:   map[string]string{},
+		resolvedPaths:  map[string]string{},
+	}
+}
+
+// reset clears all pending imports, selected tags and resolved paths, in preparation for a reload
+// of all packages. The constraints found in the load just completed are retained, replacing those
+// retained previously.
+func (vs *versionSelector) reset() {
+	vs.Lock()
+	defer vs.Unlock()
+
+	vs.retainedSeen[constraintSetKey(vs.retained)] = true
+	vs.retained = vs.constraints
+	vs.constraints = map[string]map[string]versionConstraint{}
+
+	vs.pendingImports = make([]pathInformation, 0)
+	vs.selectedTags = map[string]string{}
+	vs.resolvedPaths = map[string]string{}
+	vs.reselect = false
+}
+
+// markNeedsReselection marks that a tag selected was excluded by a constraint found later, which
+// requires all packages to be reloaded.
+func (vs *versionSelector) markNeedsReselection() {
+	vs.Lock()
+	defer vs.Unlock()
+	vs.reselect = true
+}
+
+// needsReselection returns whether a tag selected was excluded by a constraint found later, or
+// whether a retained constraint was not found again, in which case the versions selected may depend
+// on a constraint placed by a package at a version no longer selected. To ensure termination, the
+// latter only applies if reloading would select using a set of constraints not already used.
+func (vs *versionSelector) needsReselection() bool {
+	vs.Lock()
+	defer vs.Unlock()
+
+	if vs.reselect {
+		return true
+	}
+
+	for repositoryURL, retained := range vs.retained {
+		for key := range retained {
+			if _, found := vs.constraints[repositoryURL][key]; !found {
+				return !vs.retainedSeen[constraintSetKey(vs.constraints)]
+			}
+		}
+	}
+
+	return false
+}
+
+// addPendingImport adds a version range import to be resolved once all other loading has completed.
+func (vs *versionSelector) addPendingImport(importPath pathInformation) {
+	vs.Lock()
+	defer vs.Unlock()
+	vs.pendingImports = append(vs.pendingImports, importPath)
+}
+
+// takePendingImports returns all pending version range imports, grouped by repository URL, and
+// clears the pending list.
+func (vs *versionSelector) takePendingImports() map[string][]pathInformation {
+	vs.Lock()
+	defer vs.Unlock()
+
+	pending := map[string][]pathInformation{}
+	for _, importPath := range vs.pendingImports {
+		parsed, _ := vcs.ParseVCSPath(importPath.path)
+		pending[parsed.URL()] = append(pending[parsed.URL()], importPath)
+	}
+
+	vs.pendingImports = make([]pathInformation, 0)
+	return pending
+}
+
+// addConstraints adds the given constraints to those found for the repository, returning all
+// constraints found or retained for the repository, as well as the tag already selected for it, if any.
+func (vs *versionSelector) addConstraints(repositoryURL string, constraints []versionConstraint) ([]versionConstraint, string, bool) {
+	vs.Lock()
+	defer vs.Unlock()
+
+	if _, exists := vs.constraints[repositoryURL]; !exists {
+		vs.constraints[repositoryURL] = map[string]versionConstraint{}
+	}
+
+	for _, constraint := range constraints {
+		vs.constraints[repositoryURL][constraint.key()] = constraint
+	}
+
+	combined := map[string]versionConstraint{}
+	for key, constraint := range vs.retained[repositoryURL] {
+		combined[key] = constraint
+	}
+
+	for key, constraint := range vs.constraints[repositoryURL] {
+		combined[key] = constraint
+	}
+
+	keys := make([]string, 0, len(combined))
+	for key := range combined {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	allConstraints := make([]versionConstraint, len(keys))
+	for index, key := range keys {
+		allConstraints[index] = combined[key]
+	}
+
+	selectedTag, hasSelectedTag := vs.selectedTags[repositoryURL]
+	return allConstraints, selectedTag, hasSelectedTag
+}
+
+// key returns the key of the constraint, which is the source range of the import that placed it.
+func (vc versionConstraint) key() string {
+	return fmt.Sprintf("%v", vc.sourceRange)
+}
+
+// constraintSetKey returns a key uniquely identifying the given set of constraints.
+func constraintSetKey(constraints map[string]map[string]versionConstraint) string {
+	keys := make([]string, 0)
+	for repositoryURL, repositoryConstraints := range constraints {
+		for key, constraint := range repositoryConstraints {
+			keys = append(keys, fmt.Sprintf("%s|%s|%s", repositoryURL, key, constraint.versionRange))
+		}
+	}
+
+	sort.Strings(keys)
+	return strings.Join(keys, "\n")
+}
+
+// selectTag marks the given tag as selected for the repository.
+func (vs *versionSelector) selectTag(repositoryURL string, tag string) {
+	vs.Lock()
+	defer vs.Unlock()
+	vs.selectedTags[repositoryURL] = tag
+}
+
+// recordResolvedPath records the VCS path at which the version range import is checked out.
+func (vs *versionSelector) recordResolvedPath(importPath string, resolvedPath string) {
+	vs.Lock()
+	defer vs.Unlock()
+	vs.resolvedPaths[importPath] = resolvedPath
+}
+
+// resolvedPath returns the VCS path at which the version range import was checked out, if any.
+func (vs *versionSelector) resolvedPath(importPath string) (string, bool) {
+	vs.Lock()
+	defer vs.Unlock()
+	resolvedPath, found := vs.resolvedPaths[importPath]
+	return resolvedPath, found
+}
+
+// resolveVersionRanges performs version selection for all pending version range imports and
+// starts the loading of the selected packages. Returns false if there were no pending imports.
+func (p *PackageLoader) resolveVersionRanges() bool {
+	pending := p.versionSelector.takePendingImports()
+	if len(pending) == 0 {
+		return false
+	}
+
+	for repositoryURL, imports := range pending {
+		p.workTracker.Add(1)
+		go p.selectVersion(repositoryURL, imports)
+	}
+
+	return true
+}
+
+// selectVersion selects the minimal version of the VCS repository at the given URL that satisfies
+// all constraints found on it and loads the given imports at that version. If a version was
+// selected for the repository in an earlier round and does not satisfy the new constraints, the
+// selector is marked as needing reselection, as packages have already been loaded at that version.
+func (p *PackageLoader) selectVersion(repositoryURL string, imports []pathInformation) {
+	defer p.workTracker.Done()
+
+	if p.cancelationHandle.WasCanceled() {
+		return
+	}
+
+	constraints := make([]versionConstraint, 0, len(imports))
+	for _, importPath := range imports {
+		parsed, _ := vcs.ParseVCSPath(importPath.path)
+		versionRange, _ := compilerutil.ParseSemanticVersionRange(parsed.VersionRange())
+		constraints = append(constraints, versionConstraint{versionRange, importPath.sourceRange})
+	}
+
+	allConstraints, selectedTag, alreadySelected := p.versionSelector.addConstraints(repositoryURL, constraints)
+	if alreadySelected {
+		var satisfied = true
+		for _, constraint := range constraints {
+			if !constraint.versionRange.Contains(selectedTag) {
+				satisfied = false
+				break
+			}
+		}
+
+		if !satisfied {
+			// Ensure a version satisfying all constraints exists before reloading with it selected.
+			if _, found := p.minimalSatisfyingTag(repositoryURL, allConstraints, constraints); found {
+				p.versionSelector.markNeedsReselection()
+			}
+			return
+		}
+	} else {
+		minimalTag, found := p.minimalSatisfyingTag(repositoryURL, allConstraints, constraints)
+		if !found {
+			return
+		}
+
+		selectedTag = minimalTag
+		p.versionSelector.selectTag(repositoryURL, selectedTag)
+	}
+
+	// Load each of the imports at the selected version.
+	for _, importPath := range imports {
+		parsed, _ := vcs.ParseVCSPath(importPath.path)
+		resolvedPath := parsed.WithTag(selectedTag).String()
+		p.versionSelector.recordResolvedPath(importPath.path, resolvedPath)
+
+		p.workTracker.Add(1)
+		go p.loadResolvedVCSPackage(importPath, resolvedPath)
+	}
+}
+
+// minimalSatisfyingTag returns the minimal tag of the VCS repository at the given URL that satisfies
+// all the given constraints. If none is found, an error is reported on each of the new constraints.
+func (p *PackageLoader) minimalSatisfyingTag(repositoryURL string, allConstraints []versionConstraint, newConstraints []versionConstraint) (string, bool) {
+	var cacheOption = vcs.VCSFollowNormalCacheRules
+	if p.skipVCSRefresh {
+		cacheOption = vcs.VCSAlwaysUseCache
+	}
+
+	pkgDirectory := p.pathLoader.VCSPackageDirectory(p.entrypoint)
+	tags, err := vcs.ListVCSTags(repositoryURL, pkgDirectory, cacheOption, p.vcsDevelopmentDirectories...)
+	if err != nil {
+		for _, constraint := range newConstraints {
+			p.enqueueError(compilercommon.SourceErrorf(constraint.sourceRange, "Could not list versions of VCS package '%s': %v", repositoryURL, err))
+		}
+		return "", false
+	}
+
+	ranges := make([]compilerutil.SemanticVersionRange, len(allConstraints))
+	for index, constraint := range allConstraints {
+		ranges[index] = constraint.versionRange
+	}
+
+	minimalTag, found := compilerutil.MinimalSatisfyingVersion(ranges, tags)
+	if !found {
+		for _, constraint := range newConstraints {
+			p.enqueueError(compilercommon.SourceErrorf(constraint.sourceRange,
+				"No version of VCS package '%s' satisfies %s. Available versions: %s",
+				repositoryURL, describeConstraints(allConstraints), describeVersions(tags)))
+		}
+		return "", false
+	}
+
+	return minimalTag, true
+}
+
+// loadResolvedVCSPackage loads the package for the version range import at the VCS path selected for it.
+func (p *PackageLoader) loadResolvedVCSPackage(importPath pathInformation, resolvedPath string) {
+	defer p.workTracker.Done()
+	p.loadVCSPackageAt(importPath, resolvedPath)
+}
+
+// describeConstraints returns a human-readable description of the given constraints.
+func describeConstraints(constraints []versionConstraint) string {
+	if len(constraints) == 1 {
+		return fmt.Sprintf("constraint `%s`", constraints[0].versionRange)
+	}
+
+	seen := map[string]bool{}
+	described := make([]string, 0, len(constraints))
+	for _, constraint := range constraints {
+		if seen[constraint.versionRange.String()] {
+			continue
+		}
+
+		seen[constraint.versionRange.String()] = true
+		described = append(described, fmt.Sprintf("`%s`", constraint.versionRange))
+	}
+
+	return "all of constraints " + strings.Join(described, ", ")
+}
+
+// describeVersions returns a human-readable description of the given available versions.
+func describeVersions(versions []string) string {
+	if len(versions) == 0 {
+		return "(none)"
+	}
+
+	return strings.Join(versions, ", ")
+}
@@ -0,0 +1,183 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packageloader
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/vcs"
+
+	"github.com/stretchr/testify/assert"
+
+	cmap "github.com/streamrail/concurrent-map"
+)
+
+func TestVersionSelectorReset(t *testing.T) {
+	broadRange, _ := compilerutil.ParseSemanticVersionRange("^1.0.0")
+	narrowRange, _ := compilerutil.ParseSemanticVersionRange("^1.6.0")
+
+	source := compilercommon.InputSource("somefile.seru")
+	broadConstraint := versionConstraint{broadRange, source.RangeForRunePositions(0, 10, nil)}
+	narrowConstraint := versionConstraint{narrowRange, source.RangeForRunePositions(20, 30, nil)}
+
+	vs := newVersionSelector()
+	vs.addPendingImport(pathInformation{path: "github.com/some/project@^1.0.0"})
+	vs.addConstraints("github.com/some/project", []versionConstraint{broadConstraint})
+	vs.selectTag("github.com/some/project", "v1.5.0")
+	vs.recordResolvedPath("github.com/some/project@^1.0.0", "github.com/some/project@v1.5.0")
+
+	allConstraints, selectedTag, alreadySelected := vs.addConstraints("github.com/some/project", []versionConstraint{narrowConstraint})
+	assert.True(t, alreadySelected)
+	assert.Equal(t, "v1.5.0", selectedTag)
+	assert.Equal(t, 2, len(allConstraints))
+
+	vs.markNeedsReselection()
+	assert.True(t, vs.needsReselection())
+
+	// Resetting should retain the constraints found, but clear all selections.
+	vs.reset()
+	assert.False(t, vs.needsReselection())
+	assert.Equal(t, 0, len(vs.takePendingImports()))
+
+	_, found := vs.resolvedPath("github.com/some/project@^1.0.0")
+	assert.False(t, found, "Expected resolved path to be cleared")
+
+	allConstraints, _, alreadySelected = vs.addConstraints("github.com/some/project", []versionConstraint{})
+	assert.False(t, alreadySelected, "Expected selected tag to be cleared")
+	assert.Equal(t, 2, len(allConstraints))
+
+	minimalTag, found := compilerutil.MinimalSatisfyingVersion([]compilerutil.SemanticVersionRange{allConstraints[0].versionRange, allConstraints[1].versionRange}, []string{"v1.5.0", "v1.6.2", "v1.7.0"})
+	assert.True(t, found)
+	assert.Equal(t, "v1.6.2", minimalTag)
+
+	// Encountering the same imports again on reload should not duplicate their constraints.
+	allConstraints, _, _ = vs.addConstraints("github.com/some/project", []versionConstraint{broadConstraint, narrowConstraint})
+	assert.Equal(t, 2, len(allConstraints))
+	assert.False(t, vs.needsReselection())
+}
+
+func TestVersionSelectorDropsStaleConstraints(t *testing.T) {
+	broadRange, _ := compilerutil.ParseSemanticVersionRange("^1.0.0")
+	narrowRange, _ := compilerutil.ParseSemanticVersionRange("^1.6.0")
+
+	source := compilercommon.InputSource("somefile.seru")
+	broadConstraint := versionConstraint{broadRange, source.RangeForRunePositions(0, 10, nil)}
+	staleConstraint := versionConstraint{narrowRange, compilercommon.InputSource("otherfile.seru").RangeForRunePositions(0, 10, nil)}
+
+	vs := newVersionSelector()
+	vs.addConstraints("github.com/some/project", []versionConstraint{broadConstraint, staleConstraint})
+	vs.markNeedsReselection()
+	vs.reset()
+
+	// The reload only finds the broad constraint, as the package that placed the narrow constraint
+	// is no longer loaded. The narrow constraint remains in force for this load.
+	allConstraints, _, _ := vs.addConstraints("github.com/some/project", []versionConstraint{broadConstraint})
+	assert.Equal(t, 2, len(allConstraints))
+	assert.True(t, vs.needsReselection(), "Expected reselection due to stale constraint")
+
+	// Once reset, only the constraints found in the final load are retained.
+	vs.reset()
+	allConstraints, _, _ = vs.addConstraints("github.com/some/project", []versionConstraint{broadConstraint})
+	if assert.Equal(t, 1, len(allConstraints)) {
+		assert.Equal(t, "^1.0.0", allConstraints[0].versionRange.String())
+	}
+	assert.False(t, vs.needsReselection())
+}
+
+// writeArchivePackage writes a tar.gz archive containing a single test file with the given VCS
+// imports, as the given ref of the package under the mirror directory.
+func writeArchivePackage(t *testing.T, mirrorDir string, packageName string, ref string, vcsImports ...string) {
+	contents, _ := json.Marshal(testFile{VCSImports: vcsImports})
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	tarWriter.WriteHeader(&tar.Header{Name: packageName + "-" + ref + "/" + packageName + ".json", Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg})
+	tarWriter.Write(contents)
+	tarWriter.Close()
+	gzipWriter.Close()
+
+	packageDir := path.Join(mirrorDir, packageName)
+	os.MkdirAll(packageDir, 0755)
+	if !assert.Nil(t, ioutil.WriteFile(path.Join(packageDir, ref+".tar.gz"), buffer.Bytes(), 0644)) {
+		t.FailNow()
+	}
+}
+
+func TestVersionReselection(t *testing.T) {
+	mirrorDir, err := ioutil.TempDir("", "mirror")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(mirrorDir)
+
+	// `first` at 1.0.0 constrains `third` to ^1.1.0, while `first` at 1.1.0 allows ^1.0.0. `second`
+	// requires `first` at ^1.1.0, which forces the reselection of `first` once found.
+	writeArchivePackage(t, mirrorDir, "first", "1.0.0", "example.com/lib/third@^1.1.0")
+	writeArchivePackage(t, mirrorDir, "first", "1.1.0", "example.com/lib/third@^1.0.0")
+	writeArchivePackage(t, mirrorDir, "first", "HEAD", "example.com/lib/third@^1.0.0")
+	writeArchivePackage(t, mirrorDir, "second", "1.0.0", "example.com/lib/first@^1.1.0")
+	writeArchivePackage(t, mirrorDir, "second", "HEAD", "example.com/lib/first@^1.1.0")
+	writeArchivePackage(t, mirrorDir, "third", "1.0.0")
+	writeArchivePackage(t, mirrorDir, "third", "1.1.0")
+	writeArchivePackage(t, mirrorDir, "third", "HEAD")
+
+	ioutil.WriteFile(path.Join(mirrorDir, "first", "tags"), []byte("1.0.0\n1.1.0\n"), 0644)
+	ioutil.WriteFile(path.Join(mirrorDir, "second", "tags"), []byte("1.0.0\n"), 0644)
+	ioutil.WriteFile(path.Join(mirrorDir, "third", "tags"), []byte("1.0.0\n1.1.0\n"), 0644)
+
+	vcs.SetMirrorConfig(vcs.MirrorConfig{
+		Mirrors: []vcs.Mirror{
+			vcs.Mirror{
+				Prefix: "example.com/lib/",
+				Kind:   "archive",
+				URL:    mirrorDir + "/{rest}/{ref}.tar.gz",
+				Tags:   mirrorDir + "/{rest}/tags",
+			},
+		},
+	})
+	defer vcs.SetMirrorConfig(vcs.MirrorConfig{})
+
+	projectDir, err := ioutil.TempDir("", "project")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(projectDir)
+
+	entrypoint, _ := json.Marshal(testFile{VCSImports: []string{"example.com/lib/first@^1.0.0", "example.com/lib/second@^1.0.0"}})
+	ioutil.WriteFile(path.Join(projectDir, "somefile.json"), entrypoint, 0644)
+
+	tt := &testTracker{
+		pathsImported: cmap.New(),
+	}
+
+	result := NewPackageLoader(NewBasicConfig(path.Join(projectDir, "somefile.json"), tt.createHandler())).Load()
+	if !assert.True(t, result.Status, "Expected success, found: %v", result.Errors) {
+		return
+	}
+
+	checkoutPaths := map[string]string{}
+	for vcsPath, info := range result.VCSPackages {
+		checkoutPaths[vcsPath] = info.CheckoutPath
+	}
+
+	// `first` is reselected at 1.1.0, and `third` is selected at 1.0.0, as the constraint placed by
+	// `first` at 1.0.0 is no longer in force.
+	assert.Equal(t, map[string]string{
+		"example.com/lib/first@^1.0.0":  "example.com/lib/first@1.1.0",
+		"example.com/lib/first@^1.1.0":  "example.com/lib/first@1.1.0",
+		"example.com/lib/second@^1.0.0": "example.com/lib/second@1.0.0",
+		"example.com/lib/third@^1.0.0":  "example.com/lib/third@1.0.0",
+	}, checkoutPaths)
+}
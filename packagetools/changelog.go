@@ -0,0 +1,195 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packagetools
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/graphs/typegraph/diff"
+)
+
+// ChangelogFileName is the name of the changelog file maintained in the root of a package.
+const ChangelogFileName = "CHANGELOG.md"
+
+// changelogTitle is the title placed at the top of newly created changelog files.
+const changelogTitle = "# Changelog"
+
+// changelogSection holds the entries of a single section of a changelog, grouped by module.
+type changelogSection struct {
+	title   string
+	entries map[string][]string
+}
+
+// add adds an entry under the given module to the section.
+func (cs *changelogSection) add(module string, format string, args ...interface{}) {
+	cs.entries[module] = append(cs.entries[module], fmt.Sprintf(format, args...))
+}
+
+// write writes the section, if it has any entries, to the given buffer.
+func (cs *changelogSection) write(buf *bytes.Buffer) {
+	if len(cs.entries) == 0 {
+		return
+	}
+
+	modules := make([]string, 0, len(cs.entries))
+	for module := range cs.entries {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	buf.WriteString(fmt.Sprintf("### %s\n\n", cs.title))
+	for _, module := range modules {
+		buf.WriteString(fmt.Sprintf("#### `%s`\n\n", module))
+		for _, entry := range cs.entries[module] {
+			buf.WriteString(fmt.Sprintf("- %s\n", entry))
+		}
+		buf.WriteString("\n")
+	}
+}
+
+// changelogBuilder builds the changelog section for a new version of a package.
+type changelogBuilder struct {
+	breaking      *changelogSection
+	additions     *changelogSection
+	modifications *changelogSection
+}
+
+// generateChangelog returns a Markdown changelog section describing the changes found in the given
+// package diff, as released in the new version.
+func generateChangelog(packageDiff diff.PackageDiff, newVersion string, previousVersion string, revisionNote string) string {
+	builder := changelogBuilder{
+		breaking:      &changelogSection{"Breaking changes", map[string][]string{}},
+		additions:     &changelogSection{"Additions", map[string][]string{}},
+		modifications: &changelogSection{"Modifications", map[string][]string{}},
+	}
+
+	for _, typeDiff := range packageDiff.Types {
+		builder.addTypeDiff(typeDiff)
+	}
+
+	for _, memberDiff := range packageDiff.Members {
+		builder.addMemberDiff(memberDiff, "")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("## %s\n\n", newVersion))
+
+	if revisionNote != "" {
+		buf.WriteString(revisionNote)
+		buf.WriteString("\n\n")
+	}
+
+	if packageDiff.Kind == diff.Same {
+		buf.WriteString(fmt.Sprintf("No API changes since `%s`.\n\n", previousVersion))
+		return buf.String()
+	}
+
+	buf.WriteString(fmt.Sprintf("Changes since `%s`:\n\n", previousVersion))
+	builder.breaking.write(&buf)
+	builder.additions.write(&buf)
+	builder.modifications.write(&buf)
+	return buf.String()
+}
+
+// addTypeDiff adds the changes found in the given type diff to the changelog.
+func (cb changelogBuilder) addTypeDiff(typeDiff diff.TypeDiff) {
+	switch typeDiff.Kind {
+	case diff.Same:
+		return
+
+	case diff.Added:
+		cb.additions.add(changelogModule(typeDiff.Updated.ParentModule()), "Added %s `%s`", typeDiff.Updated.Title(), typeDiff.Name)
+		return
+
+	case diff.Removed:
+		cb.breaking.add(changelogModule(typeDiff.Original.ParentModule()), "Removed %s `%s`", typeDiff.Original.Title(), typeDiff.Name)
+		return
+	}
+
+	module := changelogModule(typeDiff.Updated.ParentModule())
+	for _, change := range typeDiff.ChangeReason.Expand() {
+		compatibility, description := change.Describe()
+		if compatibility == diff.BackwardIncompatible {
+			cb.breaking.add(module, "`%s`: %s", typeDiff.Name, description)
+		} else {
+			cb.modifications.add(module, "`%s`: %s", typeDiff.Name, description)
+		}
+	}
+
+	for _, memberDiff := range typeDiff.Members {
+		cb.addMemberDiff(memberDiff, typeDiff.Name+".")
+	}
+}
+
+// addMemberDiff adds the changes found in the given member diff to the changelog. The prefix is
+// placed before the name of the member.
+func (cb changelogBuilder) addMemberDiff(memberDiff diff.MemberDiff, prefix string) {
+	switch memberDiff.Kind {
+	case diff.Same:
+		return
+
+	case diff.Added:
+		cb.additions.add(changelogModule(memberDiff.Updated.Parent().ParentModule()), "Added %s `%s%s`", memberDiff.Updated.Title(), prefix, memberDiff.Name)
+		return
+
+	case diff.Removed:
+		cb.breaking.add(changelogModule(memberDiff.Original.Parent().ParentModule()), "Removed %s `%s%s`", memberDiff.Original.Title(), prefix, memberDiff.Name)
+		return
+	}
+
+	module := changelogModule(memberDiff.Updated.Parent().ParentModule())
+	for _, change := range memberDiff.ChangeReason.Expand() {
+		compatibility, description := change.Describe()
+		if compatibility == diff.BackwardIncompatible {
+			cb.breaking.add(module, "`%s%s`: %s", prefix, memberDiff.Name, description)
+		} else {
+			cb.modifications.add(module, "`%s%s`: %s", prefix, memberDiff.Name, description)
+		}
+	}
+}
+
+// changelogModule returns the name under which changes to the given module are grouped. As a package
+// consists of the modules found directly in its directory, this is the module's file name.
+func changelogModule(module typegraph.TGModule) string {
+	return path.Base(string(module.Path()))
+}
+
+// prependChangelog prepends the given changelog section to the changelog file found in the given
+// package directory, creating the file if necessary. Returns the path of the changelog file.
+func prependChangelog(packagePath string, section string) (string, error) {
+	changelogPath := path.Join(packagePath, ChangelogFileName)
+
+	existing, err := ioutil.ReadFile(changelogPath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	// Keep the title of the changelog, if any, at the top of the file.
+	contents := strings.TrimLeft(string(existing), "\n")
+	title := changelogTitle
+	if strings.HasPrefix(contents, "# ") {
+		lines := strings.SplitN(contents, "\n", 2)
+		title = lines[0]
+		contents = ""
+		if len(lines) > 1 {
+			contents = strings.TrimLeft(lines[1], "\n")
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(title)
+	buf.WriteString("\n\n")
+	buf.WriteString(section)
+	buf.WriteString(contents)
+
+	return changelogPath, ioutil.WriteFile(changelogPath, append(bytes.TrimRight(buf.Bytes(), "\n"), '\n'), 0644)
+}
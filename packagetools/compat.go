@@ -0,0 +1,182 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packagetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/graphs/typegraph/diff"
+)
+
+// versionIncrementRank ranks each kind of version increment by significance.
+var versionIncrementRank = map[compilerutil.NextVersionOption]int{
+	compilerutil.NextPatchVersion: 0,
+	compilerutil.NextMinorVersion: 1,
+	compilerutil.NextMajorVersion: 2,
+}
+
+// compatibilityReport is the report emitted by CheckCompatibility.
+type compatibilityReport struct {
+	Package           string                         `json:"package"`
+	ComparisonVersion string                         `json:"comparisonVersion"`
+	RequiredIncrement compilerutil.NextVersionOption `json:"requiredIncrement"`
+	RequiredVersion   string                         `json:"requiredVersion"`
+	DeclaredVersion   string                         `json:"declaredVersion,omitempty"`
+	Compatible        bool                           `json:"compatible"`
+	Breaking          bool                           `json:"breaking"`
+	Changes           []compatibilityReason          `json:"changes"`
+	Types             []compatibilityTypeDiff        `json:"types"`
+	Members           []compatibilityMemberDiff      `json:"members"`
+}
+
+// compatibilityReason describes a single reason an item changed.
+type compatibilityReason struct {
+	Reason        string                   `json:"reason,omitempty"`
+	Compatibility diff.ReasonCompatibility `json:"compatibility"`
+	Description   string                   `json:"description"`
+}
+
+// compatibilityTypeDiff describes the changes to a type.
+type compatibilityTypeDiff struct {
+	Name    string                    `json:"name"`
+	Kind    diff.DiffKind             `json:"kind"`
+	Reasons []compatibilityReason     `json:"reasons"`
+	Members []compatibilityMemberDiff `json:"members"`
+}
+
+// compatibilityMemberDiff describes the changes to a member.
+type compatibilityMemberDiff struct {
+	Name    string                `json:"name"`
+	Kind    diff.DiffKind         `json:"kind"`
+	Reasons []compatibilityReason `json:"reasons"`
+}
+
+// CheckCompatibility compares the Serulian package found at the given path against the version
+// found in the same repository with (optional) comparison version, and emits a JSON report of all
+// changes found to standard output. Returns false if the changes require a more significant version
+// increment than intended: if a declared version is given, the changes must be allowed by that
+// version; otherwise, the changes must not be breaking.
+func CheckCompatibility(packagePath string, comparisonVersion string, declaredVersion string, debug bool, vcsDevelopmentDirectories ...string) bool {
+	// Log all human-readable output to standard error, to keep standard output for the report.
+	color.Output = os.Stderr
+
+	packageDiff, comparisonVersion, ok := computePackageDiff(packagePath, comparisonVersion, debug, vcsDevelopmentDirectories)
+	if !ok {
+		return false
+	}
+
+	requiredIncrement := requiredVersionIncrement(packageDiff)
+	requiredVersion, err := compilerutil.NextSemanticVersion(comparisonVersion, requiredIncrement)
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not compute required version: %v", err)
+		return false
+	}
+
+	// Determine the version increment intended by the author.
+	var intendedIncrement = compilerutil.NextMinorVersion
+	if declaredVersion != "" {
+		intendedIncrement, err = compilerutil.SemanticVersionIncrement(comparisonVersion, declaredVersion)
+		if err != nil {
+			compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Invalid declared version `%s`: %v", declaredVersion, err)
+			return false
+		}
+	}
+
+	report := buildCompatibilityReport(packageDiff)
+	report.Package = packagePath
+	report.ComparisonVersion = comparisonVersion
+	report.RequiredIncrement = requiredIncrement
+	report.RequiredVersion = requiredVersion
+	report.DeclaredVersion = declaredVersion
+	report.Compatible = versionIncrementRank[requiredIncrement] <= versionIncrementRank[intendedIncrement]
+
+	contents, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not write report: %v", err)
+		return false
+	}
+
+	fmt.Println(string(contents))
+
+	if !report.Compatible {
+		if declaredVersion != "" {
+			compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil,
+				"Package `%s` requires a %s version increment from `%s` (to `%s`), but declared version `%s` is only a %s increment",
+				packagePath, requiredIncrement, comparisonVersion, requiredVersion, declaredVersion, intendedIncrement)
+		} else {
+			compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil,
+				"Package `%s` contains breaking changes from `%s`, requiring a major version increment (to `%s`)",
+				packagePath, comparisonVersion, requiredVersion)
+		}
+		return false
+	}
+
+	compilerutil.LogToConsole(compilerutil.SuccessLogLevel, nil,
+		"Package `%s` is compatible with a %s version increment from `%s`", packagePath, intendedIncrement, comparisonVersion)
+	return true
+}
+
+// buildCompatibilityReport returns a report describing the changes found in the given package diff.
+func buildCompatibilityReport(packageDiff diff.PackageDiff) compatibilityReport {
+	report := compatibilityReport{
+		Breaking: packageDiff.HasBreakingChange(),
+		Changes:  []compatibilityReason{},
+		Types:    []compatibilityTypeDiff{},
+		Members:  buildCompatibilityMemberDiffs(packageDiff.Members),
+	}
+
+	for _, change := range packageDiff.ChangeReason.Expand() {
+		compatibility, description := change.Describe()
+		report.Changes = append(report.Changes, compatibilityReason{"", compatibility, description})
+	}
+
+	for _, typeDiff := range packageDiff.Types {
+		if typeDiff.Kind == diff.Same {
+			continue
+		}
+
+		reasons := []compatibilityReason{}
+		for _, change := range typeDiff.ChangeReason.Expand() {
+			compatibility, description := change.Describe()
+			reasons = append(reasons, compatibilityReason{change.String(), compatibility, description})
+		}
+
+		report.Types = append(report.Types, compatibilityTypeDiff{
+			Name:    typeDiff.Name,
+			Kind:    typeDiff.Kind,
+			Reasons: reasons,
+			Members: buildCompatibilityMemberDiffs(typeDiff.Members),
+		})
+	}
+
+	return report
+}
+
+// buildCompatibilityMemberDiffs returns descriptions of the changed members in the given member diffs.
+func buildCompatibilityMemberDiffs(memberDiffs []diff.MemberDiff) []compatibilityMemberDiff {
+	members := []compatibilityMemberDiff{}
+	for _, memberDiff := range memberDiffs {
+		if memberDiff.Kind == diff.Same {
+			continue
+		}
+
+		reasons := []compatibilityReason{}
+		for _, change := range memberDiff.ChangeReason.Expand() {
+			compatibility, description := change.Describe()
+			reasons = append(reasons, compatibilityReason{change.String(), compatibility, description})
+		}
+
+		members = append(members, compatibilityMemberDiff{
+			Name:    memberDiff.Name,
+			Kind:    memberDiff.Kind,
+			Reasons: reasons,
+		})
+	}
+	return members
+}
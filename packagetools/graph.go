@@ -0,0 +1,405 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packagetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/vcs"
+)
+
+// GraphFormat defines the output formats supported for the import graph.
+type GraphFormat string
+
+const (
+	// GraphFormatTree outputs the import graph as an indented tree.
+	GraphFormatTree GraphFormat = "tree"
+
+	// GraphFormatDOT outputs the import graph in the Graphviz DOT language.
+	GraphFormatDOT GraphFormat = "dot"
+
+	// GraphFormatJSON outputs the import graph as JSON.
+	GraphFormatJSON GraphFormat = "json"
+)
+
+// importGraphNodeKind defines the kinds of nodes in the import graph.
+type importGraphNodeKind string
+
+const (
+	// graphNodeModule is a single module imported (or loaded as an entrypoint) directly.
+	graphNodeModule importGraphNodeKind = "module"
+
+	// graphNodePackage is a local package.
+	graphNodePackage importGraphNodeKind = "package"
+
+	// graphNodeVCS is a package loaded from a VCS.
+	graphNodeVCS importGraphNodeKind = "vcs"
+)
+
+// importGraphNode is a package or module in the import graph.
+type importGraphNode struct {
+	ID         string              `json:"id"`
+	Path       string              `json:"path"`
+	SourceKind string              `json:"sourceKind"`
+	Kind       importGraphNodeKind `json:"kind"`
+	VCS        *importGraphVCSInfo `json:"vcs,omitempty"`
+	Imports    []string            `json:"imports"`
+}
+
+// importGraphVCSInfo holds the VCS information for a VCS package in the import graph.
+type importGraphVCSInfo struct {
+	URL            string `json:"url"`
+	Tag            string `json:"tag,omitempty"`
+	BranchOrCommit string `json:"branchOrCommit,omitempty"`
+	VersionRange   string `json:"versionRange,omitempty"`
+	Commit         string `json:"commit,omitempty"`
+	Status         string `json:"status"`
+	Directory      string `json:"directory"`
+}
+
+// importGraph is the full transitive import graph of a project.
+type importGraph struct {
+	Roots    []string           `json:"roots"`
+	Packages []*importGraphNode `json:"packages"`
+
+	nodesByID map[string]*importGraphNode
+}
+
+// ImportGraph loads the project with the given entrypoint and writes its full transitive import graph
+// to stdout in the given format.
+func ImportGraph(entrypoint string, format GraphFormat, debug bool, vcsDevelopmentDirectories ...string) bool {
+	// Disable logging unless the debug flag is on.
+	if !debug {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	graph, ok := loadImportGraph(entrypoint, vcsDevelopmentDirectories)
+	if !ok {
+		return false
+	}
+
+	switch format {
+	case GraphFormatTree:
+		graph.writeTree(os.Stdout)
+
+	case GraphFormatDOT:
+		graph.writeDOT(os.Stdout)
+
+	case GraphFormatJSON:
+		if err := graph.writeJSON(os.Stdout); err != nil {
+			compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not write import graph: %v", err)
+			return false
+		}
+
+	default:
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Unknown import graph format `%s`. Expected `tree`, `dot` or `json`.", format)
+		return false
+	}
+
+	return true
+}
+
+// WhyImported loads the project with the given entrypoint and writes to stdout every import chain
+// that reaches the given package. The package can be specified by its path, or, for VCS packages,
+// by the URL of its repository.
+func WhyImported(entrypoint string, packagePath string, debug bool, vcsDevelopmentDirectories ...string) bool {
+	// Disable logging unless the debug flag is on.
+	if !debug {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	graph, ok := loadImportGraph(entrypoint, vcsDevelopmentDirectories)
+	if !ok {
+		return false
+	}
+
+	chains := graph.chainsTo(packagePath)
+	if len(chains) == 0 {
+		compilerutil.LogToConsole(compilerutil.WarningLogLevel, nil, "Package `%s` is not imported by `%s`", packagePath, entrypoint)
+		return false
+	}
+
+	for _, chain := range chains {
+		labels := make([]string, len(chain))
+		for index, node := range chain {
+			labels[index] = node.label()
+		}
+
+		fmt.Println(strings.Join(labels, "\n  -> "))
+		fmt.Println()
+	}
+
+	compilerutil.LogToConsole(compilerutil.SuccessLogLevel, nil, "Found %v import chain(s) reaching `%s`", len(chains), packagePath)
+	return true
+}
+
+// loadImportGraph loads the project with the given entrypoint and builds its import graph.
+func loadImportGraph(entrypoint string, vcsDevelopmentDirectories []string) (*importGraph, bool) {
+	loadResult, ok := loadPackages(entrypoint, packageloader.VCSLockUseIfPresent, packageloader.VCSSumVerify, false, vcsDevelopmentDirectories)
+	if !ok {
+		return nil, false
+	}
+
+	// Inspect the commit of any VCS packages not found in the lockfile.
+	pkgDirectory := packageloader.LocalFilePathLoader{}.VCSPackageDirectory(packageloader.Entrypoint(entrypoint))
+	for vcsPath, info := range loadResult.VCSPackages {
+		if info.Commit != "" {
+			continue
+		}
+
+		commit, err := vcs.InspectVCSCheckout(info.CheckoutPath, pkgDirectory, vcsDevelopmentDirectories...)
+		if err != nil {
+			compilerutil.LogToConsole(compilerutil.WarningLogLevel, nil, "Could not determine commit of VCS package `%s`: %v", vcsPath, err)
+			continue
+		}
+
+		info.Commit = commit
+		loadResult.VCSPackages[vcsPath] = info
+	}
+
+	return buildImportGraph(loadResult.PackageMap, loadResult.Imports, loadResult.VCSPackages), true
+}
+
+// buildImportGraph builds the import graph for the given loaded packages and imports.
+func buildImportGraph(packageMap packageloader.LoadedPackageMap, imports []packageloader.ImportEdge, vcsPackages map[string]packageloader.VCSPackageInfo) *importGraph {
+	graph := &importGraph{
+		Roots:     make([]string, 0),
+		Packages:  make([]*importGraphNode, 0),
+		nodesByID: map[string]*importGraphNode{},
+	}
+
+	// Add a node for each package, and index the nodes by the modules they contain.
+	nodesByModule := map[string][]*importGraphNode{}
+	for _, entry := range packageMap.Entries() {
+		node := &importGraphNode{
+			ID:         graphNodeID(entry.Info.Kind(), entry.Path),
+			Path:       entry.Path,
+			SourceKind: entry.Info.Kind(),
+			Kind:       graphNodePackage,
+			Imports:    make([]string, 0),
+		}
+
+		if vcsInfo, isVCS := vcsPackages[entry.Path]; isVCS {
+			parsed, _ := vcs.ParseVCSPath(vcsInfo.CheckoutPath)
+			requested, _ := vcs.ParseVCSPath(vcsInfo.VCSPath)
+
+			node.Kind = graphNodeVCS
+			node.VCS = &importGraphVCSInfo{
+				URL:            parsed.URL(),
+				Tag:            parsed.Tag(),
+				BranchOrCommit: parsed.BranchOrCommit(),
+				VersionRange:   requested.VersionRange(),
+				Commit:         vcsInfo.Commit,
+				Status:         vcsInfo.Status.String(),
+				Directory:      vcsInfo.Directory,
+			}
+		} else if len(entry.Info.ModulePaths()) == 1 && string(entry.Info.ModulePaths()[0]) == entry.Path {
+			node.Kind = graphNodeModule
+		}
+
+		graph.Packages = append(graph.Packages, node)
+		graph.nodesByID[node.ID] = node
+
+		for _, modulePath := range entry.Info.ModulePaths() {
+			nodesByModule[string(modulePath)] = append(nodesByModule[string(modulePath)], node)
+		}
+	}
+
+	// Add the edges for each import, from every package containing the importing module.
+	imported := map[string]bool{}
+	for _, importEdge := range imports {
+		targetID := graphNodeID(importEdge.Kind, importEdge.ReferenceID)
+		if _, exists := graph.nodesByID[targetID]; !exists {
+			continue
+		}
+
+		for _, sourceNode := range nodesByModule[string(importEdge.SourceModule)] {
+			if sourceNode.ID == targetID || sourceNode.imports(targetID) {
+				continue
+			}
+
+			sourceNode.Imports = append(sourceNode.Imports, targetID)
+			imported[targetID] = true
+		}
+	}
+
+	// The roots of the graph are those nodes not imported by any other.
+	for _, node := range graph.Packages {
+		sort.Strings(node.Imports)
+		if !imported[node.ID] {
+			graph.Roots = append(graph.Roots, node.ID)
+		}
+	}
+
+	return graph
+}
+
+// graphNodeID returns the ID of the node for the package with the given source kind and path.
+func graphNodeID(sourceKind string, path string) string {
+	if sourceKind == "" {
+		return path
+	}
+
+	return sourceKind + ":" + path
+}
+
+// imports returns true if the node directly imports the node with the given ID.
+func (n *importGraphNode) imports(nodeID string) bool {
+	for _, importedID := range n.Imports {
+		if importedID == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// label returns a human-readable label for the node.
+func (n *importGraphNode) label() string {
+	annotations := make([]string, 0, 4)
+	if n.SourceKind != "" {
+		annotations = append(annotations, n.SourceKind)
+	}
+
+	if n.VCS != nil {
+		if n.VCS.VersionRange != "" {
+			annotations = append(annotations, "range "+n.VCS.VersionRange)
+		}
+
+		if n.VCS.Tag != "" {
+			annotations = append(annotations, "tag "+n.VCS.Tag)
+		} else if n.VCS.BranchOrCommit != "" {
+			annotations = append(annotations, "branch/commit "+n.VCS.BranchOrCommit)
+		}
+
+		if n.VCS.Commit != "" {
+			annotations = append(annotations, "commit "+n.VCS.Commit)
+		}
+
+		annotations = append(annotations, n.VCS.Status)
+	}
+
+	if len(annotations) == 0 {
+		return n.Path
+	}
+
+	return fmt.Sprintf("%s (%s)", n.Path, strings.Join(annotations, ", "))
+}
+
+// matches returns true if the node matches the given package query.
+func (n *importGraphNode) matches(query string) bool {
+	return n.Path == query || n.ID == query || (n.VCS != nil && n.VCS.URL == query)
+}
+
+// writeTree writes the import graph as an indented tree. Nodes already written are marked with
+// `(*)` and their imports are not repeated.
+func (g *importGraph) writeTree(w io.Writer) {
+	written := map[string]bool{}
+
+	var writeNode func(node *importGraphNode, prefix string, childPrefix string)
+	writeNode = func(node *importGraphNode, prefix string, childPrefix string) {
+		if written[node.ID] {
+			fmt.Fprintf(w, "%s%s (*)\n", prefix, node.label())
+			return
+		}
+
+		written[node.ID] = true
+		fmt.Fprintf(w, "%s%s\n", prefix, node.label())
+
+		for index, importedID := range node.Imports {
+			if index == len(node.Imports)-1 {
+				writeNode(g.nodesByID[importedID], childPrefix+"└── ", childPrefix+"    ")
+			} else {
+				writeNode(g.nodesByID[importedID], childPrefix+"├── ", childPrefix+"│   ")
+			}
+		}
+	}
+
+	for _, rootID := range g.Roots {
+		writeNode(g.nodesByID[rootID], "", "")
+	}
+
+	// Write any nodes only reachable via cycles.
+	for _, node := range g.Packages {
+		if !written[node.ID] {
+			writeNode(node, "", "")
+		}
+	}
+}
+
+// writeDOT writes the import graph in the Graphviz DOT language.
+func (g *importGraph) writeDOT(w io.Writer) {
+	fmt.Fprintln(w, "digraph imports {")
+	for _, node := range g.Packages {
+		var shape = "box"
+		switch node.Kind {
+		case graphNodeModule:
+			shape = "note"
+
+		case graphNodeVCS:
+			shape = "box3d"
+		}
+
+		fmt.Fprintf(w, "  %q [label=%q, shape=%s];\n", node.ID, node.label(), shape)
+	}
+
+	for _, node := range g.Packages {
+		for _, importedID := range node.Imports {
+			fmt.Fprintf(w, "  %q -> %q;\n", node.ID, importedID)
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// writeJSON writes the import graph as JSON.
+func (g *importGraph) writeJSON(w io.Writer) error {
+	encoded, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(encoded))
+	return err
+}
+
+// chainsTo returns every import chain, starting at a root of the graph, that reaches a node
+// matching the given package query.
+func (g *importGraph) chainsTo(query string) [][]*importGraphNode {
+	chains := make([][]*importGraphNode, 0)
+	onChain := map[string]bool{}
+
+	var walk func(node *importGraphNode, chain []*importGraphNode)
+	walk = func(node *importGraphNode, chain []*importGraphNode) {
+		chain = append(chain, node)
+		if node.matches(query) {
+			found := make([]*importGraphNode, len(chain))
+			copy(found, chain)
+			chains = append(chains, found)
+			return
+		}
+
+		onChain[node.ID] = true
+		for _, importedID := range node.Imports {
+			if !onChain[importedID] {
+				walk(g.nodesByID[importedID], chain)
+			}
+		}
+		onChain[node.ID] = false
+	}
+
+	for _, rootID := range g.Roots {
+		walk(g.nodesByID[rootID], make([]*importGraphNode, 0))
+	}
+
+	return chains
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packagetools
+
+import (
+	"io/ioutil"
+	"log"
+
+	"github.com/serulian/compiler/builder"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/graphs/srg"
+	"github.com/serulian/compiler/integration"
+	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/typescript"
+	"github.com/serulian/compiler/webidl"
+)
+
+// LockImports resolves all VCS packages imported (transitively) by the project with the given
+// entrypoint and writes the commit resolved for each to the project's lockfile. Subsequent builds
+// of the project will check out the VCS packages at the locked commits.
+func LockImports(entrypoint string, debug bool, vcsDevelopmentDirectories ...string) bool {
+	// Disable logging unless the debug flag is on.
+	if !debug {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil, "Resolving VCS imports...")
+	loadResult, ok := loadPackages(entrypoint, packageloader.VCSLockResolve, packageloader.VCSSumVerify, false, vcsDevelopmentDirectories)
+	if !ok {
+		return false
+	}
+
+	lockFilePath := packageloader.LockFilePath(packageloader.Entrypoint(entrypoint), packageloader.LocalFilePathLoader{})
+	if err := loadResult.LockFile.Write(lockFilePath); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not write lockfile `%s`: %v", lockFilePath, err)
+		return false
+	}
+
+	for _, vcsPath := range loadResult.LockFile.VCSPaths() {
+		locked, _ := loadResult.LockFile.Lookup(vcsPath)
+		compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil, "Locked `%s` at commit `%s`", vcsPath, locked.Commit)
+	}
+
+	compilerutil.LogToConsole(compilerutil.SuccessLogLevel, nil, "Lockfile `%s` written with %v VCS package(s)", lockFilePath, len(loadResult.LockFile.Packages))
+	return true
+}
+
+// VerifyLock verifies that the lockfile of the project with the given entrypoint is up-to-date,
+// containing an entry for every VCS package imported (transitively) by the project, and no others.
+func VerifyLock(entrypoint string, debug bool, vcsDevelopmentDirectories ...string) bool {
+	// Disable logging unless the debug flag is on.
+	if !debug {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	pathLoader := packageloader.LocalFilePathLoader{}
+	lockFilePath := packageloader.LockFilePath(packageloader.Entrypoint(entrypoint), pathLoader)
+	lockFile, _, err := packageloader.ReadLockFile(lockFilePath, pathLoader)
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not read lockfile `%s`: %v", lockFilePath, err)
+		return false
+	}
+
+	compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil, "Verifying VCS imports...")
+	loadResult, ok := loadPackages(entrypoint, packageloader.VCSLockRequire, packageloader.VCSSumVerify, false, vcsDevelopmentDirectories)
+	if !ok {
+		return false
+	}
+
+	// As the lockfile is required, any packages missing from it will have been reported by the
+	// loader, so any remaining differences are entries no longer imported.
+	stale := lockFile.StaleEntries(loadResult.LockFile)
+	for _, vcsPath := range stale {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Lockfile `%s` contains VCS package `%s`, which is no longer imported", lockFilePath, vcsPath)
+	}
+
+	if len(stale) > 0 {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Lockfile `%s` is stale. Run `serulian imports lock` to update the lockfile.", lockFilePath)
+		return false
+	}
+
+	compilerutil.LogToConsole(compilerutil.SuccessLogLevel, nil, "Lockfile `%s` is up-to-date", lockFilePath)
+	return true
+}
+
+// loadPackages loads all the packages and source files of the project with the given entrypoint,
+// using the given lockfile and sums file options. If skipVendored is true, vendored copies of VCS
+// packages are ignored.
+func loadPackages(entrypoint string, lockOption packageloader.VCSLockOption, sumOption packageloader.VCSSumOption, skipVendored bool, vcsDevelopmentDirectories []string) (packageloader.LoadResult, bool) {
+	graph, err := compilergraph.NewGraph(entrypoint)
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "%s", err.Error())
+		return packageloader.LoadResult{}, false
+	}
+
+	// Configure the source handlers for Serulian and all language integrations.
+	sourceHandlers := []packageloader.SourceHandler{
+		srg.NewSRG(graph).SourceHandler(),
+		webidl.WebIDLProvider(graph).SourceHandler(),
+		typescript.TypeScriptProvider(graph).SourceHandler(),
+	}
+
+	integrations, err := integration.LoadIntegrations()
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "%s", err.Error())
+		return packageloader.LoadResult{}, false
+	}
+
+	for _, current := range integrations {
+		for _, langIntegration := range integration.GetLanguageIntegrations(current, graph) {
+			sourceHandlers = append(sourceHandlers, langIntegration.SourceHandler())
+		}
+	}
+
+	loader := packageloader.NewPackageLoader(packageloader.Config{
+		Entrypoint:                packageloader.Entrypoint(entrypoint),
+		PathLoader:                packageloader.LocalFilePathLoader{},
+		VCSDevelopmentDirectories: vcsDevelopmentDirectories,
+		SourceHandlers:            sourceHandlers,
+		VCSLockOption:             lockOption,
+		VCSSumOption:              sumOption,
+		SkipVendoredPackages:      skipVendored,
+	})
+
+	loadResult := loader.Load(builder.CORE_LIBRARY)
+	builder.OutputWarnings(loadResult.Warnings)
+	if !loadResult.Status {
+		builder.OutputErrors(loadResult.Errors)
+		return packageloader.LoadResult{}, false
+	}
+
+	return loadResult, true
+}
@@ -24,7 +24,8 @@ import (
 // Revise tags the current package with a semantic version computed by performing a diff of the package's current
 // contents against the version found in the same repository with (optional) comparison
 // version. If the comparison version is not specified, then the latest semver
-// of the package is used (if any).
+// of the package is used (if any). A changelog describing the changes is prepended to the package's
+// CHANGELOG.md and used as the annotation of the tag.
 func Revise(packagePath string, comparisonVersion string, revisionNote string, verbose bool, debug bool, vcsDevelopmentDirectories ...string) {
 	// Perform the diff of the package, outputting if necessary. This will also verify the package is valid.
 	packageDiff, upstreamVersion, ok := getAndOutputDiff(packagePath, comparisonVersion, verbose, debug, vcsDevelopmentDirectories)
@@ -43,35 +44,32 @@ func Revise(packagePath string, comparisonVersion string, revisionNote string, v
 	}
 
 	// Determine how to revise the semantic version.
-	var option = compilerutil.NextPatchVersion
-
-	switch {
-	case packageDiff.HasBreakingChange():
-		option = compilerutil.NextMajorVersion
-
-	case packageDiff.Kind != diff.Same:
-		option = compilerutil.NextMinorVersion
-	}
-
-	newVersion, err := compilerutil.NextSemanticVersion(upstreamVersion, option)
+	newVersion, err := compilerutil.NextSemanticVersion(upstreamVersion, requiredVersionIncrement(packageDiff))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// Tag the latest commit with the new semantic version.
-	if revisionNote == "" {
-		revisionNote = "Automatic revision to version " + newVersion
+	// Generate the changelog for the new version and prepend it to the package's changelog.
+	changelog := generateChangelog(packageDiff, newVersion, upstreamVersion, revisionNote)
+	changelogPath, cerr := prependChangelog(packagePath, changelog)
+	if cerr != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil,
+			"Could not write changelog for package `%s`: %v", packagePath, cerr)
+		return
 	}
 
-	terr := handler.Tag(packagePath, newVersion, revisionNote)
+	// Tag the latest commit with the new semantic version, annotated with the changelog.
+	terr := handler.Tag(packagePath, newVersion, changelog)
 	if terr != nil {
 		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil,
-			"Could not tag package with version `%s`: %v", newVersion, err)
+			"Could not tag package with version `%s`: %v", newVersion, terr)
 		return
 	}
 
 	compilerutil.LogToConsole(compilerutil.SuccessLogLevel, nil,
 		"Package `%s` tagged with revised version `%s`", packagePath, newVersion)
+	compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil,
+		"Changelog for version `%s` added to `%s`; make sure to commit it", newVersion, changelogPath)
 }
 
 // OutputDiff outputs the diff between the Serulian package found at the given
@@ -83,6 +81,41 @@ func OutputDiff(packagePath string, comparisonVersion string, verbose bool, debu
 }
 
 func getAndOutputDiff(packagePath string, comparisonVersion string, verbose bool, debug bool, vcsDevelopmentDirectories []string) (diff.PackageDiff, string, bool) {
+	packageDiff, comparisonVersion, ok := computePackageDiff(packagePath, comparisonVersion, debug, vcsDevelopmentDirectories)
+	if !ok {
+		return diff.PackageDiff{}, "", false
+	}
+
+	fmt.Println()
+
+	compilerutil.MessageColor.Print(comparisonVersion)
+	compilerutil.MessageColor.Print(" → ")
+	compilerutil.MessageColor.Print("HEAD")
+
+	if packageDiff.Kind == diff.Same {
+		compilerutil.MessageColor.Print(": No changes found\n")
+		return packageDiff, comparisonVersion, true
+	}
+
+	if packageDiff.HasBreakingChange() {
+		compilerutil.MessageColor.Print(": ")
+		compilerutil.WarningColor.Print("Breaking changes found\n")
+	} else {
+		compilerutil.MessageColor.Print(": ")
+		compilerutil.SuccessColor.Print("Compatible changes found\n")
+	}
+
+	if verbose {
+		outputDetailedDiff(packageDiff)
+	}
+
+	return packageDiff, comparisonVersion, true
+}
+
+// computePackageDiff computes the diff between the Serulian package found at the given path and
+// the version found in the same repository with (optional) comparison version, returning the diff
+// and the version compared against.
+func computePackageDiff(packagePath string, comparisonVersion string, debug bool, vcsDevelopmentDirectories []string) (diff.PackageDiff, string, bool) {
 	// Disable logging unless the debug flag is on.
 	if !debug {
 		log.SetOutput(ioutil.Discard)
@@ -177,30 +210,7 @@ func getAndOutputDiff(packagePath string, comparisonVersion string, verbose bool
 	compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil, "Computing Diff...")
 	computed := diff.ComputeDiff(original, updated, filter)
 
-	fmt.Println()
-
 	for _, packageDiff := range computed.Packages {
-		compilerutil.MessageColor.Print(comparisonVersion)
-		compilerutil.MessageColor.Print(" → ")
-		compilerutil.MessageColor.Print("HEAD")
-
-		if packageDiff.Kind == diff.Same {
-			compilerutil.MessageColor.Print(": No changes found\n")
-			return packageDiff, comparisonVersion, true
-		}
-
-		if packageDiff.HasBreakingChange() {
-			compilerutil.MessageColor.Print(": ")
-			compilerutil.WarningColor.Print("Breaking changes found\n")
-		} else {
-			compilerutil.MessageColor.Print(": ")
-			compilerutil.SuccessColor.Print("Compatible changes found\n")
-		}
-
-		if verbose {
-			outputDetailedDiff(packageDiff)
-		}
-
 		// We only care about a single package.
 		return packageDiff, comparisonVersion, true
 	}
@@ -208,6 +218,21 @@ func getAndOutputDiff(packagePath string, comparisonVersion string, verbose bool
 	return diff.PackageDiff{}, "", false
 }
 
+// requiredVersionIncrement returns the semantic version field that must be incremented for a
+// release containing the changes in the given package diff.
+func requiredVersionIncrement(packageDiff diff.PackageDiff) compilerutil.NextVersionOption {
+	switch {
+	case packageDiff.HasBreakingChange():
+		return compilerutil.NextMajorVersion
+
+	case packageDiff.Kind != diff.Same:
+		return compilerutil.NextMinorVersion
+
+	default:
+		return compilerutil.NextPatchVersion
+	}
+}
+
 func outputDetailedDiff(packageDiff diff.PackageDiff) {
 	compilerutil.BoldWhiteColor.Print("  Change summary:\n")
 	for _, change := range packageDiff.ChangeReason.Expand() {
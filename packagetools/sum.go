@@ -0,0 +1,58 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packagetools
+
+import (
+	"io/ioutil"
+	"log"
+
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/packageloader"
+)
+
+// SumImports computes the content hash of every pinned VCS package imported (transitively) by the
+// project with the given entrypoint and writes them to the project's sums file, replacing any
+// existing hashes. Subsequent builds of the project will fail if the contents of a pinned package
+// no longer match the recorded hash.
+func SumImports(entrypoint string, debug bool, vcsDevelopmentDirectories ...string) bool {
+	// Disable logging unless the debug flag is on.
+	if !debug {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	pathLoader := packageloader.LocalFilePathLoader{}
+	sumFilePath := packageloader.SumFilePath(packageloader.Entrypoint(entrypoint), pathLoader)
+	existing, _, err := packageloader.ReadSumFile(sumFilePath, pathLoader)
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.WarningLogLevel, nil, "Ignoring existing sums file `%s`: %v", sumFilePath, err)
+		existing = packageloader.NewSumFile()
+	}
+
+	compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil, "Computing checksums of VCS imports...")
+	loadResult, ok := loadPackages(entrypoint, packageloader.VCSLockUseIfPresent, packageloader.VCSSumRefresh, false, vcsDevelopmentDirectories)
+	if !ok {
+		return false
+	}
+
+	if err := loadResult.SumFile.Write(sumFilePath); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not write sums file `%s`: %v", sumFilePath, err)
+		return false
+	}
+
+	for _, vcsPath := range loadResult.SumFile.VCSPaths() {
+		sum, _ := loadResult.SumFile.Lookup(vcsPath)
+		previous, found := existing.Lookup(vcsPath)
+		switch {
+		case !found:
+			compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil, "Recorded checksum of `%s`", vcsPath)
+
+		case previous != sum:
+			compilerutil.LogToConsole(compilerutil.WarningLogLevel, nil, "Checksum of `%s` changed from `%s` to `%s`", vcsPath, previous, sum)
+		}
+	}
+
+	compilerutil.LogToConsole(compilerutil.SuccessLogLevel, nil, "Sums file `%s` written with %v VCS package(s)", sumFilePath, len(loadResult.SumFile.Sums))
+	return true
+}
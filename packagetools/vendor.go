@@ -0,0 +1,192 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packagetools
+
+import (
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/vcs"
+)
+
+// vendorSkippedDirectories are the names of directories never copied into the vendor directory.
+var vendorSkippedDirectories = map[string]bool{
+	".git":                                 true,
+	".hg":                                  true,
+	".svn":                                 true,
+	packageloader.SerulianPackageDirectory: true,
+}
+
+// VendorImports resolves all VCS packages imported (transitively) by the project with the given
+// entrypoint and copies each into the project's vendor directory, along with a manifest recording
+// the commit of each. Subsequent builds of the project will use the vendored copies in lieu of
+// VCS checkout, and therefore never access the network.
+func VendorImports(entrypoint string, debug bool, vcsDevelopmentDirectories ...string) bool {
+	// Disable logging unless the debug flag is on.
+	if !debug {
+		log.SetOutput(ioutil.Discard)
+	}
+
+	compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil, "Resolving VCS imports...")
+	loadResult, ok := loadPackages(entrypoint, packageloader.VCSLockUseIfPresent, packageloader.VCSSumVerify, true, vcsDevelopmentDirectories)
+	if !ok {
+		return false
+	}
+
+	pathLoader := packageloader.LocalFilePathLoader{}
+	vendorDirectory := packageloader.VendorDirectoryPath(packageloader.Entrypoint(entrypoint), pathLoader)
+	pkgDirectory := pathLoader.VCSPackageDirectory(packageloader.Entrypoint(entrypoint))
+
+	// Vendor into a staging directory, which replaces the existing vendor directory once complete.
+	stagingDirectory, err := ioutil.TempDir(path.Dir(vendorDirectory), ".vendor")
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not create staging directory: %v", err)
+		return false
+	}
+	defer os.RemoveAll(stagingDirectory)
+
+	if err := os.Chmod(stagingDirectory, 0755); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not create staging directory: %v", err)
+		return false
+	}
+
+	vcsPaths := make([]string, 0, len(loadResult.VCSPackages))
+	for vcsPath := range loadResult.VCSPackages {
+		vcsPaths = append(vcsPaths, vcsPath)
+	}
+	sort.Strings(vcsPaths)
+
+	manifest := packageloader.NewLockFile()
+	copied := map[string]bool{}
+	for _, vcsPath := range vcsPaths {
+		info := loadResult.VCSPackages[vcsPath]
+
+		// Determine the commit of the package, if not already known from the lockfile.
+		commit := info.Commit
+		if commit == "" {
+			commit, err = vcs.InspectVCSCheckout(info.CheckoutPath, pkgDirectory, vcsDevelopmentDirectories...)
+			if err != nil {
+				compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not determine commit of VCS package `%s`: %v", vcsPath, err)
+				return false
+			}
+		}
+
+		// Copy the entire repository containing the package, as other subpackages can be imported
+		// from the same repository.
+		destination, err := vcs.GetVendoredDirectory(vcsPath, stagingDirectory)
+		if err != nil {
+			compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not vendor VCS package `%s`: %v", vcsPath, err)
+			return false
+		}
+
+		if !copied[destination] {
+			if err := copyDirectory(repositoryDirectory(vcsPath, info.Directory), destination); err != nil {
+				compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not vendor VCS package `%s`: %v", vcsPath, err)
+				return false
+			}
+
+			copied[destination] = true
+		}
+
+		manifest.Packages[vcsPath] = packageloader.LockedPackage{Commit: commit}
+		compilerutil.LogToConsole(compilerutil.InfoLogLevel, nil, "Vendored `%s` at commit `%s`", vcsPath, commit)
+	}
+
+	if err := manifest.Write(path.Join(stagingDirectory, packageloader.LockFileName)); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not write vendor manifest: %v", err)
+		return false
+	}
+
+	// Replace the existing vendor directory.
+	if err := os.RemoveAll(vendorDirectory); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not remove existing vendor directory `%s`: %v", vendorDirectory, err)
+		return false
+	}
+
+	if err := os.Rename(stagingDirectory, vendorDirectory); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not write vendor directory `%s`: %v", vendorDirectory, err)
+		return false
+	}
+
+	compilerutil.LogToConsole(compilerutil.SuccessLogLevel, nil, "Vendor directory `%s` written with %v VCS package(s)", vendorDirectory, len(manifest.Packages))
+	return true
+}
+
+// repositoryDirectory returns the root directory of the repository containing the package
+// imported via the given VCS path and checked out at the given package directory.
+func repositoryDirectory(vcsPath string, packageDirectory string) string {
+	parsed, _ := vcs.ParseVCSPath(vcsPath)
+	if parsed.Subpackage() == "" {
+		return packageDirectory
+	}
+
+	var directory = packageDirectory
+	for range strings.Split(path.Clean(parsed.Subpackage()), "/") {
+		directory = path.Dir(directory)
+	}
+	return directory
+}
+
+// copyDirectory copies the regular files and directories found under the source directory to the
+// destination directory, skipping any VCS metadata.
+func copyDirectory(sourceDirectory string, destinationDirectory string) error {
+	return filepath.Walk(sourceDirectory, func(sourcePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(sourceDirectory, sourcePath)
+		if err != nil {
+			return err
+		}
+
+		destinationPath := filepath.Join(destinationDirectory, relativePath)
+
+		switch {
+		case info.IsDir():
+			if relativePath != "." && vendorSkippedDirectories[info.Name()] {
+				return filepath.SkipDir
+			}
+
+			return os.MkdirAll(destinationPath, info.Mode().Perm()|0700)
+
+		case info.Mode().IsRegular():
+			return copyFile(sourcePath, destinationPath, info.Mode().Perm())
+
+		default:
+			// Skip symlinks and other special files.
+			return nil
+		}
+	})
+}
+
+// copyFile copies the file at the source path to the destination path.
+func copyFile(sourcePath string, destinationPath string, perm os.FileMode) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	destination, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(destination, source); err != nil {
+		destination.Close()
+		return err
+	}
+
+	return destination.Close()
+}
@@ -171,6 +171,8 @@ var keywords = map[string]bool{
 	"type":      true,
 	"struct":    true,
 	"agent":     true,
+	"enum":      true,
+	"extension": true,
 	"default":   true,
 
 	"function":    true,
@@ -204,6 +206,7 @@ var keywords = map[string]bool{
 	"break":    true,
 	"continue": true,
 	"with":     true,
+	"defer":    true,
 	"match":    true,
 	"case":     true,
 	"switch":   true,
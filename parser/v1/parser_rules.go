@@ -86,6 +86,18 @@ const (
 	consumeVarRequireExplicitType
 )
 
+// consumeParameterOption defines an option for consuming parameters.
+type consumeParameterOption int
+
+const (
+	// consumeParameterAllowDefaultValue allows for the parameter to specify a default value, which
+	// is used when the caller does not provide an argument for the parameter.
+	consumeParameterAllowDefaultValue consumeParameterOption = iota
+
+	// consumeParameterNoDefaultValue disallows a default value on the parameter.
+	consumeParameterNoDefaultValue
+)
+
 // consumeTopLevel attempts to consume the top-level constructs of a Serulian source file.
 func (p *sourceParser) consumeTopLevel() shared.AstNode {
 	rootNode := p.startNode(sourceshape.NodeTypeFile)
@@ -108,7 +120,7 @@ func (p *sourceParser) consumeTopLevel() shared.AstNode {
 			return true
 		}
 
-		if p.isKeyword("class") || p.isKeyword("interface") || p.isKeyword("type") || p.isKeyword("struct") || p.isKeyword("agent") {
+		if p.isKeyword("class") || p.isKeyword("interface") || p.isKeyword("type") || p.isKeyword("struct") || p.isKeyword("agent") || p.isKeyword("enum") || p.isKeyword("extension") {
 			return true
 		}
 
@@ -138,7 +150,7 @@ Loop:
 			p.currentNode().Connect(sourceshape.NodePredicateChild, p.consumeImport())
 
 		// type definitions.
-		case p.isToken(tokenTypeAtSign) || p.isKeyword("class") || p.isKeyword("interface") || p.isKeyword("type") || p.isKeyword("struct") || p.isKeyword("agent"):
+		case p.isToken(tokenTypeAtSign) || p.isKeyword("class") || p.isKeyword("interface") || p.isKeyword("type") || p.isKeyword("struct") || p.isKeyword("agent") || p.isKeyword("enum") || p.isKeyword("extension"):
 			seenNonImport = true
 			p.currentNode().Connect(sourceshape.NodePredicateChild, p.consumeTypeDefinition())
 			p.tryConsumeStatementTerminator()
@@ -416,8 +428,12 @@ func (p *sourceParser) consumeTypeDefinition() shared.AstNode {
 		typeDef = p.consumeStructuralDefinition()
 	} else if p.isKeyword("agent") {
 		typeDef = p.consumeAgentDefinition()
+	} else if p.isKeyword("enum") {
+		typeDef = p.consumeEnumDefinition()
+	} else if p.isKeyword("extension") {
+		typeDef = p.consumeExtensionDefinition()
 	} else {
-		return p.createErrorNode("Expected 'class', 'interface', 'type', 'struct' or 'agent', Found: %s", p.currentToken.value)
+		return p.createErrorNode("Expected 'class', 'interface', 'type', 'struct', 'agent', 'enum' or 'extension', Found: %s", p.currentToken.value)
 	}
 
 	if ok {
@@ -463,6 +479,77 @@ func (p *sourceParser) consumeStructuralDefinition() shared.AstNode {
 	return structuralNode
 }
 
+// consumeEnumDefinition consumes an enum type definition.
+//
+// enum Identifier { ... }
+func (p *sourceParser) consumeEnumDefinition() shared.AstNode {
+	enumNode := p.startNode(sourceshape.NodeTypeEnum)
+	defer p.finishNode()
+
+	// enum ...
+	p.consumeKeyword("enum")
+
+	// Identifier
+	typeName, ok := p.consumeIdentifier()
+	if !ok {
+		return enumNode
+	}
+
+	enumNode.Decorate(sourceshape.NodeTypeDefinitionName, typeName)
+
+	// Open bracket.
+	if _, ok := p.consume(tokenTypeLeftBrace); !ok {
+		return enumNode
+	}
+
+	// Consume enum values.
+	p.consumeEnumValues(enumNode)
+
+	// Close bracket.
+	p.consume(tokenTypeRightBrace)
+
+	return enumNode
+}
+
+// consumeEnumValues consumes the values of an enum type.
+func (p *sourceParser) consumeEnumValues(typeNode shared.AstNode) {
+	for {
+		// Check for a close token.
+		if p.isToken(tokenTypeRightBrace) {
+			return
+		}
+
+		// Otherwise, consume the enum value.
+		typeNode.Connect(sourceshape.NodeTypeDefinitionMember, p.consumeEnumValue())
+
+		// Check for a close token.
+		if p.isToken(tokenTypeRightBrace) {
+			return
+		}
+
+		if _, ok := p.consumeStatementTerminator(); !ok {
+			return
+		}
+	}
+}
+
+// consumeEnumValue consumes a single value of an enum type.
+//
+// ValueName
+func (p *sourceParser) consumeEnumValue() shared.AstNode {
+	valueNode := p.startNode(sourceshape.NodeTypeEnumValue)
+	defer p.finishNode()
+
+	// ValueName.
+	identifier, ok := p.consumeIdentifier()
+	if !ok {
+		return valueNode
+	}
+
+	valueNode.Decorate(sourceshape.NodePredicateTypeMemberName, identifier)
+	return valueNode
+}
+
 // consumeNominalDefinition consumes a nominal type definition.
 //
 // type Identifier<T> : BaseType.Path { ... }
@@ -506,6 +593,52 @@ func (p *sourceParser) consumeNominalDefinition() shared.AstNode {
 	return nominalNode
 }
 
+// consumeExtensionDefinition consumes an extension definition.
+//
+// extension Identifier for TypeRef { ... }
+// extension Identifier<Generic> for TypeRef<Generic> { ... }
+// extension Identifier<Generic> for []Generic { ... }
+func (p *sourceParser) consumeExtensionDefinition() shared.AstNode {
+	extensionNode := p.startNode(sourceshape.NodeTypeExtension)
+	defer p.finishNode()
+
+	// extension ...
+	p.consumeKeyword("extension")
+
+	// Identifier
+	typeName, ok := p.consumeIdentifier()
+	if !ok {
+		return extensionNode
+	}
+
+	extensionNode.Decorate(sourceshape.NodeTypeDefinitionName, typeName)
+
+	// Generics (optional).
+	p.consumeGenerics(extensionNode, sourceshape.NodeTypeDefinitionGeneric)
+
+	// Extended type.
+	if !p.consumeKeyword("for") {
+		return extensionNode
+	}
+
+	// Slices and mappings can be extended, so they are allowed here. The type constructor
+	// reports any other type that cannot be extended.
+	extensionNode.Connect(sourceshape.NodeExtensionPredicateExtendedType, p.consumeTypeReference(typeReferenceNoVoid))
+
+	// Open bracket.
+	if _, ok := p.consume(tokenTypeLeftBrace); !ok {
+		return extensionNode
+	}
+
+	// Consume extension members.
+	p.consumeExtensionTypeMembers(extensionNode)
+
+	// Close bracket.
+	p.consume(tokenTypeRightBrace)
+
+	return extensionNode
+}
+
 // consumeAgentDefinition consumes an agent definition.
 //
 // agent Identifier for TypeRef { ... }
@@ -775,6 +908,27 @@ func (p *sourceParser) consumeNominalTypeMembers(typeNode shared.AstNode) {
 	}
 }
 
+// consumeExtensionTypeMembers consumes the member definitions of an extension.
+func (p *sourceParser) consumeExtensionTypeMembers(typeNode shared.AstNode) {
+	for {
+		switch {
+		case p.isKeyword("function"):
+			typeNode.Connect(sourceshape.NodeTypeDefinitionMember, p.consumeFunction(typeMemberDefinition))
+
+		case p.isKeyword("property"):
+			typeNode.Connect(sourceshape.NodeTypeDefinitionMember, p.consumeProperty(typeMemberDefinition))
+
+		case p.isToken(tokenTypeRightBrace):
+			// End of the extension members list
+			return
+
+		default:
+			p.emitError("Expected extension member, found %s", p.currentToken.value)
+			return
+		}
+	}
+}
+
 // consumeImplementedTypeMembers consumes the member definitions of a class or agent.
 func (p *sourceParser) consumeImplementedTypeMembers(typeNode shared.AstNode) {
 	p.pushErrorProduction(func(token commentedLexeme) bool {
@@ -875,7 +1029,7 @@ func (p *sourceParser) consumeOperator(option typeMemberOption) shared.AstNode {
 
 	// identifier TypeReference (, another)
 	for {
-		operatorNode.Connect(sourceshape.NodePredicateTypeMemberParameter, p.consumeParameter())
+		operatorNode.Connect(sourceshape.NodePredicateTypeMemberParameter, p.consumeParameter(consumeParameterNoDefaultValue))
 
 		if _, ok := p.tryConsume(tokenTypeComma); !ok {
 			break
@@ -1023,7 +1177,7 @@ func (p *sourceParser) consumeConstructor() shared.AstNode {
 	if _, ok := p.tryConsume(tokenTypeRightParen); !ok {
 		// identifier TypeReference (, another)
 		for {
-			constructorNode.Connect(sourceshape.NodePredicateTypeMemberParameter, p.consumeParameter())
+			constructorNode.Connect(sourceshape.NodePredicateTypeMemberParameter, p.consumeParameter(consumeParameterAllowDefaultValue))
 
 			if _, ok := p.tryConsume(tokenTypeComma); !ok {
 				break
@@ -1078,7 +1232,7 @@ func (p *sourceParser) consumeFunction(option typeMemberOption) shared.AstNode {
 			break
 		}
 
-		functionNode.Connect(sourceshape.NodePredicateTypeMemberParameter, p.consumeParameter())
+		functionNode.Connect(sourceshape.NodePredicateTypeMemberParameter, p.consumeParameter(consumeParameterAllowDefaultValue))
 
 		if _, ok := p.tryConsume(tokenTypeComma); !ok {
 			break
@@ -1108,7 +1262,7 @@ func (p *sourceParser) consumeFunction(option typeMemberOption) shared.AstNode {
 }
 
 // consumeParameter consumes a function or other type member parameter definition
-func (p *sourceParser) consumeParameter() shared.AstNode {
+func (p *sourceParser) consumeParameter(option consumeParameterOption) shared.AstNode {
 	parameterNode := p.startNode(sourceshape.NodeTypeParameter)
 	defer p.finishNode()
 
@@ -1122,6 +1276,14 @@ func (p *sourceParser) consumeParameter() shared.AstNode {
 
 	// Parameter type.
 	parameterNode.Connect(sourceshape.NodeParameterType, p.consumeTypeReference(typeReferenceNoVoid))
+
+	// Optional default value.
+	if option == consumeParameterAllowDefaultValue {
+		if _, ok := p.tryConsume(tokenTypeEquals); ok {
+			parameterNode.Connect(sourceshape.NodeParameterDefaultValue, p.consumeExpression(consumeExpressionAllowBraces))
+		}
+	}
+
 	return parameterNode
 }
 
@@ -1236,9 +1398,46 @@ func (p *sourceParser) consumeSimpleOrAnyTypeReference() (shared.AstNode, bool)
 		return structNode, true
 	}
 
+	// Check for a tuple.
+	if p.isToken(tokenTypeLeftParen) {
+		return p.consumeTupleTypeReference()
+	}
+
 	return p.consumeSimpleTypeReference()
 }
 
+// consumeTupleTypeReference consumes a tuple type reference.
+//
+// Forms:
+// (Foo, Bar)
+// (Foo, Bar, Baz)
+func (p *sourceParser) consumeTupleTypeReference() (shared.AstNode, bool) {
+	tupleNode := p.startNode(sourceshape.NodeTypeTuple)
+	defer p.finishNode()
+
+	// (
+	p.consume(tokenTypeLeftParen)
+
+	// Foo, Bar
+	var elementCount = 0
+	for {
+		tupleNode.Connect(sourceshape.NodeTypeReferenceTupleElement, p.consumeTypeReference(typeReferenceNoVoid))
+		elementCount++
+
+		if _, ok := p.tryConsume(tokenTypeComma); !ok {
+			break
+		}
+	}
+
+	if elementCount < 2 {
+		p.emitError("A tuple type must have at least two elements")
+	}
+
+	// )
+	p.consume(tokenTypeRightParen)
+	return tupleNode, true
+}
+
 // consumeSimpleTypeReference consumes a type reference that cannot be void, nullable
 // or streamable or any.
 func (p *sourceParser) consumeSimpleTypeReference() (shared.AstNode, bool) {
@@ -1533,6 +1732,10 @@ func (p *sourceParser) tryConsumeStatement() (shared.AstNode, bool) {
 	case p.isKeyword("with"):
 		return p.consumeWithStatement(), true
 
+	// Defer statement.
+	case p.isKeyword("defer"):
+		return p.consumeDeferStatement(), true
+
 	// For statement.
 	case p.isKeyword("for"):
 		return p.consumeForStatement(), true
@@ -1611,6 +1814,7 @@ func (p *sourceParser) consumeAssignableExpression() shared.AstNode {
 // Forms:
 // a := expression
 // a, b := expression
+// a, b, c := expression
 func (p *sourceParser) tryConsumeResolveStatement() (shared.AstNode, bool) {
 	// To determine if we have a resolve statement, we need to perform some
 	// lookahead, as there can be multiple forms.
@@ -1625,6 +1829,15 @@ func (p *sourceParser) tryConsumeResolveStatement() (shared.AstNode, bool) {
 
 	if _, ok := p.tryConsume(tokenTypeComma); ok {
 		resolveNode.Connect(sourceshape.NodeAssignedRejection, p.consumeAssignedValue())
+
+		// Any further names destructure the remaining values of a tuple.
+		for {
+			if _, ok := p.tryConsume(tokenTypeComma); !ok {
+				break
+			}
+
+			resolveNode.Connect(sourceshape.NodeAssignedDestructured, p.consumeAssignedValue())
+		}
 	}
 
 	// :=
@@ -1645,8 +1858,12 @@ func (p *sourceParser) lookaheadResolveStatement() bool {
 		return false
 	}
 
-	// Check for a comma. If present, we need another identifier.
-	if _, ok := t.matchToken(tokenTypeComma); ok {
+	// Check for commas. For each present, we need another identifier.
+	for {
+		if _, ok := t.matchToken(tokenTypeComma); !ok {
+			break
+		}
+
 		if _, ok := t.matchToken(tokenTypeIdentifer); !ok {
 			return false
 		}
@@ -1798,6 +2015,14 @@ TopLoop:
 //   default:
 //		statements
 // }
+//
+// match someExpr {
+//   case SomeStruct{SomeField: 0, AnotherField: someVar} if someVar > 2:
+//      statements
+//
+//   case 'hello':
+//      statements
+// }
 func (p *sourceParser) consumeMatchStatement() shared.AstNode {
 	matchNode := p.startNode(sourceshape.NodeTypeMatchStatement)
 	defer p.finishNode()
@@ -1850,12 +2075,26 @@ func (p *sourceParser) tryConsumeMatchCase(keyword string, option matchCaseOptio
 	caseNode := p.startNode(sourceshape.NodeTypeMatchStatementCase)
 	defer p.finishNode()
 
-	// Consume the type reference.
+	// Consume the type reference or pattern, followed by an optional guard.
 	if option == matchCaseWithType {
-		caseNode.Connect(sourceshape.NodeMatchStatementCaseTypeReference, p.consumeTypeReference(typeReferenceNoVoid))
+		if literalNode, ok := p.tryConsumePatternLiteral(); ok {
+			caseNode.Connect(sourceshape.NodeMatchStatementCasePattern, literalNode)
+		} else {
+			startToken := p.currentToken
+			typeRefNode := p.consumeTypeReference(typeReferenceNoVoid)
+			if p.isToken(tokenTypeLeftBrace) {
+				caseNode.Connect(sourceshape.NodeMatchStatementCasePattern, p.consumeStructPattern(startToken, typeRefNode))
+			} else {
+				caseNode.Connect(sourceshape.NodeMatchStatementCaseTypeReference, typeRefNode)
+			}
+		}
+
+		if p.tryConsumeKeyword("if") {
+			caseNode.Connect(sourceshape.NodeMatchStatementCaseGuard, p.consumeExpression(consumeExpressionNoBraces))
+		}
 	}
 
-	// Colon after the type reference.
+	// Colon after the type reference or pattern.
 	if _, ok := p.consume(tokenTypeColon); !ok {
 		return caseNode, true
 	}
@@ -1882,6 +2121,99 @@ func (p *sourceParser) tryConsumeMatchCase(keyword string, option matchCaseOptio
 	return caseNode, true
 }
 
+// consumeStructPattern consumes the fields of a struct pattern, whose type reference has already
+// been consumed starting at the given token.
+//
+// Forms:
+// SomeStruct{}
+// SomeStruct{SomeField: pattern, AnotherField: pattern}
+func (p *sourceParser) consumeStructPattern(startToken commentedLexeme, typeRefNode shared.AstNode) shared.AstNode {
+	patternNode := p.createNode(sourceshape.NodeTypeStructPattern)
+	p.decorateStartRune(patternNode, startToken.lexeme)
+	patternNode.Connect(sourceshape.NodeStructPatternTypeReference, typeRefNode)
+
+	// {
+	if _, ok := p.consume(tokenTypeLeftBrace); !ok {
+		return patternNode
+	}
+
+	if !p.isToken(tokenTypeRightBrace) {
+		for {
+			patternNode.Connect(sourceshape.NodeStructPatternField, p.consumeStructPatternField())
+
+			if _, ok := p.tryConsume(tokenTypeComma); !ok {
+				break
+			}
+		}
+	}
+
+	// }
+	p.decorateEndRune(patternNode, p.currentToken.lexeme)
+	p.consume(tokenTypeRightBrace)
+	return patternNode
+}
+
+// consumeStructPatternField consumes a field of a struct pattern.
+//
+// Forms:
+// SomeField: 42
+// SomeField: someVar
+// SomeField: SomeStruct{...}
+func (p *sourceParser) consumeStructPatternField() shared.AstNode {
+	fieldNode := p.startNode(sourceshape.NodeTypeStructPatternField)
+	defer p.finishNode()
+
+	// Consume the name of the field.
+	name, ok := p.consumeIdentifier()
+	if !ok {
+		return fieldNode
+	}
+
+	fieldNode.Decorate(sourceshape.NodeStructPatternFieldName, name)
+
+	// Consume a colon.
+	if _, ok := p.consume(tokenTypeColon); !ok {
+		return fieldNode
+	}
+
+	// Consume the pattern for the field's value.
+	fieldNode.Connect(sourceshape.NodeStructPatternFieldPattern, p.consumeSubPattern())
+	return fieldNode
+}
+
+// consumeSubPattern consumes a pattern matched against the value of a field under a struct pattern.
+//
+// Forms:
+// 42
+// someVar
+// SomeStruct{...}
+func (p *sourceParser) consumeSubPattern() shared.AstNode {
+	// Literal value.
+	if literalNode, ok := p.tryConsumePatternLiteral(); ok {
+		return literalNode
+	}
+
+	// Named value to which the field's value is bound.
+	if p.isToken(tokenTypeIdentifer) && p.isNextToken(tokenTypeComma, tokenTypeRightBrace) {
+		return p.consumeNamedValue()
+	}
+
+	// Nested struct pattern.
+	startToken := p.currentToken
+	typeRefNode := p.consumeTypeReference(typeReferenceNoVoid)
+	return p.consumeStructPattern(startToken, typeRefNode)
+}
+
+// tryConsumePatternLiteral tries to consume a literal value matched by a pattern. Only numeric,
+// boolean, string and null literals can be matched.
+func (p *sourceParser) tryConsumePatternLiteral() (shared.AstNode, bool) {
+	if !p.isToken(tokenTypeNumericLiteral, tokenTypeBooleanLiteral, tokenTypeStringLiteral) && !p.isKeyword("null") {
+		return nil, false
+	}
+
+	return p.tryConsumeLiteralValue()
+}
+
 // consumeSwitchStatement consumes a switch statement.
 //
 // Forms:
@@ -2014,6 +2346,20 @@ func (p *sourceParser) consumeWithStatement() shared.AstNode {
 	return withNode
 }
 
+// consumeDeferStatement consumes a defer statement.
+//
+// Forms:
+// defer someExpr()
+func (p *sourceParser) consumeDeferStatement() shared.AstNode {
+	deferNode := p.startNode(sourceshape.NodeTypeDeferStatement)
+	defer p.finishNode()
+
+	// defer
+	p.consumeKeyword("defer")
+	deferNode.Connect(sourceshape.NodeDeferStatementExpression, p.consumeExpression(consumeExpressionAllowBraces))
+	return deferNode
+}
+
 // consumeForStatement consumes a loop statement.
 //
 // Forms:
@@ -2814,7 +3160,7 @@ func (p *sourceParser) consumeFullLambdaExpression() shared.AstNode {
 
 	if !p.isToken(tokenTypeRightParen) {
 		for {
-			funcNode.Connect(sourceshape.NodeLambdaExpressionParameter, p.consumeParameter())
+			funcNode.Connect(sourceshape.NodeLambdaExpressionParameter, p.consumeParameter(consumeParameterNoDefaultValue))
 			if _, ok := p.tryConsume(tokenTypeComma); !ok {
 				break
 			}
@@ -3130,8 +3476,20 @@ func (p *sourceParser) tryConsumeCallAccessExpression() (shared.AstNode, bool) {
 			// Consume zero (or more) parameters.
 			if !p.isToken(tokenTypeRightParen) {
 				for {
+					// Consume an (optional) argument name: `name: expression`
+					var argumentName = ""
+					if p.isToken(tokenTypeIdentifer) && p.isNextToken(tokenTypeColon) {
+						argumentName, _ = p.consumeIdentifier()
+						p.consume(tokenTypeColon)
+					}
+
 					// Consume an expression.
-					exprNode.Connect(sourceshape.NodeFunctionCallArgument, p.consumeExpression(consumeExpressionAllowBraces))
+					argumentNode := p.consumeExpression(consumeExpressionAllowBraces)
+					if argumentName != "" {
+						argumentNode.Decorate(sourceshape.NodeFunctionCallArgumentName, argumentName)
+					}
+
+					exprNode.Connect(sourceshape.NodeFunctionCallArgument, argumentNode)
 
 					// Consume an (optional) comma.
 					if _, ok := p.tryConsume(tokenTypeComma); !ok {
@@ -3385,12 +3743,33 @@ func (p *sourceParser) tryConsumeBaseExpression() (shared.AstNode, bool) {
 		notNode.Connect(sourceshape.NodeUnaryExpressionChildExpr, p.consumeAssignableExpression())
 		return notNode, true
 
-	// Nested expression.
+	// Nested expression or tuple literal.
 	case p.isToken(tokenTypeLeftParen):
-		comments := p.currentToken.comments
+		leftParen := p.currentToken
+		comments := leftParen.comments
 
 		p.consume(tokenTypeLeftParen)
 		exprNode := p.consumeExpression(consumeExpressionAllowBraces)
+
+		// If a comma follows the first expression, we have a tuple literal.
+		if _, ok := p.tryConsume(tokenTypeComma); ok {
+			tupleNode := p.createNode(sourceshape.NodeTupleLiteralExpression)
+			p.decorateStartRuneAndComments(tupleNode, leftParen)
+			tupleNode.Connect(sourceshape.NodeTupleLiteralExpressionValue, exprNode)
+
+			for {
+				tupleNode.Connect(sourceshape.NodeTupleLiteralExpressionValue, p.consumeExpression(consumeExpressionAllowBraces))
+
+				if _, ok := p.tryConsume(tokenTypeComma); !ok {
+					break
+				}
+			}
+
+			p.decorateEndRune(tupleNode, p.currentToken.lexeme)
+			p.consume(tokenTypeRightParen)
+			return tupleNode, true
+		}
+
 		p.consume(tokenTypeRightParen)
 
 		// Attach any comments found to the consumed expression.
@@ -3441,6 +3820,29 @@ func (p *sourceParser) lookaheadTypeReference(t *lookaheadTracker) bool {
 	// Match any nullable or streams.
 	t.matchToken(tokenTypeTimes, tokenTypeQuestionMark)
 
+	// Tuples.
+	// (type, type)
+	if _, ok := t.matchToken(tokenTypeLeftParen); ok {
+		for {
+			if !p.lookaheadTypeReference(t) {
+				return false
+			}
+
+			if _, ok := t.matchToken(tokenTypeComma); !ok {
+				break
+			}
+		}
+
+		// )
+		if _, ok := t.matchToken(tokenTypeRightParen); !ok {
+			return false
+		}
+
+		// Match any nullable or streams.
+		t.matchToken(tokenTypeTimes, tokenTypeQuestionMark)
+		return true
+	}
+
 	// Slices and mappings.
 	// []type
 	// []{type}
@@ -120,6 +120,7 @@ var parserTests = []parserTest{
 	{"module const no declared type test", "module/module_const_nodeclare"},
 	{"module function test", "module/module_function"},
 	{"void function test", "module/void_function"},
+	{"default parameter value test", "module/default_param"},
 
 	// Struct success tests.
 	{"basic struct test", "struct/basic"},
@@ -160,6 +161,17 @@ var parserTests = []parserTest{
 	{"generic agent test", "agent/generic"},
 	{"missing principal type agent test", "agent/missingprincipal"},
 
+	// Enum tests.
+	{"basic enum test", "enum/basic"},
+	{"empty and single value enum test", "enum/empty"},
+	{"invalid value enum test", "enum/invalid_value"},
+
+	// Extension tests.
+	{"basic extension test", "extension/basic"},
+	{"generic extension test", "extension/generic"},
+	{"slice extension test", "extension/slice"},
+	{"invalid member extension test", "extension/invalid_member"},
+
 	// Class member success tests.
 	{"basic class function test", "class/basic_function"},
 	{"generic function test", "class/generic_function"},
@@ -192,6 +204,7 @@ var parserTests = []parserTest{
 
 	{"assignment statement test", "statement/assign"},
 	{"resolve statement test", "statement/resolve"},
+	{"destructuring resolve statement test", "statement/resolve_destructure"},
 
 	{"no expr loop statement test", "statement/loop_noexpr"},
 	{"expr loop statement test", "statement/loop_expr"},
@@ -218,6 +231,8 @@ var parserTests = []parserTest{
 	{"basic with test", "statement/with"},
 	{"with as test", "statement/with_as"},
 
+	{"basic defer test", "statement/defer"},
+
 	{"expression statement test", "statement/expression"},
 
 	{"switch statement basic test", "statement/switch"},
@@ -226,6 +241,7 @@ var parserTests = []parserTest{
 
 	{"match statement basic test", "statement/match"},
 	{"match statement as test", "statement/match_as"},
+	{"match statement pattern test", "statement/match_pattern"},
 
 	// Expression tests.
 	{"arrow expr test", "expression/arrow"},
@@ -239,6 +255,8 @@ var parserTests = []parserTest{
 	{"parens expr test", "expression/parens"},
 	{"list expr test", "expression/list"},
 	{"list call expr test", "expression/list_call"},
+	{"tuple expr test", "expression/tuple"},
+	{"named arguments call expr test", "expression/named_args"},
 	{"lambda expr test", "expression/lambda"},
 	{"map missing comma expr test", "expression/map_missingcomma"},
 	{"generic specifier expr test", "expression/generic_specifier"},
@@ -280,6 +298,8 @@ var parserTests = []parserTest{
 
 	// Type reference tests.
 	{"all type reference test", "typeref/all"},
+	{"tuple type reference test", "typeref/tuple"},
+	{"single element tuple type reference test", "typeref/tuple_single"},
 
 	// Full example tests.
 	{"basic full example test", "full/basic"},
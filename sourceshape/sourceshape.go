@@ -33,6 +33,8 @@ const (
 	NodeTypeNominal   // A nominal type
 	NodeTypeStruct    // A structural type
 	NodeTypeAgent     // An agent type
+	NodeTypeEnum      // An enum type
+	NodeTypeExtension // An extension of an existing type
 
 	NodeTypeGeneric        // A generic definition on a type
 	NodeTypeAgentReference // A single agent included in a class
@@ -46,6 +48,7 @@ const (
 	NodeTypeProperty    // A property declaration or definition
 	NodeTypeOperator    // An operator declaration or definition
 	NodeTypeField       // A field (var) definition
+	NodeTypeEnumValue   // A value under an enum type
 
 	// Type member blocks
 	NodeTypePropertyBlock // A child block (get or set) of a property definition
@@ -64,6 +67,7 @@ const (
 	NodeTypeContinueStatement    // A continue statement
 	NodeTypeVariableStatement    // A variable statement
 	NodeTypeWithStatement        // A with statement
+	NodeTypeDeferStatement       // A defer statement
 	NodeTypeSwitchStatement      // A switch statement
 	NodeTypeMatchStatement       // A match statement
 	NodeTypeAssignStatement      // An assignment statement: a = b
@@ -73,6 +77,9 @@ const (
 	NodeTypeSwitchStatementCase // A case of a switch statement.
 	NodeTypeMatchStatementCase  // A case of a match statement.
 
+	NodeTypeStructPattern      // A struct pattern under a match case: SomeStruct{Field: pattern}
+	NodeTypeStructPatternField // A field of a struct pattern: Field: pattern
+
 	NodeTypeNamedValue    // A named value added to the scope of the parent statement.
 	NodeTypeAssignedValue // A named value assigned to the scope by a parent statement.
 
@@ -147,6 +154,7 @@ const (
 	NodeValLiteralExpression       // val
 
 	NodeListLiteralExpression         // [1, 2, 3]
+	NodeTupleLiteralExpression        // (1, 'hello')
 	NodeSliceLiteralExpression        // []int{1, 2, 3}
 	NodeMappingLiteralExpression      // []{string}{a: 1, b: 2}
 	NodeMappingLiteralExpressionEntry // a: 1
@@ -171,6 +179,7 @@ const (
 	NodeTypeVoid
 	NodeTypeAny
 	NodeTypeStructReference
+	NodeTypeTuple
 
 	// Misc
 	NodeTypeIdentifierPath   // An identifier path
@@ -268,6 +277,11 @@ const (
 	//
 	NodeAgentPredicatePrincipalType = "agent-principaltypepath"
 
+	//
+	// NodeTypeExtension
+	//
+	NodeExtensionPredicateExtendedType = "extension-extendedtypepath"
+
 	//
 	// NodeTypeAgentReference
 	//
@@ -286,7 +300,7 @@ const (
 	NodePredicateBody = "definition-body"
 
 	//
-	// Type members: NodeTypeProperty, NodeTypeFunction, NodeTypeField, NodeTypeConstructor
+	// Type members: NodeTypeProperty, NodeTypeFunction, NodeTypeField, NodeTypeConstructor, NodeTypeEnumValue
 	//
 	NodePredicateTypeMemberName         = "named"
 	NodePredicateTypeMemberDeclaredType = "typemember-declared-type"
@@ -326,8 +340,9 @@ const (
 	//
 	// NodeTypeParameter
 	//
-	NodeParameterType = "parameter-type"
-	NodeParameterName = "named"
+	NodeParameterType         = "parameter-type"
+	NodeParameterName         = "named"
+	NodeParameterDefaultValue = "parameter-default-value"
 
 	//
 	// NodeTypeTypeReference
@@ -337,6 +352,11 @@ const (
 	NodeTypeReferenceParameter = "typereference-parameter"
 	NodeTypeReferenceInnerType = "typereference-inner-type"
 
+	//
+	// NodeTypeTuple
+	//
+	NodeTypeReferenceTupleElement = "typereference-tuple-element"
+
 	//
 	// NodeTypeIdentifierPath
 	//
@@ -376,8 +396,9 @@ const (
 	//
 	NodeResolveStatementSource = "resolve-statement-expr"
 
-	NodeAssignedDestination = "assigned-value-destination"
-	NodeAssignedRejection   = "assigned-value-rejection"
+	NodeAssignedDestination  = "assigned-value-destination"
+	NodeAssignedRejection    = "assigned-value-rejection"
+	NodeAssignedDestructured = "assigned-value-destructured"
 
 	//
 	// NodeTypeField/NodeTypeVariable/NodeTypeVariableStatement
@@ -454,6 +475,11 @@ const (
 	NodeWithStatementExpression = "with-expression"
 	NodeWithStatementBlock      = "with-block"
 
+	//
+	// NodeTypeDeferStatement
+	//
+	NodeDeferStatementExpression = "defer-expression"
+
 	//
 	// NodeTypeSwitchStatement
 	//
@@ -476,8 +502,22 @@ const (
 	// NodeTypeMatchStatementCase
 	//
 	NodeMatchStatementCaseTypeReference = "match-case-typeref"
+	NodeMatchStatementCasePattern       = "match-case-pattern"
+	NodeMatchStatementCaseGuard         = "match-case-guard"
 	NodeMatchStatementCaseStatement     = "match-case-statement"
 
+	//
+	// NodeTypeStructPattern
+	//
+	NodeStructPatternTypeReference = "struct-pattern-typeref"
+	NodeStructPatternField         = "struct-pattern-field"
+
+	//
+	// NodeTypeStructPatternField
+	//
+	NodeStructPatternFieldName    = "struct-pattern-field-name"
+	NodeStructPatternFieldPattern = "struct-pattern-field-pattern"
+
 	//
 	// NodeTypeExpressionStatement
 	//
@@ -559,6 +599,7 @@ const (
 	// NodeFunctionCallExpression
 	//
 	NodeFunctionCallArgument            = "function-call-argument"
+	NodeFunctionCallArgumentName        = "function-call-argument-name"
 	NodeFunctionCallExpressionChildExpr = "function-call-expr"
 
 	//
@@ -566,6 +607,11 @@ const (
 	//
 	NodeListLiteralExpressionValue = "list-expr-value"
 
+	//
+	// NodeTupleLiteralExpression
+	//
+	NodeTupleLiteralExpressionValue = "tuple-expr-value"
+
 	//
 	// NodeSliceLiteralExpression
 	//
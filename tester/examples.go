@@ -0,0 +1,219 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tester
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/serulian/compiler/builder"
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/graphs/scopegraph"
+	"github.com/serulian/compiler/graphs/srg"
+	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/sourceshape"
+)
+
+// exampleFenceMarker is found in any source file containing a Serulian code example in its
+// documentation. Source files without it are skipped without being built.
+const exampleFenceMarker = "```seru"
+
+// exampleModuleSuffix is placed after the name of a module (followed by the example's index) to
+// form the name of the synthetic test module generated for each of its examples.
+const exampleModuleSuffix = "_example"
+
+// testFunctionPrefix is the prefix of the declaration of the function run by the test runners.
+const testFunctionPrefix = "function TEST("
+
+// runExamplesViaRunner runs the Serulian code examples found in the documentation comments of the
+// non-test source files at the given source path via the runner. Returns the number of examples
+// found and whether all of them succeeded.
+func runExamplesViaRunner(runner TestRunner, path string, vcsDevelopmentDirectories []string) (int, bool) {
+	examplesFound := 0
+	overallSuccess := true
+	_, err := compilerutil.WalkSourcePath(path, func(currentPath string, info os.FileInfo) (bool, error) {
+		if !strings.HasSuffix(info.Name(), sourceshape.SerulianFileExtension) ||
+			strings.HasSuffix(info.Name(), packageloader.SerulianTestSuffix+sourceshape.SerulianFileExtension) {
+			return false, nil
+		}
+
+		count, success := runModuleExamples(currentPath, vcsDevelopmentDirectories, runner)
+		examplesFound += count
+		overallSuccess = overallSuccess && success
+		return true, nil
+	}, packageloader.SerulianPackageDirectory)
+
+	return examplesFound, overallSuccess && err == nil
+}
+
+// runModuleExamples runs the Serulian code examples found in the documentation comments of the
+// module at the given path via the runner. Each example is compiled as a synthetic test module
+// placed next to the module, importing all of the module's types and members. Returns the number
+// of examples found and whether all of them succeeded.
+func runModuleExamples(modulePath string, vcsDevelopmentDirectories []string, runner TestRunner) (int, bool) {
+	contents, err := ioutil.ReadFile(modulePath)
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not read source file `%s`: %v", modulePath, err)
+		return 0, false
+	}
+
+	if !strings.Contains(string(contents), exampleFenceMarker) {
+		return 0, true
+	}
+
+	scopeResult, err := scopegraph.ParseAndBuildScopeGraph(modulePath,
+		vcsDevelopmentDirectories,
+		builder.CORE_LIBRARY)
+
+	if err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "%s", fmt.Errorf("Error building examples in %s: %v", modulePath, err))
+		return 0, false
+	}
+
+	if !scopeResult.Status {
+		builder.OutputErrors(scopeResult.Errors)
+		return 0, false
+	}
+
+	sourceGraph := scopeResult.Graph.SourceGraph()
+	module, found := sourceGraph.FindModuleBySource(compilercommon.InputSource(modulePath))
+	if !found {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, nil, "Could not find module `%s`", modulePath)
+		return 0, false
+	}
+
+	importStatement := moduleImportStatement(module)
+
+	examplesFound := 0
+	overallSuccess := true
+	for _, example := range sourceGraph.AllExamples() {
+		if example.SourceRange.Source() != module.InputSource() {
+			continue
+		}
+
+		examplesFound++
+		if !runExample(module, example, examplesFound, importStatement, vcsDevelopmentDirectories, runner) {
+			compilerutil.LogToConsole(compilerutil.ErrorLogLevel, example.SourceRange, "Documentation example failed")
+			overallSuccess = false
+		}
+	}
+
+	return examplesFound, overallSuccess
+}
+
+// runExample generates the synthetic test module for the given example, and then builds and runs it
+// via the runner. Any errors or warnings found in the synthetic module are reported at the matching
+// location of the example in its documentation comment.
+func runExample(module srg.SRGModule, example srg.SRGCodeExample, index int, importStatement string, vcsDevelopmentDirectories []string, runner TestRunner) bool {
+	modulePath := string(module.InputSource())
+	moduleName := strings.TrimSuffix(filepath.Base(modulePath), sourceshape.SerulianFileExtension)
+	examplePath := filepath.Join(filepath.Dir(modulePath),
+		fmt.Sprintf("%s%s%d%s%s", moduleName, exampleModuleSuffix, index, packageloader.SerulianTestSuffix, sourceshape.SerulianFileExtension))
+
+	source, exampleLines := generateExampleModule(example.Code, importStatement)
+	if err := ioutil.WriteFile(examplePath, []byte(source), 0644); err != nil {
+		compilerutil.LogToConsole(compilerutil.ErrorLogLevel, example.SourceRange, "Could not write example module: %v", err)
+		return false
+	}
+
+	defer os.Remove(examplePath)
+
+	mapper := func(sourceRange compilercommon.SourceRange) compilercommon.SourceRange {
+		if sourceRange == nil || sourceRange.Source() != compilercommon.InputSource(examplePath) {
+			return sourceRange
+		}
+
+		line, _, err := sourceRange.Start().LineAndColumn()
+		if err != nil || line >= len(exampleLines) || exampleLines[line] < 0 {
+			return example.SourceRange
+		}
+
+		return example.LineRange(exampleLines[line])
+	}
+
+	return buildAndRunTests(examplePath, vcsDevelopmentDirectories, runner, mapper)
+}
+
+// moduleImportStatement returns the statement importing all the named types and members of the given
+// module into a synthetic example module.
+func moduleImportStatement(module srg.SRGModule) string {
+	var names = make([]string, 0)
+	for _, srgType := range module.GetTypes() {
+		if name, hasName := srgType.Name(); hasName {
+			names = append(names, name)
+		}
+	}
+
+	for _, member := range module.GetMembers() {
+		if name, hasName := member.Name(); hasName {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+
+	moduleName := strings.TrimSuffix(module.Name(), sourceshape.SerulianFileExtension)
+	return fmt.Sprintf("from %s import %s", moduleName, strings.Join(names, ", "))
+}
+
+// generateExampleModule returns the source of the synthetic test module for the given example code.
+// Examples declaring their own TEST function are used as-is; otherwise, the statements of the example
+// (following any of its imports) become the body of the TEST function. Also returns, for each line of
+// the generated source, the matching line of the example, or -1 if none.
+func generateExampleModule(code string, importStatement string) (string, []int) {
+	codeLines := strings.Split(code, "\n")
+
+	var lines = make([]string, 0, len(codeLines)+3)
+	var exampleLines = make([]int, 0, len(codeLines)+3)
+
+	addLine := func(line string, exampleLine int) {
+		lines = append(lines, line)
+		exampleLines = append(exampleLines, exampleLine)
+	}
+
+	if importStatement != "" {
+		addLine(importStatement, -1)
+	}
+
+	declaresTest := false
+	for _, line := range codeLines {
+		if strings.HasPrefix(line, testFunctionPrefix) {
+			declaresTest = true
+			break
+		}
+	}
+
+	// Keep the imports of the example at the top of the module.
+	var bodyStart = 0
+	if !declaresTest {
+		for bodyStart < len(codeLines) && isImportLine(codeLines[bodyStart]) {
+			addLine(codeLines[bodyStart], bodyStart)
+			bodyStart++
+		}
+
+		addLine(testFunctionPrefix+") {", -1)
+	}
+
+	for index := bodyStart; index < len(codeLines); index++ {
+		addLine(codeLines[index], index)
+	}
+
+	if !declaresTest {
+		addLine("}", -1)
+	}
+
+	return strings.Join(lines, "\n") + "\n", exampleLines
+}
+
+// isImportLine returns whether the given line of example code is an import statement.
+func isImportLine(line string) bool {
+	return strings.HasPrefix(line, "import ") || strings.HasPrefix(line, "from ")
+}
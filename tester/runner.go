@@ -15,6 +15,7 @@ import (
 
 	"github.com/serulian/compiler/builder"
 	"github.com/serulian/compiler/bundle"
+	"github.com/serulian/compiler/compilercommon"
 	"github.com/serulian/compiler/compilerutil"
 	"github.com/serulian/compiler/graphs/scopegraph"
 	"github.com/serulian/compiler/packageloader"
@@ -77,21 +78,55 @@ func runTestsViaRunner(runner TestRunner, path string, vcsDevelopmentDirectories
 			return false, nil
 		}
 
-		success := buildAndRunTests(currentPath, vcsDevelopmentDirectories, runner)
+		success := buildAndRunTests(currentPath, vcsDevelopmentDirectories, runner, nil)
 		overallSuccess = overallSuccess && success
 		return true, nil
 	}, packageloader.SerulianPackageDirectory)
 
-	if filesWalked == 0 {
-		compilerutil.LogToConsole(compilerutil.WarningLogLevel, nil, "No valid test source files found for path `%s`", path)
+	// Run the examples found in the documentation of the (non-test) source files.
+	examplesRun, examplesSuccess := runExamplesViaRunner(runner, path, vcsDevelopmentDirectories)
+
+	if filesWalked == 0 && examplesRun == 0 {
+		compilerutil.LogToConsole(compilerutil.WarningLogLevel, nil, "No valid test source files or documentation examples found for path `%s`", path)
 		return false
 	}
 
-	return overallSuccess && err == nil
+	return overallSuccess && examplesSuccess && err == nil
+}
+
+// sourceRangeMapper maps the range of an error or warning found when building a test module to the
+// range at which it should be reported.
+type sourceRangeMapper func(sourceRange compilercommon.SourceRange) compilercommon.SourceRange
+
+// mapWarnings returns the given warnings with their ranges mapped by the mapper, if any.
+func mapWarnings(warnings []compilercommon.SourceWarning, mapper sourceRangeMapper) []compilercommon.SourceWarning {
+	if mapper == nil {
+		return warnings
+	}
+
+	mapped := make([]compilercommon.SourceWarning, 0, len(warnings))
+	for _, warning := range warnings {
+		mapped = append(mapped, compilercommon.NewSourceWarning(mapper(warning.SourceRange()), warning.Warning()))
+	}
+	return mapped
+}
+
+// mapErrors returns the given errors with their ranges mapped by the mapper, if any.
+func mapErrors(errors []compilercommon.SourceError, mapper sourceRangeMapper) []compilercommon.SourceError {
+	if mapper == nil {
+		return errors
+	}
+
+	mapped := make([]compilercommon.SourceError, 0, len(errors))
+	for _, err := range errors {
+		mapped = append(mapped, compilercommon.NewSourceError(mapper(err.SourceRange()), err.Error()))
+	}
+	return mapped
 }
 
 // buildAndRunTests builds the source found at the given path and then runs its tests via the runner.
-func buildAndRunTests(filePath string, vcsDevelopmentDirectories []string, runner TestRunner) bool {
+// If given, the mapper maps the ranges of any errors or warnings found before they are reported.
+func buildAndRunTests(filePath string, vcsDevelopmentDirectories []string, runner TestRunner, mapper sourceRangeMapper) bool {
 	log.Printf("Building %s...", filePath)
 
 	filename := path.Base(filePath)
@@ -105,10 +140,10 @@ func buildAndRunTests(filePath string, vcsDevelopmentDirectories []string, runne
 		return false
 	}
 
-	builder.OutputWarnings(scopeResult.Warnings)
+	builder.OutputWarnings(mapWarnings(scopeResult.Warnings, mapper))
 
 	if !scopeResult.Status {
-		builder.OutputErrors(scopeResult.Errors)
+		builder.OutputErrors(mapErrors(scopeResult.Errors, mapper))
 		return false
 	}
 
@@ -164,7 +199,7 @@ func DecorateRunners(command *cobra.Command, vcsDevelopmentDirectories *[]string
 		var runnerCmd = &cobra.Command{
 			Use:   fmt.Sprintf("%s [source path]", name),
 			Short: "Runs the tests defined at the given source path via " + runner.Title(),
-			Long:  fmt.Sprintf("Runs the tests found in any *%s.seru files at the given source path", packageloader.SerulianTestSuffix),
+			Long:  fmt.Sprintf("Runs the tests found in any *%s.seru files, and the examples found in documentation comments, at the given source path", packageloader.SerulianTestSuffix),
 			Run: func(cmd *cobra.Command, args []string) {
 				if len(args) != 1 {
 					fmt.Println("Expected source path")
@@ -0,0 +1,33 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// tsASTNode represents a parser-compatible AST node, backed by a TypeScript graph node.
+type tsASTNode struct {
+	graphNode compilergraph.ModifiableGraphNode // The backing graph node.
+}
+
+// Connect connects a TypeScript AST node to another TypeScript AST node.
+func (ast *tsASTNode) Connect(predicate string, other parser.AstNode) parser.AstNode {
+	ast.graphNode.Connect(compilergraph.Predicate(predicate), other.(*tsASTNode).graphNode)
+	return ast
+}
+
+// Decorate decorates a TypeScript AST node with the given string value.
+func (ast *tsASTNode) Decorate(predicate string, value string) parser.AstNode {
+	ast.graphNode.Decorate(compilergraph.Predicate(predicate), value)
+	return ast
+}
+
+// DecorateWith decorates a TypeScript AST node with the given int value.
+func (ast *tsASTNode) DecorateWithInt(predicate string, value int) parser.AstNode {
+	ast.graphNode.DecorateWith(compilergraph.Predicate(predicate), value)
+	return ast
+}
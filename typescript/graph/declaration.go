@@ -0,0 +1,128 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// Declarations returns all the declarations in the TypeScript graph.
+func (g *TSGraph) Declarations() []TSDeclaration {
+	dit := g.findAllNodes(parser.NodeTypeDeclaration).BuildNodeIterator()
+
+	var declarations = make([]TSDeclaration, 0)
+	for dit.Next() {
+		declaration := TSDeclaration{dit.Node(), g}
+		declarations = append(declarations, declaration)
+	}
+
+	return declarations
+}
+
+// FindDeclaration finds the first declaration with the given name in the graph, if any.
+func (g *TSGraph) FindDeclaration(name string) (TSDeclaration, bool) {
+	declNode, hasDeclaration := g.layer.StartQuery(name).
+		In(parser.NodePredicateDeclarationName).
+		TryGetNode()
+
+	if !hasDeclaration {
+		return TSDeclaration{}, false
+	}
+
+	return TSDeclaration{declNode, g}, true
+}
+
+// TSDeclaration wraps a TypeScript declaration.
+type TSDeclaration struct {
+	compilergraph.GraphNode
+	tsg *TSGraph // The parent graph.
+}
+
+// Name returns the name of the declaration.
+func (d *TSDeclaration) Name() string {
+	return d.GraphNode.Get(parser.NodePredicateDeclarationName)
+}
+
+// Kind returns the kind of declaration.
+func (d *TSDeclaration) Kind() parser.DeclarationKind {
+	return parser.DeclarationKind(d.GraphNode.Get(parser.NodePredicateDeclarationKind))
+}
+
+// IsType returns true if the declaration declares a type (interface or class).
+func (d *TSDeclaration) IsType() bool {
+	kind := d.Kind()
+	return kind == parser.InterfaceDeclaration || kind == parser.ClassDeclaration
+}
+
+// IsReadonly returns true if the declaration is a constant variable.
+func (d *TSDeclaration) IsReadonly() bool {
+	_, isReadonly := d.GraphNode.TryGet(parser.NodePredicateDeclarationReadonly)
+	return isReadonly
+}
+
+// IsAbstract returns true if the declaration is an abstract class.
+func (d *TSDeclaration) IsAbstract() bool {
+	_, isAbstract := d.GraphNode.TryGet(parser.NodePredicateDeclarationAbstract)
+	return isAbstract
+}
+
+// Module returns the parent module.
+func (d *TSDeclaration) Module() TSModule {
+	moduleNode := d.GraphNode.GetIncomingNode(parser.NodePredicateChild)
+	return TSModule{moduleNode, d.tsg}
+}
+
+// Documentation returns the documentation comment found on the declaration, if any.
+func (d *TSDeclaration) Documentation() (string, bool) {
+	return documentationOf(d.GraphNode)
+}
+
+// Extends returns the type references to the types extended by this declaration.
+func (d *TSDeclaration) Extends() []TSTypeRef {
+	return d.tsg.typeRefs(d.GraphNode, parser.NodePredicateDeclarationExtends)
+}
+
+// Implements returns the type references to the interfaces implemented by this declaration.
+func (d *TSDeclaration) Implements() []TSTypeRef {
+	return d.tsg.typeRefs(d.GraphNode, parser.NodePredicateDeclarationImplements)
+}
+
+// DeclaredType returns the declared type of the declaration, if any. For functions, this
+// is the return type and for type aliases, the aliased type.
+func (d *TSDeclaration) DeclaredType() (TSTypeRef, bool) {
+	return d.tsg.typeRef(d.GraphNode, parser.NodePredicateDeclarationType)
+}
+
+// Generics returns the generics defined on the declaration.
+func (d *TSDeclaration) Generics() []TSGeneric {
+	return d.tsg.generics(d.GraphNode, parser.NodePredicateDeclarationGeneric)
+}
+
+// Parameters returns the parameters of a function declaration.
+func (d *TSDeclaration) Parameters() []TSParameter {
+	return d.tsg.parameters(d.GraphNode, parser.NodePredicateDeclarationParameter)
+}
+
+// Members returns all the members declared in the declaration.
+func (d *TSDeclaration) Members() []TSMember {
+	mit := d.GraphNode.StartQuery().
+		Out(parser.NodePredicateDeclarationMember).
+		BuildNodeIterator()
+
+	var members = make([]TSMember, 0)
+	for mit.Next() {
+		member := TSMember{mit.Node(), d.tsg}
+		members = append(members, member)
+	}
+
+	return members
+}
+
+// SourceRange returns the source range of the declaration in source.
+func (d *TSDeclaration) SourceRange() (compilercommon.SourceRange, bool) {
+	return d.tsg.SourceRangeOf(d.GraphNode)
+}
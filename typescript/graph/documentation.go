@@ -0,0 +1,50 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"strings"
+
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// documentationOf returns the contents of the last JSDoc comment (`/** ... */`) attached to
+// the given node, if any.
+func documentationOf(node compilergraph.GraphNode) (string, bool) {
+	cit := node.StartQuery().
+		Out(parser.NodePredicateChild).
+		Has(parser.NodePredicateCommentValue).
+		BuildNodeIterator(parser.NodePredicateCommentValue)
+
+	var docComment = ""
+	var found = false
+	for cit.Next() {
+		value := cit.GetPredicate(parser.NodePredicateCommentValue).String()
+		if strings.HasPrefix(value, "/**") {
+			docComment = value
+			found = true
+		}
+	}
+
+	if !found {
+		return "", false
+	}
+
+	return trimDocComment(docComment), true
+}
+
+// trimDocComment returns the trimmed contents of the given JSDoc comment.
+func trimDocComment(value string) string {
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "/**"), "*/")
+	lines := strings.Split(value, "\n")
+
+	var newLines = make([]string, 0, len(lines))
+	for _, line := range lines {
+		newLines = append(newLines, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*")))
+	}
+
+	return strings.TrimSpace(strings.Join(newLines, "\n"))
+}
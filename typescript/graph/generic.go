@@ -0,0 +1,40 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// TSGeneric wraps a generic defined on a TypeScript declaration or member.
+type TSGeneric struct {
+	compilergraph.GraphNode
+	tsg *TSGraph // The parent graph.
+}
+
+// generics returns the generics connected to the given node via the given predicate.
+func (g *TSGraph) generics(node compilergraph.GraphNode, predicate compilergraph.Predicate) []TSGeneric {
+	git := node.StartQuery().
+		Out(predicate).
+		BuildNodeIterator()
+
+	var generics = make([]TSGeneric, 0)
+	for git.Next() {
+		generics = append(generics, TSGeneric{git.Node(), g})
+	}
+
+	return generics
+}
+
+// Name returns the name of the generic.
+func (g *TSGeneric) Name() string {
+	return g.GraphNode.Get(parser.NodePredicateGenericName)
+}
+
+// Constraint returns the constraint on the generic, if any.
+func (g *TSGeneric) Constraint() (TSTypeRef, bool) {
+	return g.tsg.typeRef(g.GraphNode, parser.NodePredicateGenericConstraint)
+}
@@ -0,0 +1,41 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+// NATIVE_TYPES maps from the TypeScript primitive types to the native types (as defined in
+// WebIDL) that represent them in ES.
+var NATIVE_TYPES = map[string]string{
+	"string":  "String",
+	"number":  "Number",
+	"boolean": "Boolean",
+	"true":    "Boolean",
+	"false":   "Boolean",
+	"object":  "Object",
+}
+
+// ARRAY_TYPE is the name of the native type used to represent array and tuple types.
+const ARRAY_TYPE = "Array"
+
+// ANY_TYPES are the TypeScript types that cannot be represented more specifically than `any`.
+var ANY_TYPES = map[string]bool{
+	"any":     true,
+	"unknown": true,
+	"symbol":  true,
+	"bigint":  true,
+}
+
+// VOID_TYPES are the TypeScript types that are represented as `void`.
+var VOID_TYPES = map[string]bool{
+	"void":      true,
+	"undefined": true,
+	"null":      true,
+	"never":     true,
+}
+
+// NULL_TYPES are the TypeScript types that, when found in a union, make the union nullable.
+var NULL_TYPES = map[string]bool{
+	"null":      true,
+	"undefined": true,
+}
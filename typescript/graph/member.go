@@ -0,0 +1,85 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// TSMember wraps a member of a TypeScript interface or class.
+type TSMember struct {
+	compilergraph.GraphNode
+	tsg *TSGraph // The parent graph.
+}
+
+// Name returns the name of the member, if any. Constructors, index signatures and computed
+// members won't have names.
+func (m *TSMember) Name() (string, bool) {
+	return m.GraphNode.TryGet(parser.NodePredicateMemberName)
+}
+
+// Kind returns the kind of the member.
+func (m *TSMember) Kind() parser.MemberKind {
+	return parser.MemberKind(m.GraphNode.Get(parser.NodePredicateMemberKind))
+}
+
+// IsOptional returns true if this member is optional.
+func (m *TSMember) IsOptional() bool {
+	_, isOptional := m.GraphNode.TryGet(parser.NodePredicateMemberOptional)
+	return isOptional
+}
+
+// IsStatic returns true if this member is static.
+func (m *TSMember) IsStatic() bool {
+	_, isStatic := m.GraphNode.TryGet(parser.NodePredicateMemberStatic)
+	return isStatic
+}
+
+// IsReadonly returns true if this member is read-only.
+func (m *TSMember) IsReadonly() bool {
+	_, isReadonly := m.GraphNode.TryGet(parser.NodePredicateMemberReadonly)
+	return isReadonly
+}
+
+// IsHidden returns true if this member is private or protected.
+func (m *TSMember) IsHidden() bool {
+	_, isHidden := m.GraphNode.TryGet(parser.NodePredicateMemberHidden)
+	return isHidden
+}
+
+// IsComputed returns true if this member has a computed name (or no name at all, in the
+// case of call signatures).
+func (m *TSMember) IsComputed() bool {
+	_, isComputed := m.GraphNode.TryGet(parser.NodePredicateMemberComputed)
+	return isComputed
+}
+
+// Documentation returns the documentation comment found on the member, if any.
+func (m *TSMember) Documentation() (string, bool) {
+	return documentationOf(m.GraphNode)
+}
+
+// DeclaredType returns the declared type of the member, if any. For methods, this is the
+// return type.
+func (m *TSMember) DeclaredType() (TSTypeRef, bool) {
+	return m.tsg.typeRef(m.GraphNode, parser.NodePredicateMemberType)
+}
+
+// Parameters returns all the parameters declared on the member.
+func (m *TSMember) Parameters() []TSParameter {
+	return m.tsg.parameters(m.GraphNode, parser.NodePredicateMemberParameter)
+}
+
+// Generics returns the generics defined on the member.
+func (m *TSMember) Generics() []TSGeneric {
+	return m.tsg.generics(m.GraphNode, parser.NodePredicateMemberGeneric)
+}
+
+// SourceRange returns the source range of the member in source.
+func (m *TSMember) SourceRange() (compilercommon.SourceRange, bool) {
+	return m.tsg.SourceRangeOf(m.GraphNode)
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"path"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// TSModule wraps a module (declarations file) defined in the graph.
+type TSModule struct {
+	compilergraph.GraphNode
+	tsg *TSGraph // The parent graph.
+}
+
+// GetModules returns all the modules defined in the graph.
+func (g *TSGraph) GetModules() []TSModule {
+	it := g.findAllNodes(parser.NodeTypeFile).BuildNodeIterator()
+	var modules []TSModule
+
+	for it.Next() {
+		modules = append(modules, TSModule{it.Node(), g})
+	}
+
+	return modules
+}
+
+// InputSource returns the input source for this module.
+func (m TSModule) InputSource() compilercommon.InputSource {
+	return compilercommon.InputSource(m.GraphNode.Get(parser.NodePredicateSource))
+}
+
+// Name returns the name of the module.
+func (m TSModule) Name() string {
+	return path.Base(string(m.InputSource()))
+}
+
+// Node returns the underlying node.
+func (m TSModule) Node() compilergraph.GraphNode {
+	return m.GraphNode
+}
+
+// Declarations returns the declarations directly under the module.
+func (m TSModule) Declarations() []TSDeclaration {
+	it := m.GraphNode.StartQuery().
+		Out(parser.NodePredicateChild).
+		IsKind(parser.NodeTypeDeclaration).
+		BuildNodeIterator()
+
+	var decls []TSDeclaration
+	for it.Next() {
+		decls = append(decls, TSDeclaration{it.Node(), m.tsg})
+	}
+
+	return decls
+}
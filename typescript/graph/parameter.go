@@ -0,0 +1,58 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// TSParameter wraps a TypeScript parameter.
+type TSParameter struct {
+	compilergraph.GraphNode
+	tsg *TSGraph // The parent graph.
+}
+
+// parameters returns the parameters connected to the given node via the given predicate.
+func (g *TSGraph) parameters(node compilergraph.GraphNode, predicate compilergraph.Predicate) []TSParameter {
+	pit := node.StartQuery().
+		Out(predicate).
+		BuildNodeIterator()
+
+	var parameters = make([]TSParameter, 0)
+	for pit.Next() {
+		parameters = append(parameters, TSParameter{pit.Node(), g})
+	}
+
+	return parameters
+}
+
+// Name returns the name of the parameter.
+func (p *TSParameter) Name() string {
+	return p.GraphNode.Get(parser.NodePredicateParameterName)
+}
+
+// IsOptional returns true if this parameter is optional.
+func (p *TSParameter) IsOptional() bool {
+	_, isOptional := p.GraphNode.TryGet(parser.NodePredicateParameterOptional)
+	return isOptional
+}
+
+// IsRest returns true if this parameter is a rest parameter.
+func (p *TSParameter) IsRest() bool {
+	_, isRest := p.GraphNode.TryGet(parser.NodePredicateParameterRest)
+	return isRest
+}
+
+// DeclaredType returns the declared type of the parameter, if any.
+func (p *TSParameter) DeclaredType() (TSTypeRef, bool) {
+	return p.tsg.typeRef(p.GraphNode, parser.NodePredicateParameterType)
+}
+
+// SourceRange returns the source range of the parameter in source.
+func (p *TSParameter) SourceRange() (compilercommon.SourceRange, bool) {
+	return p.tsg.SourceRangeOf(p.GraphNode)
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// tsSourceHandler implements the SourceHandler interface from the packageloader for
+// populating the TypeScript graph from declaration files.
+type tsSourceHandler struct {
+	tsg *TSGraph // The graph being populated.
+}
+
+func (sh tsSourceHandler) Kind() string {
+	return "typescript"
+}
+
+func (sh tsSourceHandler) PackageFileExtension() string {
+	return ".d.ts"
+}
+
+func (sh tsSourceHandler) NewParser() packageloader.SourceHandlerParser {
+	return tsSourceHandlerParser{sh.tsg, sh.tsg.layer.NewModifier()}
+}
+
+type tsSourceHandlerParser struct {
+	tsg      *TSGraph // The graph being populated.
+	modifier compilergraph.GraphLayerModifier
+}
+
+func (sh tsSourceHandlerParser) Parse(source compilercommon.InputSource, input string, importHandler packageloader.ImportHandler) {
+	rootNode := sh.modifier.Modify(sh.tsg.rootModuleNode)
+	parser.Parse(&tsASTNode{rootNode}, sh.buildASTNode, source, input)
+}
+
+func (sh tsSourceHandlerParser) Apply(packageMap packageloader.LoadedPackageMap, sourceTracker packageloader.SourceTracker, cancelationHandle compilerutil.CancelationHandle) {
+	// Apply the changes to the graph.
+	sh.modifier.ApplyOrClose(!cancelationHandle.WasCanceled())
+	if cancelationHandle.WasCanceled() {
+		return
+	}
+
+	sh.tsg.sourceTracker = sourceTracker
+
+	// Only mark the graph as valid if we didn't encounter any errors.
+	sh.tsg.isValid = !sh.tsg.findAllNodes(parser.NodeTypeError).BuildNodeIterator().Next()
+}
+
+func (sh tsSourceHandlerParser) Cancel() {
+	sh.modifier.Close()
+}
+
+func (sh tsSourceHandlerParser) Verify(errorReporter packageloader.ErrorReporter, warningReporter packageloader.WarningReporter, cancelationHandle compilerutil.CancelationHandle) {
+	g := sh.tsg
+
+	// Collect any parse errors found and add them to the result.
+	eit := g.findAllNodes(parser.NodeTypeError).BuildNodeIterator(
+		parser.NodePredicateErrorMessage)
+
+	for eit.Next() {
+		if cancelationHandle.WasCanceled() {
+			return
+		}
+
+		sourceRange, hasSourceRange := g.SourceRangeOf(eit.Node())
+		if !hasSourceRange {
+			continue
+		}
+
+		errorReporter(compilercommon.NewSourceError(sourceRange, eit.GetPredicate(parser.NodePredicateErrorMessage).String()))
+	}
+}
+
+// buildASTNode constructs a new node in the graph.
+func (sh tsSourceHandlerParser) buildASTNode(source compilercommon.InputSource, kind parser.NodeType) parser.AstNode {
+	graphNode := sh.modifier.CreateNode(kind)
+	return &tsASTNode{
+		graphNode: graphNode,
+	}
+}
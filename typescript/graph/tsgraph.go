@@ -0,0 +1,109 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package graph defines the graph for the TypeScript declarations integration.
+package graph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// TSGraph defines a graph for the supported subset of TypeScript declarations.
+type TSGraph struct {
+	graph compilergraph.SerulianGraph // The root graph.
+
+	layer          compilergraph.GraphLayer // The TypeScript layer in the graph.
+	rootModuleNode compilergraph.GraphNode  // The root module node.
+
+	sourceTracker packageloader.SourceTracker // The source tracker.
+	isValid       bool                        // Whether the graph was loaded without parse errors.
+}
+
+// NewTSGraph returns a new TypeScript graph for populating the graph with parsed source.
+func NewTSGraph(graph compilergraph.SerulianGraph) *TSGraph {
+	tsg := &TSGraph{
+		graph: graph,
+		layer: graph.NewGraphLayer("tsg", parser.NodeTypeTagged),
+	}
+
+	modifier := tsg.layer.NewModifier()
+	defer modifier.Apply()
+
+	tsg.rootModuleNode = modifier.CreateNode(parser.NodeTypeGlobalModule).AsNode()
+	return tsg
+}
+
+// GetUniqueId returns a unique hash ID for the TypeScript node that is stable across compilations.
+func GetUniqueId(tsNode compilergraph.GraphNode) string {
+	hashBytes := []byte(tsNode.Get(parser.NodePredicateSource) + ":" + strconv.Itoa(tsNode.GetValue(parser.NodePredicateStartRune).Int()))
+	sha256bytes := sha256.Sum256(hashBytes)
+	return hex.EncodeToString(sha256bytes[:])[0:8]
+}
+
+// IsValid returns whether the graph was loaded without any parse errors. Will be false until
+// after source has been loaded into the graph.
+func (g *TSGraph) IsValid() bool {
+	return g.isValid
+}
+
+// RootModuleNode returns the node for the root module containing all the files.
+func (g *TSGraph) RootModuleNode() compilergraph.GraphNode {
+	return g.rootModuleNode
+}
+
+// SourceHandler returns a SourceHandler for populating the graph via a package loader.
+func (g *TSGraph) SourceHandler() packageloader.SourceHandler {
+	return tsSourceHandler{g}
+}
+
+// findAllNodes starts a new query over the graph from nodes of the given type.
+func (g *TSGraph) findAllNodes(nodeTypes ...parser.NodeType) compilergraph.GraphQuery {
+	var nodeTypesTagged []compilergraph.TaggedValue = make([]compilergraph.TaggedValue, len(nodeTypes))
+	for index, nodeType := range nodeTypes {
+		nodeTypesTagged[index] = nodeType
+	}
+
+	return g.layer.FindNodesOfKind(nodeTypesTagged...)
+}
+
+// GetNode returns the node with the given ID in this layer or panics.
+func (g *TSGraph) GetNode(nodeId compilergraph.GraphNodeId) compilergraph.GraphNode {
+	return g.layer.GetNode(nodeId)
+}
+
+// TryGetNode attempts to return the node with the given ID in this layer, if any.
+func (g *TSGraph) TryGetNode(nodeId compilergraph.GraphNodeId) (compilergraph.GraphNode, bool) {
+	return g.layer.TryGetNode(nodeId)
+}
+
+// SourceRangesOf returns the source ranges of the given TypeScript node.
+func (g *TSGraph) SourceRangesOf(node compilergraph.GraphNode) []compilercommon.SourceRange {
+	sourceRange, hasSourceRange := g.SourceRangeOf(node)
+	if !hasSourceRange {
+		return []compilercommon.SourceRange{}
+	}
+
+	return []compilercommon.SourceRange{sourceRange}
+}
+
+// SourceRangeOf returns the source range of the given TypeScript node.
+func (g *TSGraph) SourceRangeOf(node compilergraph.GraphNode) (compilercommon.SourceRange, bool) {
+	startRune, hasStartRune := node.TryGetValue(parser.NodePredicateStartRune)
+	endRune, hasEndRune := node.TryGetValue(parser.NodePredicateEndRune)
+	sourcePath, hasSource := node.TryGet(parser.NodePredicateSource)
+
+	if !hasStartRune || !hasEndRune || !hasSource {
+		return nil, false
+	}
+
+	source := compilercommon.InputSource(sourcePath)
+	return source.RangeForRunePositions(startRune.Int(), endRune.Int(), g.sourceTracker), true
+}
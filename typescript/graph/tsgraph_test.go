@@ -0,0 +1,145 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/packageloader"
+	"github.com/serulian/compiler/typescript/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = fmt.Printf
+
+func getTSGraph(t *testing.T, path string) *TSGraph {
+	graph, err := compilergraph.NewGraph(path)
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+
+	testTSG := NewTSGraph(graph)
+	loader := packageloader.NewPackageLoader(packageloader.NewBasicConfig(graph.RootSourceFilePath(), testTSG.SourceHandler()))
+	result := loader.Load()
+	if !result.Status {
+		t.Errorf("Failed to load TypeScript graph: %v", result.Errors)
+	}
+
+	return testTSG
+}
+
+func findMember(declaration TSDeclaration, name string) (TSMember, bool) {
+	for _, member := range declaration.Members() {
+		if memberName, hasName := member.Name(); hasName && memberName == name {
+			return member, true
+		}
+	}
+
+	return TSMember{}, false
+}
+
+func TestBasicLoading(t *testing.T) {
+	testTSG := getTSGraph(t, "../tests/basic.d.ts")
+	decl := testTSG.Declarations()
+
+	if !assert.Equal(t, 1, len(decl), "Expected 1 declaration") {
+		return
+	}
+
+	if !assert.True(t, testTSG.IsValid()) {
+		return
+	}
+
+	someInterface, hasSomeInterface := testTSG.FindDeclaration("SomeInterface")
+	if !assert.True(t, hasSomeInterface, "Missing SomeInterface") {
+		return
+	}
+
+	if !assert.Equal(t, parser.InterfaceDeclaration, someInterface.Kind()) {
+		return
+	}
+
+	documentation, hasDocumentation := someInterface.Documentation()
+	if !assert.True(t, hasDocumentation, "Missing documentation") {
+		return
+	}
+
+	if !assert.Equal(t, "Some interface.", documentation) {
+		return
+	}
+
+	members := someInterface.Members()
+	if !assert.Equal(t, 2, len(members), "Expected 2 members") {
+		return
+	}
+
+	coolthing, hasCoolThing := findMember(someInterface, "coolThing")
+	if !assert.True(t, hasCoolThing, "Missing coolThing") {
+		return
+	}
+
+	if !assert.Equal(t, parser.PropertyMember, coolthing.Kind()) {
+		return
+	}
+
+	if !assert.True(t, coolthing.IsReadonly()) {
+		return
+	}
+
+	if !assert.False(t, coolthing.IsStatic()) {
+		return
+	}
+
+	coolType, hasCoolType := coolthing.DeclaredType()
+	if !assert.True(t, hasCoolType, "Missing type on coolThing") {
+		return
+	}
+
+	coolTypeName, _ := coolType.Name()
+	if !assert.Equal(t, "object", coolTypeName) {
+		return
+	}
+
+	anotherthing, hasAnotherThing := findMember(someInterface, "anotherThing")
+	if !assert.True(t, hasAnotherThing, "Missing anotherThing") {
+		return
+	}
+
+	if !assert.Equal(t, parser.MethodMember, anotherthing.Kind()) {
+		return
+	}
+
+	parameters := anotherthing.Parameters()
+	if !assert.Equal(t, 1, len(parameters)) {
+		return
+	}
+
+	if !assert.Equal(t, "someparam", parameters[0].Name()) {
+		return
+	}
+
+	if !assert.False(t, parameters[0].IsOptional()) {
+		return
+	}
+
+	if !assert.False(t, anotherthing.IsReadonly()) {
+		return
+	}
+}
+
+func TestParsingIssue(t *testing.T) {
+	graph, err := compilergraph.NewGraph("../tests/parseissue.d.ts")
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+
+	testTSG := NewTSGraph(graph)
+	loader := packageloader.NewPackageLoader(packageloader.NewBasicConfig(graph.RootSourceFilePath(), testTSG.SourceHandler()))
+	result := loader.Load()
+	assert.False(t, result.Status, "Expected parsing issue")
+	assert.False(t, testTSG.IsValid(), "Expected invalid graph")
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graph
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// TSTypeRef wraps a type reference in a TypeScript declaration.
+type TSTypeRef struct {
+	compilergraph.GraphNode
+	tsg *TSGraph // The parent graph.
+}
+
+// typeRef returns the type reference connected to the given node via the given predicate, if any.
+func (g *TSGraph) typeRef(node compilergraph.GraphNode, predicate compilergraph.Predicate) (TSTypeRef, bool) {
+	typeRefNode, hasTypeRef := node.TryGetNode(predicate)
+	if !hasTypeRef {
+		return TSTypeRef{}, false
+	}
+
+	return TSTypeRef{typeRefNode, g}, true
+}
+
+// typeRefs returns the type references connected to the given node via the given predicate.
+func (g *TSGraph) typeRefs(node compilergraph.GraphNode, predicate compilergraph.Predicate) []TSTypeRef {
+	tit := node.StartQuery().
+		Out(predicate).
+		BuildNodeIterator()
+
+	var typeRefs = make([]TSTypeRef, 0)
+	for tit.Next() {
+		typeRefs = append(typeRefs, TSTypeRef{tit.Node(), g})
+	}
+
+	return typeRefs
+}
+
+// Kind returns the kind of the type reference.
+func (t TSTypeRef) Kind() parser.TypeRefKind {
+	return parser.TypeRefKind(t.GraphNode.Get(parser.NodePredicateTypeRefKind))
+}
+
+// Name returns the name of a named type reference or the value of a literal type reference.
+func (t TSTypeRef) Name() (string, bool) {
+	return t.GraphNode.TryGet(parser.NodePredicateTypeRefName)
+}
+
+// Generics returns the generic arguments of a named type reference.
+func (t TSTypeRef) Generics() []TSTypeRef {
+	return t.tsg.typeRefs(t.GraphNode, parser.NodePredicateTypeRefGeneric)
+}
+
+// Elements returns the element types of an array, union, intersection or tuple type reference.
+func (t TSTypeRef) Elements() []TSTypeRef {
+	return t.tsg.typeRefs(t.GraphNode, parser.NodePredicateTypeRefElement)
+}
+
+// Parameters returns the parameters of a function type reference.
+func (t TSTypeRef) Parameters() []TSParameter {
+	return t.tsg.parameters(t.GraphNode, parser.NodePredicateTypeRefParameter)
+}
+
+// ReturnType returns the return type of a function type reference.
+func (t TSTypeRef) ReturnType() (TSTypeRef, bool) {
+	return t.tsg.typeRef(t.GraphNode, parser.NodePredicateTypeRefReturn)
+}
+
+// SourceRange returns the source range of the type reference in source.
+func (t TSTypeRef) SourceRange() (compilercommon.SourceRange, bool) {
+	return t.tsg.SourceRangeOf(t.GraphNode)
+}
@@ -0,0 +1,281 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Based on design first introduced in: http://blog.golang.org/two-go-talks-lexical-scanning-in-go-and
+// Portions copied and modified from: https://github.com/golang/go/blob/master/src/text/template/parse/lex.go
+
+//go:generate stringer -type=tokenType
+//go:generate gengen github.com/serulian/compiler/genericparser tokenType NodeType
+
+package parser
+
+import (
+	"unicode"
+
+	"github.com/serulian/compiler/compilercommon"
+)
+
+// lex creates a new scanner for the input string.
+func lex(source compilercommon.InputSource, input string) *lexer {
+	return buildlex(source, input, performLexSource, isWhitespaceToken, tokenTypeError, tokenTypeNumber)
+}
+
+// tokenType identifies the type of lexer lexemes.
+type tokenType int
+
+const (
+	tokenTypeError tokenType = iota // error occurred; value is text of error
+	tokenTypeEOF
+	tokenTypeWhitespace
+	tokenTypeSinglelineComment
+	tokenTypeMultilineComment
+
+	tokenTypeKeyword    // interface
+	tokenTypeIdentifier // helloworld
+	tokenTypeNumber     // 123
+	tokenTypeString     // 'hello' or "hello"
+
+	tokenTypeLeftBrace    // {
+	tokenTypeRightBrace   // }
+	tokenTypeLeftParen    // (
+	tokenTypeRightParen   // )
+	tokenTypeLeftBracket  // [
+	tokenTypeRightBracket // ]
+	tokenTypeLessThan     // <
+	tokenTypeGreaterThan  // >
+
+	tokenTypeEquals       // =
+	tokenTypeArrow        // =>
+	tokenTypeSemicolon    // ;
+	tokenTypeComma        // ,
+	tokenTypeQuestionMark // ?
+	tokenTypeColon        // :
+	tokenTypePipe         // |
+	tokenTypeAmpersand    // &
+	tokenTypeDot          // .
+	tokenTypeEllipsis     // ...
+)
+
+// keywords contains the full set of keywords supported. Note that TypeScript allows most
+// keywords to be used as member and parameter names, so the parser accepts keywords in those
+// positions as well.
+var keywords = map[string]bool{
+	"interface":  true,
+	"class":      true,
+	"function":   true,
+	"type":       true,
+	"var":        true,
+	"let":        true,
+	"const":      true,
+	"declare":    true,
+	"export":     true,
+	"extends":    true,
+	"implements": true,
+	"readonly":   true,
+	"static":     true,
+	"abstract":   true,
+	"public":     true,
+	"private":    true,
+	"protected":  true,
+	"new":        true,
+	"typeof":     true,
+	"keyof":      true,
+	"module":     true,
+	"namespace":  true,
+	"import":     true,
+	"default":    true,
+}
+
+func isWhitespaceToken(kind tokenType) bool {
+	return kind == tokenTypeWhitespace
+}
+
+// performLexSource scans until EOFRUNE
+func performLexSource(l *lexer) stateFn {
+Loop:
+	for {
+		switch r := l.next(); {
+		case r == EOFRUNE:
+			break Loop
+
+		case r == '{':
+			l.emit(tokenTypeLeftBrace)
+
+		case r == '}':
+			l.emit(tokenTypeRightBrace)
+
+		case r == '(':
+			l.emit(tokenTypeLeftParen)
+
+		case r == ')':
+			l.emit(tokenTypeRightParen)
+
+		case r == '[':
+			l.emit(tokenTypeLeftBracket)
+
+		case r == ']':
+			l.emit(tokenTypeRightBracket)
+
+		case r == '<':
+			l.emit(tokenTypeLessThan)
+
+		case r == '>':
+			l.emit(tokenTypeGreaterThan)
+
+		case r == ';':
+			l.emit(tokenTypeSemicolon)
+
+		case r == ',':
+			l.emit(tokenTypeComma)
+
+		case r == '?':
+			l.emit(tokenTypeQuestionMark)
+
+		case r == ':':
+			l.emit(tokenTypeColon)
+
+		case r == '|':
+			l.emit(tokenTypePipe)
+
+		case r == '&':
+			l.emit(tokenTypeAmpersand)
+
+		case r == '=':
+			if l.peek() == '>' {
+				l.next()
+				l.emit(tokenTypeArrow)
+			} else {
+				l.emit(tokenTypeEquals)
+			}
+
+		case r == '.':
+			if l.peekValue("..") {
+				l.acceptString("..")
+				l.emit(tokenTypeEllipsis)
+			} else {
+				l.emit(tokenTypeDot)
+			}
+
+		case r == '\'':
+			return buildLexStringLiteral('\'')
+
+		case r == '"':
+			return buildLexStringLiteral('"')
+
+		case r == '-' || unicode.IsDigit(r):
+			l.backup()
+			return lexNumber
+
+		case isSpace(r) || isNewline(r):
+			l.emit(tokenTypeWhitespace)
+
+		case isIdentifierRune(r):
+			l.backup()
+			return lexIdentifierOrKeyword
+
+		case r == '/':
+			if l.peekValue("/") {
+				l.backup()
+				return lexSinglelineComment
+			}
+
+			if l.peekValue("*") {
+				l.backup()
+				return lexMultilineComment
+			}
+
+			return l.errorf("unrecognized character at this location: %#U", r)
+
+		default:
+			return l.errorf("unrecognized character at this location: %#U", r)
+		}
+	}
+
+	l.emit(tokenTypeEOF)
+	return nil
+}
+
+// lexSinglelineComment scans until newline or EOFRUNE
+func lexSinglelineComment(l *lexer) stateFn {
+	checker := func(r rune) (bool, error) {
+		result := r == EOFRUNE || isNewline(r)
+		return !result, nil
+	}
+
+	l.acceptString("//")
+	return buildLexUntil(tokenTypeSinglelineComment, checker)
+}
+
+// lexMultilineComment scans until the close of the multiline comment or EOFRUNE
+func lexMultilineComment(l *lexer) stateFn {
+	l.acceptString("/*")
+	for {
+		// Check for the end of the multiline comment.
+		if l.peekValue("*/") {
+			l.acceptString("*/")
+			l.emit(tokenTypeMultilineComment)
+			return lexSource
+		}
+
+		// Otherwise, consume until we hit EOFRUNE.
+		r := l.next()
+		if r == EOFRUNE {
+			return l.errorf("Unterminated multiline comment")
+		}
+	}
+}
+
+// buildLexStringLiteral returns a state function for lexing a string literal terminated
+// by the given rune.
+func buildLexStringLiteral(terminationRune rune) stateFn {
+	return func(l *lexer) stateFn {
+		for {
+			r := l.next()
+			if r == EOFRUNE || isNewline(r) {
+				return l.errorf("Unterminated string literal")
+			}
+
+			if r == terminationRune {
+				break
+			}
+
+			if r == '\\' {
+				// Skip the next rune.
+				l.next()
+				continue
+			}
+		}
+
+		l.emit(tokenTypeString)
+		return lexSource
+	}
+}
+
+// lexIdentifierOrKeyword searches for a keyword or literal identifier.
+func lexIdentifierOrKeyword(l *lexer) stateFn {
+	for {
+		if !isIdentifierRune(l.peek()) {
+			break
+		}
+
+		l.next()
+	}
+
+	_, is_keyword := keywords[l.value()]
+
+	switch {
+	case is_keyword:
+		l.emit(tokenTypeKeyword)
+
+	default:
+		l.emit(tokenTypeIdentifier)
+	}
+
+	return lexSource
+}
+
+// isIdentifierRune reports whether r can appear in a TypeScript identifier.
+func isIdentifierRune(r rune) bool {
+	return r == '$' || isAlphaNumeric(r)
+}
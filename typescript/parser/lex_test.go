@@ -0,0 +1,116 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Portions copied and modified from: https://github.com/golang/go/blob/master/src/text/template/parse/lex_test.go
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/serulian/compiler/compilercommon"
+)
+
+type lexerTest struct {
+	name   string
+	input  string
+	tokens []lexeme
+}
+
+var (
+	tEOF        = lexeme{tokenTypeEOF, 0, ""}
+	tWhitespace = lexeme{tokenTypeWhitespace, 0, " "}
+)
+
+var lexerTests = []lexerTest{
+	// Simple tests.
+	{"empty", "", []lexeme{tEOF}},
+
+	{"single whitespace", " ", []lexeme{tWhitespace, tEOF}},
+	{"single tab", "\t", []lexeme{lexeme{tokenTypeWhitespace, 0, "\t"}, tEOF}},
+	{"newline n", "\n", []lexeme{lexeme{tokenTypeWhitespace, 0, "\n"}, tEOF}},
+
+	{"singleline comment", "// a comment", []lexeme{lexeme{tokenTypeSinglelineComment, 0, "// a comment"}, tEOF}},
+	{"multiline comment", "/** a\n comment */", []lexeme{lexeme{tokenTypeMultilineComment, 0, "/** a\n comment */"}, tEOF}},
+	{"unterminated multiline comment", "/* a", []lexeme{lexeme{tokenTypeError, 0, "Unterminated multiline comment"}}},
+
+	{"left brace", "{", []lexeme{lexeme{tokenTypeLeftBrace, 0, "{"}, tEOF}},
+	{"right brace", "}", []lexeme{lexeme{tokenTypeRightBrace, 0, "}"}, tEOF}},
+	{"left bracket", "[", []lexeme{lexeme{tokenTypeLeftBracket, 0, "["}, tEOF}},
+	{"right bracket", "]", []lexeme{lexeme{tokenTypeRightBracket, 0, "]"}, tEOF}},
+	{"left paren", "(", []lexeme{lexeme{tokenTypeLeftParen, 0, "("}, tEOF}},
+	{"right paren", ")", []lexeme{lexeme{tokenTypeRightParen, 0, ")"}, tEOF}},
+	{"less than", "<", []lexeme{lexeme{tokenTypeLessThan, 0, "<"}, tEOF}},
+	{"greater than", ">", []lexeme{lexeme{tokenTypeGreaterThan, 0, ">"}, tEOF}},
+
+	{"equals", "=", []lexeme{lexeme{tokenTypeEquals, 0, "="}, tEOF}},
+	{"arrow", "=>", []lexeme{lexeme{tokenTypeArrow, 0, "=>"}, tEOF}},
+	{"semicolon", ";", []lexeme{lexeme{tokenTypeSemicolon, 0, ";"}, tEOF}},
+	{"comma", ",", []lexeme{lexeme{tokenTypeComma, 0, ","}, tEOF}},
+	{"question mark", "?", []lexeme{lexeme{tokenTypeQuestionMark, 0, "?"}, tEOF}},
+	{"colon", ":", []lexeme{lexeme{tokenTypeColon, 0, ":"}, tEOF}},
+	{"pipe", "|", []lexeme{lexeme{tokenTypePipe, 0, "|"}, tEOF}},
+	{"ampersand", "&", []lexeme{lexeme{tokenTypeAmpersand, 0, "&"}, tEOF}},
+	{"dot", ".", []lexeme{lexeme{tokenTypeDot, 0, "."}, tEOF}},
+	{"ellipsis", "...", []lexeme{lexeme{tokenTypeEllipsis, 0, "..."}, tEOF}},
+
+	{"number", "42", []lexeme{lexeme{tokenTypeNumber, 0, "42"}, tEOF}},
+	{"negative number", "-1", []lexeme{lexeme{tokenTypeNumber, 0, "-1"}, tEOF}},
+	{"single quoted string", "'hello'", []lexeme{lexeme{tokenTypeString, 0, "'hello'"}, tEOF}},
+	{"double quoted string", "\"hel\\\"lo\"", []lexeme{lexeme{tokenTypeString, 0, "\"hel\\\"lo\""}, tEOF}},
+	{"unterminated string", "'hello", []lexeme{lexeme{tokenTypeError, 0, "Unterminated string literal"}}},
+
+	{"keyword", "interface", []lexeme{lexeme{tokenTypeKeyword, 0, "interface"}, tEOF}},
+	{"identifier", "interace", []lexeme{lexeme{tokenTypeIdentifier, 0, "interace"}, tEOF}},
+	{"dollar identifier", "$foo_bar", []lexeme{lexeme{tokenTypeIdentifier, 0, "$foo_bar"}, tEOF}},
+
+	{"optional member", "foo?: string", []lexeme{
+		lexeme{tokenTypeIdentifier, 0, "foo"},
+		lexeme{tokenTypeQuestionMark, 0, "?"},
+		lexeme{tokenTypeColon, 0, ":"},
+		tWhitespace,
+		lexeme{tokenTypeIdentifier, 0, "string"},
+		tEOF}},
+}
+
+func TestLexer(t *testing.T) {
+	for _, test := range lexerTests {
+		tokens := collect(&test)
+		if !equal(tokens, test.tokens, false) {
+			t.Errorf("%s: got\n\t%+v\nexpected\n\t%v", test.name, tokens, test.tokens)
+		}
+	}
+}
+
+// collect gathers the emitted tokens into a slice.
+func collect(t *lexerTest) (tokens []lexeme) {
+	l := lex(compilercommon.InputSource(t.name), t.input)
+	for {
+		token := l.nextToken()
+		tokens = append(tokens, token)
+		if token.kind == tokenTypeEOF || token.kind == tokenTypeError {
+			break
+		}
+	}
+	return
+}
+
+// equal checks that the two sets of tokens are structurally equal
+func equal(i1, i2 []lexeme, checkPos bool) bool {
+	if len(i1) != len(i2) {
+		return false
+	}
+	for k := range i1 {
+		if i1[k].kind != i2[k].kind {
+			return false
+		}
+		if i1[k].value != i2[k].value {
+			return false
+		}
+		if checkPos && i1[k].position != i2[k].position {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// parser package defines the parser and lexer for translating a *supported subset* of
+// TypeScript declaration files (`.d.ts`) into an AST.
+package parser
+
+// tryConsumeIdentifier attempts to consume an expected identifier.
+func (p *sourceParser) tryConsumeIdentifier() (string, bool) {
+	if !p.isToken(tokenTypeIdentifier) {
+		return "", false
+	}
+
+	value := p.currentToken.value
+	p.consumeToken()
+	return value, true
+}
+
+// consumeIdentifier consumes an expected identifier token or adds an error node.
+func (p *sourceParser) consumeIdentifier() (string, bool) {
+	if identifier, ok := p.tryConsumeIdentifier(); ok {
+		return identifier, true
+	}
+
+	p.emitError("Expected identifier, found token %v", p.currentToken.kind)
+	return "", false
+}
+
+// consumeName consumes an expected identifier or keyword token or adds an error node. Used
+// for member and parameter names, which in TypeScript can be keywords.
+func (p *sourceParser) consumeName() (string, bool) {
+	if p.isToken(tokenTypeKeyword) {
+		value := p.currentToken.value
+		p.consumeToken()
+		return value, true
+	}
+
+	return p.consumeIdentifier()
+}
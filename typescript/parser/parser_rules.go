@@ -0,0 +1,942 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package parser
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+)
+
+// Parse parses the given TypeScript declarations source into a parse tree.
+func Parse(moduleNode AstNode, builder NodeBuilder, source compilercommon.InputSource, input string) AstNode {
+	lexer := lex(source, input)
+
+	config := parserConfig{
+		ignoredTokenTypes: map[tokenType]bool{
+			tokenTypeWhitespace:        true,
+			tokenTypeSinglelineComment: true,
+			tokenTypeMultilineComment:  true,
+		},
+
+		childPredicate: NodePredicateChild,
+
+		sourcePredicate:    NodePredicateSource,
+		startRunePredicate: NodePredicateStartRune,
+		endRunePredicate:   NodePredicateEndRune,
+
+		errorNodeType:         NodeTypeError,
+		errorMessagePredicate: NodePredicateErrorMessage,
+
+		commentNodeType:           NodeTypeComment,
+		commentNodeValuePredicate: NodePredicateCommentValue,
+
+		isCommentToken: func(kind tokenType) bool {
+			return kind == tokenTypeSinglelineComment || kind == tokenTypeMultilineComment
+		},
+
+		keywordTokenType: tokenTypeKeyword,
+		errorTokenType:   tokenTypeError,
+		eofTokenType:     tokenTypeEOF,
+	}
+
+	parser := buildParser(lexer, builder, config, source, bytePosition(0), input)
+	return parser.consumeTopLevel(moduleNode)
+}
+
+// consumeTopLevel attempts to consume the top-level declarations of a TypeScript declarations file.
+func (p *sourceParser) consumeTopLevel(moduleNode AstNode) AstNode {
+	rootNode := p.startNode(NodeTypeFile)
+	defer p.finishNode()
+
+	moduleNode.Connect(NodePredicateChild, rootNode)
+
+	// Start at the first token.
+	p.consumeToken()
+
+	if p.currentToken.kind == tokenTypeError {
+		p.emitError("%s", p.currentToken.value)
+		return rootNode
+	}
+
+	for {
+		if p.isToken(tokenTypeEOF) {
+			break
+		}
+
+		// Stray semicolons are allowed between declarations.
+		if _, ok := p.tryConsume(tokenTypeSemicolon); ok {
+			continue
+		}
+
+		declNode, ok := p.consumeDeclaration()
+		rootNode.Connect(NodePredicateChild, declNode)
+		if !ok {
+			break
+		}
+	}
+
+	return rootNode
+}
+
+// consumeDeclaration attempts to consume a top-level declaration, with optional modifiers.
+func (p *sourceParser) consumeDeclaration() (AstNode, bool) {
+	declNode := p.startNode(NodeTypeDeclaration)
+	defer p.finishNode()
+
+	// The `export` and `declare` modifiers have no meaning for declarations files, so they are
+	// simply skipped.
+	p.tryConsumeKeyword("export")
+	p.tryConsumeKeyword("declare")
+
+	switch {
+	case p.isKeyword("interface"):
+		return declNode, p.consumeInterface(declNode)
+
+	case p.isKeyword("abstract") || p.isKeyword("class"):
+		return declNode, p.consumeClass(declNode)
+
+	case p.isKeyword("function"):
+		return declNode, p.consumeFunction(declNode)
+
+	case p.isKeyword("type"):
+		return declNode, p.consumeTypeAlias(declNode)
+
+	case p.isKeyword("var") || p.isKeyword("let") || p.isKeyword("const"):
+		return declNode, p.consumeVariable(declNode)
+
+	case p.isToken(tokenTypeError):
+		p.emitError("%s", p.currentToken.value)
+		return declNode, false
+
+	case p.isToken(tokenTypeKeyword):
+		p.emitError("Unsupported declaration kind: %s", p.currentToken.value)
+		return declNode, false
+
+	default:
+		p.emitError("Unexpected token at root level: %v", p.currentToken.kind)
+		return declNode, false
+	}
+}
+
+// consumeInterface consumes an interface declaration.
+//
+// interface Foo<T> extends Bar, Baz { ... }
+func (p *sourceParser) consumeInterface(declNode AstNode) bool {
+	if !p.consumeKeyword("interface") {
+		return false
+	}
+
+	declNode.Decorate(NodePredicateDeclarationKind, string(InterfaceDeclaration))
+	if !p.consumeDeclarationName(declNode) {
+		return false
+	}
+
+	if !p.tryConsumeGenerics(declNode, NodePredicateDeclarationGeneric) {
+		return false
+	}
+
+	if p.tryConsumeKeyword("extends") {
+		if !p.consumeTypeRefList(declNode, NodePredicateDeclarationExtends) {
+			return false
+		}
+	}
+
+	return p.consumeMembers(declNode)
+}
+
+// consumeClass consumes a class declaration.
+//
+// abstract class Foo<T> extends Bar implements Baz { ... }
+func (p *sourceParser) consumeClass(declNode AstNode) bool {
+	if p.tryConsumeKeyword("abstract") {
+		declNode.Decorate(NodePredicateDeclarationAbstract, "true")
+	}
+
+	if !p.consumeKeyword("class") {
+		return false
+	}
+
+	declNode.Decorate(NodePredicateDeclarationKind, string(ClassDeclaration))
+	if !p.consumeDeclarationName(declNode) {
+		return false
+	}
+
+	if !p.tryConsumeGenerics(declNode, NodePredicateDeclarationGeneric) {
+		return false
+	}
+
+	if p.tryConsumeKeyword("extends") {
+		typeRef, ok := p.consumeTypeRef()
+		declNode.Connect(NodePredicateDeclarationExtends, typeRef)
+		if !ok {
+			return false
+		}
+	}
+
+	if p.tryConsumeKeyword("implements") {
+		if !p.consumeTypeRefList(declNode, NodePredicateDeclarationImplements) {
+			return false
+		}
+	}
+
+	return p.consumeMembers(declNode)
+}
+
+// consumeFunction consumes a function declaration.
+//
+// function foo<T>(a: T, b?: string): T;
+func (p *sourceParser) consumeFunction(declNode AstNode) bool {
+	if !p.consumeKeyword("function") {
+		return false
+	}
+
+	declNode.Decorate(NodePredicateDeclarationKind, string(FunctionDeclaration))
+	if !p.consumeDeclarationName(declNode) {
+		return false
+	}
+
+	if !p.tryConsumeGenerics(declNode, NodePredicateDeclarationGeneric) {
+		return false
+	}
+
+	if !p.consumeParameters(declNode, NodePredicateDeclarationParameter) {
+		return false
+	}
+
+	if !p.tryConsumeTypeAnnotation(declNode, NodePredicateDeclarationType) {
+		return false
+	}
+
+	p.tryConsume(tokenTypeSemicolon)
+	return true
+}
+
+// consumeTypeAlias consumes a type alias declaration.
+//
+// type Foo<T> = Bar<T> | null;
+func (p *sourceParser) consumeTypeAlias(declNode AstNode) bool {
+	if !p.consumeKeyword("type") {
+		return false
+	}
+
+	declNode.Decorate(NodePredicateDeclarationKind, string(TypeAliasDeclaration))
+	if !p.consumeDeclarationName(declNode) {
+		return false
+	}
+
+	if !p.tryConsumeGenerics(declNode, NodePredicateDeclarationGeneric) {
+		return false
+	}
+
+	if _, ok := p.consume(tokenTypeEquals); !ok {
+		return false
+	}
+
+	typeRef, ok := p.consumeTypeRef()
+	declNode.Connect(NodePredicateDeclarationType, typeRef)
+	if !ok {
+		return false
+	}
+
+	p.tryConsume(tokenTypeSemicolon)
+	return true
+}
+
+// consumeVariable consumes a variable declaration.
+//
+// const foo: string;
+func (p *sourceParser) consumeVariable(declNode AstNode) bool {
+	if p.tryConsumeKeyword("const") {
+		declNode.Decorate(NodePredicateDeclarationReadonly, "true")
+	} else if !p.tryConsumeKeyword("var") && !p.consumeKeyword("let") {
+		return false
+	}
+
+	declNode.Decorate(NodePredicateDeclarationKind, string(VariableDeclaration))
+	if !p.consumeDeclarationName(declNode) {
+		return false
+	}
+
+	if !p.tryConsumeTypeAnnotation(declNode, NodePredicateDeclarationType) {
+		return false
+	}
+
+	p.tryConsume(tokenTypeSemicolon)
+	return true
+}
+
+// consumeDeclarationName consumes the name of a declaration and decorates the declaration with it.
+func (p *sourceParser) consumeDeclarationName(declNode AstNode) bool {
+	name, ok := p.consumeIdentifier()
+	if !ok {
+		return false
+	}
+
+	declNode.Decorate(NodePredicateDeclarationName, name)
+	return true
+}
+
+// consumeMembers consumes the body of an interface or class declaration.
+func (p *sourceParser) consumeMembers(declNode AstNode) bool {
+	// {
+	if _, ok := p.consume(tokenTypeLeftBrace); !ok {
+		return false
+	}
+
+	for {
+		// }
+		if _, ok := p.tryConsume(tokenTypeRightBrace); ok {
+			return true
+		}
+
+		// Members can be separated by either semicolons or commas.
+		if _, ok := p.tryConsume(tokenTypeSemicolon, tokenTypeComma); ok {
+			continue
+		}
+
+		memberNode, ok := p.consumeMember()
+		declNode.Connect(NodePredicateDeclarationMember, memberNode)
+		if !ok {
+			return false
+		}
+	}
+}
+
+// memberModifiers defines the keywords that can act as modifiers on members.
+var memberModifiers = map[string]bool{
+	"static":    true,
+	"readonly":  true,
+	"abstract":  true,
+	"declare":   true,
+	"public":    true,
+	"private":   true,
+	"protected": true,
+}
+
+// isMemberModifier returns whether the current token is a member modifier. As TypeScript
+// allows modifier keywords to be used as member names, the keyword is only considered a
+// modifier if it is followed by another name or a bracket.
+func (p *sourceParser) isMemberModifier() bool {
+	if !p.isToken(tokenTypeKeyword) || !memberModifiers[p.currentToken.value] {
+		return false
+	}
+
+	return p.isNextToken(tokenTypeIdentifier, tokenTypeKeyword, tokenTypeString, tokenTypeNumber, tokenTypeLeftBracket)
+}
+
+// consumeMember attempts to consume a member definition in a declaration.
+func (p *sourceParser) consumeMember() (AstNode, bool) {
+	memberNode := p.startNode(NodeTypeMember)
+	defer p.finishNode()
+
+	// Modifiers.
+	for p.isMemberModifier() {
+		modifier := p.currentToken.value
+		p.consumeToken()
+
+		switch modifier {
+		case "static":
+			memberNode.Decorate(NodePredicateMemberStatic, "true")
+
+		case "readonly":
+			memberNode.Decorate(NodePredicateMemberReadonly, "true")
+
+		case "private", "protected":
+			memberNode.Decorate(NodePredicateMemberHidden, "true")
+		}
+	}
+
+	// Construct signatures: new (...): T
+	if p.isKeyword("new") && p.isNextToken(tokenTypeLeftParen, tokenTypeLessThan) {
+		p.consumeToken()
+		memberNode.Decorate(NodePredicateMemberKind, string(ConstructorMember))
+		return memberNode, p.consumeSignature(memberNode)
+	}
+
+	// Call signatures: (...): T. Since these have no name, they are marked as computed.
+	if p.isToken(tokenTypeLeftParen, tokenTypeLessThan) {
+		memberNode.Decorate(NodePredicateMemberKind, string(MethodMember))
+		memberNode.Decorate(NodePredicateMemberComputed, "true")
+		return memberNode, p.consumeSignature(memberNode)
+	}
+
+	// Index signatures ([key: string]: T) and computed names ([Symbol.iterator]).
+	if p.isToken(tokenTypeLeftBracket) {
+		if p.isIndexSignature() {
+			return memberNode, p.consumeIndexSignature(memberNode)
+		}
+
+		if !p.consumeComputedName() {
+			return memberNode, false
+		}
+
+		memberNode.Decorate(NodePredicateMemberComputed, "true")
+	} else {
+		name, ok := p.consumeMemberName()
+		if !ok {
+			return memberNode, false
+		}
+
+		if name == "constructor" && p.isToken(tokenTypeLeftParen) {
+			memberNode.Decorate(NodePredicateMemberKind, string(ConstructorMember))
+			return memberNode, p.consumeSignature(memberNode)
+		}
+
+		memberNode.Decorate(NodePredicateMemberName, name)
+	}
+
+	// ?
+	if _, ok := p.tryConsume(tokenTypeQuestionMark); ok {
+		memberNode.Decorate(NodePredicateMemberOptional, "true")
+	}
+
+	// Methods.
+	if p.isToken(tokenTypeLeftParen, tokenTypeLessThan) {
+		memberNode.Decorate(NodePredicateMemberKind, string(MethodMember))
+		return memberNode, p.consumeSignature(memberNode)
+	}
+
+	// Properties.
+	memberNode.Decorate(NodePredicateMemberKind, string(PropertyMember))
+	return memberNode, p.tryConsumeTypeAnnotation(memberNode, NodePredicateMemberType)
+}
+
+// consumeMemberName consumes the name of a member, which can be an identifier, a keyword or a
+// string literal.
+func (p *sourceParser) consumeMemberName() (string, bool) {
+	if token, ok := p.tryConsume(tokenTypeString); ok {
+		return token.value[1 : len(token.value)-1], true
+	}
+
+	return p.consumeName()
+}
+
+// consumeSignature consumes the generics, parameters and (optional) return type of a method,
+// constructor or call signature.
+func (p *sourceParser) consumeSignature(memberNode AstNode) bool {
+	if !p.tryConsumeGenerics(memberNode, NodePredicateMemberGeneric) {
+		return false
+	}
+
+	if !p.consumeParameters(memberNode, NodePredicateMemberParameter) {
+		return false
+	}
+
+	return p.tryConsumeTypeAnnotation(memberNode, NodePredicateMemberType)
+}
+
+// isIndexSignature returns whether the current bracket starts an index signature.
+func (p *sourceParser) isIndexSignature() bool {
+	t := p.newLookaheadTracker()
+	t.nextToken()
+
+	if _, ok := t.matchToken(tokenTypeIdentifier, tokenTypeKeyword); !ok {
+		return false
+	}
+
+	_, ok := t.matchToken(tokenTypeColon)
+	return ok
+}
+
+// consumeIndexSignature consumes an index signature.
+//
+// [key: string]: T
+func (p *sourceParser) consumeIndexSignature(memberNode AstNode) bool {
+	memberNode.Decorate(NodePredicateMemberKind, string(IndexMember))
+
+	// [
+	if _, ok := p.consume(tokenTypeLeftBracket); !ok {
+		return false
+	}
+
+	paramNode, ok := p.consumeParameter()
+	memberNode.Connect(NodePredicateMemberParameter, paramNode)
+	if !ok {
+		return false
+	}
+
+	// ]
+	if _, ok := p.consume(tokenTypeRightBracket); !ok {
+		return false
+	}
+
+	return p.tryConsumeTypeAnnotation(memberNode, NodePredicateMemberType)
+}
+
+// consumeComputedName consumes a computed member name, such as `[Symbol.iterator]`.
+func (p *sourceParser) consumeComputedName() bool {
+	// [
+	if _, ok := p.consume(tokenTypeLeftBracket); !ok {
+		return false
+	}
+
+	for {
+		if p.isToken(tokenTypeEOF, tokenTypeError) {
+			p.emitError("Unterminated computed member name")
+			return false
+		}
+
+		// ]
+		if _, ok := p.tryConsume(tokenTypeRightBracket); ok {
+			return true
+		}
+
+		p.consumeToken()
+	}
+}
+
+// consumeParameters attempts to consume a set of parameters.
+func (p *sourceParser) consumeParameters(parentNode AstNode, predicate string) bool {
+	// (
+	if _, ok := p.consume(tokenTypeLeftParen); !ok {
+		return false
+	}
+
+	for {
+		// )
+		if _, ok := p.tryConsume(tokenTypeRightParen); ok {
+			return true
+		}
+
+		paramNode, ok := p.consumeParameter()
+		parentNode.Connect(predicate, paramNode)
+		if !ok {
+			return false
+		}
+
+		if _, ok := p.tryConsume(tokenTypeComma); !ok {
+			_, ok := p.consume(tokenTypeRightParen)
+			return ok
+		}
+	}
+}
+
+// consumeParameter attempts to consume a parameter.
+//
+// ...name?: T
+func (p *sourceParser) consumeParameter() (AstNode, bool) {
+	paramNode := p.startNode(NodeTypeParameter)
+	defer p.finishNode()
+
+	// ...
+	if _, ok := p.tryConsume(tokenTypeEllipsis); ok {
+		paramNode.Decorate(NodePredicateParameterRest, "true")
+	}
+
+	name, ok := p.consumeName()
+	if !ok {
+		return paramNode, false
+	}
+
+	paramNode.Decorate(NodePredicateParameterName, name)
+
+	// ?
+	if _, ok := p.tryConsume(tokenTypeQuestionMark); ok {
+		paramNode.Decorate(NodePredicateParameterOptional, "true")
+	}
+
+	return paramNode, p.tryConsumeTypeAnnotation(paramNode, NodePredicateParameterType)
+}
+
+// tryConsumeGenerics attempts to consume a set of generics, if any.
+//
+// <T extends Foo = Bar, U>
+func (p *sourceParser) tryConsumeGenerics(parentNode AstNode, predicate string) bool {
+	// <
+	if _, ok := p.tryConsume(tokenTypeLessThan); !ok {
+		return true
+	}
+
+	for {
+		genericNode, ok := p.consumeGeneric()
+		parentNode.Connect(predicate, genericNode)
+		if !ok {
+			return false
+		}
+
+		if _, ok := p.tryConsume(tokenTypeComma); !ok {
+			break
+		}
+	}
+
+	// >
+	_, ok := p.consume(tokenTypeGreaterThan)
+	return ok
+}
+
+// consumeGeneric consumes a single generic definition.
+func (p *sourceParser) consumeGeneric() (AstNode, bool) {
+	genericNode := p.startNode(NodeTypeGeneric)
+	defer p.finishNode()
+
+	name, ok := p.consumeIdentifier()
+	if !ok {
+		return genericNode, false
+	}
+
+	genericNode.Decorate(NodePredicateGenericName, name)
+
+	if p.tryConsumeKeyword("extends") {
+		typeRef, ok := p.consumeTypeRef()
+		genericNode.Connect(NodePredicateGenericConstraint, typeRef)
+		if !ok {
+			return genericNode, false
+		}
+	}
+
+	if _, ok := p.tryConsume(tokenTypeEquals); ok {
+		typeRef, ok := p.consumeTypeRef()
+		genericNode.Connect(NodePredicateGenericDefault, typeRef)
+		if !ok {
+			return genericNode, false
+		}
+	}
+
+	return genericNode, true
+}
+
+// tryConsumeTypeAnnotation consumes a `: T` type annotation, if any, and connects it to the
+// parent node.
+func (p *sourceParser) tryConsumeTypeAnnotation(parentNode AstNode, predicate string) bool {
+	// :
+	if _, ok := p.tryConsume(tokenTypeColon); !ok {
+		return true
+	}
+
+	typeRef, ok := p.consumeTypeRef()
+	parentNode.Connect(predicate, typeRef)
+	return ok
+}
+
+// consumeTypeRefList consumes a comma-separated list of type references.
+func (p *sourceParser) consumeTypeRefList(parentNode AstNode, predicate string) bool {
+	for {
+		typeRef, ok := p.consumeTypeRef()
+		parentNode.Connect(predicate, typeRef)
+		if !ok {
+			return false
+		}
+
+		if _, ok := p.tryConsume(tokenTypeComma); !ok {
+			return true
+		}
+	}
+}
+
+// consumeTypeRef consumes a type reference.
+func (p *sourceParser) consumeTypeRef() (AstNode, bool) {
+	return p.consumeCompositeTypeRef(tokenTypePipe, UnionTypeRef, p.consumeIntersectionTypeRef)
+}
+
+// consumeIntersectionTypeRef consumes an intersection type reference or any of its operands.
+func (p *sourceParser) consumeIntersectionTypeRef() (AstNode, bool) {
+	return p.consumeCompositeTypeRef(tokenTypeAmpersand, IntersectionTypeRef, p.consumePostfixTypeRef)
+}
+
+// consumeCompositeTypeRef consumes one or more type references, as parsed by the sub parser,
+// separated by the given operator. If more than one is found, a composite type reference of
+// the given kind is returned.
+func (p *sourceParser) consumeCompositeTypeRef(operator tokenType, kind TypeRefKind, subParser tryParserFn) (AstNode, bool) {
+	startToken := p.currentToken
+
+	// TypeScript allows a leading operator, e.g. `| Foo | Bar`.
+	p.tryConsume(operator)
+
+	firstNode, ok := subParser()
+	if !ok || !p.isToken(operator) {
+		return firstNode, ok
+	}
+
+	compositeNode := p.createNode(NodeTypeTypeRef)
+	p.decorateStartRuneAndComments(compositeNode, commentedLexeme{startToken.lexeme, []string{}})
+	compositeNode.Decorate(NodePredicateTypeRefKind, string(kind))
+	compositeNode.Connect(NodePredicateTypeRefElement, firstNode)
+
+	for {
+		if _, ok := p.tryConsume(operator); !ok {
+			break
+		}
+
+		elementNode, ok := subParser()
+		compositeNode.Connect(NodePredicateTypeRefElement, elementNode)
+		if !ok {
+			p.decorateEndRune(compositeNode, p.previousToken)
+			return compositeNode, false
+		}
+	}
+
+	p.decorateEndRune(compositeNode, p.previousToken)
+	return compositeNode, true
+}
+
+// consumePostfixTypeRef consumes a type reference, followed by any array or indexed access
+// suffixes.
+func (p *sourceParser) consumePostfixTypeRef() (AstNode, bool) {
+	startToken := p.currentToken
+
+	typeRef, ok := p.consumePrimaryTypeRef()
+	if !ok {
+		return typeRef, false
+	}
+
+	for p.isToken(tokenTypeLeftBracket) {
+		wrapperNode := p.createNode(NodeTypeTypeRef)
+		p.decorateStartRuneAndComments(wrapperNode, commentedLexeme{startToken.lexeme, []string{}})
+		wrapperNode.Connect(NodePredicateTypeRefElement, typeRef)
+		p.consumeToken()
+
+		if _, ok := p.tryConsume(tokenTypeRightBracket); ok {
+			// T[]
+			wrapperNode.Decorate(NodePredicateTypeRefKind, string(ArrayTypeRef))
+		} else {
+			// T[K] is an indexed access type, which is treated as a type query.
+			wrapperNode.Decorate(NodePredicateTypeRefKind, string(QueryTypeRef))
+
+			indexRef, ok := p.consumeTypeRef()
+			wrapperNode.Connect(NodePredicateTypeRefElement, indexRef)
+			if !ok {
+				p.decorateEndRune(wrapperNode, p.previousToken)
+				return wrapperNode, false
+			}
+
+			if _, ok := p.consume(tokenTypeRightBracket); !ok {
+				p.decorateEndRune(wrapperNode, p.previousToken)
+				return wrapperNode, false
+			}
+		}
+
+		p.decorateEndRune(wrapperNode, p.previousToken)
+		typeRef = wrapperNode
+	}
+
+	return typeRef, true
+}
+
+// consumePrimaryTypeRef consumes a non-composite type reference.
+func (p *sourceParser) consumePrimaryTypeRef() (AstNode, bool) {
+	switch {
+	case p.isToken(tokenTypeLessThan):
+		return p.consumeFunctionTypeRef()
+
+	case p.isToken(tokenTypeLeftParen):
+		if p.isFunctionTypeRef() {
+			return p.consumeFunctionTypeRef()
+		}
+
+		// (T)
+		p.consumeToken()
+		typeRef, ok := p.consumeTypeRef()
+		if !ok {
+			return typeRef, false
+		}
+
+		_, ok = p.consume(tokenTypeRightParen)
+		return typeRef, ok
+
+	case p.isKeyword("new"):
+		// Constructor types are treated as function types.
+		p.consumeToken()
+		return p.consumeFunctionTypeRef()
+
+	case p.isToken(tokenTypeLeftBrace):
+		return p.consumeObjectTypeRef()
+
+	case p.isToken(tokenTypeLeftBracket):
+		return p.consumeTupleTypeRef()
+
+	case p.isToken(tokenTypeString, tokenTypeNumber):
+		literalNode := p.startNode(NodeTypeTypeRef)
+		defer p.finishNode()
+
+		literalNode.Decorate(NodePredicateTypeRefKind, string(LiteralTypeRef))
+		literalNode.Decorate(NodePredicateTypeRefName, p.currentToken.value)
+		p.consumeToken()
+		return literalNode, true
+
+	case p.isKeyword("typeof") || p.isKeyword("keyof"):
+		queryNode := p.startNode(NodeTypeTypeRef)
+		defer p.finishNode()
+
+		queryNode.Decorate(NodePredicateTypeRefKind, string(QueryTypeRef))
+		queryNode.Decorate(NodePredicateTypeRefName, p.currentToken.value)
+		p.consumeToken()
+
+		typeRef, ok := p.consumePostfixTypeRef()
+		queryNode.Connect(NodePredicateTypeRefElement, typeRef)
+		return queryNode, ok
+
+	default:
+		return p.consumeNamedTypeRef()
+	}
+}
+
+// consumeNamedTypeRef consumes a reference to a named type, with optional generic arguments.
+//
+// Foo.Bar<T, U>
+func (p *sourceParser) consumeNamedTypeRef() (AstNode, bool) {
+	typeNode := p.startNode(NodeTypeTypeRef)
+	defer p.finishNode()
+
+	typeNode.Decorate(NodePredicateTypeRefKind, string(NamedTypeRef))
+
+	name, ok := p.consumeIdentifier()
+	if !ok {
+		return typeNode, false
+	}
+
+	// Type predicates (`arg is Foo`) are only found in return position and are treated
+	// as booleans.
+	if p.isToken(tokenTypeIdentifier) && p.currentToken.value == "is" {
+		p.consumeToken()
+		typeNode.Decorate(NodePredicateTypeRefName, "boolean")
+
+		typeRef, ok := p.consumeTypeRef()
+		typeNode.Connect(NodePredicateTypeRefElement, typeRef)
+		return typeNode, ok
+	}
+
+	for {
+		if _, ok := p.tryConsume(tokenTypeDot); !ok {
+			break
+		}
+
+		namePart, ok := p.consumeName()
+		if !ok {
+			return typeNode, false
+		}
+
+		name = name + "." + namePart
+	}
+
+	typeNode.Decorate(NodePredicateTypeRefName, name)
+
+	// <
+	if _, ok := p.tryConsume(tokenTypeLessThan); !ok {
+		return typeNode, true
+	}
+
+	if !p.consumeTypeRefList(typeNode, NodePredicateTypeRefGeneric) {
+		return typeNode, false
+	}
+
+	// >
+	_, ok = p.consume(tokenTypeGreaterThan)
+	return typeNode, ok
+}
+
+// isFunctionTypeRef returns whether the current parenthesis starts a function type, rather
+// than a parenthesized type.
+func (p *sourceParser) isFunctionTypeRef() bool {
+	t := p.newLookaheadTracker()
+	t.nextToken()
+
+	// () or (...
+	if _, ok := t.matchToken(tokenTypeRightParen, tokenTypeEllipsis); ok {
+		return true
+	}
+
+	if _, ok := t.matchToken(tokenTypeIdentifier, tokenTypeKeyword); !ok {
+		return false
+	}
+
+	// (a: or (a, or (a?
+	if _, ok := t.matchToken(tokenTypeColon, tokenTypeComma, tokenTypeQuestionMark); ok {
+		return true
+	}
+
+	// (a) =>
+	if _, ok := t.matchToken(tokenTypeRightParen); !ok {
+		return false
+	}
+
+	_, ok := t.matchToken(tokenTypeArrow)
+	return ok
+}
+
+// consumeFunctionTypeRef consumes a function type.
+//
+// <T>(a: T) => U
+func (p *sourceParser) consumeFunctionTypeRef() (AstNode, bool) {
+	typeNode := p.startNode(NodeTypeTypeRef)
+	defer p.finishNode()
+
+	typeNode.Decorate(NodePredicateTypeRefKind, string(FunctionTypeRef))
+
+	// Generics on function types are parsed but, as they cannot be referenced outside the type,
+	// are simply attached as children.
+	if !p.tryConsumeGenerics(typeNode, NodePredicateChild) {
+		return typeNode, false
+	}
+
+	if !p.consumeParameters(typeNode, NodePredicateTypeRefParameter) {
+		return typeNode, false
+	}
+
+	// =>
+	if _, ok := p.consume(tokenTypeArrow); !ok {
+		return typeNode, false
+	}
+
+	returnRef, ok := p.consumeTypeRef()
+	typeNode.Connect(NodePredicateTypeRefReturn, returnRef)
+	return typeNode, ok
+}
+
+// consumeObjectTypeRef consumes an anonymous object type. As object types cannot be represented
+// in Serulian, their contents are skipped.
+//
+// { foo: T; bar(): U }
+func (p *sourceParser) consumeObjectTypeRef() (AstNode, bool) {
+	typeNode := p.startNode(NodeTypeTypeRef)
+	defer p.finishNode()
+
+	typeNode.Decorate(NodePredicateTypeRefKind, string(ObjectTypeRef))
+
+	var depth = 0
+	for {
+		switch {
+		case p.isToken(tokenTypeEOF, tokenTypeError):
+			p.emitError("Unterminated object type")
+			return typeNode, false
+
+		case p.isToken(tokenTypeLeftBrace):
+			depth++
+
+		case p.isToken(tokenTypeRightBrace):
+			depth--
+		}
+
+		p.consumeToken()
+		if depth == 0 {
+			return typeNode, true
+		}
+	}
+}
+
+// consumeTupleTypeRef consumes a tuple type.
+//
+// [T, U]
+func (p *sourceParser) consumeTupleTypeRef() (AstNode, bool) {
+	typeNode := p.startNode(NodeTypeTypeRef)
+	defer p.finishNode()
+
+	typeNode.Decorate(NodePredicateTypeRefKind, string(TupleTypeRef))
+
+	// [
+	if _, ok := p.consume(tokenTypeLeftBracket); !ok {
+		return typeNode, false
+	}
+
+	// ]
+	if _, ok := p.tryConsume(tokenTypeRightBracket); ok {
+		return typeNode, true
+	}
+
+	if !p.consumeTypeRefList(typeNode, NodePredicateTypeRefElement) {
+		return typeNode, false
+	}
+
+	// ]
+	_, ok := p.consume(tokenTypeRightBracket)
+	return typeNode, ok
+}
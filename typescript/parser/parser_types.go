@@ -0,0 +1,269 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package parser
+
+import (
+	"strconv"
+)
+
+//go:generate stringer -type=NodeType
+
+// NodeType identifies the type of AST node.
+type NodeType int
+
+const (
+	// Top-level
+	NodeTypeError        NodeType = iota // error occurred; value is text of error
+	NodeTypeGlobalModule                 // Virtual node created to hold all files.
+
+	NodeTypeFile    // The file root node
+	NodeTypeComment // A single or multiline comment
+
+	NodeTypeDeclaration // interface Foo { ... }
+	NodeTypeMember      // readonly something: string
+	NodeTypeParameter   // someArg?: number
+	NodeTypeGeneric     // <T extends Foo>
+	NodeTypeTypeRef     // Array<string> | null
+
+	NodeTypeTagged
+)
+
+// DeclarationKind defines the various kinds of top-level declarations supported.
+type DeclarationKind string
+
+const (
+	// InterfaceDeclaration is an `interface Foo { ... }`.
+	InterfaceDeclaration DeclarationKind = "interface"
+
+	// ClassDeclaration is a `class Foo { ... }`.
+	ClassDeclaration DeclarationKind = "class"
+
+	// FunctionDeclaration is a `function foo(...): T;`.
+	FunctionDeclaration DeclarationKind = "function"
+
+	// TypeAliasDeclaration is a `type Foo = T;`.
+	TypeAliasDeclaration DeclarationKind = "type"
+
+	// VariableDeclaration is a `var foo: T;`, `let foo: T;` or `const foo: T;`.
+	VariableDeclaration DeclarationKind = "variable"
+)
+
+// MemberKind defines the various kinds of members supported under interfaces and classes.
+type MemberKind string
+
+const (
+	// PropertyMember is a `foo: T` or `foo?: T`.
+	PropertyMember MemberKind = "property"
+
+	// MethodMember is a `foo(...): T`.
+	MethodMember MemberKind = "method"
+
+	// ConstructorMember is a `constructor(...)` or `new (...): T`.
+	ConstructorMember MemberKind = "constructor"
+
+	// IndexMember is a `[key: string]: T`.
+	IndexMember MemberKind = "index"
+)
+
+// TypeRefKind defines the various kinds of type references.
+type TypeRefKind string
+
+const (
+	// NamedTypeRef is a reference to a named type, such as `string` or `Foo<T>`.
+	NamedTypeRef TypeRefKind = "named"
+
+	// ArrayTypeRef is an array type, such as `T[]`.
+	ArrayTypeRef TypeRefKind = "array"
+
+	// UnionTypeRef is a union type, such as `T | null`.
+	UnionTypeRef TypeRefKind = "union"
+
+	// IntersectionTypeRef is an intersection type, such as `T & U`.
+	IntersectionTypeRef TypeRefKind = "intersection"
+
+	// FunctionTypeRef is a function type, such as `(a: T) => U`.
+	FunctionTypeRef TypeRefKind = "function"
+
+	// LiteralTypeRef is a literal type, such as `'foo'` or `42`.
+	LiteralTypeRef TypeRefKind = "literal"
+
+	// ObjectTypeRef is an anonymous object type, such as `{ foo: T }`.
+	ObjectTypeRef TypeRefKind = "object"
+
+	// TupleTypeRef is a tuple type, such as `[T, U]`.
+	TupleTypeRef TypeRefKind = "tuple"
+
+	// QueryTypeRef is a type query or operator, such as `typeof foo` or `keyof T`.
+	QueryTypeRef TypeRefKind = "query"
+)
+
+const (
+	//
+	// All nodes
+	//
+	// The source of this node.
+	NodePredicateSource = "input-source"
+
+	// The rune position in the input string at which this node begins.
+	NodePredicateStartRune = "start-rune"
+
+	// The rune position in the input string at which this node ends.
+	NodePredicateEndRune = "end-rune"
+
+	// A direct child of this node. Implementations should handle the ordering
+	// automatically for this predicate.
+	NodePredicateChild = "child-node"
+
+	//
+	// NodeTypeError
+	//
+
+	// The message for the parsing error.
+	NodePredicateErrorMessage = "error-message"
+
+	//
+	// NodeTypeComment
+	//
+
+	// The value of the comment, including its delimeter(s)
+	NodePredicateCommentValue = "comment-value"
+
+	//
+	// NodeTypeDeclaration
+	//
+
+	// Decorates a declaration with its kind (interface, class, etc)
+	NodePredicateDeclarationKind = "declaration-kind"
+
+	// Decorates a declaration with its name.
+	NodePredicateDeclarationName = "declaration-name"
+
+	// Connects a declaration to the type(s) it extends.
+	NodePredicateDeclarationExtends = "declaration-extends"
+
+	// Connects a declaration to the type(s) it implements.
+	NodePredicateDeclarationImplements = "declaration-implements"
+
+	// Connects a declaration to one of its member definitions.
+	NodePredicateDeclarationMember = "declaration-member"
+
+	// Connects a declaration to one of its generics.
+	NodePredicateDeclarationGeneric = "declaration-generic"
+
+	// Connects a function declaration to one of its parameters.
+	NodePredicateDeclarationParameter = "declaration-parameter"
+
+	// Connects a function, variable or type alias declaration to its declared type. For functions,
+	// this is the return type.
+	NodePredicateDeclarationType = "declaration-type"
+
+	// Decorates a variable declaration as being constant.
+	NodePredicateDeclarationReadonly = "declaration-readonly"
+
+	// Decorates a class declaration as being abstract.
+	NodePredicateDeclarationAbstract = "declaration-abstract"
+
+	//
+	// NodeTypeMember
+	//
+
+	// Decorates a member with its kind (property, method, etc).
+	NodePredicateMemberKind = "member-kind"
+
+	// Decorates a member with its name.
+	NodePredicateMemberName = "member-name"
+
+	// Decorates a member as being optional.
+	NodePredicateMemberOptional = "member-optional"
+
+	// Decorates a member as being static.
+	NodePredicateMemberStatic = "member-static"
+
+	// Decorates a member as being readonly.
+	NodePredicateMemberReadonly = "member-readonly"
+
+	// Decorates a member as being private or protected, and therefore not accessible.
+	NodePredicateMemberHidden = "member-hidden"
+
+	// Decorates a member as having a computed name (e.g. `[Symbol.iterator]`), which is not
+	// accessible from Serulian.
+	NodePredicateMemberComputed = "member-computed"
+
+	// Connects a member to its declared type. For methods, this is the return type.
+	NodePredicateMemberType = "member-type"
+
+	// Connects a member to a parameter.
+	NodePredicateMemberParameter = "member-parameter"
+
+	// Connects a member to one of its generics.
+	NodePredicateMemberGeneric = "member-generic"
+
+	//
+	// NodeTypeParameter
+	//
+
+	// Decorates a parameter with its name.
+	NodePredicateParameterName = "parameter-name"
+
+	// Decorates a parameter as being optional.
+	NodePredicateParameterOptional = "parameter-optional"
+
+	// Decorates a parameter as being a rest parameter (`...args`).
+	NodePredicateParameterRest = "parameter-rest"
+
+	// Connects a parameter to its declared type.
+	NodePredicateParameterType = "parameter-type"
+
+	//
+	// NodeTypeGeneric
+	//
+
+	// Decorates a generic with its name.
+	NodePredicateGenericName = "generic-name"
+
+	// Connects a generic to its constraint.
+	NodePredicateGenericConstraint = "generic-constraint"
+
+	// Connects a generic to its default type.
+	NodePredicateGenericDefault = "generic-default"
+
+	//
+	// NodeTypeTypeRef
+	//
+
+	// Decorates a type reference with its kind.
+	NodePredicateTypeRefKind = "typeref-kind"
+
+	// Decorates a named type reference with its (possibly dotted) name, or a literal type reference
+	// with its literal value.
+	NodePredicateTypeRefName = "typeref-name"
+
+	// Connects a named type reference to one of its generic arguments.
+	NodePredicateTypeRefGeneric = "typeref-generic"
+
+	// Connects an array, union, intersection or tuple type reference to one of its element types.
+	NodePredicateTypeRefElement = "typeref-element"
+
+	// Connects a function type reference to one of its parameters.
+	NodePredicateTypeRefParameter = "typeref-parameter"
+
+	// Connects a function type reference to its return type.
+	NodePredicateTypeRefReturn = "typeref-return"
+)
+
+func (t NodeType) Name() string {
+	return "TSNodeType"
+}
+
+func (t NodeType) Value() string {
+	return strconv.Itoa(int(t))
+}
+
+func (t NodeType) Build(value string) interface{} {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		panic("Invalid value for TSNodeType: " + value)
+	}
+	return NodeType(i)
+}
@@ -0,0 +1,5 @@
+declare class SomeClass extends BaseClass implements IFirst, ISecond {
+    constructor(value: string);
+}
+
+export declare abstract class AbstractClass {}
@@ -0,0 +1,9 @@
+// Some leading comment.
+
+/**
+ * SomeInterface is documented.
+ */
+interface SomeInterface {
+    /** The property is documented too. */
+    someProperty: string;
+}
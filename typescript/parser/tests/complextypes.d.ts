@@ -0,0 +1,19 @@
+interface ComplexTypes {
+    array: string[];
+    nestedArray: Array<number[]>[];
+    union: string | number | null;
+    intersection: First & Second;
+    parens: (string | number)[];
+    callback: (error: Error, value?: string) => void;
+    emptyCallback: () => void;
+    restCallback: (...args: any[]) => void;
+    ctor: new () => SomeClass;
+    literal: 'first' | "second" | 42;
+    object: { first: string; nested: { second: number } };
+    tuple: [string, number];
+    query: typeof someVar;
+    keys: keyof SomeInterface;
+    indexed: SomeInterface['someProperty'];
+    qualified: Some.Qualified.Name;
+    isString(value: any): value is string;
+}
@@ -0,0 +1,4 @@
+interface Iterable {
+    [Symbol.iterator](): Iterator;
+    [Symbol.toStringTag]: string;
+}
@@ -0,0 +1,35 @@
+// Type definitions for a small library.
+
+/**
+ * Options for creating a widget.
+ */
+export interface WidgetOptions {
+    name: string;
+    size?: number;
+    onClick?: (event: Event) => void;
+}
+
+/**
+ * A widget.
+ */
+export declare class Widget implements EventTarget {
+    constructor(options: WidgetOptions);
+    constructor(name: string, size?: number);
+
+    readonly name: string;
+    static count: number;
+
+    render(container: Element): void;
+    render(container: Element, replace: boolean): void;
+
+    on(event: 'click' | 'hover', handler: () => void): this;
+
+    private internalState;
+}
+
+export type WidgetName = string;
+
+export declare function createWidget(options: WidgetOptions): Widget;
+export declare function createWidget(name: string): Widget;
+
+export declare const VERSION: string;
@@ -0,0 +1,3 @@
+declare function noParams(): void;
+declare function someFunction(first: string, second?: number, ...rest: boolean[]): string
+export function untyped(value)
@@ -0,0 +1,5 @@
+interface Container<T, U extends SomeInterface = DefaultType> {
+    get<V>(key: V): Map<V, T>;
+}
+
+declare function identity<T>(value: T): T;
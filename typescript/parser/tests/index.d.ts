@@ -0,0 +1,4 @@
+interface Dictionary {
+    [key: string]: number;
+    readonly [index: number]: string;
+}
@@ -0,0 +1,4 @@
+interface Child extends Parent, OtherParent {
+}
+
+export interface Another extends Child {}
@@ -0,0 +1,12 @@
+interface SomeInterface {
+    someProperty: string;
+    optionalProperty?: number,
+    someMethod(first: string, second?: boolean): void;
+    optionalMethod?(): any;
+    'quoted-name': string;
+    new (value: number): SomeInterface;
+    (callable: string): number;
+    readonly: boolean;
+    delete(key: string): void;
+    untyped;
+}
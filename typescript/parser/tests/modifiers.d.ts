@@ -0,0 +1,8 @@
+declare class SomeClass {
+    static create(): SomeClass;
+    readonly name: string;
+    static readonly DEFAULT: number;
+    public visible: boolean;
+    private hidden;
+    protected alsoHidden(): void;
+}
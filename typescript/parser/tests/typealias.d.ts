@@ -0,0 +1,5 @@
+type SimpleAlias = string;
+type NullableAlias = SomeInterface | null;
+type StringOrNumber =
+    | string
+    | number;
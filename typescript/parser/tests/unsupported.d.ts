@@ -0,0 +1,5 @@
+interface Supported {}
+
+declare namespace SomeNamespace {
+    interface Unsupported {}
+}
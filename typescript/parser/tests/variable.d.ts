@@ -0,0 +1,4 @@
+declare var someVar: string;
+declare let someLet: number;
+declare const someConst: boolean;
+declare var untyped;
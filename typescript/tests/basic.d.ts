@@ -0,0 +1,5 @@
+/** Some interface. */
+interface SomeInterface {
+	readonly coolThing: object;
+	anotherThing(someparam: number): AnotherType;
+}
@@ -0,0 +1,3 @@
+interface foo {
+	baz(param: string
+}
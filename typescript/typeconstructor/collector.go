@@ -0,0 +1,253 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeconstructor
+
+import (
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/typegraph"
+
+	tsgraph "github.com/serulian/compiler/typescript/graph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// tsType holds all the declarations that contribute to a single type. TypeScript allows
+// interfaces (and classes) to be declared across multiple declarations, all of which are
+// merged into a single type in the type graph, defined under the module of the first
+// declaration.
+type tsType struct {
+	name         string                  // The name of the type.
+	declarations []tsgraph.TSDeclaration // The declarations contributing to the type.
+	aliases      []tsgraph.TSDeclaration // The declarations found in other modules, which become aliases.
+	members      []*memberGroup          // The members of the type, once collected.
+}
+
+// primary returns the declaration that acts as the source of the type.
+func (t *tsType) primary() *tsgraph.TSDeclaration {
+	return &t.declarations[0]
+}
+
+// isConstructable returns whether constructors declared on the type are accessible.
+func (t *tsType) isConstructable() bool {
+	for _, declaration := range t.declarations {
+		if declaration.Kind() == parser.ClassDeclaration && !declaration.IsAbstract() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// genericNames returns the names of the generics declared on the type. As generics are
+// erased, these are only used to resolve references to them.
+func (t *tsType) genericNames() map[string]bool {
+	names := map[string]bool{}
+	for _, declaration := range t.declarations {
+		for _, generic := range declaration.Generics() {
+			names[generic.Name()] = true
+		}
+	}
+	return names
+}
+
+// signature represents a single declared signature of a member.
+type signature struct {
+	node         compilergraph.GraphNode // The node declaring the signature.
+	parameters   []tsgraph.TSParameter   // The parameters of the signature, if any.
+	declaredType tsgraph.TSTypeRef       // The declared (or return) type, if any.
+	hasType      bool                    // Whether there is a declared type.
+	genericNames map[string]bool         // The names of the generics declared on the signature.
+}
+
+// memberGroup holds all the signatures (overloads) declared for a single member of a type or
+// module. As Serulian does not support overloading, the signatures are merged into a single
+// member.
+type memberGroup struct {
+	name          string                  // The name of the member in the type graph.
+	kind          parser.MemberKind       // The kind of the member.
+	isStatic      bool                    // Whether the member is static.
+	isReadonly    bool                    // Whether the member is read-only.
+	isOptional    bool                    // Whether the member is optional.
+	documentation string                  // The documentation of the member, if any.
+	signatures    []signature             // The signatures of the member.
+	thisType      *tsType                 // The type containing the member, if any.
+	parentNode    compilergraph.GraphNode // The source node of the parent type or module.
+}
+
+// sourceNode returns the node acting as the source of the member.
+func (g *memberGroup) sourceNode() compilergraph.GraphNode {
+	return g.signatures[0].node
+}
+
+// isOperator returns whether the member is defined as an operator.
+func (g *memberGroup) isOperator() bool {
+	return g.kind == parser.IndexMember
+}
+
+// longestSignature returns the signature with the most parameters, whose parameters are used
+// for the merged member.
+func (g *memberGroup) longestSignature() signature {
+	var longest = g.signatures[0]
+	for _, signature := range g.signatures[1:] {
+		if len(signature.parameters) > len(longest.parameters) {
+			longest = signature
+		}
+	}
+	return longest
+}
+
+// memberSignature returns the signature for the given member.
+func memberSignature(member tsgraph.TSMember) signature {
+	declaredType, hasType := member.DeclaredType()
+	genericNames := map[string]bool{}
+	for _, generic := range member.Generics() {
+		genericNames[generic.Name()] = true
+	}
+
+	return signature{member.GraphNode, member.Parameters(), declaredType, hasType, genericNames}
+}
+
+// declarationSignature returns the signature for the given function or variable declaration.
+func declarationSignature(declaration tsgraph.TSDeclaration) signature {
+	declaredType, hasType := declaration.DeclaredType()
+	genericNames := map[string]bool{}
+	for _, generic := range declaration.Generics() {
+		genericNames[generic.Name()] = true
+	}
+
+	return signature{declaration.GraphNode, declaration.Parameters(), declaredType, hasType, genericNames}
+}
+
+// collectTypeMembers collects the members of all the declarations of the given type into member
+// groups, reporting any members that cannot be merged.
+func collectTypeMembers(t *tsType, reporter typegraph.IssueReporter) {
+	groups := map[string]*memberGroup{}
+	isConstructable := t.isConstructable()
+
+	for _, declaration := range t.declarations {
+		for _, member := range declaration.Members() {
+			// Private, protected and computed members are not accessible from Serulian.
+			if member.IsHidden() || member.IsComputed() {
+				continue
+			}
+
+			var key string
+			var name string
+
+			switch member.Kind() {
+			case parser.ConstructorMember:
+				// Construct signatures on interfaces describe constructor objects, rather than
+				// the interface itself, so only constructors on concrete classes are defined.
+				if declaration.Kind() != parser.ClassDeclaration || !isConstructable {
+					continue
+				}
+
+				key = "(constructor)"
+				name = "new"
+
+			case parser.IndexMember:
+				key = "(index)"
+				name = "index"
+
+			default:
+				name, _ = member.Name()
+				key = name
+			}
+
+			existing, found := groups[key]
+			if !found {
+				documentation, _ := member.Documentation()
+				group := &memberGroup{
+					name:          name,
+					kind:          member.Kind(),
+					isStatic:      member.IsStatic() || member.Kind() == parser.ConstructorMember,
+					isReadonly:    member.IsReadonly(),
+					isOptional:    member.IsOptional(),
+					documentation: documentation,
+					signatures:    []signature{memberSignature(member)},
+					thisType:      t,
+					parentNode:    t.primary().GraphNode,
+				}
+
+				groups[key] = group
+				t.members = append(t.members, group)
+				continue
+			}
+
+			if existing.kind != member.Kind() {
+				reporter.ReportError(member.GraphNode, "Member '%s' under TypeScript type '%s' is declared as both a %s and a %s", name, t.name, existing.kind, member.Kind())
+				continue
+			}
+
+			if existing.isStatic != member.IsStatic() && member.Kind() != parser.ConstructorMember {
+				reporter.ReportError(member.GraphNode, "Member '%s' under TypeScript type '%s' is declared as both static and non-static", name, t.name)
+				continue
+			}
+
+			// Subsequent property declarations must have the same type in TypeScript, so only
+			// the first is used.
+			if member.Kind() == parser.PropertyMember {
+				continue
+			}
+
+			existing.signatures = append(existing.signatures, memberSignature(member))
+		}
+	}
+}
+
+// collectModuleMembers collects the functions and variables declared directly under the given
+// module into member groups.
+func collectModuleMembers(module tsgraph.TSModule, typeNames map[string]bool, reporter typegraph.IssueReporter) []*memberGroup {
+	groups := map[string]*memberGroup{}
+	var members = make([]*memberGroup, 0)
+
+	for _, declaration := range module.Declarations() {
+		var kind parser.MemberKind
+
+		switch declaration.Kind() {
+		case parser.FunctionDeclaration:
+			kind = parser.MethodMember
+
+		case parser.VariableDeclaration:
+			// A variable with the same name as a type is the type's constructor object
+			// (`declare var Foo: FooConstructor`), which is not represented.
+			if typeNames[declaration.Name()] {
+				continue
+			}
+
+			kind = parser.PropertyMember
+
+		default:
+			continue
+		}
+
+		name := declaration.Name()
+		existing, found := groups[name]
+		if !found {
+			documentation, _ := declaration.Documentation()
+			group := &memberGroup{
+				name:          name,
+				kind:          kind,
+				isStatic:      true,
+				isReadonly:    declaration.IsReadonly(),
+				documentation: documentation,
+				signatures:    []signature{declarationSignature(declaration)},
+				parentNode:    module.Node(),
+			}
+
+			groups[name] = group
+			members = append(members, group)
+			continue
+		}
+
+		if existing.kind != kind || kind != parser.MethodMember {
+			reporter.ReportError(declaration.GraphNode, "'%s' is already declared under TypeScript module '%s'", name, module.Name())
+			continue
+		}
+
+		existing.signatures = append(existing.signatures, declarationSignature(declaration))
+	}
+
+	return members
+}
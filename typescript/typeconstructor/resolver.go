@@ -0,0 +1,221 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeconstructor
+
+import (
+	"fmt"
+
+	"github.com/serulian/compiler/graphs/typegraph"
+
+	tsgraph "github.com/serulian/compiler/typescript/graph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// resolutionScope defines the context in which a TypeScript type reference is resolved.
+type resolutionScope struct {
+	genericNames map[string]bool // The names of the generics in scope, which are erased to `any`.
+	thisType     *tsType         // The type referred to by `this`, if any.
+}
+
+// withGenerics returns a copy of the scope with the given generic names added.
+func (rs resolutionScope) withGenerics(genericNames map[string]bool) resolutionScope {
+	if len(genericNames) == 0 {
+		return rs
+	}
+
+	combined := map[string]bool{}
+	for name := range rs.genericNames {
+		combined[name] = true
+	}
+
+	for name := range genericNames {
+		combined[name] = true
+	}
+
+	return resolutionScope{combined, rs.thisType}
+}
+
+// nativeType returns a reference to the native type (as defined in WebIDL) with the given name,
+// if any.
+func (ttc *tsTypeConstructor) nativeType(name string, graph *typegraph.TypeGraph) (typegraph.TypeReference, bool) {
+	if ttc.nativeTypes == nil {
+		ttc.nativeTypes = map[string]typegraph.TGTypeDecl{}
+		for _, typeDecl := range graph.TypeDecls() {
+			if typeDecl.SourceGraphId() == "webidl" && typeDecl.TypeKind() == typegraph.ExternalInternalType {
+				ttc.nativeTypes[typeDecl.Name()] = typeDecl
+			}
+		}
+	}
+
+	typeDecl, found := ttc.nativeTypes[name]
+	if !found {
+		return graph.AnyTypeReference(), false
+	}
+
+	return typeDecl.GetTypeReference(), true
+}
+
+// requiredNativeType returns a reference to the native type (as defined in WebIDL) with the given
+// name, or an error if the core WebIDL does not define it.
+func (ttc *tsTypeConstructor) requiredNativeType(name string, graph *typegraph.TypeGraph) (typegraph.TypeReference, error) {
+	nativeType, found := ttc.nativeType(name, graph)
+	if !found {
+		return nativeType, fmt.Errorf("Could not find native type %v; TypeScript declarations require the core WebIDL", name)
+	}
+
+	return nativeType, nil
+}
+
+// resolveOptionalType resolves the given type reference, if any, returning `any` if none.
+func (ttc *tsTypeConstructor) resolveOptionalType(typeRef tsgraph.TSTypeRef, hasTypeRef bool, scope resolutionScope, graph *typegraph.TypeGraph) (typegraph.TypeReference, error) {
+	if !hasTypeRef {
+		return graph.AnyTypeReference(), nil
+	}
+
+	return ttc.resolveType(typeRef, scope, graph)
+}
+
+// resolveType attempts to resolve the given TypeScript type reference into a type graph
+// reference. As the TypeScript type system is far more expressive than that of Serulian,
+// any type that cannot be represented is resolved to `any`.
+func (ttc *tsTypeConstructor) resolveType(typeRef tsgraph.TSTypeRef, scope resolutionScope, graph *typegraph.TypeGraph) (typegraph.TypeReference, error) {
+	switch typeRef.Kind() {
+	case parser.NamedTypeRef:
+		name, _ := typeRef.Name()
+		return ttc.resolveNamedType(name, scope, graph)
+
+	case parser.ArrayTypeRef, parser.TupleTypeRef:
+		return ttc.requiredNativeType(tsgraph.ARRAY_TYPE, graph)
+
+	case parser.LiteralTypeRef:
+		value, _ := typeRef.Name()
+		if len(value) > 0 && (value[0] == '\'' || value[0] == '"') {
+			return ttc.requiredNativeType(tsgraph.NATIVE_TYPES["string"], graph)
+		}
+
+		return ttc.requiredNativeType(tsgraph.NATIVE_TYPES["number"], graph)
+
+	case parser.UnionTypeRef:
+		return ttc.resolveUnionType(typeRef, scope, graph)
+
+	default:
+		// Intersections, functions, anonymous objects and type queries have no equivalent.
+		return graph.AnyTypeReference(), nil
+	}
+}
+
+// resolveUnionType resolves a union type reference. Unions containing `null` or `undefined`
+// become nullable, and unions whose remaining members all resolve to the same type become
+// that type. All other unions resolve to `any`.
+func (ttc *tsTypeConstructor) resolveUnionType(typeRef tsgraph.TSTypeRef, scope resolutionScope, graph *typegraph.TypeGraph) (typegraph.TypeReference, error) {
+	var isNullable = false
+	var resolved = make([]typegraph.TypeReference, 0)
+
+	for _, element := range typeRef.Elements() {
+		if element.Kind() == parser.NamedTypeRef {
+			name, _ := element.Name()
+			if tsgraph.NULL_TYPES[name] {
+				isNullable = true
+				continue
+			}
+		}
+
+		elementType, err := ttc.resolveType(element, scope, graph)
+		if err != nil {
+			return graph.AnyTypeReference(), err
+		}
+
+		resolved = append(resolved, elementType)
+	}
+
+	if len(resolved) == 0 {
+		return graph.VoidTypeReference(), nil
+	}
+
+	for _, elementType := range resolved[1:] {
+		if elementType != resolved[0] {
+			return graph.AnyTypeReference(), nil
+		}
+	}
+
+	if isNullable {
+		return resolved[0].AsNullable(), nil
+	}
+
+	return resolved[0], nil
+}
+
+// resolveNamedType resolves a reference to a type by name.
+func (ttc *tsTypeConstructor) resolveNamedType(name string, scope resolutionScope, graph *typegraph.TypeGraph) (typegraph.TypeReference, error) {
+	// Generics are erased.
+	if scope.genericNames[name] {
+		return graph.AnyTypeReference(), nil
+	}
+
+	if name == "this" {
+		if scope.thisType == nil {
+			return graph.AnyTypeReference(), nil
+		}
+
+		return ttc.typeReference(scope.thisType, graph), nil
+	}
+
+	if tsgraph.ANY_TYPES[name] {
+		return graph.AnyTypeReference(), nil
+	}
+
+	if tsgraph.VOID_TYPES[name] {
+		return graph.VoidTypeReference(), nil
+	}
+
+	// Perform native type mapping.
+	if nativeName, ok := tsgraph.NATIVE_TYPES[name]; ok {
+		return ttc.requiredNativeType(nativeName, graph)
+	}
+
+	// Find the TypeScript type with the matching name.
+	if t, found := ttc.types[name]; found {
+		return ttc.typeReference(t, graph), nil
+	}
+
+	// Find the type alias with the matching name and resolve its target.
+	if alias, found := ttc.aliases[name]; found {
+		if ttc.resolving[name] {
+			return graph.AnyTypeReference(), fmt.Errorf("TypeScript type alias %v refers to itself", name)
+		}
+
+		ttc.resolving[name] = true
+		defer delete(ttc.resolving, name)
+
+		target, _ := alias.DeclaredType()
+		return ttc.resolveType(target, resolutionScope{genericNamesOf(alias), nil}, graph)
+	}
+
+	// Otherwise, look for a native type defined in WebIDL with the same name.
+	if nativeType, found := ttc.nativeType(name, graph); found {
+		return nativeType, nil
+	}
+
+	return graph.AnyTypeReference(), fmt.Errorf("Could not find TypeScript type %v", name)
+}
+
+// typeReference returns a reference to the type graph type defined for the given TypeScript type.
+func (ttc *tsTypeConstructor) typeReference(t *tsType, graph *typegraph.TypeGraph) typegraph.TypeReference {
+	typeDecl, hasTypeDecl := graph.GetTypeForSourceNode(t.primary().GraphNode)
+	if !hasTypeDecl {
+		panic(fmt.Sprintf("Missing type declaration for TypeScript type %v", t.name))
+	}
+
+	return typeDecl.GetTypeReference()
+}
+
+// genericNamesOf returns the names of the generics declared on the given declaration.
+func genericNamesOf(declaration tsgraph.TSDeclaration) map[string]bool {
+	names := map[string]bool{}
+	for _, generic := range declaration.Generics() {
+		names[generic.Name()] = true
+	}
+	return names
+}
@@ -0,0 +1,3 @@
+interface Foo {}
+
+type Foo = any;
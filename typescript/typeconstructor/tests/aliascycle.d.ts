@@ -0,0 +1,6 @@
+interface SomeInterface {
+	foo: First;
+}
+
+type First = Second;
+type Second = First;
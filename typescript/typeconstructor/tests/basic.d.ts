@@ -0,0 +1,10 @@
+/** Some interface. */
+interface SomeInterface {
+	readonly coolThing: SomeInterface;
+	optionalThing?: SomeInterface;
+	anotherThing(someparam: number, optionalparam?: string): SomeInterface;
+}
+
+declare function someFunction(first: SomeInterface): void;
+
+declare const someConstant: SomeInterface;
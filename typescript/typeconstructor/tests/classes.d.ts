@@ -0,0 +1,11 @@
+declare class SomeClass {
+	constructor(first: any);
+	constructor(first: any, second: any);
+
+	static create(): SomeClass;
+	private hidden: any;
+}
+
+declare abstract class AbstractClass {
+	constructor();
+}
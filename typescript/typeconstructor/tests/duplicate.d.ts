@@ -0,0 +1,2 @@
+declare var foo: any;
+declare function foo(): void;
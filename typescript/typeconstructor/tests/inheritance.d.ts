@@ -0,0 +1,6 @@
+interface First {}
+interface Second {}
+
+interface Child extends First, Second {}
+
+declare class SomeClass implements First {}
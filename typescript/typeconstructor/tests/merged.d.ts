@@ -0,0 +1,7 @@
+interface SomeInterface {
+	first: any;
+}
+
+interface SomeInterface {
+	second(): void;
+}
@@ -0,0 +1,7 @@
+interface SomeInterface {
+	foo: any;
+}
+
+interface SomeInterface {
+	foo(): void;
+}
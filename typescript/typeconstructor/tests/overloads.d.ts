@@ -0,0 +1,5 @@
+declare function overloaded(first: any): SomeInterface;
+declare function overloaded(first: any, second: any): SomeInterface;
+declare function overloaded(first: any, second: any, third: any): any;
+
+interface SomeInterface {}
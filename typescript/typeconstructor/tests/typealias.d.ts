@@ -0,0 +1,9 @@
+interface SomeInterface {
+	aliased: SomeAlias;
+	nullable: NullableAlias;
+	erased: ErasedAlias;
+}
+
+type SomeAlias = SomeInterface;
+type NullableAlias = SomeInterface | null;
+type ErasedAlias = { foo: any };
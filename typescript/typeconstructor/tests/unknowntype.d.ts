@@ -0,0 +1,3 @@
+interface SomeInterface {
+	foo: Bar;
+}
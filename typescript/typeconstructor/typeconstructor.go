@@ -0,0 +1,428 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeconstructor
+
+import (
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/typegraph"
+
+	tsgraph "github.com/serulian/compiler/typescript/graph"
+	"github.com/serulian/compiler/typescript/parser"
+)
+
+// GetConstructor returns a TypeGraph constructor for the given TypeScript graph.
+func GetConstructor(tsg *tsgraph.TSGraph) *tsTypeConstructor {
+	ttc := &tsTypeConstructor{
+		tsg:       tsg,
+		types:     map[string]*tsType{},
+		typeList:  make([]*tsType, 0),
+		aliases:   map[string]tsgraph.TSDeclaration{},
+		resolving: map[string]bool{},
+	}
+
+	// If the graph failed to load, nothing is defined.
+	if !tsg.IsValid() {
+		return ttc
+	}
+
+	for _, declaration := range tsg.Declarations() {
+		name := declaration.Name()
+
+		switch declaration.Kind() {
+		case parser.InterfaceDeclaration, parser.ClassDeclaration:
+			existing, found := ttc.types[name]
+			if !found {
+				t := &tsType{name: name, declarations: []tsgraph.TSDeclaration{declaration}}
+				ttc.types[name] = t
+				ttc.typeList = append(ttc.typeList, t)
+				continue
+			}
+
+			existing.declarations = append(existing.declarations, declaration)
+
+		case parser.TypeAliasDeclaration:
+			if _, found := ttc.aliases[name]; found {
+				ttc.conflicts = append(ttc.conflicts, declaration)
+				continue
+			}
+
+			ttc.aliases[name] = declaration
+		}
+	}
+
+	for _, t := range ttc.typeList {
+		// Type aliases cannot share a name with an interface or class.
+		if alias, found := ttc.aliases[t.name]; found {
+			ttc.conflicts = append(ttc.conflicts, alias)
+			delete(ttc.aliases, t.name)
+		}
+
+		// Merged declarations found under modules other than that of the primary declaration
+		// are exposed in their modules as aliases to the type.
+		modules := map[compilercommon.InputSource]bool{
+			t.primary().Module().InputSource(): true,
+		}
+
+		for _, declaration := range t.declarations[1:] {
+			source := declaration.Module().InputSource()
+			if modules[source] {
+				continue
+			}
+
+			modules[source] = true
+			t.aliases = append(t.aliases, declaration)
+		}
+	}
+
+	return ttc
+}
+
+// tsTypeConstructor defines a type for populating a type graph from the TypeScript graph.
+type tsTypeConstructor struct {
+	tsg *tsgraph.TSGraph // The TypeScript graph being transformed.
+
+	types    map[string]*tsType // The types defined, by name.
+	typeList []*tsType          // The types defined, in declaration order.
+
+	aliases   map[string]tsgraph.TSDeclaration // The type aliases declared, by name.
+	conflicts []tsgraph.TSDeclaration          // The type aliases that conflict with other declarations.
+
+	moduleMembers []*memberGroup // The members defined under modules.
+
+	nativeTypes map[string]typegraph.TGTypeDecl // The native types defined by WebIDL, lazily loaded.
+	resolving   map[string]bool                 // The type aliases currently being resolved.
+}
+
+func (ttc *tsTypeConstructor) DefineModules(builder typegraph.GetModuleBuilder) {
+	for _, module := range ttc.tsg.GetModules() {
+		builder().
+			Name(module.Name()).
+			Path(string(module.InputSource())).
+			SourceNode(module.Node()).
+			Define()
+	}
+}
+
+func (ttc *tsTypeConstructor) DefineTypes(builder typegraph.GetTypeBuilder) {
+	for _, t := range ttc.typeList {
+		primary := t.primary()
+
+		typeBuilder := builder(primary.Module().Node()).
+			Name(t.name).
+			Exported(true).
+			GlobalId(t.name).
+			SourceNode(primary.GraphNode).
+			TypeKind(typegraph.ExternalInternalType)
+
+		for _, declaration := range t.declarations {
+			if documentation, hasDocumentation := declaration.Documentation(); hasDocumentation {
+				typeBuilder.Documentation(documentation)
+				break
+			}
+		}
+
+		typeBuilder.Define()
+
+		for _, declaration := range t.aliases {
+			builder(declaration.Module().Node()).
+				Name(t.name).
+				Exported(true).
+				GlobalId(tsgraph.GetUniqueId(declaration.GraphNode)).
+				SourceNode(declaration.GraphNode).
+				TypeKind(typegraph.AliasType).
+				Define()
+		}
+	}
+
+	// Type aliases referring directly to an interface or class are defined as aliases in the
+	// type graph. All others are resolved in place when referenced.
+	for _, alias := range ttc.aliases {
+		if _, isTypeAlias := ttc.aliasedType(alias); !isTypeAlias {
+			continue
+		}
+
+		builder(alias.Module().Node()).
+			Name(alias.Name()).
+			Exported(true).
+			GlobalId(tsgraph.GetUniqueId(alias.GraphNode)).
+			SourceNode(alias.GraphNode).
+			TypeKind(typegraph.AliasType).
+			Define()
+	}
+}
+
+// aliasedType returns the TypeScript type to which the given type alias directly refers, if any.
+func (ttc *tsTypeConstructor) aliasedType(alias tsgraph.TSDeclaration) (*tsType, bool) {
+	target, hasTarget := alias.DeclaredType()
+	if !hasTarget || target.Kind() != parser.NamedTypeRef {
+		return nil, false
+	}
+
+	name, _ := target.Name()
+	if genericNamesOf(alias)[name] {
+		return nil, false
+	}
+
+	t, found := ttc.types[name]
+	return t, found
+}
+
+func (ttc *tsTypeConstructor) DefineDependencies(annotator typegraph.Annotator, graph *typegraph.TypeGraph) {
+	for _, conflict := range ttc.conflicts {
+		annotator.ReportError(conflict.GraphNode, "TypeScript type alias '%s' conflicts with another declaration of the same name", conflict.Name())
+	}
+
+	for _, t := range ttc.typeList {
+		primaryNode := t.primary().GraphNode
+		scope := resolutionScope{t.genericNames(), t}
+
+		// Define the parent types of the type. TypeScript allows an interface to extend multiple
+		// interfaces, and a class to implement multiple interfaces, all of which are considered
+		// parents.
+		parentTypes := map[typegraph.TypeReference]bool{}
+		selfType := ttc.typeReference(t, graph)
+
+		for _, declaration := range t.declarations {
+			parentRefs := append(declaration.Extends(), declaration.Implements()...)
+			for _, parentRef := range parentRefs {
+				parentType, err := ttc.resolveType(parentRef, scope, graph)
+				if err != nil {
+					annotator.ReportError(parentRef.GraphNode, "%v", err)
+					continue
+				}
+
+				if parentType.IsAny() || parentType == selfType || parentTypes[parentType] {
+					continue
+				}
+
+				parentTypes[parentType] = true
+				annotator.DefineParentType(primaryNode, parentType)
+			}
+		}
+
+		// Alias each merged declaration to the type created.
+		primaryType, _ := graph.GetTypeForSourceNode(primaryNode)
+		for _, declaration := range t.aliases {
+			annotator.DefineAliasedType(declaration.GraphNode, primaryType)
+		}
+	}
+
+	for _, alias := range ttc.aliases {
+		t, isTypeAlias := ttc.aliasedType(alias)
+		if !isTypeAlias {
+			continue
+		}
+
+		aliasedType, _ := graph.GetTypeForSourceNode(t.primary().GraphNode)
+		annotator.DefineAliasedType(alias.GraphNode, aliasedType)
+	}
+}
+
+func (ttc *tsTypeConstructor) DefineMembers(builder typegraph.GetMemberBuilder, reporter typegraph.IssueReporter, graph *typegraph.TypeGraph) {
+	// Define the members under the modules.
+	typeNames := map[string]bool{}
+	for name := range ttc.types {
+		typeNames[name] = true
+	}
+
+	for _, module := range ttc.tsg.GetModules() {
+		ttc.moduleMembers = append(ttc.moduleMembers, collectModuleMembers(module, typeNames, reporter)...)
+	}
+
+	for _, group := range ttc.moduleMembers {
+		ttc.defineMember(group, builder)
+	}
+
+	// Define the members of the types.
+	for _, t := range ttc.typeList {
+		collectTypeMembers(t, reporter)
+		for _, group := range t.members {
+			ttc.defineMember(group, builder)
+		}
+	}
+}
+
+// defineMember defines a single member under a TypeScript module or type.
+func (ttc *tsTypeConstructor) defineMember(group *memberGroup, builder typegraph.GetMemberBuilder) {
+	b := builder(group.parentNode, group.isOperator()).
+		Name(group.name).
+		Documentation(group.documentation).
+		SourceNode(group.sourceNode())
+
+	// Add the parameters.
+	for _, parameter := range group.longestSignature().parameters {
+		b.WithParameter(parameter.Name(), "", parameter.GraphNode)
+	}
+
+	b.Define()
+}
+
+func (ttc *tsTypeConstructor) DecorateMembers(decorator typegraph.GetMemberDecorator, reporter typegraph.IssueReporter, graph *typegraph.TypeGraph) {
+	for _, group := range ttc.moduleMembers {
+		ttc.decorateMember(group, decorator, reporter, graph)
+	}
+
+	for _, t := range ttc.typeList {
+		for _, group := range t.members {
+			ttc.decorateMember(group, decorator, reporter, graph)
+		}
+	}
+}
+
+// decorateMember decorates the metadata on a member of a type or module.
+func (ttc *tsTypeConstructor) decorateMember(group *memberGroup, decorator typegraph.GetMemberDecorator, reporter typegraph.IssueReporter, graph *typegraph.TypeGraph) {
+	var scope = resolutionScope{map[string]bool{}, group.thisType}
+	if group.thisType != nil {
+		scope = resolutionScope{group.thisType.genericNames(), group.thisType}
+	}
+
+	memberDecorator := decorator(group.sourceNode())
+
+	var memberType typegraph.TypeReference
+	var memberKind typegraph.MemberSignatureKind
+	var isReadOnly = true
+
+	switch group.kind {
+	case parser.PropertyMember:
+		signature := group.signatures[0]
+		declaredType, err := ttc.resolveOptionalType(signature.declaredType, signature.hasType, scope, graph)
+		if err != nil {
+			reporter.ReportError(signature.node, "%v", err)
+		}
+
+		if group.isOptional {
+			declaredType = declaredType.AsNullable()
+		}
+
+		memberType = declaredType
+		memberKind = typegraph.NativePropertyMemberSignature
+		isReadOnly = group.isReadonly
+
+	case parser.ConstructorMember:
+		memberType = ttc.mergeSignatures(group, memberDecorator, ttc.typeReference(group.thisType, graph), scope, reporter, graph)
+		memberKind = typegraph.NativeConstructorMemberSignature
+
+	case parser.MethodMember, parser.IndexMember:
+		memberType = ttc.mergeSignatures(group, memberDecorator, graph.AnyTypeReference(), scope, reporter, graph)
+		memberKind = typegraph.NativeFunctionMemberSignature
+
+	default:
+		panic("Unknown TypeScript member kind")
+	}
+
+	if group.isOperator() {
+		memberDecorator.Native(true)
+	}
+
+	memberDecorator.Exported(true).
+		Static(group.isStatic).
+		ReadOnly(isReadOnly).
+		MemberKind(memberKind).
+		MemberType(memberType).
+		Decorate()
+}
+
+// mergeSignatures merges all the signatures of the given member group into a single function
+// type, as Serulian does not support overloading. Parameter types are intersected, parameters
+// not required by every signature are made nullable and differing return types become `any`.
+// If returnType is not `any`, it is used as the return type of the function.
+func (ttc *tsTypeConstructor) mergeSignatures(group *memberGroup, memberDecorator *typegraph.MemberDecorator, returnType typegraph.TypeReference, scope resolutionScope, reporter typegraph.IssueReporter, graph *typegraph.TypeGraph) typegraph.TypeReference {
+	longest := group.longestSignature()
+
+	var parameterTypes = make([]typegraph.TypeReference, 0, len(longest.parameters))
+	var requiredCount = len(longest.parameters)
+	var mergedReturnType = returnType
+
+	for index, signature := range group.signatures {
+		signatureScope := scope.withGenerics(signature.genericNames)
+
+		for parameterIndex, parameter := range signature.parameters {
+			// Optional and rest parameters (and all those following them) are not required.
+			if (parameter.IsOptional() || parameter.IsRest()) && parameterIndex < requiredCount {
+				requiredCount = parameterIndex
+			}
+
+			declaredType, hasDeclaredType := parameter.DeclaredType()
+			parameterType, err := ttc.resolveOptionalType(declaredType, hasDeclaredType, signatureScope, graph)
+			if err != nil {
+				reporter.ReportError(parameter.GraphNode, "%v", err)
+			}
+
+			// Rest parameters are declared as arrays, but are passed as individual arguments, so
+			// the parameter accepts a single argument of any type.
+			if parameter.IsRest() {
+				parameterType = graph.AnyTypeReference()
+			}
+
+			if parameterIndex >= len(parameterTypes) {
+				parameterTypes = append(parameterTypes, parameterType)
+			} else {
+				parameterTypes[parameterIndex] = parameterTypes[parameterIndex].Intersect(parameterType)
+			}
+		}
+
+		// Parameters not found in every signature are not required.
+		if len(signature.parameters) < requiredCount {
+			requiredCount = len(signature.parameters)
+		}
+
+		if !returnType.IsAny() {
+			continue
+		}
+
+		// Determine the return type.
+		signatureReturnType, err := ttc.resolveOptionalType(signature.declaredType, signature.hasType, signatureScope, graph)
+		if err != nil {
+			reporter.ReportError(signature.node, "%v", err)
+		}
+
+		if index == 0 {
+			mergedReturnType = signatureReturnType
+		} else if mergedReturnType != signatureReturnType {
+			mergedReturnType = graph.AnyTypeReference()
+		}
+	}
+
+	// Build the function type. All optional parameters get marked as nullable, which means we
+	// can skip passing them on function calls.
+	var functionType = graph.FunctionTypeReference(mergedReturnType)
+	for parameterIndex, parameter := range longest.parameters {
+		var parameterType = parameterTypes[parameterIndex]
+		if parameterIndex >= requiredCount {
+			parameterType = parameterType.AsNullable()
+		}
+
+		functionType = functionType.WithParameter(parameterType)
+		memberDecorator.DefineParameterType(parameter.GraphNode, parameterType)
+	}
+
+	return functionType
+}
+
+func (ttc *tsTypeConstructor) Validate(reporter typegraph.IssueReporter, graph *typegraph.TypeGraph) {
+}
+
+func (ttc *tsTypeConstructor) GetRanges(sourceNodeID compilergraph.GraphNodeId) []compilercommon.SourceRange {
+	layerNode, found := ttc.tsg.TryGetNode(sourceNodeID)
+	if !found {
+		return []compilercommon.SourceRange{}
+	}
+
+	// If the node is the primary declaration of a merged type, return the ranges of all its
+	// declarations.
+	if layerNode.Kind() == parser.NodeTypeDeclaration {
+		if t, found := ttc.types[layerNode.Get(parser.NodePredicateDeclarationName)]; found && t.primary().GraphNode.NodeId == sourceNodeID {
+			var ranges = make([]compilercommon.SourceRange, 0, len(t.declarations))
+			for _, declaration := range t.declarations {
+				ranges = append(ranges, ttc.tsg.SourceRangesOf(declaration.GraphNode)...)
+			}
+			return ranges
+		}
+	}
+
+	// Otherwise, return the ranges for the single node.
+	return ttc.tsg.SourceRangesOf(layerNode)
+}
@@ -0,0 +1,244 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeconstructor
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/serulian/compiler/compilercommon"
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/compilerutil"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/packageloader"
+	tsgraph "github.com/serulian/compiler/typescript/graph"
+	webidl "github.com/serulian/compiler/webidl/graph"
+	webidltc "github.com/serulian/compiler/webidl/typeconstructor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = fmt.Printf
+
+const TESTLIB_PATH = "../../testlib"
+
+type typegraphCheck func(t *testing.T, graph *typegraph.TypeGraph, source compilercommon.InputSource)
+
+type typegraphTest struct {
+	name          string
+	entrypoint    string
+	expectedError string
+	check         typegraphCheck
+}
+
+var typeGraphTests = []typegraphTest{
+	// Success tests.
+	typegraphTest{"basic test", "basic", "", checkBasic},
+	typegraphTest{"merged declarations test", "merged", "", checkMerged},
+	typegraphTest{"inheritance test", "inheritance", "", checkInheritance},
+	typegraphTest{"overloads test", "overloads", "", checkOverloads},
+	typegraphTest{"classes test", "classes", "", checkClasses},
+	typegraphTest{"type alias test", "typealias", "", checkTypeAlias},
+	typegraphTest{"indexer test", "indexer", "", checkIndexer},
+
+	// Failure tests.
+	typegraphTest{"unknown type test", "unknowntype", "Could not find TypeScript type Bar", nil},
+	typegraphTest{"mixed member test", "mixedmember", "Member 'foo' under TypeScript type 'SomeInterface' is declared as both a property and a method", nil},
+	typegraphTest{"alias cycle test", "aliascycle", "TypeScript type alias First refers to itself", nil},
+	typegraphTest{"alias conflict test", "aliasconflict", "TypeScript type alias 'Foo' conflicts with another declaration of the same name", nil},
+	typegraphTest{"duplicate module member test", "duplicate", "'foo' is already declared under TypeScript module 'duplicate.d.ts'", nil},
+}
+
+func TestGraphs(t *testing.T) {
+	for _, test := range typeGraphTests {
+		if os.Getenv("FILTER") != "" {
+			if !strings.Contains(test.name, os.Getenv("FILTER")) {
+				continue
+			} else {
+				fmt.Printf("Matched Test: %v\n", test.name)
+			}
+		}
+
+		graph, err := compilergraph.NewGraph("tests/" + test.entrypoint + ".d.ts")
+		if err != nil {
+			t.Errorf("Got error on test %s: %v", test.name, err)
+			continue
+		}
+
+		testTSG := tsgraph.NewTSGraph(graph)
+		testIRG := webidl.NewIRG(graph)
+		loader := packageloader.NewPackageLoader(packageloader.NewBasicConfig(graph.RootSourceFilePath(), testTSG.SourceHandler(), testIRG.SourceHandler()))
+		tsgResult := loader.Load(packageloader.Library{TESTLIB_PATH, false, "webidl", "testcore"})
+
+		// Make sure we had no errors during construction.
+		if !assert.True(t, tsgResult.Status, "Got error for TypeScript graph construction %v: %s", test.name, tsgResult.Errors) {
+			continue
+		}
+
+		// Construct the type graph.
+		result, _ := typegraph.BuildTypeGraphWithOption(graph, typegraph.BuildForTesting, compilerutil.NoopCancelationHandle(),
+			GetConstructor(testTSG), webidltc.GetConstructor(testIRG), typegraph.NewBasicTypesConstructorForTesting(graph))
+
+		if test.expectedError == "" {
+			// Make sure we had no errors during construction.
+			if !assert.True(t, result.Status, "Got error for type graph construction %v: %s", test.name, result.Errors) {
+				continue
+			}
+
+			test.check(t, result.Graph, compilercommon.InputSource(graph.RootSourceFilePath()))
+		} else {
+			// Make sure we had an error during construction.
+			if !assert.False(t, result.Status, "Found no error for type graph construction %v: %s", test.name, result.Errors) {
+				continue
+			}
+
+			// Make sure the error expected is found.
+			assert.Equal(t, 1, len(result.Errors), "In test %v: Expected one error, found: %v", test.name, result.Errors)
+			assert.Equal(t, test.expectedError, result.Errors[0].Error(), "Error mismatch on test %v", test.name)
+		}
+	}
+}
+
+func lookupType(t *testing.T, graph *typegraph.TypeGraph, name string, source compilercommon.InputSource) typegraph.TGTypeDecl {
+	typeDecl, found := graph.LookupType(name, source)
+	if !found {
+		t.Fatalf("Missing type %s", name)
+	}
+
+	return typeDecl
+}
+
+func lookupMember(t *testing.T, typeDecl typegraph.TGTypeDecl, name string) typegraph.TGMember {
+	member, found := typeDecl.GetMemberOrOperator(name)
+	if !found {
+		t.Fatalf("Missing member %s under type %s", name, typeDecl.Name())
+	}
+
+	return member
+}
+
+func checkBasic(t *testing.T, graph *typegraph.TypeGraph, source compilercommon.InputSource) {
+	someInterface := lookupType(t, graph, "SomeInterface", source)
+	interfaceRef := someInterface.GetTypeReference()
+
+	documentation, _ := someInterface.Documentation()
+	assert.Equal(t, "Some interface.", documentation)
+
+	coolThing := lookupMember(t, someInterface, "coolThing")
+	assert.True(t, coolThing.IsReadOnly())
+	assert.Equal(t, interfaceRef, coolThing.MemberType())
+
+	optionalThing := lookupMember(t, someInterface, "optionalThing")
+	assert.False(t, optionalThing.IsReadOnly())
+	assert.Equal(t, interfaceRef.AsNullable(), optionalThing.MemberType())
+
+	anotherThing := lookupMember(t, someInterface, "anotherThing")
+	assert.Equal(t, interfaceRef, anotherThing.MemberType().Generics()[0])
+	assert.Equal(t, 2, anotherThing.MemberType().ParameterCount())
+	assert.True(t, anotherThing.MemberType().Parameters()[1].IsNullable())
+
+	// Ensure `string` maps to the native String type defined by the core WebIDL.
+	stringParam := anotherThing.MemberType().Parameters()[1]
+	if assert.True(t, stringParam.IsNormal(), "Expected native type for string parameter") {
+		assert.Equal(t, "String", stringParam.ReferredType().Name())
+		assert.Equal(t, "webidl", stringParam.ReferredType().SourceGraphId())
+		assert.Equal(t, typegraph.ExternalInternalType, stringParam.ReferredType().TypeKind())
+	}
+
+	someFunction, found := graph.LookupModuleMember("someFunction", source)
+	if assert.True(t, found, "Missing someFunction") {
+		assert.True(t, someFunction.IsStatic())
+		assert.True(t, someFunction.MemberType().Generics()[0].IsVoid())
+		assert.Equal(t, interfaceRef, someFunction.MemberType().Parameters()[0])
+	}
+
+	someConstant, found := graph.LookupModuleMember("someConstant", source)
+	if assert.True(t, found, "Missing someConstant") {
+		assert.True(t, someConstant.IsReadOnly())
+		assert.Equal(t, interfaceRef, someConstant.MemberType())
+	}
+}
+
+func checkMerged(t *testing.T, graph *typegraph.TypeGraph, source compilercommon.InputSource) {
+	someInterface := lookupType(t, graph, "SomeInterface", source)
+	lookupMember(t, someInterface, "first")
+	lookupMember(t, someInterface, "second")
+}
+
+func checkInheritance(t *testing.T, graph *typegraph.TypeGraph, source compilercommon.InputSource) {
+	first := lookupType(t, graph, "First", source)
+	second := lookupType(t, graph, "Second", source)
+
+	child := lookupType(t, graph, "Child", source)
+	assert.Equal(t, 2, len(child.ParentTypes()))
+	assert.True(t, child.GetTypeReference().CheckSubTypeOf(first.GetTypeReference()) == nil)
+	assert.True(t, child.GetTypeReference().CheckSubTypeOf(second.GetTypeReference()) == nil)
+
+	someClass := lookupType(t, graph, "SomeClass", source)
+	assert.Equal(t, 1, len(someClass.ParentTypes()))
+}
+
+func checkOverloads(t *testing.T, graph *typegraph.TypeGraph, source compilercommon.InputSource) {
+	overloaded, found := graph.LookupModuleMember("overloaded", source)
+	if !assert.True(t, found, "Missing overloaded") {
+		return
+	}
+
+	memberType := overloaded.MemberType()
+	assert.True(t, memberType.Generics()[0].IsAny())
+	assert.Equal(t, 3, memberType.ParameterCount())
+	assert.False(t, memberType.Parameters()[0].IsNullable())
+	assert.True(t, memberType.Parameters()[1].IsAny())
+	assert.True(t, memberType.Parameters()[2].IsAny())
+}
+
+func checkClasses(t *testing.T, graph *typegraph.TypeGraph, source compilercommon.InputSource) {
+	someClass := lookupType(t, graph, "SomeClass", source)
+
+	constructor := lookupMember(t, someClass, "new")
+	assert.True(t, constructor.IsStatic())
+	assert.Equal(t, someClass.GetTypeReference(), constructor.MemberType().Generics()[0])
+	assert.Equal(t, 2, constructor.MemberType().ParameterCount())
+
+	create := lookupMember(t, someClass, "create")
+	assert.True(t, create.IsStatic())
+
+	_, hasHidden := someClass.GetMember("hidden")
+	assert.False(t, hasHidden, "Expected hidden member to be skipped")
+
+	abstractClass := lookupType(t, graph, "AbstractClass", source)
+	_, hasConstructor := abstractClass.GetMember("new")
+	assert.False(t, hasConstructor, "Expected constructor on abstract class to be skipped")
+}
+
+func checkTypeAlias(t *testing.T, graph *typegraph.TypeGraph, source compilercommon.InputSource) {
+	someInterface := lookupType(t, graph, "SomeInterface", source)
+	interfaceRef := someInterface.GetTypeReference()
+
+	// Lookups of type aliases return the aliased type.
+	someAlias := lookupType(t, graph, "SomeAlias", source)
+	assert.Equal(t, interfaceRef, someAlias.GetTypeReference())
+
+	assert.Equal(t, interfaceRef, lookupMember(t, someInterface, "aliased").MemberType())
+	assert.Equal(t, interfaceRef.AsNullable(), lookupMember(t, someInterface, "nullable").MemberType())
+	assert.True(t, lookupMember(t, someInterface, "erased").MemberType().IsAny())
+
+	_, hasNullableAlias := graph.LookupType("NullableAlias", source)
+	assert.False(t, hasNullableAlias, "Expected union type alias to be inlined")
+}
+
+func checkIndexer(t *testing.T, graph *typegraph.TypeGraph, source compilercommon.InputSource) {
+	someInterface := lookupType(t, graph, "SomeInterface", source)
+
+	indexer, found := someInterface.GetOperator("index")
+	if !assert.True(t, found, "Missing index operator") {
+		return
+	}
+
+	assert.True(t, indexer.IsNative())
+	assert.Equal(t, someInterface.GetTypeReference(), indexer.MemberType().Generics()[0])
+}
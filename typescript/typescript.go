@@ -0,0 +1,66 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package typescript defines the TypeScript integration for Serulian. TypeScript declaration
+// files (.d.ts) allow for type-safe invocation and reference of types and functions defined by
+// existing JavaScript libraries.
+package typescript
+
+import (
+	"fmt"
+
+	"github.com/serulian/compiler/compilergraph"
+	"github.com/serulian/compiler/graphs/typegraph"
+	"github.com/serulian/compiler/integration"
+	"github.com/serulian/compiler/packageloader"
+
+	tsgraph "github.com/serulian/compiler/typescript/graph"
+	tstc "github.com/serulian/compiler/typescript/typeconstructor"
+)
+
+type typescriptProvider struct {
+	tsg *tsgraph.TSGraph
+}
+
+// TypeScriptProvider returns a provider of a TypeScript integration.
+func TypeScriptProvider(graph compilergraph.SerulianGraph) typescriptProvider {
+	return typescriptProvider{tsgraph.NewTSGraph(graph)}
+}
+
+func (p typescriptProvider) GraphID() string {
+	return "typescript"
+}
+
+func (p typescriptProvider) SourceHandler() packageloader.SourceHandler {
+	return p.tsg.SourceHandler()
+}
+
+func (p typescriptProvider) TypeConstructor() typegraph.TypeGraphConstructor {
+	return tstc.GetConstructor(p.tsg)
+}
+
+func (p typescriptProvider) PathHandler() integration.PathHandler {
+	return pathHandler{p.tsg}
+}
+
+func (p typescriptProvider) UsesNativeConstructors() bool {
+	return true
+}
+
+type pathHandler struct {
+	tsg *tsgraph.TSGraph
+}
+
+func (p pathHandler) GetStaticMemberPath(member typegraph.TGMember, referenceType typegraph.TypeReference) string {
+	if member.Name() == "new" {
+		parentType, _ := member.ParentType()
+		return fmt.Sprintf("$t.nativenew($global.%s)", parentType.Name())
+	}
+
+	return "" // Use the default.
+}
+
+func (p pathHandler) GetModulePath(module typegraph.TGModule) string {
+	return "$global"
+}
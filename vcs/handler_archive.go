@@ -0,0 +1,418 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vcs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// archiveInfoFileName is the name of the file placed in the checkout directory of an archive package,
+// recording from where and at which reference the archive was retrieved.
+const archiveInfoFileName = ".serulian-archive"
+
+// archiveRefPlaceholder is the placeholder in an archive download path replaced by the tag, branch or
+// commit requested.
+const archiveRefPlaceholder = "{ref}"
+
+// commitShaRegex is a regular expression matching (possibly shortened) commit SHAs.
+var commitShaRegex = regexp.MustCompile("^[0-9a-f]{7,40}$")
+
+// archiveInfo holds the information recorded for a checked out archive package.
+type archiveInfo struct {
+	URL            string `json:"url"`
+	DownloadPath   string `json:"download"`
+	TagsURL        string `json:"tags,omitempty"`
+	Tag            string `json:"tag,omitempty"`
+	BranchOrCommit string `json:"branchOrCommit,omitempty"`
+}
+
+// ref returns the tag, branch or commit of the archive, or HEAD if none.
+func (ai archiveInfo) ref() string {
+	switch {
+	case ai.Tag != "":
+		return ai.Tag
+
+	case ai.BranchOrCommit != "":
+		return ai.BranchOrCommit
+
+	default:
+		return "HEAD"
+	}
+}
+
+// isDetached returns whether the archive refers to a fixed tag or commit.
+func (ai archiveInfo) isDetached() bool {
+	return ai.Tag != "" || commitShaRegex.MatchString(ai.BranchOrCommit)
+}
+
+// archiveVcs is a handler for packages retrieved as tar or zip archives, either over HTTP or from
+// the local file system. The download path is a template, in which `{ref}` is replaced by the tag,
+// branch or commit requested. As archives carry no history, the revision of an archive package is
+// the reference at which it was retrieved.
+type archiveVcs struct{}
+
+func (av archiveVcs) Kind() string {
+	return "archive"
+}
+
+func (av archiveVcs) Detect(checkoutDir string) bool {
+	_, err := os.Stat(path.Join(checkoutDir, archiveInfoFileName))
+	return err == nil
+}
+
+func (av archiveVcs) Checkout(vcsPath vcsPackagePath, downloadPath string, checkoutDir string) error {
+	lock := modificationLockMap.GetLock(checkoutDir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	info := archiveInfo{
+		URL:            vcsPath.url,
+		DownloadPath:   downloadPath,
+		Tag:            vcsPath.tag,
+		BranchOrCommit: vcsPath.branchOrCommit,
+	}
+
+	if mirror, found := lookupMirror(vcsPath.url); found && mirror.Tags != "" {
+		info.TagsURL = mirror.expand(mirror.Tags, vcsPath.url)
+	}
+
+	return av.retrieve(info, checkoutDir)
+}
+
+func (av archiveVcs) HasLocalChanges(checkoutDir string, ignoreEntries ...string) bool {
+	return false
+}
+
+func (av archiveVcs) Update(checkoutDir string) error {
+	lock := modificationLockMap.GetLock(checkoutDir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	info, err := readArchiveInfo(checkoutDir)
+	if err != nil {
+		return err
+	}
+
+	if info.isDetached() {
+		return nil
+	}
+
+	return av.retrieve(info, checkoutDir)
+}
+
+func (av archiveVcs) Inspect(checkoutDir string) (string, error) {
+	info, err := readArchiveInfo(checkoutDir)
+	if err != nil {
+		return "", err
+	}
+
+	return info.ref(), nil
+}
+
+func (av archiveVcs) ListTags(checkoutDir string) ([]string, error) {
+	info, err := readArchiveInfo(checkoutDir)
+	if err != nil {
+		return []string{}, err
+	}
+
+	if info.TagsURL == "" {
+		return []string{}, fmt.Errorf("Could not list tags: no tags URL is configured for archive package %s", info.URL)
+	}
+
+	contents, err := readArchiveSource(info.TagsURL)
+	if err != nil {
+		return []string{}, fmt.Errorf("Could not list tags: %v", err)
+	}
+
+	lines := strings.Split(string(contents), "\n")
+	tags := make([]string, 0, len(lines))
+	for _, line := range lines {
+		tag := strings.TrimSpace(line)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+func (av archiveVcs) IsDetached(checkoutDir string) (bool, error) {
+	info, err := readArchiveInfo(checkoutDir)
+	if err != nil {
+		return false, err
+	}
+
+	return info.isDetached(), nil
+}
+
+func (av archiveVcs) GetPackagePath(checkoutDir string) (vcsPackagePath, error) {
+	info, err := readArchiveInfo(checkoutDir)
+	if err != nil {
+		return vcsPackagePath{}, err
+	}
+
+	return vcsPackagePath{
+		url:            info.URL,
+		branchOrCommit: info.BranchOrCommit,
+		tag:            info.Tag,
+		subpackage:     "",
+	}, nil
+}
+
+func (av archiveVcs) Tag(checkoutDir string, tag string, message string) error {
+	return fmt.Errorf("Archive packages cannot be tagged")
+}
+
+// retrieve downloads and extracts the archive described by the given info, replacing the contents
+// of the checkout directory.
+func (av archiveVcs) retrieve(info archiveInfo, checkoutDir string) error {
+	source := strings.Replace(info.DownloadPath, archiveRefPlaceholder, info.ref(), -1)
+	log.Printf("Retrieving archive: %s", source)
+
+	contents, err := readArchiveSource(source)
+	if err != nil {
+		return fmt.Errorf("Error retrieving archive package %s: %v", source, err)
+	}
+
+	// Extract into a staging directory, which replaces the checkout directory once complete.
+	if err := os.MkdirAll(path.Dir(checkoutDir), 0755); err != nil {
+		return err
+	}
+
+	stagingDir, err := ioutil.TempDir(path.Dir(checkoutDir), ".archive")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := extractArchive(source, contents, stagingDir); err != nil {
+		return fmt.Errorf("Error extracting archive package %s: %v", source, err)
+	}
+
+	// Archives commonly place all their contents under a single top-level directory, which is
+	// treated as the root of the package.
+	rootDir := stagingDir
+	entries, err := ioutil.ReadDir(stagingDir)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 1 && entries[0].IsDir() {
+		rootDir = path.Join(stagingDir, entries[0].Name())
+	}
+
+	infoContents, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path.Join(rootDir, archiveInfoFileName), infoContents, 0644); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(rootDir, 0755); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(checkoutDir); err != nil {
+		return err
+	}
+
+	return os.Rename(rootDir, checkoutDir)
+}
+
+// readArchiveInfo reads the archive information recorded in the given checkout directory.
+func readArchiveInfo(checkoutDir string) (archiveInfo, error) {
+	contents, err := ioutil.ReadFile(path.Join(checkoutDir, archiveInfoFileName))
+	if err != nil {
+		return archiveInfo{}, fmt.Errorf("Invalid archive package: %v", err)
+	}
+
+	info := archiveInfo{}
+	if err := json.Unmarshal(contents, &info); err != nil {
+		return archiveInfo{}, fmt.Errorf("Invalid archive package: %v", err)
+	}
+
+	return info, nil
+}
+
+// readArchiveSource returns the contents found at the given source, which is either an HTTP(S) URL
+// or a local file path.
+func readArchiveSource(source string) ([]byte, error) {
+	parsedUrl, err := url.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	switch parsedUrl.Scheme {
+	case "":
+		return ioutil.ReadFile(source)
+
+	case "http", "https":
+		response, err := http.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Server returned status %s", response.Status)
+		}
+
+		return ioutil.ReadAll(response.Body)
+
+	default:
+		return nil, fmt.Errorf("Unsupported scheme '%s'", parsedUrl.Scheme)
+	}
+}
+
+// extractArchive extracts the given contents of the archive retrieved from the given source into the
+// target directory. The format of the archive is determined by the extension of the source.
+func extractArchive(source string, contents []byte, targetDir string) error {
+	sourcePath := strings.ToLower(source)
+	if parsedUrl, err := url.Parse(source); err == nil && parsedUrl.Scheme != "" {
+		sourcePath = strings.ToLower(parsedUrl.Path)
+	}
+
+	switch {
+	case strings.HasSuffix(sourcePath, ".zip"):
+		return extractZip(contents, targetDir)
+
+	case strings.HasSuffix(sourcePath, ".tar.gz") || strings.HasSuffix(sourcePath, ".tgz"):
+		gzipReader, err := gzip.NewReader(bytes.NewReader(contents))
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+		return extractTar(gzipReader, targetDir)
+
+	case strings.HasSuffix(sourcePath, ".tar"):
+		return extractTar(bytes.NewReader(contents), targetDir)
+
+	default:
+		return fmt.Errorf("Unknown archive format; expected .zip, .tar, .tar.gz or .tgz")
+	}
+}
+
+// extractTar extracts the tar archive read from the given reader into the target directory.
+func extractTar(reader io.Reader, targetDir string) error {
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		targetPath, err := archiveEntryPath(targetDir, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return err
+			}
+
+		case tar.TypeReg, tar.TypeRegA:
+			if err := writeArchiveFile(targetPath, tarReader, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+
+		default:
+			// Skip links and other special entries.
+			continue
+		}
+	}
+}
+
+// extractZip extracts the zip archive with the given contents into the target directory.
+func extractZip(contents []byte, targetDir string) error {
+	zipReader, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return err
+	}
+
+	for _, file := range zipReader.File {
+		targetPath, err := archiveEntryPath(targetDir, file.Name)
+		if err != nil {
+			return err
+		}
+
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !file.Mode().IsRegular() {
+			// Skip links and other special entries.
+			continue
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return err
+		}
+
+		err = writeArchiveFile(targetPath, reader, file.Mode().Perm())
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// archiveEntryPath returns the path under the target directory for the archive entry with the
+// given name, ensuring it does not escape the target directory.
+func archiveEntryPath(targetDir string, entryName string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(entryName))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("Archive entry '%s' is outside the archive", entryName)
+	}
+
+	return filepath.Join(targetDir, cleaned), nil
+}
+
+// writeArchiveFile writes the contents read from the given reader to the file at the target path.
+func writeArchiveFile(targetPath string, reader io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm|0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,258 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vcs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var archiveFiles = map[string]string{
+	"project-1.0.0/somefile.seru":     "function DoSomething() {}",
+	"project-1.0.0/sub/another.seru":  "function DoAnother() {}",
+	"project-1.0.0/sub/data.webidl":   "interface Data {};",
+	"project-1.0.0/README.md":         "A project",
+	"project-1.0.0/nested/deep/x.txt": "deep",
+}
+
+func buildTarGz(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, contents := range files {
+		err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg})
+		if !assert.Nil(t, err) {
+			t.FailNow()
+		}
+
+		tarWriter.Write([]byte(contents))
+	}
+
+	tarWriter.Close()
+	gzipWriter.Close()
+	return buffer.Bytes()
+}
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	for name, contents := range files {
+		writer, err := zipWriter.Create(name)
+		if !assert.Nil(t, err) {
+			t.FailNow()
+		}
+
+		writer.Write([]byte(contents))
+	}
+
+	zipWriter.Close()
+	return buffer.Bytes()
+}
+
+// newArchiveServer returns a test HTTP server serving archives of the test files for the
+// 1.0.0 tag of `github.com/some/project`, as well as the tags of the project.
+func newArchiveServer(t *testing.T) *httptest.Server {
+	tarGz := buildTarGz(t, archiveFiles)
+	zipContents := buildZip(t, archiveFiles)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/github/some/project/1.0.0.tar.gz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(tarGz)
+	})
+	mux.HandleFunc("/github/some/project/1.0.0.zip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(zipContents)
+	})
+	mux.HandleFunc("/github/some/project/tags", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("0.9.0\n1.0.0\n\n"))
+	})
+	return httptest.NewServer(mux)
+}
+
+func assertArchiveCheckout(t *testing.T, checkoutDir string) {
+	for name, expected := range archiveFiles {
+		contents, err := ioutil.ReadFile(path.Join(checkoutDir, name[len("project-1.0.0/"):]))
+		if assert.Nil(t, err, "Missing file %s", name) {
+			assert.Equal(t, expected, string(contents))
+		}
+	}
+}
+
+func TestArchiveCheckout(t *testing.T) {
+	server := newArchiveServer(t)
+	defer server.Close()
+
+	for _, extension := range []string{".tar.gz", ".zip"} {
+		cacheDir, err := ioutil.TempDir("", "archive")
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer os.RemoveAll(cacheDir)
+
+		vcsPath, _ := ParseVCSPath("github.com/some/project@1.0.0")
+		checkoutDir := path.Join(cacheDir, vcsPath.cacheDirectory())
+
+		handler := archiveVcs{}
+		err = handler.Checkout(vcsPath, server.URL+"/github/some/project/{ref}"+extension, checkoutDir)
+		if !assert.Nil(t, err, "Got error for archive %s", extension) {
+			continue
+		}
+
+		assertArchiveCheckout(t, checkoutDir)
+
+		assert.True(t, handler.Detect(checkoutDir))
+		assert.False(t, handler.HasLocalChanges(checkoutDir))
+
+		detached, err := handler.IsDetached(checkoutDir)
+		assert.Nil(t, err)
+		assert.True(t, detached)
+
+		revision, err := handler.Inspect(checkoutDir)
+		assert.Nil(t, err)
+		assert.Equal(t, "1.0.0", revision)
+
+		packagePath, err := handler.GetPackagePath(checkoutDir)
+		assert.Nil(t, err)
+		assert.Equal(t, "github.com/some/project@1.0.0", packagePath.String())
+
+		// Updating a detached archive package is a no-op.
+		assert.Nil(t, handler.Update(checkoutDir))
+		assertArchiveCheckout(t, checkoutDir)
+	}
+}
+
+func TestArchiveCheckoutMissing(t *testing.T) {
+	server := newArchiveServer(t)
+	defer server.Close()
+
+	cacheDir, err := ioutil.TempDir("", "archive")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(cacheDir)
+
+	vcsPath, _ := ParseVCSPath("github.com/some/project@2.0.0")
+	checkoutDir := path.Join(cacheDir, vcsPath.cacheDirectory())
+
+	err = archiveVcs{}.Checkout(vcsPath, server.URL+"/github/some/project/{ref}.tar.gz", checkoutDir)
+	assert.NotNil(t, err)
+
+	_, serr := os.Stat(checkoutDir)
+	assert.True(t, os.IsNotExist(serr), "Expected no checkout directory on failure")
+}
+
+func TestArchiveEscapingEntry(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "archive")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(cacheDir)
+
+	archivePath := path.Join(cacheDir, "bad.tar.gz")
+	ioutil.WriteFile(archivePath, buildTarGz(t, map[string]string{"../escaped.seru": "bad"}), 0644)
+
+	vcsPath, _ := ParseVCSPath("github.com/some/bad@1.0.0")
+	err = archiveVcs{}.Checkout(vcsPath, archivePath, path.Join(cacheDir, "checkout"))
+	assert.NotNil(t, err)
+
+	_, serr := os.Stat(path.Join(cacheDir, "escaped.seru"))
+	assert.True(t, os.IsNotExist(serr), "Expected escaping entry to not be written")
+}
+
+func TestArchiveMirror(t *testing.T) {
+	server := newArchiveServer(t)
+	defer server.Close()
+
+	cacheDir, err := ioutil.TempDir("", "archive")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(cacheDir)
+
+	SetMirrorConfig(MirrorConfig{
+		Mirrors: []Mirror{
+			Mirror{Prefix: "github.com/", Kind: "git", URL: "https://github.com/{rest}"},
+			Mirror{
+				Prefix: "github.com/some/",
+				Kind:   "archive",
+				URL:    server.URL + "/github/some/{rest}/{ref}.tar.gz",
+				Tags:   server.URL + "/github/some/{rest}/tags",
+			},
+		},
+	})
+	defer SetMirrorConfig(MirrorConfig{})
+
+	result, err := PerformVCSCheckout("github.com/some/project//sub@1.0.0", cacheDir, VCSFollowNormalCacheRules)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, DetachedPackage, result.Status)
+	assert.Equal(t, path.Join(cacheDir, "github.com/some/project/tag/1.0.0/sub"), result.PackageDirectory)
+
+	contents, err := ioutil.ReadFile(path.Join(result.PackageDirectory, "another.seru"))
+	assert.Nil(t, err)
+	assert.Equal(t, "function DoAnother() {}", string(contents))
+
+	tags, err := archiveVcs{}.ListTags(path.Join(cacheDir, "github.com/some/project/tag/1.0.0"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0.9.0", "1.0.0"}, tags)
+}
+
+func TestLookupMirror(t *testing.T) {
+	SetMirrorConfig(MirrorConfig{
+		Mirrors: []Mirror{
+			Mirror{Prefix: "github.com/", Kind: "archive", URL: "/mnt/mirror/{rest}/{ref}.zip"},
+			Mirror{Prefix: "github.com/serulian/", Kind: "git", URL: "https://git.internal/{path}"},
+		},
+	})
+	defer SetMirrorConfig(MirrorConfig{})
+
+	info, err := resolveVCSInformation("github.com/some/project")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "archive", info.Kind)
+		assert.Equal(t, "/mnt/mirror/some/project/{ref}.zip", info.DownloadPath)
+	}
+
+	info, err = resolveVCSInformation("github.com/serulian/corelib")
+	if assert.Nil(t, err) {
+		assert.Equal(t, "git", info.Kind)
+		assert.Equal(t, "https://git.internal/github.com/serulian/corelib", info.DownloadPath)
+	}
+
+	_, found := lookupMirror("example.com/some/project")
+	assert.False(t, found)
+}
+
+func TestLoadMirrorConfig(t *testing.T) {
+	configDir, err := ioutil.TempDir("", "mirror")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(configDir)
+
+	validPath := path.Join(configDir, "valid.json")
+	ioutil.WriteFile(validPath, []byte(`{"mirrors": [{"prefix": "github.com/", "kind": "archive", "url": "/mnt/{rest}/{ref}.zip"}]}`), 0644)
+
+	config, err := LoadMirrorConfig(validPath)
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(config.Mirrors)) {
+		assert.Equal(t, "github.com/", config.Mirrors[0].Prefix)
+	}
+
+	invalidPath := path.Join(configDir, "invalid.json")
+	ioutil.WriteFile(invalidPath, []byte(`{"mirrors": [{"prefix": "github.com/", "kind": "svn", "url": "/mnt"}]}`), 0644)
+
+	_, err = LoadMirrorConfig(invalidPath)
+	assert.NotNil(t, err)
+}
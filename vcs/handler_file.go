@@ -0,0 +1,222 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// fileSourceFileName is the name of the file placed in the checkout directory of a plain directory
+// package, recording the directory from which the package was copied.
+const fileSourceFileName = ".serulian-file"
+
+// fileSourceInfo holds the information recorded for a checked out plain directory package.
+type fileSourceInfo struct {
+	URL       string `json:"url"`
+	SourceDir string `json:"source"`
+}
+
+// fileSkippedDirectories are the names of directories never copied from a plain directory package.
+var fileSkippedDirectories = map[string]bool{
+	".git": true,
+	".hg":  true,
+	".svn": true,
+	".pkg": true,
+}
+
+// fileVcs is a read-only handler for packages found in plain directories (such as on a shared
+// drive), referenced via a `file://` download path. As plain directories have no history, packages
+// can only be referenced at HEAD, and are copied anew on every update.
+type fileVcs struct{}
+
+func (fv fileVcs) Kind() string {
+	return "file"
+}
+
+func (fv fileVcs) Detect(checkoutDir string) bool {
+	_, err := os.Stat(path.Join(checkoutDir, fileSourceFileName))
+	return err == nil
+}
+
+func (fv fileVcs) Checkout(vcsPath vcsPackagePath, downloadPath string, checkoutDir string) error {
+	lock := modificationLockMap.GetLock(checkoutDir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if vcsPath.tag != "" || (vcsPath.branchOrCommit != "" && vcsPath.branchOrCommit != "HEAD") {
+		return fmt.Errorf("Plain directory package %s cannot be checked out at a tag, branch or commit", vcsPath.url)
+	}
+
+	parsedUrl, err := url.Parse(downloadPath)
+	if err != nil || parsedUrl.Scheme != "file" || parsedUrl.Path == "" {
+		return fmt.Errorf("Invalid download path for plain directory package %s: %s", vcsPath.url, downloadPath)
+	}
+
+	return fv.copySource(fileSourceInfo{vcsPath.url, parsedUrl.Path}, checkoutDir)
+}
+
+func (fv fileVcs) HasLocalChanges(checkoutDir string, ignoreEntries ...string) bool {
+	return false
+}
+
+func (fv fileVcs) Update(checkoutDir string) error {
+	lock := modificationLockMap.GetLock(checkoutDir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	info, err := readFileSourceInfo(checkoutDir)
+	if err != nil {
+		return err
+	}
+
+	return fv.copySource(info, checkoutDir)
+}
+
+func (fv fileVcs) Inspect(checkoutDir string) (string, error) {
+	return "HEAD", nil
+}
+
+func (fv fileVcs) ListTags(checkoutDir string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (fv fileVcs) IsDetached(checkoutDir string) (bool, error) {
+	return false, nil
+}
+
+func (fv fileVcs) GetPackagePath(checkoutDir string) (vcsPackagePath, error) {
+	info, err := readFileSourceInfo(checkoutDir)
+	if err != nil {
+		return vcsPackagePath{}, err
+	}
+
+	return vcsPackagePath{
+		url:            info.URL,
+		branchOrCommit: "",
+		tag:            "",
+		subpackage:     "",
+	}, nil
+}
+
+func (fv fileVcs) Tag(checkoutDir string, tag string, message string) error {
+	return fmt.Errorf("Plain directory packages are read-only and cannot be tagged")
+}
+
+// copySource copies the contents of the source directory of the given package into the checkout
+// directory, replacing any existing contents.
+func (fv fileVcs) copySource(info fileSourceInfo, checkoutDir string) error {
+	sourceDir := info.SourceDir
+	log.Printf("Copying plain directory package: %s", sourceDir)
+	if sourceInfo, err := os.Stat(sourceDir); err != nil || !sourceInfo.IsDir() {
+		return fmt.Errorf("Plain directory package source %s is not a directory", sourceDir)
+	}
+
+	// Copy into a staging directory, which replaces the checkout directory once complete.
+	if err := os.MkdirAll(path.Dir(checkoutDir), 0755); err != nil {
+		return err
+	}
+
+	stagingDir, err := ioutil.TempDir(path.Dir(checkoutDir), ".file")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	err = filepath.Walk(sourceDir, func(sourcePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(sourceDir, sourcePath)
+		if err != nil {
+			return err
+		}
+
+		targetPath := filepath.Join(stagingDir, relativePath)
+
+		switch {
+		case info.IsDir():
+			if relativePath != "." && fileSkippedDirectories[info.Name()] {
+				return filepath.SkipDir
+			}
+
+			return os.MkdirAll(targetPath, 0755)
+
+		case info.Mode().IsRegular():
+			return copyPlainFile(sourcePath, targetPath)
+
+		default:
+			// Skip symlinks and other special files.
+			return nil
+		}
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error copying plain directory package %s: %v", sourceDir, err)
+	}
+
+	infoContents, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path.Join(stagingDir, fileSourceFileName), infoContents, 0644); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(stagingDir, 0755); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(checkoutDir); err != nil {
+		return err
+	}
+
+	return os.Rename(stagingDir, checkoutDir)
+}
+
+// readFileSourceInfo reads the plain directory information recorded in the given checkout directory.
+func readFileSourceInfo(checkoutDir string) (fileSourceInfo, error) {
+	contents, err := ioutil.ReadFile(path.Join(checkoutDir, fileSourceFileName))
+	if err != nil {
+		return fileSourceInfo{}, fmt.Errorf("Invalid plain directory package: %v", err)
+	}
+
+	info := fileSourceInfo{}
+	if err := json.Unmarshal(contents, &info); err != nil {
+		return fileSourceInfo{}, fmt.Errorf("Invalid plain directory package: %v", err)
+	}
+
+	return info, nil
+}
+
+// copyPlainFile copies the file at the source path to the target path.
+func copyPlainFile(sourcePath string, targetPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(target, source); err != nil {
+		target.Close()
+		return err
+	}
+
+	return target.Close()
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vcs
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCheckout(t *testing.T) {
+	sourceDir, err := ioutil.TempDir("", "fileshare")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(sourceDir)
+
+	os.MkdirAll(path.Join(sourceDir, "sub"), 0755)
+	os.MkdirAll(path.Join(sourceDir, ".git"), 0755)
+	ioutil.WriteFile(path.Join(sourceDir, "somefile.seru"), []byte("function DoSomething() {}"), 0644)
+	ioutil.WriteFile(path.Join(sourceDir, "sub", "another.seru"), []byte("function DoAnother() {}"), 0644)
+	ioutil.WriteFile(path.Join(sourceDir, ".git", "HEAD"), []byte("ref: refs/heads/master"), 0644)
+
+	cacheDir, err := ioutil.TempDir("", "filecache")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(cacheDir)
+
+	vcsPath, _ := ParseVCSPath("example.com/shared/project")
+	checkoutDir := path.Join(cacheDir, vcsPath.cacheDirectory())
+
+	handler := fileVcs{}
+	if !assert.Nil(t, handler.Checkout(vcsPath, "file://"+sourceDir, checkoutDir)) {
+		return
+	}
+
+	contents, err := ioutil.ReadFile(path.Join(checkoutDir, "sub", "another.seru"))
+	assert.Nil(t, err)
+	assert.Equal(t, "function DoAnother() {}", string(contents))
+
+	// VCS metadata is never copied, so the checkout is detected as a plain directory package.
+	_, serr := os.Stat(path.Join(checkoutDir, ".git"))
+	assert.True(t, os.IsNotExist(serr))
+
+	detected, ok := DetectHandler(checkoutDir)
+	if assert.True(t, ok) {
+		assert.Equal(t, "file", detected.Kind())
+	}
+
+	packagePath, err := handler.GetPackagePath(checkoutDir)
+	assert.Nil(t, err)
+	assert.Equal(t, "example.com/shared/project", packagePath.String())
+
+	detached, err := handler.IsDetached(checkoutDir)
+	assert.Nil(t, err)
+	assert.False(t, detached)
+
+	tags, err := handler.ListTags(checkoutDir)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(tags))
+
+	assert.NotNil(t, handler.Tag(checkoutDir, "v1.0.0", "Some tag"))
+
+	// Updates pick up changes made to the source directory.
+	ioutil.WriteFile(path.Join(sourceDir, "newfile.seru"), []byte("function DoNew() {}"), 0644)
+	os.Remove(path.Join(sourceDir, "somefile.seru"))
+	if !assert.Nil(t, handler.Update(checkoutDir)) {
+		return
+	}
+
+	_, serr = os.Stat(path.Join(checkoutDir, "newfile.seru"))
+	assert.Nil(t, serr)
+
+	_, serr = os.Stat(path.Join(checkoutDir, "somefile.seru"))
+	assert.True(t, os.IsNotExist(serr))
+}
+
+func TestFileCheckoutAtTag(t *testing.T) {
+	sourceDir, err := ioutil.TempDir("", "fileshare")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(sourceDir)
+
+	vcsPath, _ := ParseVCSPath("example.com/shared/project@1.0.0")
+	err = fileVcs{}.Checkout(vcsPath, "file://"+sourceDir, path.Join(sourceDir, "checkout"))
+	assert.NotNil(t, err)
+}
+
+func TestFileCheckoutInvalidPath(t *testing.T) {
+	vcsPath, _ := ParseVCSPath("example.com/shared/project")
+	err := fileVcs{}.Checkout(vcsPath, "https://example.com/shared/project", path.Join(os.TempDir(), "checkout"))
+	assert.NotNil(t, err)
+}
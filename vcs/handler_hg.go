@@ -0,0 +1,240 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vcs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"strings"
+)
+
+// hgPinnedFileName is the name of the file placed under the .hg directory of a checkout when it
+// was checked out at a tag or commit. Mercurial has no notion of a detached checkout, so this
+// distinguishes checkouts that must never be updated from those following a branch.
+const hgPinnedFileName = "serulian-pinned"
+
+type hgVcs struct{}
+
+func (hv hgVcs) Kind() string {
+	return "hg"
+}
+
+func (hv hgVcs) Checkout(vcsPath vcsPackagePath, downloadPath string, checkoutDir string) error {
+	lock := modificationLockMap.GetLock(checkoutDir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Make the new directory.
+	log.Printf("Making HG package path: %s", checkoutDir)
+	err := os.MkdirAll(checkoutDir, 0744)
+	if err != nil {
+		return err
+	}
+
+	// Run the clone to checkout the package.
+	log.Printf("Clone hg repository: %s", downloadPath)
+	if _, errStr, err := runCommand(checkoutDir, "hg", "clone", downloadPath, "."); err != nil {
+		return fmt.Errorf("Error cloning hg package %s: %s", downloadPath, errStr)
+	}
+
+	// Switch to the tag, branch or commit if necessary.
+	var revision = ""
+	var pinned = false
+	switch {
+	case vcsPath.branchOrCommit != "":
+		revision = vcsPath.branchOrCommit
+		pinned = !hv.isBranch(checkoutDir, vcsPath.branchOrCommit)
+
+	case vcsPath.tag != "":
+		revision = vcsPath.tag
+		pinned = true
+	}
+
+	if revision != "" {
+		log.Printf("Switch to revision on hg repository: %s => %s", downloadPath, revision)
+		if _, errStr, err := runCommand(checkoutDir, "hg", "update", "-r", revision); err != nil {
+			return fmt.Errorf("Error changing revision of hg package %s: %s", downloadPath, errStr)
+		}
+	}
+
+	if pinned {
+		return ioutil.WriteFile(path.Join(checkoutDir, ".hg", hgPinnedFileName), []byte(revision), 0644)
+	}
+
+	return nil
+}
+
+func (hv hgVcs) Inspect(checkoutDir string) (string, error) {
+	output, errStr, err := runCommand(checkoutDir, "hg", "log", "-r", ".", "--template", "{node|short}")
+	if err != nil {
+		return "", fmt.Errorf("Invalid repository: %s", errStr)
+	}
+
+	trimmed := strings.TrimSpace(output)
+	if len(trimmed) < 7 {
+		return "", fmt.Errorf("Invalid repository")
+	}
+
+	return trimmed[0:7], nil
+}
+
+func (hv hgVcs) Detect(checkoutDir string) bool {
+	hgDirectory := path.Join(checkoutDir, ".hg")
+	_, err := os.Stat(hgDirectory)
+	return !os.IsNotExist(err)
+}
+
+func (hv hgVcs) Update(checkoutDir string) error {
+	lock := modificationLockMap.GetLock(checkoutDir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, errStr, err := runCommand(checkoutDir, "hg", "pull", "-u"); err != nil {
+		return fmt.Errorf("Error updating hg package %s: %s", checkoutDir, errStr)
+	}
+
+	return nil
+}
+
+func (hv hgVcs) HasLocalChanges(checkoutDir string, ignoreEntries ...string) bool {
+	output, _, err := runCommand(checkoutDir, "hg", "status")
+	if err != nil {
+		return true
+	}
+
+	lines := strings.Split(output, "\n")
+lineLoop:
+	for _, line := range lines {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		for _, entry := range ignoreEntries {
+			if strings.Contains(line, entry) {
+				continue lineLoop
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (hv hgVcs) ListTags(checkoutDir string) ([]string, error) {
+	output, errStr, err := runCommand(checkoutDir, "hg", "tags", "--template", "{tag}\n")
+	if err != nil {
+		return []string{}, fmt.Errorf("Could not list tags: %v", errStr)
+	}
+
+	lines := strings.Split(output, "\n")
+	tags := make([]string, 0, len(lines))
+	for _, line := range lines {
+		tag := strings.TrimSpace(line)
+		if tag == "" || tag == "tip" {
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (hv hgVcs) IsDetached(checkoutDir string) (bool, error) {
+	_, err := os.Stat(path.Join(checkoutDir, ".hg", hgPinnedFileName))
+	if err == nil {
+		return true, nil
+	}
+
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("Could check repo status: %v", err)
+}
+
+func (hv hgVcs) GetPackagePath(checkoutDir string) (vcsPackagePath, error) {
+	// Find the default URL.
+	defaultUrlStr, _, err := runCommand(checkoutDir, "hg", "paths", "default")
+	if err != nil {
+		return vcsPackagePath{}, err
+	}
+
+	// Check for a branch.
+	isDetached, err := hv.IsDetached(checkoutDir)
+	if err != nil {
+		return vcsPackagePath{}, err
+	}
+
+	if !isDetached {
+		branchStr, _, err := runCommand(checkoutDir, "hg", "branch")
+		if err != nil {
+			return vcsPackagePath{}, err
+		}
+
+		return vcsPackagePath{
+			url:            strings.TrimSpace(defaultUrlStr),
+			branchOrCommit: strings.TrimSpace(branchStr),
+			tag:            "",
+			subpackage:     "",
+		}, nil
+	}
+
+	// Check for a tag.
+	tagsStr, _, err := runCommand(checkoutDir, "hg", "log", "-r", ".", "--template", "{join(tags, '\\n')}")
+	if err != nil {
+		return vcsPackagePath{}, err
+	}
+
+	for _, tag := range strings.Split(tagsStr, "\n") {
+		currentTag := strings.TrimSpace(tag)
+		if len(currentTag) > 0 && currentTag != "tip" {
+			return vcsPackagePath{
+				url:            strings.TrimSpace(defaultUrlStr),
+				branchOrCommit: "",
+				tag:            currentTag,
+				subpackage:     "",
+			}, nil
+		}
+	}
+
+	// Use the inspect result.
+	currentSHA, err := hv.Inspect(checkoutDir)
+	if err != nil {
+		return vcsPackagePath{}, err
+	}
+
+	return vcsPackagePath{
+		url:            strings.TrimSpace(defaultUrlStr),
+		branchOrCommit: currentSHA,
+		tag:            "",
+		subpackage:     "",
+	}, nil
+}
+
+func (hv hgVcs) Tag(checkoutDir string, tag string, message string) error {
+	_, _, err := runCommand(checkoutDir, "hg", "tag", "-m", message, tag)
+	return err
+}
+
+// isBranch returns whether the given name refers to a named branch in the checked out repository.
+func (hv hgVcs) isBranch(checkoutDir string, name string) bool {
+	output, _, err := runCommand(checkoutDir, "hg", "branches", "--template", "{branch}\n")
+	if err != nil {
+		return false
+	}
+
+	for _, branch := range strings.Split(output, "\n") {
+		if strings.TrimSpace(branch) == name {
+			return true
+		}
+	}
+
+	return false
+}
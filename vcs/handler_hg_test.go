@@ -0,0 +1,140 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vcs
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createHgRepository creates a local Mercurial repository with two commits, the first of which
+// is tagged `v1.0.0`.
+func createHgRepository(t *testing.T) string {
+	if _, err := exec.LookPath("hg"); err != nil {
+		t.Skip("hg is not installed")
+	}
+
+	os.Setenv("HGUSER", "Serulian Test <test@serulian.io>")
+
+	repoDir, err := ioutil.TempDir("", "hgrepo")
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+
+	hgCommand := func(args ...string) {
+		_, errStr, err := runCommand(repoDir, "hg", args...)
+		if !assert.Nil(t, err, "hg %v failed: %s", args, errStr) {
+			t.FailNow()
+		}
+	}
+
+	hgCommand("init")
+	ioutil.WriteFile(path.Join(repoDir, "somefile.seru"), []byte("function DoSomething() {}"), 0644)
+	hgCommand("add", "somefile.seru")
+	hgCommand("commit", "-m", "First commit")
+	hgCommand("tag", "-m", "Tag v1.0.0", "v1.0.0")
+
+	ioutil.WriteFile(path.Join(repoDir, "anotherfile.seru"), []byte("function DoAnother() {}"), 0644)
+	hgCommand("add", "anotherfile.seru")
+	hgCommand("commit", "-m", "Second commit")
+	return repoDir
+}
+
+func TestHgCheckoutHead(t *testing.T) {
+	repoDir := createHgRepository(t)
+	defer os.RemoveAll(repoDir)
+
+	cacheDir, err := ioutil.TempDir("", "hgcache")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(cacheDir)
+
+	vcsPath, _ := ParseVCSPath("example.com/hg/project")
+	checkoutDir := path.Join(cacheDir, vcsPath.cacheDirectory())
+
+	handler := hgVcs{}
+	if !assert.Nil(t, handler.Checkout(vcsPath, repoDir, checkoutDir)) {
+		return
+	}
+
+	detected, ok := DetectHandler(checkoutDir)
+	if assert.True(t, ok) {
+		assert.Equal(t, "hg", detected.Kind())
+	}
+
+	_, serr := os.Stat(path.Join(checkoutDir, "anotherfile.seru"))
+	assert.Nil(t, serr)
+
+	detached, err := handler.IsDetached(checkoutDir)
+	assert.Nil(t, err)
+	assert.False(t, detached)
+
+	sha, err := handler.Inspect(checkoutDir)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, len(sha))
+
+	tags, err := handler.ListTags(checkoutDir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, tags)
+
+	assert.False(t, handler.HasLocalChanges(checkoutDir))
+
+	ioutil.WriteFile(path.Join(checkoutDir, "somefile.seru"), []byte("function Changed() {}"), 0644)
+	assert.True(t, handler.HasLocalChanges(checkoutDir))
+	assert.False(t, handler.HasLocalChanges(checkoutDir, "somefile.seru"))
+}
+
+func TestHgCheckoutTag(t *testing.T) {
+	repoDir := createHgRepository(t)
+	defer os.RemoveAll(repoDir)
+
+	cacheDir, err := ioutil.TempDir("", "hgcache")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(cacheDir)
+
+	vcsPath, _ := ParseVCSPath("example.com/hg/project@v1.0.0")
+	checkoutDir := path.Join(cacheDir, vcsPath.cacheDirectory())
+
+	handler := hgVcs{}
+	if !assert.Nil(t, handler.Checkout(vcsPath, repoDir, checkoutDir)) {
+		return
+	}
+
+	_, serr := os.Stat(path.Join(checkoutDir, "somefile.seru"))
+	assert.Nil(t, serr)
+
+	_, serr = os.Stat(path.Join(checkoutDir, "anotherfile.seru"))
+	assert.True(t, os.IsNotExist(serr), "Expected tagged checkout to not contain later commit")
+
+	detached, err := handler.IsDetached(checkoutDir)
+	assert.Nil(t, err)
+	assert.True(t, detached)
+
+	packagePath, err := handler.GetPackagePath(checkoutDir)
+	assert.Nil(t, err)
+	assert.Equal(t, "v1.0.0", packagePath.Tag())
+}
+
+func TestHgTag(t *testing.T) {
+	repoDir := createHgRepository(t)
+	defer os.RemoveAll(repoDir)
+
+	handler := hgVcs{}
+	if !assert.Nil(t, handler.Tag(repoDir, "v2.0.0", "Tag v2.0.0")) {
+		return
+	}
+
+	tags, err := handler.ListTags(repoDir)
+	assert.Nil(t, err)
+	assert.Contains(t, tags, "v2.0.0")
+}
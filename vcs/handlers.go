@@ -39,6 +39,9 @@ type VCSHandler interface {
 
 var vcsByKind = map[string]VCSHandler{
 	"git":          gitVcs{},
+	"hg":           hgVcs{},
+	"archive":      archiveVcs{},
+	"file":         fileVcs{},
 	"__fake_git__": fakeGitVcs{},
 }
 
@@ -2,6 +2,7 @@ package vcs
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -57,8 +58,15 @@ func DiscoverVCSInformation(vcsUrl string) (VCSUrlInformation, error) {
 		return VCSUrlInformation{}, fmt.Errorf("Could not download VCS url '%s': %v", vcsUrl, err)
 	}
 
+	defer response.Body.Close()
+	return parseVCSDiscoveryDocument(vcsUrl, response.Body)
+}
+
+// parseVCSDiscoveryDocument parses the given HTML document returned for the given VCS URL, finds
+// the discovery <meta> tag, and returns the VCSUrlInformation found.
+func parseVCSDiscoveryDocument(vcsUrl string, body io.Reader) (VCSUrlInformation, error) {
 	// Parse the contents into HTML and search for the <meta> tag.
-	doc, err := html.Parse(response.Body)
+	doc, err := html.Parse(body)
 	if err != nil {
 		return VCSUrlInformation{}, fmt.Errorf("VCS url '%s' returned an invalid body", vcsUrl)
 	}
@@ -86,7 +94,7 @@ func DiscoverVCSInformation(vcsUrl string) (VCSUrlInformation, error) {
 
 	// <meta name="go-import" content="github.com/repo/path git https://github.com/repo/path">
 	pieces := strings.SplitN(metaTagValue, " ", 3)
-	if pieces == nil {
+	if len(pieces) != 3 {
 		log.Printf("<meta> tag value could not be parsed for VCS URL %v", vcsUrl)
 		return VCSUrlInformation{}, notFoundErr
 	}
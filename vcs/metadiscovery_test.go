@@ -0,0 +1,49 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vcs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type discoveryTest struct {
+	vcsUrl        string
+	document      string
+	expectedError bool
+	expectedKind  string
+	expectedPath  string
+}
+
+var discoveryTests = []discoveryTest{
+	discoveryTest{"github.com/some/project", `<html><head><meta name="go-import" content="github.com/some/project git https://github.com/some/project"></head></html>`, false, "git", "https://github.com/some/project"},
+	discoveryTest{"example.com/hg/project", `<html><head><meta name="go-import" content="example.com/hg/project hg https://hg.example.com/project"></head></html>`, false, "hg", "https://hg.example.com/project"},
+	discoveryTest{"example.com/shared/project", `<html><head><meta name="go-import" content="example.com/shared/project file file:///mnt/shared/project"></head></html>`, false, "file", "file:///mnt/shared/project"},
+	discoveryTest{"example.com/archived/project", `<html><head><meta name="go-import" content="example.com/archived/project archive https://example.com/archives/{ref}.tar.gz"></head></html>`, false, "archive", "https://example.com/archives/{ref}.tar.gz"},
+
+	discoveryTest{"example.com/svn/project", `<html><head><meta name="go-import" content="example.com/svn/project svn https://svn.example.com/project"></head></html>`, true, "", ""},
+	discoveryTest{"example.com/other/project", `<html><head><meta name="go-import" content="example.com/some/project git https://example.com/some/project"></head></html>`, true, "", ""},
+	discoveryTest{"example.com/missing/project", `<html><head></head></html>`, true, "", ""},
+	discoveryTest{"example.com/invalid/project", `<html><head><meta name="go-import" content="example.com/invalid/project"></head></html>`, true, "", ""},
+}
+
+func TestVCSDiscovery(t *testing.T) {
+	for _, test := range discoveryTests {
+		info, err := parseVCSDiscoveryDocument(test.vcsUrl, strings.NewReader(test.document))
+		if test.expectedError {
+			assert.NotNil(t, err, "Expected error for VCS URL %s", test.vcsUrl)
+			continue
+		}
+
+		if !assert.Nil(t, err, "Got error for VCS URL %s", test.vcsUrl) {
+			continue
+		}
+
+		assert.Equal(t, test.expectedKind, info.Kind, "Kind mismatch for VCS URL %s", test.vcsUrl)
+		assert.Equal(t, test.expectedPath, info.DownloadPath, "Download path mismatch for VCS URL %s", test.vcsUrl)
+	}
+}
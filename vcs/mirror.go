@@ -0,0 +1,128 @@
+// Copyright 2018 The Serulian Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"sync"
+)
+
+// MirrorConfigEnvironmentVariable is the environment variable holding the path of the mirror
+// configuration file to use, if any.
+const MirrorConfigEnvironmentVariable = "SERULIAN_VCS_MIRRORS"
+
+// Mirror defines a rewrite of all VCS packages whose URL begins with a prefix to a mirror.
+//
+// Example:
+//   {
+//     "prefix": "github.com/",
+//     "kind": "archive",
+//     "url": "https://mirror.internal/github/{rest}/{ref}.tar.gz",
+//     "tags": "https://mirror.internal/github/{rest}/tags"
+//   }
+type Mirror struct {
+	// Prefix is the prefix of the VCS package URLs redirected to the mirror.
+	Prefix string `json:"prefix"`
+
+	// Kind is the kind of VCS handler used to retrieve packages from the mirror.
+	Kind string `json:"kind"`
+
+	// URL is the template of the download path for packages under the mirror. The template can
+	// reference `{path}` (the full VCS package URL), `{rest}` (the VCS package URL after the prefix)
+	// and, for archive mirrors, `{ref}` (the tag, branch or commit requested, or `HEAD`). Archive
+	// mirrors can also specify a local file path in lieu of an HTTP URL.
+	URL string `json:"url"`
+
+	// Tags is the template of the URL returning the tags of a package under the mirror, one per
+	// line. Only used by archive mirrors, which cannot otherwise list tags.
+	Tags string `json:"tags,omitempty"`
+}
+
+// MirrorConfig defines the mirrors to use in lieu of discovery when retrieving VCS packages.
+type MirrorConfig struct {
+	// Mirrors are the mirrors configured. When multiple mirrors match a VCS package, that with the
+	// longest prefix is used.
+	Mirrors []Mirror `json:"mirrors"`
+}
+
+var mirrorConfig = MirrorConfig{}
+var mirrorConfigLock sync.RWMutex
+
+// LoadMirrorConfig reads the mirror configuration found in the JSON file at the given path.
+func LoadMirrorConfig(configPath string) (MirrorConfig, error) {
+	contents, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return MirrorConfig{}, err
+	}
+
+	config := MirrorConfig{}
+	if err := json.Unmarshal(contents, &config); err != nil {
+		return MirrorConfig{}, fmt.Errorf("Could not parse mirror configuration: %v", err)
+	}
+
+	for _, mirror := range config.Mirrors {
+		if mirror.Prefix == "" || mirror.URL == "" {
+			return MirrorConfig{}, fmt.Errorf("Mirror configuration requires a prefix and URL for every mirror")
+		}
+
+		if _, ok := GetHandlerByKind(mirror.Kind); !ok {
+			return MirrorConfig{}, fmt.Errorf("Mirror for prefix '%s' requires engine '%s', which is not currently supported", mirror.Prefix, mirror.Kind)
+		}
+	}
+
+	return config, nil
+}
+
+// SetMirrorConfig sets the mirror configuration used for all subsequent VCS checkouts.
+func SetMirrorConfig(config MirrorConfig) {
+	mirrorConfigLock.Lock()
+	defer mirrorConfigLock.Unlock()
+	mirrorConfig = config
+}
+
+// lookupMirror returns the mirror configured for the given VCS package URL, if any.
+func lookupMirror(vcsUrl string) (Mirror, bool) {
+	mirrorConfigLock.RLock()
+	defer mirrorConfigLock.RUnlock()
+
+	var found = false
+	var bestMirror Mirror
+	for _, mirror := range mirrorConfig.Mirrors {
+		if !strings.HasPrefix(vcsUrl, mirror.Prefix) {
+			continue
+		}
+
+		if !found || len(mirror.Prefix) > len(bestMirror.Prefix) {
+			bestMirror = mirror
+			found = true
+		}
+	}
+
+	return bestMirror, found
+}
+
+// expand returns the given template for the mirror, with references to the VCS package URL replaced.
+func (m Mirror) expand(template string, vcsUrl string) string {
+	replacer := strings.NewReplacer(
+		"{path}", vcsUrl,
+		"{rest}", strings.TrimPrefix(strings.TrimPrefix(vcsUrl, m.Prefix), "/"))
+	return replacer.Replace(template)
+}
+
+// resolveVCSInformation returns the VCSUrlInformation for the given VCS package URL, using the
+// mirror configured for the URL if any, and discovery otherwise.
+func resolveVCSInformation(vcsUrl string) (VCSUrlInformation, error) {
+	mirror, found := lookupMirror(vcsUrl)
+	if !found {
+		return DiscoverVCSInformation(vcsUrl)
+	}
+
+	log.Printf("Using mirror with prefix %s for VCS URL %s", mirror.Prefix, vcsUrl)
+	return VCSUrlInformation{mirror.Prefix, mirror.Kind, mirror.expand(mirror.URL, vcsUrl)}, nil
+}
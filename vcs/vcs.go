@@ -41,8 +41,38 @@ const (
 	// CachedPackage indicates that the package was returned from cache without further operation.
 	// Should only be returned if the always-use-cache options is specified (typically by tooling).
 	CachedPackage
+
+	// VendoredPackage indicates that the package was found in the vendor directory and was therefore
+	// loaded from that location without any VCS operation.
+	VendoredPackage
 )
 
+// String returns a human-readable name for the package status.
+func (s VCSPackageStatus) String() string {
+	switch s {
+	case DetachedPackage:
+		return "detached"
+
+	case BranchOrHEADPackage:
+		return "branch-or-head"
+
+	case LocallyModifiedPackage:
+		return "locally-modified"
+
+	case DevelopmentPackage:
+		return "development"
+
+	case CachedPackage:
+		return "cached"
+
+	case VendoredPackage:
+		return "vendored"
+
+	default:
+		return "unknown"
+	}
+}
+
 // VCSCheckoutResult is the result of a VCS checkout, if it succeeds.
 type VCSCheckoutResult struct {
 	PackageDirectory string
@@ -66,6 +96,10 @@ func GetVCSCheckoutDirectory(vcsPath string, pkgCacheRootPath string, vcsDevelop
 		return "", perr
 	}
 
+	if parsedPath.IsVersionRange() {
+		return "", fmt.Errorf("VCS package '%s' refers to a version range, which must be resolved to a tag", vcsPath)
+	}
+
 	// Check for a local development cache.
 	if parsedPath.isRepoOnlyReference() {
 		for _, directoryPath := range vcsDevelopmentDirectories {
@@ -100,12 +134,27 @@ const (
 // vcsDevelopmentDirectories specifies optional directories to check for branchless and tagless copies
 // of the repository first. If found, the copy will be used in lieu of a normal checkout.
 func PerformVCSCheckout(vcsPath string, pkgCacheRootPath string, cacheOption VCSCacheOption, vcsDevelopmentDirectories ...string) (VCSCheckoutResult, error) {
+	return PerformVCSCheckoutAtCommit(vcsPath, "", pkgCacheRootPath, cacheOption, vcsDevelopmentDirectories...)
+}
+
+// PerformVCSCheckoutAtCommit performs the checkout of the given VCS path at the given commit SHA
+// and returns the local system directory at which the package was checked out. If the commit SHA
+// is empty, the tag, branch or commit specified in the VCS path is used, as per PerformVCSCheckout.
+//
+// Note that the VCS development directories are checked using the VCS path as given, so a local
+// copy of the package found under a development directory is used in lieu of the commit.
+func PerformVCSCheckoutAtCommit(vcsPath string, commitSha string, pkgCacheRootPath string, cacheOption VCSCacheOption, vcsDevelopmentDirectories ...string) (VCSCheckoutResult, error) {
 	// Parse the VCS path.
 	parsedPath, perr := ParseVCSPath(vcsPath)
 	if perr != nil {
 		return VCSCheckoutResult{}, perr
 	}
 
+	// Version ranges must be resolved to a specific tag before checkout, unless a commit was given.
+	if parsedPath.IsVersionRange() && commitSha == "" {
+		return VCSCheckoutResult{}, fmt.Errorf("VCS package '%s' refers to a version range, which must be resolved to a tag before checkout", vcsPath)
+	}
+
 	var err error
 	var warning string
 
@@ -123,6 +172,11 @@ func PerformVCSCheckout(vcsPath string, pkgCacheRootPath string, cacheOption VCS
 		}
 	}
 
+	// If a specific commit was requested, check it out instead.
+	if commitSha != "" {
+		parsedPath = parsedPath.WithCommit(commitSha)
+	}
+
 	// Conduct the checkout or update.
 	fullCacheDirectory := path.Join(pkgCacheRootPath, parsedPath.cacheDirectory())
 	status, err := checkCacheAndPull(parsedPath, fullCacheDirectory, cacheOption)
@@ -148,6 +202,43 @@ func PerformVCSCheckout(vcsPath string, pkgCacheRootPath string, cacheOption VCS
 	return VCSCheckoutResult{fullCacheDirectory, warning, status}, nil
 }
 
+// GetVendoredCheckout returns the checkout result for the vendored copy of the given VCS path found
+// under the vendor root directory. Vendored copies are placed under the vendor root directory using
+// the same layout as the package cache, but contain no VCS information.
+func GetVendoredCheckout(vcsPath string, vendorRootPath string) (VCSCheckoutResult, error) {
+	parsedPath, err := ParseVCSPath(vcsPath)
+	if err != nil {
+		return VCSCheckoutResult{}, err
+	}
+
+	fullVendorDirectory := path.Join(vendorRootPath, parsedPath.cacheDirectory())
+	if _, serr := os.Stat(fullVendorDirectory); os.IsNotExist(serr) {
+		return VCSCheckoutResult{}, fmt.Errorf("Vendored copy of package '%s' does not exist at '%s'", vcsPath, fullVendorDirectory)
+	}
+
+	if parsedPath.subpackage != "" {
+		subpackageVendorDirectory := path.Join(fullVendorDirectory, parsedPath.subpackage)
+		if _, serr := os.Stat(subpackageVendorDirectory); os.IsNotExist(serr) {
+			return VCSCheckoutResult{}, fmt.Errorf("Subpackage '%s' does not exist under vendored VCS package '%s'", parsedPath.subpackage, parsedPath.url)
+		}
+
+		return VCSCheckoutResult{subpackageVendorDirectory, "", VendoredPackage}, nil
+	}
+
+	return VCSCheckoutResult{fullVendorDirectory, "", VendoredPackage}, nil
+}
+
+// GetVendoredDirectory returns the directory under the vendor root directory at which the repository
+// of the given VCS path is placed when vendored.
+func GetVendoredDirectory(vcsPath string, vendorRootPath string) (string, error) {
+	parsedPath, err := ParseVCSPath(vcsPath)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(vendorRootPath, parsedPath.cacheDirectory()), nil
+}
+
 type tagGetter func() ([]string, error)
 
 // InspectInfo holds all the data returned from a call to PerformVCSCheckoutAndInspect.
@@ -183,6 +274,40 @@ func PerformVCSCheckoutAndInspect(vcsPath string, pkgCacheRootPath string, cache
 	return InspectInfo{handler.Kind(), sha, tagGetter}, result.Warning, nil
 }
 
+// ListVCSTags performs the checkout and updating of the HEAD of the repository containing the given
+// VCS path and returns all the tags found for the repository. Any tag, branch, commit, version range
+// or subpackage specified in the VCS path is ignored.
+func ListVCSTags(vcsPath string, pkgCacheRootPath string, cacheOption VCSCacheOption, vcsDevelopmentDirectories ...string) ([]string, error) {
+	parsedPath, err := ParseVCSPath(vcsPath)
+	if err != nil {
+		return []string{}, err
+	}
+
+	repositoryPath := vcsPackagePath{url: parsedPath.url}
+	inspectInfo, _, err := PerformVCSCheckoutAndInspect(repositoryPath.String(), pkgCacheRootPath, cacheOption, vcsDevelopmentDirectories...)
+	if err != nil {
+		return []string{}, err
+	}
+
+	return inspectInfo.GetTags()
+}
+
+// InspectVCSCheckout returns the HEAD commit SHA of the checkout of the given VCS path, which must
+// have been previously checked out via PerformVCSCheckout.
+func InspectVCSCheckout(vcsPath string, pkgCacheRootPath string, vcsDevelopmentDirectories ...string) (string, error) {
+	checkoutDirectory, err := GetVCSCheckoutDirectory(vcsPath, pkgCacheRootPath, vcsDevelopmentDirectories...)
+	if err != nil {
+		return "", err
+	}
+
+	handler, ok := DetectHandler(checkoutDirectory)
+	if !ok {
+		return "", fmt.Errorf("Could not detect VCS for directory: %s", checkoutDirectory)
+	}
+
+	return handler.Inspect(checkoutDirectory)
+}
+
 // checkCacheAndPull conducts the cache check and necessary pulls.
 func checkCacheAndPull(parsedPath vcsPackagePath, fullCacheDirectory string, cacheOption VCSCacheOption) (VCSPackageStatus, error) {
 	// Check the package cache for the path.
@@ -204,8 +329,8 @@ func checkCacheAndPull(parsedPath vcsPackagePath, fullCacheDirectory string, cac
 
 // performFullCheckout performs a full VCS checkout of the given package path.
 func performFullCheckout(path vcsPackagePath, fullCacheDirectory string) (VCSPackageStatus, error) {
-	// Lookup the VCS discovery information.
-	discovery, err := DiscoverVCSInformation(path.url)
+	// Lookup the VCS discovery information, preferring any mirror configured.
+	discovery, err := resolveVCSInformation(path.url)
 	if err != nil {
 		return DetachedPackage, err
 	}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+
+	"github.com/serulian/compiler/compilerutil"
 )
 
 // vcsPackagePathRegex is a regular expression for parsing VCS paths.
@@ -17,7 +19,9 @@ import (
 //   github.com/some/project:somebranchorcommit
 //   github.com/some/project@sometag
 //   github.com/some/project//somesubdir@sometag
-const vcsPackagePathRegex = "^(([a-zA-Z0-9\\._-]+)(/([a-zA-Z0-9\\._-])+)*)(//([^@:]+))?((@|:)([\\.a-zA-Z0-9_\\+-]+))?$"
+//   github.com/some/project@^1.4.0
+//   github.com/some/project@~2.1
+const vcsPackagePathRegex = "^(([a-zA-Z0-9\\._-]+)(/([a-zA-Z0-9\\._-])+)*)(//([^@:]+))?((@|:)([\\^~]?[\\.a-zA-Z0-9_\\+-]+))?$"
 
 // vcsPackagePath holds information about a package located in a VCS.
 type vcsPackagePath struct {
@@ -25,6 +29,7 @@ type vcsPackagePath struct {
 	branchOrCommit string // The branch/commit of the package. Cannot be set with 'tag'.
 	tag            string // The tag of the package. Cannot be set with 'branchOrCommit'.
 	subpackage     string // The path of the subpackage, if any.
+	versionRange   string // The semantic version range of the package. Cannot be set with 'tag' or 'branchOrCommit'.
 }
 
 // ParseVCSPath parses a path/url to a VCS package into its components.
@@ -42,6 +47,7 @@ func ParseVCSPath(vcsPath string) (vcsPackagePath, error) {
 
 	var branchOrComment string
 	var tag string
+	var versionRange string
 
 	if matches[8] == ":" {
 		branchOrComment = matches[9]
@@ -49,7 +55,19 @@ func ParseVCSPath(vcsPath string) (vcsPackagePath, error) {
 		tag = matches[9]
 	}
 
-	return vcsPackagePath{cleaned, branchOrComment, tag, matches[6]}, nil
+	// Check for a semantic version range in place of the tag.
+	if compilerutil.IsSemanticVersionRange(tag) {
+		if _, err := compilerutil.ParseSemanticVersionRange(tag); err != nil {
+			return vcsPackagePath{}, fmt.Errorf("Invalid VCS package path: %s: %v", vcsPath, err)
+		}
+
+		versionRange = tag
+		tag = ""
+	} else if compilerutil.IsSemanticVersionRange(branchOrComment) {
+		return vcsPackagePath{}, fmt.Errorf("Invalid VCS package path: %s", vcsPath)
+	}
+
+	return vcsPackagePath{cleaned, branchOrComment, tag, matches[6], versionRange}, nil
 }
 
 // URL returns the URL of the VCS package.
@@ -62,6 +80,23 @@ func (pp vcsPackagePath) Tag() string {
 	return pp.tag
 }
 
+// Subpackage returns the path of the subpackage of the VCS package, if any.
+func (pp vcsPackagePath) Subpackage() string {
+	return pp.subpackage
+}
+
+// VersionRange returns the semantic version range of the VCS package, if any.
+func (pp vcsPackagePath) VersionRange() string {
+	return pp.versionRange
+}
+
+// IsVersionRange returns true if the VCS package refers to a semantic version range, rather than
+// a specific tag, branch or commit. Such packages must have their range resolved to a tag (via
+// WithTag) before being checked out.
+func (pp vcsPackagePath) IsVersionRange() bool {
+	return pp.versionRange != ""
+}
+
 // BranchOrCommit returns the branch or commit of the VCS package.
 func (pp vcsPackagePath) BranchOrCommit() string {
 	return pp.branchOrCommit
@@ -112,6 +147,9 @@ func (pp vcsPackagePath) String() string {
 	case pp.tag != "":
 		return strRep + "@" + pp.tag
 
+	case pp.versionRange != "":
+		return strRep + "@" + pp.versionRange
+
 	default:
 		return strRep
 	}
@@ -124,7 +162,7 @@ func (pp vcsPackagePath) isHEAD() bool {
 
 // isRepoOnlyReference returns true if this VCS package does not specify its tag, branch or commit.
 func (pp vcsPackagePath) isRepoOnlyReference() bool {
-	return pp.tag == "" && pp.branchOrCommit == ""
+	return pp.tag == "" && pp.branchOrCommit == "" && pp.versionRange == ""
 }
 
 // cacheDirectory returns a relative directory path at which the VCS package should be placed
@@ -138,6 +176,9 @@ func (pp vcsPackagePath) cacheDirectory() string {
 	case pp.tag != "":
 		suffix = "tag/" + pp.tag
 
+	case pp.versionRange != "":
+		suffix = "range/" + pp.versionRange
+
 	default:
 		suffix = "HEAD"
 	}
@@ -38,6 +38,25 @@ var failTests = []string{
 	"github.com/foo/bar@@blah",
 	"github.com/foo/bar:@blah",
 	"github.com/foo/../bar",
+	"github.com/foo/bar@^",
+	"github.com/foo/bar@^a.b",
+	"github.com/foo/bar@~1.2.3.4",
+	"github.com/foo/bar:^1.2.3",
+	"github.com/foo/bar@^^1.2.3",
+}
+
+type pathRangeTest struct {
+	path         string
+	versionRange string
+	subpackage   string
+	resolvedTag  string
+	resolvedPath string
+}
+
+var rangeTests = []pathRangeTest{
+	pathRangeTest{"github.com/some/project@^1.4.0", "^1.4.0", "", "v1.4.2", "github.com/some/project@v1.4.2"},
+	pathRangeTest{"github.com/some/project@~2.1", "~2.1", "", "2.1.0", "github.com/some/project@2.1.0"},
+	pathRangeTest{"github.com/some/project//somesubdir@^0.4", "^0.4", "somesubdir", "v0.4.9", "github.com/some/project//somesubdir@v0.4.9"},
 }
 
 func TestVCSParsingFailure(t *testing.T) {
@@ -83,3 +102,23 @@ func TestVCSParsingSuccess(t *testing.T) {
 		}
 	}
 }
+
+func TestVCSParsingVersionRange(t *testing.T) {
+	for _, test := range rangeTests {
+		result, err := ParseVCSPath(test.path)
+		if !assert.Nil(t, err, "Expected no error for path %s", test.path) {
+			continue
+		}
+
+		assert.True(t, result.IsVersionRange(), "Expected version range for path %s", test.path)
+		assert.Equal(t, test.versionRange, result.VersionRange())
+		assert.Equal(t, "", result.Tag())
+		assert.Equal(t, test.subpackage, result.subpackage)
+		assert.Equal(t, test.path, result.String(), "Parse <-> String mismatch")
+		assert.False(t, result.isRepoOnlyReference())
+
+		resolved := result.WithTag(test.resolvedTag)
+		assert.False(t, resolved.IsVersionRange())
+		assert.Equal(t, test.resolvedPath, resolved.String())
+	}
+}
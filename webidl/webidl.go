@@ -43,6 +43,10 @@ func (p webidlProvider) PathHandler() integration.PathHandler {
 	return pathHandler{p.irg}
 }
 
+func (p webidlProvider) UsesNativeConstructors() bool {
+	return true
+}
+
 type pathHandler struct {
 	irg *irg.WebIRG
 }